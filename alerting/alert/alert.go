@@ -6,11 +6,16 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
 	// ErrAlertWithInvalidDescription is the error with which Gatus will panic if an alert has an invalid character
 	ErrAlertWithInvalidDescription = errors.New("alert description must not have \" or \\")
+
+	// ErrAlertWithInvalidStabilizationWindow is the error with which Gatus will panic if an alert has a negative
+	// stabilization window
+	ErrAlertWithInvalidStabilizationWindow = errors.New("alert stabilization-window must not be negative")
 )
 
 // Alert is a endpoint.Endpoint's alert configuration
@@ -18,6 +23,10 @@ type Alert struct {
 	// Type of alert (required)
 	Type Type `yaml:"type"`
 
+	// Name of the alert, used to tell apart the alerts of an endpoint, e.g. when an endpoint has a "warning" and a
+	// "critical" alert with different thresholds. Use Alert.GetName() to retrieve the name with its fallbacks.
+	Name string `yaml:"name,omitempty"`
+
 	// Enabled defines whether the alert is enabled
 	//
 	// Use Alert.IsEnabled() to retrieve the value of this field.
@@ -32,6 +41,17 @@ type Alert struct {
 	// SuccessThreshold defines how many successful executions must happen in a row before an ongoing incident is marked as resolved
 	SuccessThreshold int `yaml:"success-threshold"`
 
+	// StabilizationWindow is how long the endpoint must stay healthy, starting from its first success after failures,
+	// before an ongoing incident is marked as resolved. This prevents flapping endpoints that briefly recover from
+	// sending a resolve notification that is instantly contradicted.
+	//
+	// Applies in addition to SuccessThreshold. Disabled if 0.
+	StabilizationWindow time.Duration `yaml:"stabilization-window,omitempty"`
+
+	// SuccessRatio, if set, replaces FailureThreshold and SuccessThreshold by a minimum percentage of successful
+	// results over a window of the most recent results, which suits noisy endpoints better than streaks.
+	SuccessRatio *SuccessRatio `yaml:"success-ratio,omitempty"`
+
 	// Description of the alert. Will be included in the alert sent.
 	//
 	// This is a pointer, because it is populated by YAML and we need to know whether it was explicitly set to a value
@@ -44,6 +64,16 @@ type Alert struct {
 	// or not for provider.ParseWithDefaultAlert to work. Use Alert.IsSendingOnResolved() for a non-pointer
 	SendOnResolved *bool `yaml:"send-on-resolved"`
 
+	// TriggerMessage is an optional template used instead of the provider's default message when the alert is triggered
+	//
+	// Supports placeholders such as [ENDPOINT_NAME] and [ALERT_DESCRIPTION] (see message.go). Use Alert.GetMessage() to retrieve the rendered message.
+	TriggerMessage string `yaml:"trigger-message,omitempty"`
+
+	// ResolveMessage is an optional template used instead of the provider's default message when the alert is resolved
+	//
+	// Supports placeholders such as [ENDPOINT_NAME] and [ALERT_DESCRIPTION] (see message.go). Use Alert.GetMessage() to retrieve the rendered message.
+	ResolveMessage string `yaml:"resolve-message,omitempty"`
+
 	// ResolveKey is an optional field that is used by some providers (i.e. PagerDuty's dedup_key) to resolve
 	// ongoing/triggered incidents
 	ResolveKey string `yaml:"-"`
@@ -57,6 +87,19 @@ type Alert struct {
 	// some reason, the alert provider always returns errors when trying to send the resolved notification
 	// (SendOnResolved).
 	Triggered bool `yaml:"-"`
+
+	// NumberOfFailuresInARow is the number of unsuccessful evaluations in a row, as tracked by this alert.
+	//
+	// Each alert keeps its own streak rather than relying on the endpoint's, so that alerts with different thresholds
+	// trigger and resolve independently of one another, even once their streaks have been restored from the storage.
+	NumberOfFailuresInARow int `yaml:"-"`
+
+	// NumberOfSuccessesInARow is the number of successful evaluations in a row, as tracked by this alert.
+	NumberOfSuccessesInARow int `yaml:"-"`
+
+	// RecoveryStartedAt is the time of the first successful evaluation after failures, as tracked by this alert.
+	// Reset on failure, and used to enforce StabilizationWindow.
+	RecoveryStartedAt time.Time `yaml:"-"`
 }
 
 // ValidateAndSetDefaults validates the alert's configuration and sets the default value of fields that have one
@@ -70,6 +113,14 @@ func (alert *Alert) ValidateAndSetDefaults() error {
 	if strings.ContainsAny(alert.GetDescription(), "\"\\") {
 		return ErrAlertWithInvalidDescription
 	}
+	if alert.StabilizationWindow < 0 {
+		return ErrAlertWithInvalidStabilizationWindow
+	}
+	if alert.SuccessRatio != nil {
+		if err := alert.SuccessRatio.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -81,6 +132,48 @@ func (alert *Alert) GetDescription() string {
 	return *alert.Description
 }
 
+// GetName returns the name of the alert, or its description if it has no name, or its type if it has neither
+func (alert *Alert) GetName() string {
+	if len(alert.Name) > 0 {
+		return alert.Name
+	}
+	if description := alert.GetDescription(); len(description) > 0 {
+		return description
+	}
+	return string(alert.Type)
+}
+
+// GetMessage returns the alert's ResolveMessage if resolved is true or its TriggerMessage otherwise, with all
+// placeholders replaced by their respective value from the context.
+//
+// If the relevant template is not set, defaultMessage is returned as is.
+func (alert *Alert) GetMessage(resolved bool, defaultMessage string, context *MessageContext) string {
+	template := alert.TriggerMessage
+	if resolved {
+		template = alert.ResolveMessage
+	}
+	if len(template) == 0 {
+		return defaultMessage
+	}
+	if context == nil {
+		context = &MessageContext{}
+	}
+	return strings.NewReplacer(
+		EndpointNamePlaceholder, context.EndpointName,
+		EndpointGroupPlaceholder, context.EndpointGroup,
+		EndpointURLPlaceholder, context.EndpointURL,
+		EndpointTargetPlaceholder, context.EndpointTarget,
+		AlertTypePlaceholder, string(alert.Type),
+		AlertNamePlaceholder, alert.GetName(),
+		AlertDescriptionPlaceholder, alert.GetDescription(),
+		FailureThresholdPlaceholder, strconv.Itoa(alert.FailureThreshold),
+		SuccessThresholdPlaceholder, strconv.Itoa(alert.SuccessThreshold),
+		FailureCountPlaceholder, strconv.Itoa(context.FailureCount),
+		SuccessCountPlaceholder, strconv.Itoa(context.SuccessCount),
+		TimestampPlaceholder, context.FormattedTimestamp(),
+	).Replace(template)
+}
+
 // IsEnabled returns whether an alert is enabled or not
 // Returns true if not set
 func (alert *Alert) IsEnabled() bool {
@@ -111,3 +204,20 @@ func (alert *Alert) Checksum() string {
 	)
 	return hex.EncodeToString(hash.Sum(nil))
 }
+
+// BaseProviderConfig is the configuration shared by every alerting provider, embedded inline in each of them
+type BaseProviderConfig struct {
+	// SendOnResolved defines whether the provider sends a notification when an alert is resolved.
+	//
+	// Unlike Alert.SendOnResolved, this cannot be overridden by the alerts of an endpoint, which makes it possible to
+	// opt a provider out of recovery notifications entirely. Defaults to true.
+	SendOnResolved *bool `yaml:"send-on-resolved,omitempty"`
+
+	// Retry is the configuration of the retries of alerts that failed to be sent. Alerts aren't retried by default.
+	Retry *RetryConfig `yaml:"retry,omitempty"`
+}
+
+// IsSendingOnResolved returns whether the provider sends a notification when an alert is resolved
+func (config *BaseProviderConfig) IsSendingOnResolved() bool {
+	return config.SendOnResolved == nil || *config.SendOnResolved
+}
@@ -3,6 +3,7 @@ package alert
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestAlert_ValidateAndSetDefaults(t *testing.T) {
@@ -36,6 +37,17 @@ func TestAlert_ValidateAndSetDefaults(t *testing.T) {
 			expectedFailureThreshold: 10,
 			expectedSuccessThreshold: 5,
 		},
+		{
+			name: "invalid-stabilization-window",
+			alert: Alert{
+				FailureThreshold:    10,
+				SuccessThreshold:    5,
+				StabilizationWindow: -time.Minute,
+			},
+			expectedError:            ErrAlertWithInvalidStabilizationWindow,
+			expectedFailureThreshold: 10,
+			expectedSuccessThreshold: 5,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -73,6 +85,98 @@ func TestAlert_GetDescription(t *testing.T) {
 	}
 }
 
+func TestAlert_GetMessage(t *testing.T) {
+	description := "description"
+	context := &MessageContext{EndpointName: "name", EndpointGroup: "group", EndpointURL: "https://status.example.org/endpoints/group_name", EndpointTarget: "https://example.org/health", FailureCount: 7, SuccessCount: 2, Timestamp: time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)}
+	scenarios := []struct {
+		name     string
+		alert    Alert
+		resolved bool
+		expected string
+	}{
+		{
+			name:     "triggered-without-trigger-message",
+			alert:    Alert{ResolveMessage: "resolved"},
+			resolved: false,
+			expected: "default",
+		},
+		{
+			name:     "resolved-without-resolve-message",
+			alert:    Alert{TriggerMessage: "triggered"},
+			resolved: true,
+			expected: "default",
+		},
+		{
+			name:     "triggered-with-trigger-message",
+			alert:    Alert{TriggerMessage: "[ENDPOINT_GROUP]/[ENDPOINT_NAME] ([ENDPOINT_TARGET]) failed [FAILURE_THRESHOLD] times: [ALERT_DESCRIPTION]", ResolveMessage: "resolved", Description: &description, FailureThreshold: 3, SuccessThreshold: 2},
+			resolved: false,
+			expected: "group/name (https://example.org/health) failed 3 times: description",
+		},
+		{
+			name:     "resolved-with-resolve-message",
+			alert:    Alert{TriggerMessage: "triggered", ResolveMessage: "[ENDPOINT_NAME] succeeded [SUCCESS_THRESHOLD] times, see https://runbook.example.org", FailureThreshold: 3, SuccessThreshold: 2},
+			resolved: true,
+			expected: "name succeeded 2 times, see https://runbook.example.org",
+		},
+		{
+			name:     "triggered-with-failure-count",
+			alert:    Alert{TriggerMessage: "[ENDPOINT_NAME] failed [FAILURE_COUNT] times in a row", FailureThreshold: 3},
+			resolved: false,
+			expected: "name failed 7 times in a row",
+		},
+		{
+			name:     "resolved-with-success-count",
+			alert:    Alert{ResolveMessage: "[ENDPOINT_NAME] succeeded [SUCCESS_COUNT] times in a row", SuccessThreshold: 2},
+			resolved: true,
+			expected: "name succeeded 2 times in a row",
+		},
+		{
+			name:     "triggered-with-page-url",
+			alert:    Alert{TriggerMessage: "[ENDPOINT_NAME] is down, see [ENDPOINT_URL]", FailureThreshold: 3},
+			resolved: false,
+			expected: "name is down, see https://status.example.org/endpoints/group_name",
+		},
+		{
+			name:     "triggered-with-timestamp",
+			alert:    Alert{TriggerMessage: "[ENDPOINT_NAME] went down at [TIMESTAMP]", FailureThreshold: 3},
+			resolved: false,
+			expected: "name went down at 2024-03-10T14:30:00Z",
+		},
+		{
+			name:     "triggered-with-endpoint-target",
+			alert:    Alert{TriggerMessage: "[ENDPOINT_NAME] is down, see [ENDPOINT_TARGET]"},
+			resolved: false,
+			expected: "name is down, see https://example.org/health",
+		},
+		{
+			name:     "triggered-with-alert-type-and-name",
+			alert:    Alert{Type: TypeSlack, Name: "critical", TriggerMessage: "[[ALERT_NAME]] [ENDPOINT_NAME] is down ([ALERT_TYPE])", FailureThreshold: 3},
+			resolved: false,
+			expected: "[critical] name is down (slack)",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if message := scenario.alert.GetMessage(scenario.resolved, "default", context); message != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, message)
+			}
+		})
+	}
+}
+
+func TestAlert_GetName(t *testing.T) {
+	description := "description"
+	if name := (&Alert{Type: TypeSlack, Name: "critical", Description: &description}).GetName(); name != "critical" {
+		t.Errorf("expected the name to be used, got %q", name)
+	}
+	if name := (&Alert{Type: TypeSlack, Description: &description}).GetName(); name != "description" {
+		t.Errorf("expected the description to be used when there is no name, got %q", name)
+	}
+	if name := (&Alert{Type: TypeSlack}).GetName(); name != "slack" {
+		t.Errorf("expected the type to be used when there is neither a name nor a description, got %q", name)
+	}
+}
+
 func TestAlert_IsSendingOnResolved(t *testing.T) {
 	if (&Alert{SendOnResolved: nil}).IsSendingOnResolved() {
 		t.Error("alert.IsSendingOnResolved() should've returned false, because SendOnResolved was set to nil")
@@ -85,6 +189,18 @@ func TestAlert_IsSendingOnResolved(t *testing.T) {
 	}
 }
 
+func TestBaseProviderConfig_IsSendingOnResolved(t *testing.T) {
+	if !(&BaseProviderConfig{SendOnResolved: nil}).IsSendingOnResolved() {
+		t.Error("config.IsSendingOnResolved() should've returned true, because SendOnResolved was set to nil")
+	}
+	if value := false; (&BaseProviderConfig{SendOnResolved: &value}).IsSendingOnResolved() {
+		t.Error("config.IsSendingOnResolved() should've returned false, because SendOnResolved was set to false")
+	}
+	if value := true; !(&BaseProviderConfig{SendOnResolved: &value}).IsSendingOnResolved() {
+		t.Error("config.IsSendingOnResolved() should've returned true, because SendOnResolved was set to true")
+	}
+}
+
 func TestAlert_Checksum(t *testing.T) {
 	description1, description2 := "a", "b"
 	yes, no := true, false
@@ -0,0 +1,75 @@
+package alert
+
+import "time"
+
+const (
+	// EndpointNamePlaceholder is a placeholder for the display name of the endpoint the alert is for
+	EndpointNamePlaceholder = "[ENDPOINT_NAME]"
+
+	// EndpointGroupPlaceholder is a placeholder for the group of the endpoint the alert is for
+	EndpointGroupPlaceholder = "[ENDPOINT_GROUP]"
+
+	// EndpointURLPlaceholder is a placeholder for the URL of the page of the endpoint the alert is for on the
+	// dashboard. If web.public-url isn't set, it falls back to the same value as EndpointTargetPlaceholder, so that the
+	// templates written before web.public-url existed keep working.
+	EndpointURLPlaceholder = "[ENDPOINT_URL]"
+
+	// EndpointTargetPlaceholder is a placeholder for the URL that the endpoint the alert is for checks, as opposed to
+	// EndpointURLPlaceholder. It never includes the credentials the URL may embed, and is redacted if the endpoint has
+	// ui.hide-url set.
+	EndpointTargetPlaceholder = "[ENDPOINT_TARGET]"
+
+	// AlertTypePlaceholder is a placeholder for the type of the alert (e.g. slack)
+	AlertTypePlaceholder = "[ALERT_TYPE]"
+
+	// AlertNamePlaceholder is a placeholder for the name of the alert, which identifies the alert that fired when an
+	// endpoint has several of them. See Alert.GetName.
+	AlertNamePlaceholder = "[ALERT_NAME]"
+
+	// AlertDescriptionPlaceholder is a placeholder for the description of the alert
+	AlertDescriptionPlaceholder = "[ALERT_DESCRIPTION]"
+
+	// FailureThresholdPlaceholder is a placeholder for the failure threshold of the alert
+	FailureThresholdPlaceholder = "[FAILURE_THRESHOLD]"
+
+	// SuccessThresholdPlaceholder is a placeholder for the success threshold of the alert
+	SuccessThresholdPlaceholder = "[SUCCESS_THRESHOLD]"
+
+	// FailureCountPlaceholder is a placeholder for the number of consecutive failures of the endpoint
+	FailureCountPlaceholder = "[FAILURE_COUNT]"
+
+	// SuccessCountPlaceholder is a placeholder for the number of consecutive successes of the endpoint
+	SuccessCountPlaceholder = "[SUCCESS_COUNT]"
+
+	// TimestampPlaceholder is a placeholder for the time of the evaluation that triggered or resolved the alert,
+	// formatted as configured by alerting.timestamp (see SetTimestampConfig)
+	TimestampPlaceholder = "[TIMESTAMP]"
+)
+
+// MessageContext contains the values used to replace the placeholders of Alert.TriggerMessage and
+// Alert.ResolveMessage that aren't part of the alert itself
+type MessageContext struct {
+	EndpointName  string
+	EndpointGroup string
+
+	// EndpointURL is the URL of the endpoint's page on the dashboard if web.public-url is set, or EndpointTarget
+	// otherwise
+	EndpointURL string
+
+	// EndpointTarget is the URL of the endpoint without the credentials it may embed
+	EndpointTarget string
+
+	// FailureCount is the number of failed evaluations in a row, as tracked by the watchdog
+	FailureCount int
+
+	// SuccessCount is the number of successful evaluations in a row, as tracked by the watchdog
+	SuccessCount int
+
+	// Timestamp is the time of the evaluation that triggered or resolved the alert
+	Timestamp time.Time
+}
+
+// FormattedTimestamp returns the Timestamp formatted as configured by SetTimestampConfig
+func (context *MessageContext) FormattedTimestamp() string {
+	return FormatTimestamp(context.Timestamp)
+}
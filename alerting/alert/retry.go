@@ -0,0 +1,117 @@
+package alert
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRetryDelay is the default base delay of the backoff between two attempts at sending an alert
+	DefaultRetryDelay = time.Second
+
+	// DefaultMaximumRetryDelay is the default upper bound of the backoff between two attempts at sending an alert
+	DefaultMaximumRetryDelay = 30 * time.Second
+)
+
+var (
+	// sleep is used to wait between two attempts, and returns early with an error if ctx is done. Swappable for tests.
+	sleep = func(ctx context.Context, duration time.Duration) error {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+
+	// randomDuration returns a random duration in [0, max). Swappable for tests.
+	randomDuration = func(max time.Duration) time.Duration {
+		return time.Duration(rand.Int63n(int64(max)))
+	}
+)
+
+// RetryConfig is the configuration of the retries of an alerting provider.
+//
+// The delay before each retry is picked at random between 0 and an exponentially growing ceiling (full jitter), so
+// that endpoints failing at the same time don't all retry a flaky provider at the same time.
+type RetryConfig struct {
+	// Attempts is the number of times sending an alert is retried after the first attempt failed
+	Attempts int `yaml:"attempts"`
+
+	// Delay is the base delay of the backoff, which doubles with every retry. Defaults to DefaultRetryDelay.
+	Delay time.Duration `yaml:"delay,omitempty"`
+
+	// MaximumDelay is the upper bound of the backoff. Defaults to DefaultMaximumRetryDelay.
+	MaximumDelay time.Duration `yaml:"maximum-delay,omitempty"`
+
+	// MaximumConcurrency is the maximum number of retries to the provider that may be in flight at the same time.
+	// Retries beyond that wait for their turn. 0 means that there is no limit.
+	MaximumConcurrency int `yaml:"maximum-concurrency,omitempty"`
+
+	semaphore     chan struct{}
+	semaphoreOnce sync.Once
+}
+
+// backoff returns how long to wait before the given retry, starting from 1
+func (config *RetryConfig) backoff(retry int) time.Duration {
+	delay, maximumDelay := config.Delay, config.MaximumDelay
+	if delay <= 0 {
+		delay = DefaultRetryDelay
+	}
+	if maximumDelay <= 0 {
+		maximumDelay = DefaultMaximumRetryDelay
+	}
+	ceiling := delay
+	for i := 1; i < retry && ceiling < maximumDelay; i++ {
+		ceiling *= 2
+	}
+	if ceiling > maximumDelay {
+		ceiling = maximumDelay
+	}
+	return randomDuration(ceiling)
+}
+
+// acquire waits for a retry slot to be available and returns a function releasing it, or an error if ctx is done
+// before a slot became available
+func (config *RetryConfig) acquire(ctx context.Context) (func(), error) {
+	if config.MaximumConcurrency <= 0 {
+		return func() {}, nil
+	}
+	config.semaphoreOnce.Do(func() {
+		config.semaphore = make(chan struct{}, config.MaximumConcurrency)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case config.semaphore <- struct{}{}:
+		return func() { <-config.semaphore }, nil
+	}
+}
+
+// SendWithRetry calls send and, if it fails and retries are configured, calls it again up to Retry.Attempts times,
+// waiting a random backoff between each attempt.
+//
+// Only the error of the last attempt is returned. The retries stop as soon as ctx is done, e.g. when Gatus is shutting
+// down, in which case the error of the last attempt is returned as well.
+func (config *BaseProviderConfig) SendWithRetry(ctx context.Context, send func() error) error {
+	err := send()
+	if config.Retry == nil {
+		return err
+	}
+	for retry := 1; retry <= config.Retry.Attempts && err != nil; retry++ {
+		if sleep(ctx, config.Retry.backoff(retry)) != nil {
+			return err
+		}
+		release, acquireErr := config.Retry.acquire(ctx)
+		if acquireErr != nil {
+			return err
+		}
+		err = send()
+		release()
+	}
+	return err
+}
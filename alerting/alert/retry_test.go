@@ -0,0 +1,179 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryConfig_backoff(t *testing.T) {
+	defer func(original func(time.Duration) time.Duration) { randomDuration = original }(randomDuration)
+	// Return the ceiling itself, which is the upper bound of the jittered backoff
+	randomDuration = func(max time.Duration) time.Duration { return max }
+	scenarios := []struct {
+		name     string
+		config   *RetryConfig
+		retry    int
+		expected time.Duration
+	}{
+		{name: "defaults-first-retry", config: &RetryConfig{}, retry: 1, expected: DefaultRetryDelay},
+		{name: "defaults-third-retry", config: &RetryConfig{}, retry: 3, expected: 4 * DefaultRetryDelay},
+		{name: "defaults-capped", config: &RetryConfig{}, retry: 20, expected: DefaultMaximumRetryDelay},
+		{name: "custom", config: &RetryConfig{Delay: 100 * time.Millisecond, MaximumDelay: time.Second}, retry: 2, expected: 200 * time.Millisecond},
+		{name: "custom-capped", config: &RetryConfig{Delay: 100 * time.Millisecond, MaximumDelay: 250 * time.Millisecond}, retry: 3, expected: 250 * time.Millisecond},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if backoff := scenario.config.backoff(scenario.retry); backoff != scenario.expected {
+				t.Errorf("expected %s, got %s", scenario.expected, backoff)
+			}
+		})
+	}
+}
+
+func TestBaseProviderConfig_SendWithRetry(t *testing.T) {
+	defer func(original func(context.Context, time.Duration) error) { sleep = original }(sleep)
+	sleep = func(context.Context, time.Duration) error { return nil }
+	scenarios := []struct {
+		name                 string
+		config               BaseProviderConfig
+		failures             int
+		expectedCalls        int
+		expectedErrorPresent bool
+	}{
+		{name: "no-retry-config", config: BaseProviderConfig{}, failures: 1, expectedCalls: 1, expectedErrorPresent: true},
+		{name: "success-on-first-attempt", config: BaseProviderConfig{Retry: &RetryConfig{Attempts: 3}}, failures: 0, expectedCalls: 1},
+		{name: "success-on-retry", config: BaseProviderConfig{Retry: &RetryConfig{Attempts: 3}}, failures: 2, expectedCalls: 3},
+		{name: "out-of-attempts", config: BaseProviderConfig{Retry: &RetryConfig{Attempts: 3}}, failures: 10, expectedCalls: 4, expectedErrorPresent: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			calls := 0
+			err := scenario.config.SendWithRetry(context.Background(), func() error {
+				calls++
+				if calls <= scenario.failures {
+					return errors.New("error")
+				}
+				return nil
+			})
+			if calls != scenario.expectedCalls {
+				t.Errorf("expected %d calls, got %d", scenario.expectedCalls, calls)
+			}
+			if (err != nil) != scenario.expectedErrorPresent {
+				t.Errorf("expected error to be present: %t, got %v", scenario.expectedErrorPresent, err)
+			}
+		})
+	}
+}
+
+func TestBaseProviderConfig_SendWithRetrySpreadsRetriesOverTime(t *testing.T) {
+	config := BaseProviderConfig{Retry: &RetryConfig{Attempts: 1, Delay: 100 * time.Millisecond}}
+	start := time.Now()
+	var mutex sync.Mutex
+	var retriedAfter []time.Duration
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempt := 0
+			_ = config.SendWithRetry(context.Background(), func() error {
+				attempt++
+				if attempt == 1 {
+					return errors.New("error")
+				}
+				mutex.Lock()
+				retriedAfter = append(retriedAfter, time.Since(start))
+				mutex.Unlock()
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	if len(retriedAfter) != 20 {
+		t.Fatalf("expected 20 retries, got %d", len(retriedAfter))
+	}
+	earliest, latest := retriedAfter[0], retriedAfter[0]
+	for _, elapsed := range retriedAfter {
+		if elapsed < earliest {
+			earliest = elapsed
+		}
+		if elapsed > latest {
+			latest = elapsed
+		}
+	}
+	if latest-earliest < 20*time.Millisecond {
+		t.Errorf("expected retries to be spread over time, but they all happened within %s", latest-earliest)
+	}
+	if latest > time.Second {
+		t.Errorf("expected retries to happen within the maximum backoff, but the last one happened after %s", latest)
+	}
+}
+
+func TestBaseProviderConfig_SendWithRetryBoundsConcurrentRetries(t *testing.T) {
+	defer func(original func(context.Context, time.Duration) error) { sleep = original }(sleep)
+	sleep = func(context.Context, time.Duration) error { return nil }
+	config := BaseProviderConfig{Retry: &RetryConfig{Attempts: 2, MaximumConcurrency: 2}}
+	var inFlight, maximumInFlight, retries int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempt := 0
+			_ = config.SendWithRetry(context.Background(), func() error {
+				attempt++
+				if attempt == 1 {
+					return errors.New("error")
+				}
+				atomic.AddInt32(&retries, 1)
+				current := atomic.AddInt32(&inFlight, 1)
+				for {
+					maximum := atomic.LoadInt32(&maximumInFlight)
+					if current <= maximum || atomic.CompareAndSwapInt32(&maximumInFlight, maximum, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return errors.New("error")
+			})
+		}()
+	}
+	wg.Wait()
+	if retries != 20 {
+		t.Errorf("expected 20 retries, got %d", retries)
+	}
+	if maximumInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent retries, got %d", maximumInFlight)
+	}
+	if maximumInFlight < 2 {
+		t.Errorf("expected retries to run concurrently up to the limit, got %d", maximumInFlight)
+	}
+}
+
+func TestBaseProviderConfig_SendWithRetryStopsWhenContextIsDone(t *testing.T) {
+	defer func(original func(time.Duration) time.Duration) { randomDuration = original }(randomDuration)
+	randomDuration = func(max time.Duration) time.Duration { return max }
+	config := BaseProviderConfig{Retry: &RetryConfig{Attempts: 5, Delay: time.Minute, MaximumDelay: time.Minute}}
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	err := config.SendWithRetry(ctx, func() error {
+		calls++
+		return errors.New("error")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected the error of the only attempt to be returned, got %v after %d calls", err, calls)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the backoff to be interrupted by the cancellation, but it took %s", elapsed)
+	}
+}
@@ -0,0 +1,52 @@
+package alert
+
+import "errors"
+
+// MaximumSuccessRatioWindow is the largest window a success ratio can be computed over, which matches the number of
+// results kept by the storage for each endpoint
+const MaximumSuccessRatioWindow = 100
+
+var (
+	// ErrAlertWithInvalidSuccessRatio is the error with which Gatus will panic if an alert has a success ratio whose
+	// minimum isn't a percentage or whose window isn't between 1 and MaximumSuccessRatioWindow
+	ErrAlertWithInvalidSuccessRatio = errors.New("alert success-ratio must have a minimum between 0 (exclusive) and 100, and a window between 1 and 100")
+)
+
+// SuccessRatio is an alternative to the failure and success thresholds of an alert for endpoints that are too noisy
+// for streaks to be meaningful: the endpoint is considered healthy as long as at least Minimum percent of its last
+// Window results were successful.
+//
+// The alert is triggered once the ratio drops below the minimum and resolved once it is back to the minimum or above.
+// No decision is made until the endpoint has at least Window results.
+type SuccessRatio struct {
+	// Minimum is the percentage of the results in the window that must be successful, e.g. 80
+	Minimum float64 `yaml:"minimum"`
+
+	// Window is the number of most recent results the ratio is computed over
+	Window int `yaml:"window"`
+}
+
+// ValidateAndSetDefaults validates the success ratio's configuration
+func (ratio *SuccessRatio) ValidateAndSetDefaults() error {
+	if ratio.Minimum <= 0 || ratio.Minimum > 100 || ratio.Window < 1 || ratio.Window > MaximumSuccessRatioWindow {
+		return ErrAlertWithInvalidSuccessRatio
+	}
+	return nil
+}
+
+// Evaluate returns whether the ratio of successes over the last Window entries of history, ordered from the oldest
+// result to the newest, meets the minimum.
+//
+// If history has fewer than Window entries, there isn't enough data to make a decision, and isWindowFull is false.
+func (ratio *SuccessRatio) Evaluate(history []bool) (isMet, isWindowFull bool) {
+	if len(history) < ratio.Window {
+		return false, false
+	}
+	successes := 0
+	for _, success := range history[len(history)-ratio.Window:] {
+		if success {
+			successes++
+		}
+	}
+	return float64(successes)*100 >= ratio.Minimum*float64(ratio.Window), true
+}
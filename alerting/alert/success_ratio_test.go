@@ -0,0 +1,79 @@
+package alert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSuccessRatio_ValidateAndSetDefaults(t *testing.T) {
+	if err := (&SuccessRatio{Minimum: 80, Window: 10}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	for _, ratio := range []*SuccessRatio{{Minimum: 0, Window: 10}, {Minimum: 101, Window: 10}, {Minimum: 80, Window: 0}, {Minimum: 80, Window: MaximumSuccessRatioWindow + 1}} {
+		if err := ratio.ValidateAndSetDefaults(); !errors.Is(err, ErrAlertWithInvalidSuccessRatio) {
+			t.Errorf("expected error %v for %+v, got %v", ErrAlertWithInvalidSuccessRatio, ratio, err)
+		}
+	}
+	if err := (&Alert{SuccessRatio: &SuccessRatio{Minimum: 80}}).ValidateAndSetDefaults(); !errors.Is(err, ErrAlertWithInvalidSuccessRatio) {
+		t.Errorf("expected alert validation to fail with %v, got %v", ErrAlertWithInvalidSuccessRatio, err)
+	}
+}
+
+func TestSuccessRatio_Evaluate(t *testing.T) {
+	ratio := &SuccessRatio{Minimum: 80, Window: 5}
+	scenarios := []struct {
+		name                 string
+		history              []bool
+		expectedIsMet        bool
+		expectedIsWindowFull bool
+	}{
+		{
+			name:                 "empty",
+			history:              nil,
+			expectedIsWindowFull: false,
+		},
+		{
+			name:                 "window-not-full",
+			history:              []bool{false, false, false, false},
+			expectedIsWindowFull: false,
+		},
+		{
+			name:                 "all-successful",
+			history:              []bool{true, true, true, true, true},
+			expectedIsMet:        true,
+			expectedIsWindowFull: true,
+		},
+		{
+			name:                 "exactly-at-minimum",
+			history:              []bool{true, false, true, true, true},
+			expectedIsMet:        true,
+			expectedIsWindowFull: true,
+		},
+		{
+			name:                 "below-minimum",
+			history:              []bool{false, true, true, false, true},
+			expectedIsMet:        false,
+			expectedIsWindowFull: true,
+		},
+		{
+			name:                 "older-results-outside-of-window-are-ignored",
+			history:              []bool{false, false, false, true, true, true, true, true},
+			expectedIsMet:        true,
+			expectedIsWindowFull: true,
+		},
+		{
+			name:                 "recent-failures-despite-older-successes",
+			history:              []bool{true, true, true, true, false, false, true, true},
+			expectedIsMet:        false,
+			expectedIsWindowFull: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			isMet, isWindowFull := ratio.Evaluate(scenario.history)
+			if isMet != scenario.expectedIsMet || isWindowFull != scenario.expectedIsWindowFull {
+				t.Errorf("expected isMet=%v and isWindowFull=%v, got %v and %v", scenario.expectedIsMet, scenario.expectedIsWindowFull, isMet, isWindowFull)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package alert
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrInvalidTimestampTimezone is the error returned when alerting.timestamp.timezone isn't a valid IANA timezone
+	ErrInvalidTimestampTimezone = errors.New("invalid alerting.timestamp.timezone, must be a valid IANA timezone (e.g. America/Toronto)")
+
+	// timestampConfig is the configuration used to render the TimestampPlaceholder
+	timestampConfig atomic.Pointer[TimestampConfig]
+)
+
+// TimestampConfig is the configuration of how the TimestampPlaceholder is rendered in alert messages
+type TimestampConfig struct {
+	// Format is the Go layout used to format the timestamp (e.g. "2006-01-02 15:04:05 MST").
+	// Defaults to RFC3339.
+	Format string `yaml:"format,omitempty"`
+
+	// Timezone is the IANA name of the timezone the timestamp is rendered in (e.g. America/Toronto).
+	// Defaults to UTC.
+	Timezone string `yaml:"timezone,omitempty"`
+
+	location *time.Location
+}
+
+// ValidateAndSetDefaults validates the timestamp configuration and sets the default values if necessary
+func (config *TimestampConfig) ValidateAndSetDefaults() error {
+	if len(config.Format) == 0 {
+		config.Format = time.RFC3339
+	}
+	if len(config.Timezone) == 0 {
+		config.Timezone = "UTC"
+	}
+	location, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		return ErrInvalidTimestampTimezone
+	}
+	config.location = location
+	return nil
+}
+
+// SetTimestampConfig sets the configuration used to render the TimestampPlaceholder of every alert message.
+// Passing nil restores the default, which is RFC3339 in UTC.
+//
+// The configuration must have been validated with ValidateAndSetDefaults.
+func SetTimestampConfig(config *TimestampConfig) {
+	timestampConfig.Store(config)
+}
+
+// FormatTimestamp formats the timestamp as configured by SetTimestampConfig
+func FormatTimestamp(timestamp time.Time) string {
+	config := timestampConfig.Load()
+	if config == nil || config.location == nil {
+		return timestamp.UTC().Format(time.RFC3339)
+	}
+	return timestamp.In(config.location).Format(config.Format)
+}
@@ -0,0 +1,67 @@
+package alert
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimestampConfig_ValidateAndSetDefaults(t *testing.T) {
+	config := &TimestampConfig{}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Format != time.RFC3339 {
+		t.Errorf("expected format to default to %s, got %s", time.RFC3339, config.Format)
+	}
+	if config.Timezone != "UTC" {
+		t.Errorf("expected timezone to default to UTC, got %s", config.Timezone)
+	}
+	if err := (&TimestampConfig{Timezone: "Mars/Olympus_Mons"}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidTimestampTimezone) {
+		t.Errorf("expected error %v, got %v", ErrInvalidTimestampTimezone, err)
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	defer SetTimestampConfig(nil)
+	timestamp := time.Date(2024, 3, 10, 14, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	scenarios := []struct {
+		name     string
+		config   *TimestampConfig
+		expected string
+	}{
+		{
+			name:     "default",
+			config:   nil,
+			expected: "2024-03-10T12:30:00Z",
+		},
+		{
+			name:     "default-format-with-timezone",
+			config:   &TimestampConfig{Timezone: "America/Toronto"},
+			expected: "2024-03-10T08:30:00-04:00",
+		},
+		{
+			name:     "custom-format-with-timezone",
+			config:   &TimestampConfig{Format: "2006-01-02 15:04 MST", Timezone: "Asia/Tokyo"},
+			expected: "2024-03-10 21:30 JST",
+		},
+		{
+			name:     "custom-format-in-utc",
+			config:   &TimestampConfig{Format: time.Kitchen},
+			expected: "12:30PM",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.config != nil {
+				if err := scenario.config.ValidateAndSetDefaults(); err != nil {
+					t.Fatal("expected no error, got", err.Error())
+				}
+			}
+			SetTimestampConfig(scenario.config)
+			if formatted := FormatTimestamp(timestamp); formatted != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, formatted)
+			}
+		})
+	}
+}
@@ -8,6 +8,9 @@ const (
 	// TypeAWSSES is the Type for the awsses alerting provider
 	TypeAWSSES Type = "aws-ses"
 
+	// TypeAWSSNS is the Type for the awssns alerting provider
+	TypeAWSSNS Type = "aws-sns"
+
 	// TypeCustom is the Type for the custom alerting provider
 	TypeCustom Type = "custom"
 
@@ -32,9 +35,24 @@ const (
 	// TypeGotify is the Type for the gotify alerting provider
 	TypeGotify Type = "gotify"
 
+	// TypeHomeAssistant is the Type for the homeassistant alerting provider
+	TypeHomeAssistant Type = "homeassistant"
+
+	// TypeIlert is the Type for the ilert alerting provider
+	TypeIlert Type = "ilert"
+
+	// TypeIncidentIO is the Type for the incidentio alerting provider
+	TypeIncidentIO Type = "incidentio"
+
 	// TypeJetBrainsSpace is the Type for the jetbrains alerting provider
 	TypeJetBrainsSpace Type = "jetbrainsspace"
 
+	// TypeKafka is the Type for the kafka alerting provider
+	TypeKafka Type = "kafka"
+
+	// TypeLog is the Type for the log alerting provider
+	TypeLog Type = "log"
+
 	// TypeMatrix is the Type for the matrix alerting provider
 	TypeMatrix Type = "matrix"
 
@@ -56,9 +74,18 @@ const (
 	// TypePushover is the Type for the pushover alerting provider
 	TypePushover Type = "pushover"
 
+	// TypeRabbitMQ is the Type for the rabbitmq alerting provider
+	TypeRabbitMQ Type = "rabbitmq"
+
+	// TypeRocketChat is the Type for the rocketchat alerting provider
+	TypeRocketChat Type = "rocketchat"
+
 	// TypeSlack is the Type for the slack alerting provider
 	TypeSlack Type = "slack"
 
+	// TypeSplunk is the Type for the splunk alerting provider
+	TypeSplunk Type = "splunk"
+
 	// TypeTeams is the Type for the teams alerting provider
 	TypeTeams Type = "teams"
 
@@ -68,6 +95,9 @@ const (
 	// TypeTwilio is the Type for the twilio alerting provider
 	TypeTwilio Type = "twilio"
 
+	// TypeWebex is the Type for the webex alerting provider
+	TypeWebex Type = "webex"
+
 	// TypeZulip is the Type for the Zulip alerting provider
 	TypeZulip Type = "zulip"
 )
@@ -1,6 +1,8 @@
 package alerting
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"reflect"
 	"strings"
@@ -8,6 +10,7 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/alerting/provider"
 	"github.com/TwiN/gatus/v5/alerting/provider/awsses"
+	"github.com/TwiN/gatus/v5/alerting/provider/awssns"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
@@ -16,7 +19,12 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/gitlab"
 	"github.com/TwiN/gatus/v5/alerting/provider/googlechat"
 	"github.com/TwiN/gatus/v5/alerting/provider/gotify"
+	"github.com/TwiN/gatus/v5/alerting/provider/homeassistant"
+	"github.com/TwiN/gatus/v5/alerting/provider/ilert"
+	"github.com/TwiN/gatus/v5/alerting/provider/incidentio"
 	"github.com/TwiN/gatus/v5/alerting/provider/jetbrainsspace"
+	"github.com/TwiN/gatus/v5/alerting/provider/kafka"
+	"github.com/TwiN/gatus/v5/alerting/provider/logging"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
 	"github.com/TwiN/gatus/v5/alerting/provider/messagebird"
@@ -24,18 +32,51 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/opsgenie"
 	"github.com/TwiN/gatus/v5/alerting/provider/pagerduty"
 	"github.com/TwiN/gatus/v5/alerting/provider/pushover"
+	"github.com/TwiN/gatus/v5/alerting/provider/rabbitmq"
+	"github.com/TwiN/gatus/v5/alerting/provider/rocketchat"
+	"github.com/TwiN/gatus/v5/alerting/provider/secret"
 	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+	"github.com/TwiN/gatus/v5/alerting/provider/splunk"
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
+	"github.com/TwiN/gatus/v5/alerting/provider/webex"
 	"github.com/TwiN/gatus/v5/alerting/provider/zulip"
 )
 
+// MissingSecretPolicy is the policy applied when an alerting provider references an environment variable that is not
+// set, which would otherwise only surface once an alert fails to be sent
+type MissingSecretPolicy string
+
+const (
+	// MissingSecretPolicyWarn logs a warning for each provider referencing an environment variable that is not set
+	MissingSecretPolicyWarn MissingSecretPolicy = "warn"
+
+	// MissingSecretPolicyFail prevents the configuration from being loaded if a provider references an environment
+	// variable that is not set
+	MissingSecretPolicyFail MissingSecretPolicy = "fail"
+)
+
 // Config is the configuration for alerting providers
 type Config struct {
+	// Muted is whether all alerts should be muted when Gatus starts.
+	// Alerts can also be muted and unmuted at runtime through the API.
+	Muted bool `yaml:"muted,omitempty"`
+
+	// MissingSecretPolicy is what to do when a provider references an environment variable that is not set.
+	// Defaults to MissingSecretPolicyWarn.
+	MissingSecretPolicy MissingSecretPolicy `yaml:"missing-secret-policy,omitempty"`
+
+	// Timestamp is the configuration of how the [TIMESTAMP] placeholder is rendered in alert messages.
+	// Defaults to RFC3339 in UTC.
+	Timestamp *alert.TimestampConfig `yaml:"timestamp,omitempty"`
+
 	// AWSSimpleEmailService is the configuration for the aws-ses alerting provider
 	AWSSimpleEmailService *awsses.AlertProvider `yaml:"aws-ses,omitempty"`
 
+	// AWSSimpleNotificationService is the configuration for the aws-sns alerting provider
+	AWSSimpleNotificationService *awssns.AlertProvider `yaml:"aws-sns,omitempty"`
+
 	// Custom is the configuration for the custom alerting provider
 	Custom *custom.AlertProvider `yaml:"custom,omitempty"`
 
@@ -60,9 +101,24 @@ type Config struct {
 	// Gotify is the configuration for the gotify alerting provider
 	Gotify *gotify.AlertProvider `yaml:"gotify,omitempty"`
 
+	// HomeAssistant is the configuration for the homeassistant alerting provider
+	HomeAssistant *homeassistant.AlertProvider `yaml:"homeassistant,omitempty"`
+
+	// Ilert is the configuration for the ilert alerting provider
+	Ilert *ilert.AlertProvider `yaml:"ilert,omitempty"`
+
+	// IncidentIO is the configuration for the incidentio alerting provider
+	IncidentIO *incidentio.AlertProvider `yaml:"incidentio,omitempty"`
+
 	// JetBrainsSpace is the configuration for the jetbrains space alerting provider
 	JetBrainsSpace *jetbrainsspace.AlertProvider `yaml:"jetbrainsspace,omitempty"`
 
+	// Kafka is the configuration for the kafka alerting provider
+	Kafka *kafka.AlertProvider `yaml:"kafka,omitempty"`
+
+	// Log is the configuration for the log alerting provider
+	Log *logging.AlertProvider `yaml:"log,omitempty"`
+
 	// Matrix is the configuration for the matrix alerting provider
 	Matrix *matrix.AlertProvider `yaml:"matrix,omitempty"`
 
@@ -84,9 +140,18 @@ type Config struct {
 	// Pushover is the configuration for the pushover alerting provider
 	Pushover *pushover.AlertProvider `yaml:"pushover,omitempty"`
 
+	// RabbitMQ is the configuration for the rabbitmq alerting provider
+	RabbitMQ *rabbitmq.AlertProvider `yaml:"rabbitmq,omitempty"`
+
+	// RocketChat is the configuration for the rocketchat alerting provider
+	RocketChat *rocketchat.AlertProvider `yaml:"rocketchat,omitempty"`
+
 	// Slack is the configuration for the slack alerting provider
 	Slack *slack.AlertProvider `yaml:"slack,omitempty"`
 
+	// Splunk is the configuration for the splunk alerting provider
+	Splunk *splunk.AlertProvider `yaml:"splunk,omitempty"`
+
 	// Teams is the configuration for the teams alerting provider
 	Teams *teams.AlertProvider `yaml:"teams,omitempty"`
 
@@ -96,10 +161,27 @@ type Config struct {
 	// Twilio is the configuration for the twilio alerting provider
 	Twilio *twilio.AlertProvider `yaml:"twilio,omitempty"`
 
+	// Webex is the configuration for the webex alerting provider
+	Webex *webex.AlertProvider `yaml:"webex,omitempty"`
+
 	// Zulip is the configuration for the zulip alerting provider
 	Zulip *zulip.AlertProvider `yaml:"zulip,omitempty"`
 }
 
+// IsMutedByDefault returns whether alerts should be muted when the configuration is loaded
+func (config *Config) IsMutedByDefault() bool {
+	return config != nil && config.Muted
+}
+
+// GetTimestampConfig returns the configuration of how the [TIMESTAMP] placeholder is rendered, or nil if the default
+// should be used
+func (config *Config) GetTimestampConfig() *alert.TimestampConfig {
+	if config == nil {
+		return nil
+	}
+	return config.Timestamp
+}
+
 // GetAlertingProviderByAlertType returns an provider.AlertProvider by its corresponding alert.Type
 func (config *Config) GetAlertingProviderByAlertType(alertType alert.Type) provider.AlertProvider {
 	entityType := reflect.TypeOf(config).Elem()
@@ -129,3 +211,39 @@ func (config *Config) SetAlertingProviderToNil(p provider.AlertProvider) {
 		}
 	}
 }
+
+// ResolveSecretFiles replaces every value of the alerting providers' configuration that references a file through
+// secret.FilePrefix, such as a webhook URL or a token mounted as a Docker or Kubernetes secret, by the file's content.
+func (config *Config) ResolveSecretFiles() error {
+	value := reflect.ValueOf(config).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		if _, ok := field.Interface().(provider.AlertProvider); !ok {
+			continue
+		}
+		if err := secret.ResolveFiles(field.Interface()); err != nil {
+			return fmt.Errorf("provider=%s: %w", strings.Split(value.Type().Field(i).Tag.Get("yaml"), ",")[0], err)
+		}
+	}
+	return nil
+}
+
+// Close closes all alerting providers that hold resources which must be released on shutdown, such as connections
+// to a message broker.
+func (config *Config) Close() {
+	value := reflect.ValueOf(config).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		if closer, ok := field.Interface().(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Printf("[alerting.Close] Failed to close alerting provider %s: %s", value.Type().Field(i).Name, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package alerting
+
+import "sync/atomic"
+
+// muted is whether all alerts are currently muted
+var muted atomic.Bool
+
+// Mute mutes all alerts until Unmute is called.
+// While muted, endpoints are still monitored, but no alerts are sent.
+func Mute() {
+	muted.Store(true)
+}
+
+// Unmute resumes sending alerts
+func Unmute() {
+	muted.Store(false)
+}
+
+// IsMuted returns whether all alerts are currently muted
+func IsMuted() bool {
+	return muted.Load()
+}
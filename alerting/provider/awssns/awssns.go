@@ -0,0 +1,184 @@
+package awssns
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+)
+
+const (
+	// MaximumSubjectLength is the maximum length of the subject of an SNS message
+	MaximumSubjectLength = 100
+)
+
+// AlertProvider is the configuration necessary for sending an alert using AWS Simple Notification Service
+type AlertProvider struct {
+	AccessKeyID     string `yaml:"access-key-id"`
+	SecretAccessKey string `yaml:"secret-access-key"`
+	Region          string `yaml:"region"`
+
+	// TopicARN is the ARN of the SNS topic to publish the alerts to
+	TopicARN string `yaml:"topic-arn"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+
+	// client is the SNS client used to publish messages.
+	// It is created on the first alert, unless one was injected for testing purposes.
+	client snsiface.SNSAPI
+	mutex  sync.Mutex
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group    string `yaml:"group"`
+	TopicARN string `yaml:"topic-arn"`
+}
+
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	registeredGroups := make(map[string]bool)
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.TopicARN) == 0 {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	// if both AccessKeyID and SecretAccessKey are specified, we'll use these to authenticate,
+	// otherwise if neither are specified, then we'll fall back on the default credential chain.
+	return len(provider.TopicARN) > 0 && len(provider.Region) > 0 &&
+		((len(provider.AccessKeyID) == 0 && len(provider.SecretAccessKey) == 0) || (len(provider.AccessKeyID) > 0 && len(provider.SecretAccessKey) > 0))
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	svc, err := provider.getClient()
+	if err != nil {
+		return err
+	}
+	subject, message, err := provider.buildMessageSubjectAndBody(ep, alert, result, resolved)
+	if err != nil {
+		return err
+	}
+	_, err = svc.Publish(&sns.PublishInput{
+		TopicArn: aws.String(provider.getTopicARNForGroup(ep.Group)),
+		Subject:  aws.String(subject),
+		Message:  aws.String(message),
+	})
+	if err != nil {
+		return fmt.Errorf("error publishing alert to sns topic: %w", err)
+	}
+	return nil
+}
+
+type Message struct {
+	Endpoint         string             `json:"endpoint"`
+	Group            string             `json:"group,omitempty"`
+	Key              string             `json:"key"`
+	State            string             `json:"state"`
+	Description      string             `json:"description,omitempty"`
+	ConditionResults []*ConditionResult `json:"conditionResults,omitempty"`
+}
+
+type ConditionResult struct {
+	Condition string `json:"condition"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+}
+
+// buildMessageSubjectAndBody builds the message subject and body
+func (provider *AlertProvider) buildMessageSubjectAndBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) (string, string, error) {
+	var subject, state string
+	if resolved {
+		subject = fmt.Sprintf("[%s] Alert resolved", ep.DisplayName())
+		state = "resolved"
+	} else {
+		subject = fmt.Sprintf("[%s] Alert triggered", ep.DisplayName())
+		state = "triggered"
+	}
+	if len(subject) > MaximumSubjectLength {
+		subject = subject[:MaximumSubjectLength]
+	}
+	message := Message{
+		Endpoint:    ep.Name,
+		Group:       ep.Group,
+		Key:         ep.Key(),
+		State:       state,
+		Description: alert.GetDescription(),
+	}
+	for _, conditionResult := range result.ConditionResults {
+		message.ConditionResults = append(message.ConditionResults, &ConditionResult{
+			Condition: conditionResult.Condition,
+			Success:   conditionResult.Success,
+			Message:   conditionResult.Message,
+		})
+	}
+	body, err := json.Marshal(message)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, string(body), nil
+}
+
+// getTopicARNForGroup returns the appropriate topic ARN for a given group
+func (provider *AlertProvider) getTopicARNForGroup(group string) string {
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.TopicARN
+	}
+	return provider.TopicARN
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
+
+// getClient returns the provider's SNS client, creating it if it doesn't exist yet.
+// Alerts for different endpoints may be sent concurrently, hence the mutex.
+func (provider *AlertProvider) getClient() (snsiface.SNSAPI, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.client != nil {
+		return provider.client, nil
+	}
+	config := &aws.Config{
+		Region: aws.String(provider.Region),
+	}
+	if len(provider.AccessKeyID) > 0 && len(provider.SecretAccessKey) > 0 {
+		config.Credentials = credentials.NewStaticCredentials(provider.AccessKeyID, provider.SecretAccessKey, "")
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	provider.client = sns.New(sess)
+	return provider.client, nil
+}
@@ -0,0 +1,226 @@
+package awssns
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+)
+
+type mockSNSClient struct {
+	snsiface.SNSAPI
+	input *sns.PublishInput
+	err   error
+}
+
+func (m *mockSNSClient) Publish(input *sns.PublishInput) (*sns.PublishOutput, error) {
+	m.input = input
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &sns.PublishOutput{MessageId: aws.String("1")}, nil
+}
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	invalidProvider := AlertProvider{}
+	if invalidProvider.IsValid() {
+		t.Error("provider shouldn't have been valid")
+	}
+	invalidProviderWithoutRegion := AlertProvider{TopicARN: "arn:aws:sns:us-east-1:123456789012:gatus"}
+	if invalidProviderWithoutRegion.IsValid() {
+		t.Error("provider shouldn't have been valid")
+	}
+	invalidProviderWithOneKey := AlertProvider{TopicARN: "arn:aws:sns:us-east-1:123456789012:gatus", Region: "us-east-1", AccessKeyID: "1"}
+	if invalidProviderWithOneKey.IsValid() {
+		t.Error("provider shouldn't have been valid")
+	}
+	validProvider := AlertProvider{TopicARN: "arn:aws:sns:us-east-1:123456789012:gatus", Region: "us-east-1"}
+	if !validProvider.IsValid() {
+		t.Error("provider should've been valid")
+	}
+	validProviderWithKeys := AlertProvider{TopicARN: "arn:aws:sns:us-east-1:123456789012:gatus", Region: "us-east-1", AccessKeyID: "1", SecretAccessKey: "1"}
+	if !validProviderWithKeys.IsValid() {
+		t.Error("provider should've been valid")
+	}
+}
+
+func TestAlertProvider_IsValidWithOverride(t *testing.T) {
+	providerWithInvalidOverrideGroup := AlertProvider{
+		TopicARN:  "arn:aws:sns:us-east-1:123456789012:gatus",
+		Region:    "us-east-1",
+		Overrides: []Override{{TopicARN: "arn:aws:sns:us-east-1:123456789012:core", Group: ""}},
+	}
+	if providerWithInvalidOverrideGroup.IsValid() {
+		t.Error("provider Group shouldn't have been valid")
+	}
+	providerWithInvalidOverrideTopicARN := AlertProvider{
+		TopicARN:  "arn:aws:sns:us-east-1:123456789012:gatus",
+		Region:    "us-east-1",
+		Overrides: []Override{{TopicARN: "", Group: "group"}},
+	}
+	if providerWithInvalidOverrideTopicARN.IsValid() {
+		t.Error("provider topic ARN shouldn't have been valid")
+	}
+	providerWithValidOverride := AlertProvider{
+		TopicARN:  "arn:aws:sns:us-east-1:123456789012:gatus",
+		Region:    "us-east-1",
+		Overrides: []Override{{TopicARN: "arn:aws:sns:us-east-1:123456789012:core", Group: "group"}},
+	}
+	if !providerWithValidOverride.IsValid() {
+		t.Error("provider should've been valid")
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	description := "description"
+	scenarios := []struct {
+		Name             string
+		Provider         *AlertProvider
+		Group            string
+		Resolved         bool
+		PublishErr       error
+		ExpectedTopicARN string
+		ExpectedSubject  string
+		ExpectedMessage  string
+		ExpectedErr      bool
+	}{
+		{
+			Name:             "triggered",
+			Provider:         &AlertProvider{TopicARN: "arn:aws:sns:us-east-1:123456789012:gatus", Region: "us-east-1"},
+			Resolved:         false,
+			ExpectedTopicARN: "arn:aws:sns:us-east-1:123456789012:gatus",
+			ExpectedSubject:  "[endpoint-name] Alert triggered",
+			ExpectedMessage:  `{"endpoint":"endpoint-name","key":"_endpoint-name","state":"triggered","description":"description","conditionResults":[{"condition":"[CONNECTED] == true","success":false},{"condition":"[STATUS] == 200","success":false}]}`,
+		},
+		{
+			Name: "resolved-with-group-override",
+			Provider: &AlertProvider{
+				TopicARN:  "arn:aws:sns:us-east-1:123456789012:gatus",
+				Region:    "us-east-1",
+				Overrides: []Override{{Group: "core", TopicARN: "arn:aws:sns:us-east-1:123456789012:core"}},
+			},
+			Group:            "core",
+			Resolved:         true,
+			ExpectedTopicARN: "arn:aws:sns:us-east-1:123456789012:core",
+			ExpectedSubject:  "[core/endpoint-name] Alert resolved",
+			ExpectedMessage:  `{"endpoint":"endpoint-name","group":"core","key":"core_endpoint-name","state":"resolved","description":"description","conditionResults":[{"condition":"[CONNECTED] == true","success":true},{"condition":"[STATUS] == 200","success":true}]}`,
+		},
+		{
+			Name:             "publish-error",
+			Provider:         &AlertProvider{TopicARN: "arn:aws:sns:us-east-1:123456789012:gatus", Region: "us-east-1"},
+			PublishErr:       errors.New("access denied"),
+			ExpectedTopicARN: "arn:aws:sns:us-east-1:123456789012:gatus",
+			ExpectedSubject:  "[endpoint-name] Alert triggered",
+			ExpectedMessage:  `{"endpoint":"endpoint-name","key":"_endpoint-name","state":"triggered","description":"description","conditionResults":[{"condition":"[CONNECTED] == true","success":false},{"condition":"[STATUS] == 200","success":false}]}`,
+			ExpectedErr:      true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			mockClient := &mockSNSClient{err: scenario.PublishErr}
+			scenario.Provider.client = mockClient
+			err := scenario.Provider.Send(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: scenario.Group},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedErr && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedErr && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+			if mockClient.input == nil {
+				t.Fatal("expected Publish to have been called")
+			}
+			if topicARN := aws.StringValue(mockClient.input.TopicArn); topicARN != scenario.ExpectedTopicARN {
+				t.Errorf("expected topic ARN to be %s, got %s", scenario.ExpectedTopicARN, topicARN)
+			}
+			if subject := aws.StringValue(mockClient.input.Subject); subject != scenario.ExpectedSubject {
+				t.Errorf("expected subject to be %s, got %s", scenario.ExpectedSubject, subject)
+			}
+			if message := aws.StringValue(mockClient.input.Message); message != scenario.ExpectedMessage {
+				t.Errorf("expected message to be %s, got %s", scenario.ExpectedMessage, message)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildMessageSubjectAndBodyWithLongSubject(t *testing.T) {
+	subject, _, err := (&AlertProvider{}).buildMessageSubjectAndBody(
+		&endpoint.Endpoint{Name: string(make([]byte, 200))},
+		&alert.Alert{},
+		&endpoint.Result{},
+		false,
+	)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(subject) != MaximumSubjectLength {
+		t.Errorf("expected subject to be truncated to %d characters, got %d", MaximumSubjectLength, len(subject))
+	}
+}
+
+func TestAlertProvider_getClientConcurrently(t *testing.T) {
+	provider := &AlertProvider{Region: "us-east-1", AccessKeyID: "1", SecretAccessKey: "1"}
+	clients := make([]snsiface.SNSAPI, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := provider.getClient()
+			if err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+	for _, client := range clients {
+		if client != clients[0] {
+			t.Fatal("expected every alert to share the same client")
+		}
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
+
+func TestAlertProvider_getTopicARNForGroup(t *testing.T) {
+	provider := AlertProvider{
+		TopicARN: "arn:aws:sns:us-east-1:123456789012:gatus",
+		Overrides: []Override{
+			{Group: "prod-*", TopicARN: "arn:aws:sns:us-east-1:123456789012:prod"},
+			{Group: "prod-eu", TopicARN: "arn:aws:sns:eu-west-1:123456789012:prod-eu"},
+		},
+	}
+	scenarios := map[string]string{
+		"":        "arn:aws:sns:us-east-1:123456789012:gatus",
+		"staging": "arn:aws:sns:us-east-1:123456789012:gatus",
+		"prod-us": "arn:aws:sns:us-east-1:123456789012:prod",
+		"prod-eu": "arn:aws:sns:eu-west-1:123456789012:prod-eu",
+	}
+	for group, expected := range scenarios {
+		if got := provider.getTopicARNForGroup(group); got != expected {
+			t.Errorf("expected topic ARN for group %q to be %s, got %s", group, expected, got)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package custom
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +15,11 @@ import (
 	"github.com/TwiN/gatus/v5/config/endpoint"
 )
 
+const (
+	// DefaultSignatureHeader is the default name of the header containing the signature of the request body
+	DefaultSignatureHeader = "X-Gatus-Signature"
+)
+
 // AlertProvider is the configuration necessary for sending an alert using a custom HTTP request
 // Technically, all alert providers should be reachable using the custom alert provider
 type AlertProvider struct {
@@ -21,11 +29,20 @@ type AlertProvider struct {
 	Headers      map[string]string            `yaml:"headers,omitempty"`
 	Placeholders map[string]map[string]string `yaml:"placeholders,omitempty"`
 
+	// SigningSecret is the secret used to sign the request body using HMAC-SHA256 (optional)
+	SigningSecret string `yaml:"signing-secret,omitempty"`
+
+	// SignatureHeader is the name of the header in which the signature is set. Defaults to DefaultSignatureHeader
+	SignatureHeader string `yaml:"signature-header,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with the provider's target
 	ClientConfig *client.Config `yaml:"client,omitempty"`
 
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -52,16 +69,26 @@ func (provider *AlertProvider) GetAlertStatePlaceholderValue(resolved bool) stri
 
 func (provider *AlertProvider) buildHTTPRequest(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) *http.Request {
 	body, url, method := provider.Body, provider.URL, provider.Method
+	body = strings.ReplaceAll(body, "[ALERT_TYPE]", string(alert.Type))
+	url = strings.ReplaceAll(url, "[ALERT_TYPE]", string(alert.Type))
+	body = strings.ReplaceAll(body, "[ALERT_NAME]", alert.GetName())
+	url = strings.ReplaceAll(url, "[ALERT_NAME]", alert.GetName())
 	body = strings.ReplaceAll(body, "[ALERT_DESCRIPTION]", alert.GetDescription())
 	url = strings.ReplaceAll(url, "[ALERT_DESCRIPTION]", alert.GetDescription())
 	body = strings.ReplaceAll(body, "[ENDPOINT_NAME]", ep.Name)
 	url = strings.ReplaceAll(url, "[ENDPOINT_NAME]", ep.Name)
 	body = strings.ReplaceAll(body, "[ENDPOINT_GROUP]", ep.Group)
 	url = strings.ReplaceAll(url, "[ENDPOINT_GROUP]", ep.Group)
-	body = strings.ReplaceAll(body, "[ENDPOINT_URL]", ep.URL)
-	url = strings.ReplaceAll(url, "[ENDPOINT_URL]", ep.URL)
+	messageContext := ep.AlertMessageContext(result)
+	body = strings.ReplaceAll(body, "[ENDPOINT_URL]", messageContext.EndpointURL)
+	url = strings.ReplaceAll(url, "[ENDPOINT_URL]", messageContext.EndpointURL)
+	body = strings.ReplaceAll(body, "[ENDPOINT_TARGET]", messageContext.EndpointTarget)
+	url = strings.ReplaceAll(url, "[ENDPOINT_TARGET]", messageContext.EndpointTarget)
 	body = strings.ReplaceAll(body, "[RESULT_ERRORS]", strings.Join(result.Errors, ","))
 	url = strings.ReplaceAll(url, "[RESULT_ERRORS]", strings.Join(result.Errors, ","))
+	timestamp := messageContext.FormattedTimestamp()
+	body = strings.ReplaceAll(body, "[TIMESTAMP]", timestamp)
+	url = strings.ReplaceAll(url, "[TIMESTAMP]", timestamp)
 	if resolved {
 		body = strings.ReplaceAll(body, "[ALERT_TRIGGERED_OR_RESOLVED]", provider.GetAlertStatePlaceholderValue(true))
 		url = strings.ReplaceAll(url, "[ALERT_TRIGGERED_OR_RESOLVED]", provider.GetAlertStatePlaceholderValue(true))
@@ -77,9 +104,23 @@ func (provider *AlertProvider) buildHTTPRequest(ep *endpoint.Endpoint, alert *al
 	for k, v := range provider.Headers {
 		request.Header.Set(k, v)
 	}
+	if len(provider.SigningSecret) > 0 {
+		signatureHeader := provider.SignatureHeader
+		if len(signatureHeader) == 0 {
+			signatureHeader = DefaultSignatureHeader
+		}
+		request.Header.Set(signatureHeader, provider.sign([]byte(body)))
+	}
 	return request
 }
 
+// sign returns the signature of the body in the format sha256=<hex-encoded HMAC-SHA256 of the body>
+func (provider *AlertProvider) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(provider.SigningSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
 func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
 	request := provider.buildHTTPRequest(ep, alert, result, resolved)
 	response, err := client.GetHTTPClient(provider.ClientConfig).Do(request)
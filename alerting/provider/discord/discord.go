@@ -5,13 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
-	"os"
-	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
+	"github.com/TwiN/gatus/v5/alerting/provider/secret"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
 // AlertProvider is the configuration necessary for sending an alert using Discord
@@ -21,29 +23,54 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
 
 	// Title is the title of the message that will be sent
 	Title string `yaml:"title,omitempty"`
+
+	// IncludeDescriptionInTitle is whether the description of the alert should be appended to the title
+	IncludeDescriptionInTitle bool `yaml:"include-description-in-title,omitempty"`
+
+	// UseEmbeds is whether the message should be sent as an embed rather than as plain content.
+	// Defaults to true. See IsUsingEmbeds.
+	UseEmbeds *bool `yaml:"use-embeds,omitempty"`
 }
 
 // Override is a case under which the default integration is overridden
 type Override struct {
-	Group      string `yaml:"group"`
-	WebhookURL string `yaml:"webhook-url"`
+	Group string `yaml:"group,omitempty"`
+	// Labels, if set, are the labels an endpoint must have for the override to apply. If Group is also set, the
+	// endpoint must match both.
+	Labels     map[string]string `yaml:"labels,omitempty"`
+	WebhookURL string            `yaml:"webhook-url"`
 }
 
-const (
-	DISCORD_WEBHOOK_URL_PREFIX = "$"
-)
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns the labels an endpoint must have for the override to apply
+func (override Override) GetLabels() map[string]string {
+	return override.Labels
+}
 
 // IsValid returns whether the provider's configuration is valid
 func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 {
+			if len(override.Labels) > 0 {
+				if !pattern.IsValidGroup(override.Group) || len(override.WebhookURL) == 0 {
+					return false
+				}
+				continue
+			}
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.WebhookURL) == 0 {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -55,7 +82,7 @@ func (provider *AlertProvider) IsValid() bool {
 // Send an alert using the provider
 func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
 	buffer := bytes.NewBuffer(provider.buildRequestBody(ep, alert, result, resolved))
-	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForGroup(ep.Group), buffer)
+	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForEndpoint(ep), buffer)
 	if err != nil {
 		return err
 	}
@@ -74,11 +101,12 @@ func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, r
 
 type Body struct {
 	Content string  `json:"content"`
-	Embeds  []Embed `json:"embeds"`
+	Embeds  []Embed `json:"embeds,omitempty"`
 }
 
 type Embed struct {
 	Title       string  `json:"title"`
+	URL         string  `json:"url,omitempty"`
 	Description string  `json:"description"`
 	Color       int     `json:"color"`
 	Fields      []Field `json:"fields,omitempty"`
@@ -101,6 +129,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		message = fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 		colorCode = 15158332
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	var formattedConditionResults string
 	for _, conditionResult := range result.ConditionResults {
 		var prefix string
@@ -109,21 +138,36 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		} else {
 			prefix = ":x:"
 		}
-		formattedConditionResults += fmt.Sprintf("%s - `%s`\n", prefix, conditionResult.Condition)
+		formattedConditionResults += fmt.Sprintf("%s - `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 	var description string
-	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
-		description = ":\n> " + alertDescription
-	}
 	title := ":helmet_with_white_cross: Gatus"
 	if provider.Title != "" {
 		title = provider.Title
 	}
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		description = ":\n> " + alertDescription
+		if provider.IncludeDescriptionInTitle {
+			title += " - " + alertDescription
+		}
+	}
+	if !provider.IsUsingEmbeds() {
+		content := "**" + title + "**\n" + message + description
+		if len(ep.PageURL) > 0 {
+			content += "\n" + ep.PageURL
+		}
+		if len(formattedConditionResults) > 0 {
+			content += "\n\n**Condition results**\n" + formattedConditionResults
+		}
+		bodyAsJSON, _ := json.Marshal(Body{Content: content})
+		return bodyAsJSON
+	}
 	body := Body{
 		Content: "",
 		Embeds: []Embed{
 			{
 				Title:       title,
+				URL:         ep.PageURL,
 				Description: message + description,
 				Color:       colorCode,
 			},
@@ -140,29 +184,29 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	return bodyAsJSON
 }
 
-// getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
-func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.WebhookURL
-			}
-		}
+// getWebhookURLForEndpoint returns the appropriate Webhook URL for a given endpoint.
+// Overrides with labels take precedence over overrides that only have a group (see override.Resolve).
+func (provider *AlertProvider) getWebhookURLForEndpoint(ep *endpoint.Endpoint) string {
+	if o, found := override.Resolve(provider.Overrides, ep.Group, ep.Labels); found {
+		return o.WebhookURL
 	}
 
-	// Check if the discord Webhook url is a secret
-	if strings.HasPrefix(provider.WebhookURL, DISCORD_WEBHOOK_URL_PREFIX) {
-		substr := strings.ReplaceAll(provider.WebhookURL, DISCORD_WEBHOOK_URL_PREFIX, "")
-		v, found := os.LookupEnv(substr)
-		if !found {
-			fmt.Println("error fetching discord webhook url env var")
-		}
-
-		fmt.Printf("discord webhook url value: %s\n", v)
-		provider.WebhookURL = v
+	// The webhook URL may reference an environment variable or a file holding the secret
+	webhookURL, err := secret.Resolve(provider.WebhookURL)
+	if err != nil {
+		log.Printf("[discord.getWebhookURLForEndpoint] Failed to resolve webhook-url: %s", err.Error())
 	}
+	return webhookURL
+}
+
+// getWebhookURLForGroup returns the appropriate Webhook URL for a given group, ignoring overrides with labels
+func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
+	return provider.getWebhookURLForEndpoint(&endpoint.Endpoint{Group: group})
+}
 
-	return provider.WebhookURL
+// IsUsingEmbeds returns whether messages are sent as embeds, which is the case unless UseEmbeds is explicitly false
+func (provider *AlertProvider) IsUsingEmbeds() bool {
+	return provider.UseEmbeds == nil || *provider.UseEmbeds
 }
 
 // GetDefaultAlert returns the provider's default alert configuration
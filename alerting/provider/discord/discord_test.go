@@ -45,6 +45,18 @@ func TestAlertProvider_IsValidWithOverride(t *testing.T) {
 	if providerWithInvalidOverrideTo.IsValid() {
 		t.Error("provider integration key shouldn't have been valid")
 	}
+	providerWithInvalidOverrideRegex := AlertProvider{
+		WebhookURL: "http://example.com",
+		Overrides: []Override{
+			{
+				WebhookURL: "http://example.com",
+				Group:      "regex:(",
+			},
+		},
+	}
+	if providerWithInvalidOverrideRegex.IsValid() {
+		t.Error("provider Group with invalid regex shouldn't have been valid")
+	}
 	providerWithValidOverride := AlertProvider{
 		WebhookURL: "http://example.com",
 		Overrides: []Override{
@@ -180,6 +192,27 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     false,
 			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\"provider-title\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"color\":15158332,\"fields\":[{\"name\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n:x: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false}]}]}",
 		},
+		{
+			Name:         "triggered-with-description-in-title",
+			Provider:     AlertProvider{IncludeDescriptionInTitle: true},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\":helmet_with_white_cross: Gatus - description-1\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"color\":15158332,\"fields\":[{\"name\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n:x: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false}]}]}",
+		},
+		{
+			Name:         "resolved-with-modified-title-and-description-in-title",
+			Provider:     AlertProvider{Title: title, IncludeDescriptionInTitle: true},
+			Alert:        alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\"provider-title - description-2\",\"description\":\"An alert for **endpoint-name** has been resolved after passing successfully 5 time(s) in a row:\\n\\u003e description-2\",\"color\":3066993,\"fields\":[{\"name\":\"Condition results\",\"value\":\":white_check_mark: - `[CONNECTED] == true`\\n:white_check_mark: - `[STATUS] == 200`\\n:white_check_mark: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false}]}]}",
+		},
+		{
+			Name:         "triggered-with-description-in-title-but-no-description",
+			Provider:     AlertProvider{IncludeDescriptionInTitle: true},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row\",\"color\":15158332,\"fields\":[{\"name\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n:x: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false}]}]}",
+		},
 		{
 			Name:         "triggered-with-no-conditions",
 			NoConditions: true,
@@ -188,6 +221,22 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     false,
 			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\"provider-title\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"color\":15158332}]}",
 		},
+		{
+			Name:         "triggered-with-custom-message",
+			NoConditions: true,
+			Provider:     AlertProvider{Title: title},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, TriggerMessage: "[ENDPOINT_NAME] is down, see https://runbook.example.org"},
+			Resolved:     false,
+			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\"provider-title\",\"description\":\"endpoint-name is down, see https://runbook.example.org:\\n\\u003e description-1\",\"color\":15158332}]}",
+		},
+		{
+			Name:         "resolved-with-custom-message",
+			NoConditions: true,
+			Provider:     AlertProvider{Title: title},
+			Alert:        alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3, TriggerMessage: "[ENDPOINT_NAME] is down", ResolveMessage: "[ENDPOINT_NAME] is back up after [SUCCESS_THRESHOLD] successes"},
+			Resolved:     true,
+			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\"provider-title\",\"description\":\"endpoint-name is back up after 5 successes:\\n\\u003e description-2\",\"color\":3066993}]}",
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -218,6 +267,97 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildRequestBodyWithPageURL(t *testing.T) {
+	scenarios := []struct {
+		Name        string
+		PageURL     string
+		ExpectedURL string
+	}{
+		{Name: "with-page-url", PageURL: "https://status.example.org/endpoints/core_endpoint-name", ExpectedURL: "https://status.example.org/endpoints/core_endpoint-name"},
+		{Name: "without-page-url", PageURL: "", ExpectedURL: ""},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			bodyAsJSON := (&AlertProvider{}).buildRequestBody(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: "core", PageURL: scenario.PageURL},
+				&alert.Alert{FailureThreshold: 3},
+				&endpoint.Result{},
+				false,
+			)
+			var body Body
+			if err := json.Unmarshal(bodyAsJSON, &body); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if len(body.Embeds) != 1 || body.Embeds[0].URL != scenario.ExpectedURL {
+				t.Errorf("expected embed url to be %q, got %s", scenario.ExpectedURL, bodyAsJSON)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBodyWithoutEmbeds(t *testing.T) {
+	useEmbeds := false
+	description := "description-1"
+	scenarios := []struct {
+		Name            string
+		Endpoint        endpoint.Endpoint
+		Resolved        bool
+		ExpectedContent string
+	}{
+		{
+			Name:            "triggered",
+			Endpoint:        endpoint.Endpoint{Name: "endpoint-name"},
+			Resolved:        false,
+			ExpectedContent: "**:helmet_with_white_cross: Gatus**\nAn alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\n> description-1\n\n**Condition results**\n:x: - `[CONNECTED] == true`\n:x: - `[STATUS] == 200`\n",
+		},
+		{
+			Name:            "resolved-with-page-url",
+			Endpoint:        endpoint.Endpoint{Name: "endpoint-name", PageURL: "https://status.example.org/endpoints/_endpoint-name"},
+			Resolved:        true,
+			ExpectedContent: "**:helmet_with_white_cross: Gatus**\nAn alert for **endpoint-name** has been resolved after passing successfully 5 time(s) in a row:\n> description-1\nhttps://status.example.org/endpoints/_endpoint-name\n\n**Condition results**\n:white_check_mark: - `[CONNECTED] == true`\n:white_check_mark: - `[STATUS] == 200`\n",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			provider := AlertProvider{UseEmbeds: &useEmbeds}
+			bodyAsJSON := provider.buildRequestBody(
+				&scenario.Endpoint,
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			var body map[string]interface{}
+			if err := json.Unmarshal(bodyAsJSON, &body); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if _, hasEmbeds := body["embeds"]; hasEmbeds {
+				t.Error("expected body not to have embeds, got", string(bodyAsJSON))
+			}
+			if body["content"] != scenario.ExpectedContent {
+				t.Errorf("expected content to be %q, got %q", scenario.ExpectedContent, body["content"])
+			}
+		})
+	}
+}
+
+func TestAlertProvider_IsUsingEmbeds(t *testing.T) {
+	yes, no := true, false
+	if !(&AlertProvider{}).IsUsingEmbeds() {
+		t.Error("expected embeds to be used by default")
+	}
+	if !(&AlertProvider{UseEmbeds: &yes}).IsUsingEmbeds() {
+		t.Error("expected embeds to be used when use-embeds is true")
+	}
+	if (&AlertProvider{UseEmbeds: &no}).IsUsingEmbeds() {
+		t.Error("expected embeds not to be used when use-embeds is false")
+	}
+}
+
 func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
 		t.Error("expected default alert to be not nil")
@@ -280,6 +420,84 @@ func TestAlertProvider_getWebhookURLForGroup(t *testing.T) {
 			InputGroup:     "group",
 			ExpectedOutput: "http://example01.com",
 		},
+		{
+			Name: "provider-with-wildcard-override-specify-matching-group-should-override",
+			Provider: AlertProvider{
+				WebhookURL: "http://example.com",
+				Overrides: []Override{
+					{
+						Group:      "prod-*",
+						WebhookURL: "http://example01.com",
+					},
+				},
+			},
+			InputGroup:     "prod-eu",
+			ExpectedOutput: "http://example01.com",
+		},
+		{
+			Name: "provider-with-wildcard-override-specify-non-matching-group-should-default",
+			Provider: AlertProvider{
+				WebhookURL: "http://example.com",
+				Overrides: []Override{
+					{
+						Group:      "prod-*",
+						WebhookURL: "http://example01.com",
+					},
+				},
+			},
+			InputGroup:     "staging-eu",
+			ExpectedOutput: "http://example.com",
+		},
+		{
+			Name: "provider-with-regex-override-specify-matching-group-should-override",
+			Provider: AlertProvider{
+				WebhookURL: "http://example.com",
+				Overrides: []Override{
+					{
+						Group:      "regex:^prod-(eu|us)$",
+						WebhookURL: "http://example01.com",
+					},
+				},
+			},
+			InputGroup:     "prod-us",
+			ExpectedOutput: "http://example01.com",
+		},
+		{
+			Name: "provider-with-exact-and-pattern-overrides-specify-group-should-prioritize-exact-match",
+			Provider: AlertProvider{
+				WebhookURL: "http://example.com",
+				Overrides: []Override{
+					{
+						Group:      "prod-*",
+						WebhookURL: "http://example01.com",
+					},
+					{
+						Group:      "prod-eu",
+						WebhookURL: "http://example02.com",
+					},
+				},
+			},
+			InputGroup:     "prod-eu",
+			ExpectedOutput: "http://example02.com",
+		},
+		{
+			Name: "provider-with-multiple-pattern-overrides-specify-group-should-use-first-declared-match",
+			Provider: AlertProvider{
+				WebhookURL: "http://example.com",
+				Overrides: []Override{
+					{
+						Group:      "regex:^prod-.*$",
+						WebhookURL: "http://example01.com",
+					},
+					{
+						Group:      "prod-*",
+						WebhookURL: "http://example02.com",
+					},
+				},
+			},
+			InputGroup:     "prod-eu",
+			ExpectedOutput: "http://example01.com",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -7,8 +7,10 @@ import (
 	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 	gomail "gopkg.in/mail.v2"
 )
 
@@ -27,6 +29,9 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
 }
@@ -37,12 +42,22 @@ type Override struct {
 	To    string `yaml:"to"`
 }
 
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
 // IsValid returns whether the provider's configuration is valid
 func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.To) == 0 {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.To) == 0 {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -96,6 +111,7 @@ func (provider *AlertProvider) buildMessageSubjectAndBody(ep *endpoint.Endpoint,
 		subject = fmt.Sprintf("[%s] Alert triggered", ep.DisplayName())
 		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	var formattedConditionResults string
 	if len(result.ConditionResults) > 0 {
 		formattedConditionResults = "\n\nCondition results:\n"
@@ -106,7 +122,7 @@ func (provider *AlertProvider) buildMessageSubjectAndBody(ep *endpoint.Endpoint,
 			} else {
 				prefix = "❌"
 			}
-			formattedConditionResults += fmt.Sprintf("%s %s\n", prefix, conditionResult.Condition)
+			formattedConditionResults += fmt.Sprintf("%s %s%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 		}
 	}
 	var description string
@@ -118,12 +134,8 @@ func (provider *AlertProvider) buildMessageSubjectAndBody(ep *endpoint.Endpoint,
 
 // getToForGroup returns the appropriate email integration to for a given group
 func (provider *AlertProvider) getToForGroup(group string) string {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.To
-			}
-		}
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.To
 	}
 	return provider.To
 }
@@ -21,6 +21,9 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	username        string
 	repositoryOwner string
 	repositoryName  string
@@ -115,14 +118,14 @@ func (provider *AlertProvider) buildIssueBody(ep *endpoint.Endpoint, alert *aler
 			} else {
 				prefix = ":x:"
 			}
-			formattedConditionResults += fmt.Sprintf("- %s - `%s`\n", prefix, conditionResult.Condition)
+			formattedConditionResults += fmt.Sprintf("- %s - `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 		}
 	}
 	var description string
 	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
 		description = ":\n> " + alertDescription
 	}
-	message := fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
+	message := alert.GetMessage(false, fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold), ep.AlertMessageContext(result))
 	return message + description + formattedConditionResults
 }
 
@@ -23,6 +23,9 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Severity can be one of: critical, high, medium, low, info, unknown. Defaults to critical
 	Severity string `yaml:"severity,omitempty"`
 
@@ -126,7 +129,7 @@ func (provider *AlertProvider) buildAlertBody(ep *endpoint.Endpoint, alert *aler
 			} else {
 				prefix = ":x:"
 			}
-			formattedConditionResults += fmt.Sprintf("- %s - `%s`\n", prefix, conditionResult.Condition)
+			formattedConditionResults += fmt.Sprintf("- %s - `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 		}
 	}
 	var description string
@@ -139,6 +142,7 @@ func (provider *AlertProvider) buildAlertBody(ep *endpoint.Endpoint, alert *aler
 	} else {
 		message = fmt.Sprintf("An alert for *%s* has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	body.Description = message + description + formattedConditionResults
 	bodyAsJSON, _ := json.Marshal(body)
 	return bodyAsJSON
@@ -8,8 +8,10 @@ import (
 	"net/http"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
 // AlertProvider is the configuration necessary for sending an alert using Google chat
@@ -22,6 +24,9 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
 }
@@ -32,6 +37,16 @@ type Override struct {
 	WebhookURL string `yaml:"webhook-url"`
 }
 
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
 // IsValid returns whether the provider's configuration is valid
 func (provider *AlertProvider) IsValid() bool {
 	if provider.ClientConfig == nil {
@@ -40,7 +55,7 @@ func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.WebhookURL) == 0 {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -116,11 +131,12 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	var message, color string
 	if resolved {
 		color = "#36A64F"
-		message = fmt.Sprintf("<font color='%s'>An alert has been resolved after passing successfully %d time(s) in a row</font>", color, alert.SuccessThreshold)
+		message = fmt.Sprintf("An alert has been resolved after passing successfully %d time(s) in a row", alert.SuccessThreshold)
 	} else {
 		color = "#DD0000"
-		message = fmt.Sprintf("<font color='%s'>An alert has been triggered due to having failed %d time(s) in a row</font>", color, alert.FailureThreshold)
+		message = fmt.Sprintf("An alert has been triggered due to having failed %d time(s) in a row", alert.FailureThreshold)
 	}
+	message = fmt.Sprintf("<font color='%s'>%s</font>", color, alert.GetMessage(resolved, message, ep.AlertMessageContext(result)))
 	var formattedConditionResults string
 	for _, conditionResult := range result.ConditionResults {
 		var prefix string
@@ -129,7 +145,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		} else {
 			prefix = "❌"
 		}
-		formattedConditionResults += fmt.Sprintf("%s   %s<br>", prefix, conditionResult.Condition)
+		formattedConditionResults += fmt.Sprintf("%s   %s%s<br>", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 	var description string
 	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
@@ -187,12 +203,8 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 
 // getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
 func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.WebhookURL
-			}
-		}
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.WebhookURL
 	}
 	return provider.WebhookURL
 }
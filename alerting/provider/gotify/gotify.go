@@ -28,6 +28,9 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Title is the title of the message that will be sent
 	Title string `yaml:"title,omitempty"`
 }
@@ -74,6 +77,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	} else {
 		message = fmt.Sprintf("An alert for `%s` has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	var formattedConditionResults string
 	for _, conditionResult := range result.ConditionResults {
 		var prefix string
@@ -82,7 +86,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		} else {
 			prefix = "✕"
 		}
-		formattedConditionResults += fmt.Sprintf("\n%s - %s", prefix, conditionResult.Condition)
+		formattedConditionResults += fmt.Sprintf("\n%s - %s%s", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 	if len(alert.GetDescription()) > 0 {
 		message += " with the following description: " + alert.GetDescription()
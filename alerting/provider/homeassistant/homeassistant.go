@@ -0,0 +1,105 @@
+package homeassistant
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Home Assistant's notify services
+type AlertProvider struct {
+	// URL is the base URL of the Home Assistant instance (e.g. http://homeassistant.local:8123)
+	URL string `yaml:"url"`
+
+	// Token is a long-lived access token of a Home Assistant user
+	Token string `yaml:"token"`
+
+	// Service is the name of the notify service to call (e.g. mobile_app_pixel_7)
+	Service string `yaml:"service"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	return len(provider.URL) > 0 && len(provider.Token) > 0 && len(provider.Service) > 0
+}
+
+// Send an alert using the provider
+//
+// Relevant: https://developers.home-assistant.io/docs/api/rest/ (POST /api/services/<domain>/<service>)
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	buffer := bytes.NewBuffer(provider.buildRequestBody(ep, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, provider.buildServiceURL(), buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+provider.Token)
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+// buildServiceURL returns the URL of the notify service to call
+func (provider *AlertProvider) buildServiceURL() string {
+	return strings.TrimSuffix(provider.URL, "/") + "/api/services/notify/" + strings.TrimPrefix(provider.Service, "notify.")
+}
+
+type Body struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
+	var title, message string
+	if resolved {
+		title = "Resolved: " + ep.DisplayName()
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+	} else {
+		title = "Triggered: " + ep.DisplayName()
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
+	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		message += " with the following description: " + alertDescription
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✓"
+		} else {
+			prefix = "✕"
+		}
+		message += fmt.Sprintf("\n%s - %s%s", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
+	}
+	body, _ := json.Marshal(Body{
+		Title:   title,
+		Message: message,
+	})
+	return body
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
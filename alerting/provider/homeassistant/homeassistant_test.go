@@ -0,0 +1,144 @@
+package homeassistant
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{Name: "valid", Provider: AlertProvider{URL: "http://homeassistant.local:8123", Token: "token", Service: "mobile_app_phone"}, Expected: true},
+		{Name: "no-url", Provider: AlertProvider{Token: "token", Service: "mobile_app_phone"}, Expected: false},
+		{Name: "no-token", Provider: AlertProvider{URL: "http://homeassistant.local:8123", Service: "mobile_app_phone"}, Expected: false},
+		{Name: "no-service", Provider: AlertProvider{URL: "http://homeassistant.local:8123", Token: "token"}, Expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, scenario.Provider.IsValid())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	scenarios := []struct {
+		Name          string
+		Provider      AlertProvider
+		StatusCode    int
+		ExpectedURL   string
+		ExpectedError bool
+	}{
+		{
+			Name:        "success",
+			Provider:    AlertProvider{URL: "http://homeassistant.local:8123", Token: "token", Service: "mobile_app_phone"},
+			StatusCode:  http.StatusOK,
+			ExpectedURL: "http://homeassistant.local:8123/api/services/notify/mobile_app_phone",
+		},
+		{
+			Name:        "success-with-trailing-slash-and-domain",
+			Provider:    AlertProvider{URL: "http://homeassistant.local:8123/", Token: "token", Service: "notify.mobile_app_phone"},
+			StatusCode:  http.StatusOK,
+			ExpectedURL: "http://homeassistant.local:8123/api/services/notify/mobile_app_phone",
+		},
+		{
+			Name:          "error",
+			Provider:      AlertProvider{URL: "http://homeassistant.local:8123", Token: "token", Service: "mobile_app_phone"},
+			StatusCode:    http.StatusUnauthorized,
+			ExpectedURL:   "http://homeassistant.local:8123/api/services/notify/mobile_app_phone",
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != scenario.ExpectedURL {
+					t.Errorf("expected request to be sent to %s, got %s", scenario.ExpectedURL, r.URL.String())
+				}
+				if authorization := r.Header.Get("Authorization"); authorization != "Bearer token" {
+					t.Errorf("expected Authorization header to be %q, got %q", "Bearer token", authorization)
+				}
+				return &http.Response{StatusCode: scenario.StatusCode, Body: http.NoBody}
+			})})
+			err := scenario.Provider.Send(
+				&endpoint.Endpoint{Name: "endpoint-name"},
+				&alert.Alert{FailureThreshold: 3},
+				&endpoint.Result{},
+				false,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name         string
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"title\":\"Triggered: endpoint-name\",\"message\":\"An alert for endpoint-name has been triggered due to having failed 3 time(s) in a row with the following description: description-1\\n✕ - [CONNECTED] == true\\n✕ - [STATUS] == 200\"}",
+		},
+		{
+			Name:         "resolved",
+			Alert:        alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"title\":\"Resolved: endpoint-name\",\"message\":\"An alert for endpoint-name has been resolved after passing successfully 5 time(s) in a row with the following description: description-2\\n✓ - [CONNECTED] == true\\n✓ - [STATUS] == 200\"}",
+		},
+	}
+	provider := AlertProvider{URL: "http://homeassistant.local:8123", Token: "token", Service: "mobile_app_phone"}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := provider.buildRequestBody(
+				&endpoint.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&endpoint.Result{
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
@@ -0,0 +1,151 @@
+package ilert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
+)
+
+const (
+	restAPIURL = "https://api.ilert.com/api/events"
+
+	eventTypeAlert   = "ALERT"
+	eventTypeResolve = "RESOLVE"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using ilert
+type AlertProvider struct {
+	// IntegrationKey is the integration key of the ilert alert source
+	IntegrationKey string `yaml:"integration-key"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group          string `yaml:"group"`
+	IntegrationKey string `yaml:"integration-key"`
+}
+
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.Overrides != nil {
+		registeredGroups := make(map[string]bool)
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.IntegrationKey) == 0 {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	return len(provider.IntegrationKey) > 0
+}
+
+// Send an alert using the provider
+//
+// Relevant: https://api.ilert.com/api-docs/#tag/Events
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	body, err := provider.buildRequestBody(ep, alert, result, resolved)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, restAPIURL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+type Body struct {
+	APIKey    string `json:"apiKey"`
+	EventType string `json:"eventType"`
+	Summary   string `json:"summary"`
+	Details   string `json:"details,omitempty"`
+	AlertKey  string `json:"alertKey"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) ([]byte, error) {
+	var message, eventType string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		eventType = eventTypeResolve
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
+		eventType = eventTypeAlert
+	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
+	var details string
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		details = alertDescription + "\n\n"
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		details += fmt.Sprintf("%s - %s%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
+	}
+	return json.Marshal(Body{
+		APIKey:    provider.getIntegrationKeyForGroup(ep.Group),
+		EventType: eventType,
+		Summary:   message,
+		Details:   details,
+		AlertKey:  buildAlertKey(ep, alert),
+	})
+}
+
+// buildAlertKey returns the key used by ilert to correlate the event resolving an alert with the event that opened it
+func buildAlertKey(ep *endpoint.Endpoint, alert *alert.Alert) string {
+	return "gatus-" + ep.Key() + "-" + alert.Checksum()
+}
+
+// getIntegrationKeyForGroup returns the appropriate ilert integration key for a given group
+func (provider *AlertProvider) getIntegrationKeyForGroup(group string) string {
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.IntegrationKey
+	}
+	return provider.IntegrationKey
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,180 @@
+package ilert
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	invalidProvider := AlertProvider{IntegrationKey: ""}
+	if invalidProvider.IsValid() {
+		t.Error("provider shouldn't have been valid")
+	}
+	validProvider := AlertProvider{IntegrationKey: "il1api123"}
+	if !validProvider.IsValid() {
+		t.Error("provider should've been valid")
+	}
+}
+
+func TestAlertProvider_IsValidWithOverride(t *testing.T) {
+	providerWithInvalidOverrideGroup := AlertProvider{
+		IntegrationKey: "il1api123",
+		Overrides:      []Override{{IntegrationKey: "il1api456", Group: ""}},
+	}
+	if providerWithInvalidOverrideGroup.IsValid() {
+		t.Error("provider Group shouldn't have been valid")
+	}
+	providerWithInvalidOverrideIntegrationKey := AlertProvider{
+		IntegrationKey: "il1api123",
+		Overrides:      []Override{{IntegrationKey: "", Group: "group"}},
+	}
+	if providerWithInvalidOverrideIntegrationKey.IsValid() {
+		t.Error("provider integration key shouldn't have been valid")
+	}
+	providerWithDuplicateOverrideGroup := AlertProvider{
+		IntegrationKey: "il1api123",
+		Overrides:      []Override{{IntegrationKey: "il1api456", Group: "group"}, {IntegrationKey: "il1api789", Group: "group"}},
+	}
+	if providerWithDuplicateOverrideGroup.IsValid() {
+		t.Error("provider shouldn't have been valid, because the same group is overridden twice")
+	}
+	providerWithValidOverride := AlertProvider{
+		IntegrationKey: "il1api123",
+		Overrides:      []Override{{IntegrationKey: "il1api456", Group: "group"}},
+	}
+	if !providerWithValidOverride.IsValid() {
+		t.Error("provider should've been valid")
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	description := "description-1"
+	scenarios := []struct {
+		Name          string
+		Resolved      bool
+		StatusCode    int
+		ExpectedError bool
+	}{
+		{Name: "triggered", Resolved: false, StatusCode: http.StatusAccepted, ExpectedError: false},
+		{Name: "triggered-error", Resolved: false, StatusCode: http.StatusBadRequest, ExpectedError: true},
+		{Name: "resolved", Resolved: true, StatusCode: http.StatusAccepted, ExpectedError: false},
+		{Name: "resolved-error", Resolved: true, StatusCode: http.StatusInternalServerError, ExpectedError: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != restAPIURL {
+					t.Errorf("expected request to be sent to %s, got %s", restAPIURL, r.URL.String())
+				}
+				return &http.Response{StatusCode: scenario.StatusCode, Body: http.NoBody}
+			})})
+			err := (&AlertProvider{IntegrationKey: "il1api123"}).Send(
+				&endpoint.Endpoint{Name: "endpoint-name"},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	description := "description-1"
+	testAlert := alert.Alert{Type: alert.TypeIlert, Description: &description, SuccessThreshold: 5, FailureThreshold: 3}
+	alertKey := "gatus-core_endpoint-name-" + testAlert.Checksum()
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{IntegrationKey: "il1api123"},
+			Resolved:     false,
+			ExpectedBody: `{"apiKey":"il1api123","eventType":"ALERT","summary":"An alert for core/endpoint-name has been triggered due to having failed 3 time(s) in a row","details":"description-1\n\n❌ - [CONNECTED] == true\n❌ - [STATUS] == 200\n","alertKey":"` + alertKey + `"}`,
+		},
+		{
+			Name:         "resolved",
+			Provider:     AlertProvider{IntegrationKey: "il1api123"},
+			Resolved:     true,
+			ExpectedBody: `{"apiKey":"il1api123","eventType":"RESOLVE","summary":"An alert for core/endpoint-name has been resolved after passing successfully 5 time(s) in a row","details":"description-1\n\n✅ - [CONNECTED] == true\n✅ - [STATUS] == 200\n","alertKey":"` + alertKey + `"}`,
+		},
+		{
+			Name:         "triggered-with-group-override",
+			Provider:     AlertProvider{IntegrationKey: "il1api123", Overrides: []Override{{Group: "core", IntegrationKey: "il1api456"}}},
+			Resolved:     false,
+			ExpectedBody: `{"apiKey":"il1api456","eventType":"ALERT","summary":"An alert for core/endpoint-name has been triggered due to having failed 3 time(s) in a row","details":"description-1\n\n❌ - [CONNECTED] == true\n❌ - [STATUS] == 200\n","alertKey":"` + alertKey + `"}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body, err := scenario.Provider.buildRequestBody(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: "core"},
+				&testAlert,
+				&endpoint.Result{
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestBuildAlertKey(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "endpoint-name", Group: "core"}
+	testAlert := &alert.Alert{Type: alert.TypeIlert, FailureThreshold: 3}
+	triggeredAlert := *testAlert
+	triggeredAlert.Triggered = true
+	if buildAlertKey(ep, testAlert) != buildAlertKey(ep, &triggeredAlert) {
+		t.Error("expected the alert key to be the same when the alert is triggered and when it is resolved")
+	}
+	if buildAlertKey(ep, testAlert) == buildAlertKey(&endpoint.Endpoint{Name: "other-endpoint-name", Group: "core"}, testAlert) {
+		t.Error("expected the alert key to be different for different endpoints")
+	}
+}
+
+func TestAlertProvider_getIntegrationKeyForGroup(t *testing.T) {
+	provider := AlertProvider{IntegrationKey: "il1api123", Overrides: []Override{{Group: "core", IntegrationKey: "il1api456"}}}
+	if integrationKey := provider.getIntegrationKeyForGroup(""); integrationKey != "il1api123" {
+		t.Errorf("expected il1api123, got %s", integrationKey)
+	}
+	if integrationKey := provider.getIntegrationKeyForGroup("core"); integrationKey != "il1api456" {
+		t.Errorf("expected il1api456, got %s", integrationKey)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
@@ -0,0 +1,164 @@
+package incidentio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
+)
+
+const (
+	restAPIURL = "https://api.incident.io/v2/alert_events/http/"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using incident.io
+type AlertProvider struct {
+	// AlertSourceConfigID is the ID of the HTTP alert source configured in incident.io
+	AlertSourceConfigID string `yaml:"alert-source-config-id"`
+
+	// AuthToken is the token used to authenticate with the HTTP alert source
+	AuthToken string `yaml:"auth-token"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group               string `yaml:"group"`
+	AlertSourceConfigID string `yaml:"alert-source-config-id"`
+	AuthToken           string `yaml:"auth-token"`
+}
+
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.Overrides != nil {
+		registeredGroups := make(map[string]bool)
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.AlertSourceConfigID) == 0 || len(override.AuthToken) == 0 {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	return len(provider.AlertSourceConfigID) > 0 && len(provider.AuthToken) > 0
+}
+
+// Send an alert using the provider
+//
+// Relevant: https://api-docs.incident.io/tag/Alert-Events-V2
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	alertSourceConfigID, authToken := provider.getAlertSourceConfigIDAndAuthTokenForGroup(ep.Group)
+	body, err := provider.buildRequestBody(ep, alert, result, resolved)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, restAPIURL+alertSourceConfigID, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+authToken)
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+type Body struct {
+	Title            string            `json:"title"`
+	Description      string            `json:"description,omitempty"`
+	DeduplicationKey string            `json:"deduplication_key"`
+	Status           string            `json:"status"`
+	Metadata         map[string]string `json:"metadata"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) ([]byte, error) {
+	var message, status string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		status = "resolved"
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
+		status = "firing"
+	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
+	var formattedConditionResults string
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		formattedConditionResults += fmt.Sprintf("%s - `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
+	}
+	description := message
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		description += "\n\n" + alertDescription
+	}
+	if len(formattedConditionResults) > 0 {
+		description += "\n\n" + formattedConditionResults
+	}
+	return json.Marshal(Body{
+		Title:            ep.DisplayName(),
+		Description:      description,
+		DeduplicationKey: buildDeduplicationKey(ep, alert),
+		Status:           status,
+		Metadata: map[string]string{
+			"endpoint": ep.Name,
+			"group":    ep.Group,
+			"url":      ep.URL,
+		},
+	})
+}
+
+// buildDeduplicationKey returns a key that is identical when an alert is triggered and when it is resolved, which is
+// how incident.io correlates the two events
+func buildDeduplicationKey(ep *endpoint.Endpoint, alert *alert.Alert) string {
+	return "gatus-" + ep.Key() + "-" + alert.Checksum()
+}
+
+// getAlertSourceConfigIDAndAuthTokenForGroup returns the appropriate alert source config ID and auth token for a
+// given group
+func (provider *AlertProvider) getAlertSourceConfigIDAndAuthTokenForGroup(group string) (string, string) {
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.AlertSourceConfigID, o.AuthToken
+	}
+	return provider.AlertSourceConfigID, provider.AuthToken
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,248 @@
+package incidentio
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		provider AlertProvider
+		expected bool
+	}{
+		{
+			name:     "valid",
+			provider: AlertProvider{AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R66", AuthToken: "token"},
+			expected: true,
+		},
+		{
+			name:     "missing-alert-source-config-id",
+			provider: AlertProvider{AuthToken: "token"},
+			expected: false,
+		},
+		{
+			name:     "missing-auth-token",
+			provider: AlertProvider{AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R66"},
+			expected: false,
+		},
+		{
+			name: "valid-override",
+			provider: AlertProvider{
+				AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R66",
+				AuthToken:           "token",
+				Overrides:           []Override{{Group: "core", AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R67", AuthToken: "core-token"}},
+			},
+			expected: true,
+		},
+		{
+			name: "override-without-group",
+			provider: AlertProvider{
+				AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R66",
+				AuthToken:           "token",
+				Overrides:           []Override{{AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R67", AuthToken: "core-token"}},
+			},
+			expected: false,
+		},
+		{
+			name: "override-without-auth-token",
+			provider: AlertProvider{
+				AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R66",
+				AuthToken:           "token",
+				Overrides:           []Override{{Group: "core", AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R67"}},
+			},
+			expected: false,
+		},
+		{
+			name: "duplicate-override-group",
+			provider: AlertProvider{
+				AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R66",
+				AuthToken:           "token",
+				Overrides: []Override{
+					{Group: "core", AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R67", AuthToken: "core-token"},
+					{Group: "core", AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R68", AuthToken: "other-token"},
+				},
+			},
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.provider.IsValid() != scenario.expected {
+				t.Errorf("expected %t, got %t", scenario.expected, scenario.provider.IsValid())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	description := "description-1"
+	provider := AlertProvider{
+		AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R66",
+		AuthToken:           "token",
+		Overrides:           []Override{{Group: "core", AlertSourceConfigID: "01GW2G3V0S59R238FAHPDS1R67", AuthToken: "core-token"}},
+	}
+	scenarios := []struct {
+		Name               string
+		Group              string
+		Resolved           bool
+		StatusCode         int
+		ExpectedURL        string
+		ExpectedAuthHeader string
+		ExpectedError      bool
+	}{
+		{
+			Name:               "triggered",
+			Resolved:           false,
+			StatusCode:         http.StatusAccepted,
+			ExpectedURL:        "https://api.incident.io/v2/alert_events/http/01GW2G3V0S59R238FAHPDS1R66",
+			ExpectedAuthHeader: "Bearer token",
+			ExpectedError:      false,
+		},
+		{
+			Name:               "triggered-with-group-override",
+			Group:              "core",
+			Resolved:           false,
+			StatusCode:         http.StatusAccepted,
+			ExpectedURL:        "https://api.incident.io/v2/alert_events/http/01GW2G3V0S59R238FAHPDS1R67",
+			ExpectedAuthHeader: "Bearer core-token",
+			ExpectedError:      false,
+		},
+		{
+			Name:               "resolved-error",
+			Resolved:           true,
+			StatusCode:         http.StatusUnauthorized,
+			ExpectedURL:        "https://api.incident.io/v2/alert_events/http/01GW2G3V0S59R238FAHPDS1R66",
+			ExpectedAuthHeader: "Bearer token",
+			ExpectedError:      true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != scenario.ExpectedURL {
+					t.Errorf("expected request to be sent to %s, got %s", scenario.ExpectedURL, r.URL.String())
+				}
+				if authorization := r.Header.Get("Authorization"); authorization != scenario.ExpectedAuthHeader {
+					t.Errorf("expected Authorization header to be '%s', got '%s'", scenario.ExpectedAuthHeader, authorization)
+				}
+				return &http.Response{StatusCode: scenario.StatusCode, Body: http.NoBody}
+			})})
+			err := provider.Send(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: scenario.Group},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	description := "description-1"
+	testAlert := alert.Alert{Type: alert.TypeIncidentIO, Description: &description, SuccessThreshold: 5, FailureThreshold: 3}
+	deduplicationKey := "gatus-core_endpoint-name-" + testAlert.Checksum()
+	scenarios := []struct {
+		Name         string
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Resolved:     false,
+			ExpectedBody: `{"title":"core/endpoint-name","description":"An alert for core/endpoint-name has been triggered due to having failed 3 time(s) in a row\n\ndescription-1\n\n❌ - ` + "`[CONNECTED] == true`" + `\n❌ - ` + "`[STATUS] == 200`" + `\n","deduplication_key":"` + deduplicationKey + `","status":"firing","metadata":{"endpoint":"endpoint-name","group":"core","url":"https://example.org"}}`,
+		},
+		{
+			Name:         "resolved",
+			Resolved:     true,
+			ExpectedBody: `{"title":"core/endpoint-name","description":"An alert for core/endpoint-name has been resolved after passing successfully 5 time(s) in a row\n\ndescription-1\n\n✅ - ` + "`[CONNECTED] == true`" + `\n✅ - ` + "`[STATUS] == 200`" + `\n","deduplication_key":"` + deduplicationKey + `","status":"resolved","metadata":{"endpoint":"endpoint-name","group":"core","url":"https://example.org"}}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body, err := (&AlertProvider{}).buildRequestBody(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: "core", URL: "https://example.org"},
+				&testAlert,
+				&endpoint.Result{
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestBuildDeduplicationKey(t *testing.T) {
+	description := "description-1"
+	ep := &endpoint.Endpoint{Name: "endpoint-name", Group: "core"}
+	firstAlert := &alert.Alert{Type: alert.TypeIncidentIO, Description: &description, FailureThreshold: 3}
+	if buildDeduplicationKey(ep, firstAlert) != buildDeduplicationKey(ep, firstAlert) {
+		t.Error("expected the deduplication key to be stable")
+	}
+	// The state of the alert must not affect the deduplication key, otherwise the resolved event wouldn't match the
+	// firing event
+	triggeredAlert := *firstAlert
+	triggeredAlert.Triggered = true
+	if buildDeduplicationKey(ep, firstAlert) != buildDeduplicationKey(ep, &triggeredAlert) {
+		t.Error("expected the deduplication key not to depend on the state of the alert")
+	}
+	secondAlert := &alert.Alert{Type: alert.TypeIncidentIO, FailureThreshold: 5}
+	if buildDeduplicationKey(ep, firstAlert) == buildDeduplicationKey(ep, secondAlert) {
+		t.Error("expected different alerts of the same endpoint to have different deduplication keys")
+	}
+	if buildDeduplicationKey(ep, firstAlert) == buildDeduplicationKey(&endpoint.Endpoint{Name: "other-endpoint", Group: "core"}, firstAlert) {
+		t.Error("expected the same alert of different endpoints to have different deduplication keys")
+	}
+}
+
+func TestAlertProvider_getAlertSourceConfigIDAndAuthTokenForGroup(t *testing.T) {
+	provider := AlertProvider{
+		AlertSourceConfigID: "default-id",
+		AuthToken:           "default-token",
+		Overrides:           []Override{{Group: "core", AlertSourceConfigID: "core-id", AuthToken: "core-token"}},
+	}
+	if id, token := provider.getAlertSourceConfigIDAndAuthTokenForGroup(""); id != "default-id" || token != "default-token" {
+		t.Errorf("expected default configuration to be used for endpoints without a group, got %s and %s", id, token)
+	}
+	if id, token := provider.getAlertSourceConfigIDAndAuthTokenForGroup("core"); id != "core-id" || token != "core-token" {
+		t.Errorf("expected override to be used for endpoints in the core group, got %s and %s", id, token)
+	}
+	if id, token := provider.getAlertSourceConfigIDAndAuthTokenForGroup("other"); id != "default-id" || token != "default-token" {
+		t.Errorf("expected default configuration to be used for endpoints in other groups, got %s and %s", id, token)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
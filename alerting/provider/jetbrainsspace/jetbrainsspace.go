@@ -8,8 +8,10 @@ import (
 	"net/http"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
 // AlertProvider is the configuration necessary for sending an alert using JetBrains Space
@@ -21,6 +23,9 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
 }
@@ -31,12 +36,22 @@ type Override struct {
 	ChannelID string `yaml:"channel-id"`
 }
 
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
 // IsValid returns whether the provider's configuration is valid
 func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.ChannelID) == 0 {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.ChannelID) == 0 {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -121,6 +136,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		body.Content.Style = "WARNING"
 		body.Content.Sections[0].Header = fmt.Sprintf("An alert for *%s* has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	body.Content.Sections[0].Header = alert.GetMessage(resolved, body.Content.Sections[0].Header, ep.AlertMessageContext(result))
 	for _, conditionResult := range result.ConditionResults {
 		icon := "warning"
 		style := "WARNING"
@@ -137,7 +153,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 			},
 			Style:   style,
 			Size:    "REGULAR",
-			Content: conditionResult.Condition,
+			Content: conditionResult.Condition + conditionResult.MessageSuffix(),
 		})
 	}
 	bodyAsJSON, _ := json.Marshal(body)
@@ -146,12 +162,8 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 
 // getChannelIDForGroup returns the appropriate channel ID to for a given group override
 func (provider *AlertProvider) getChannelIDForGroup(group string) string {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.ChannelID
-			}
-		}
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.ChannelID
 	}
 	return provider.ChannelID
 }
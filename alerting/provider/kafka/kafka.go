@@ -0,0 +1,247 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	SASLMechanismPlain       = "PLAIN"
+	SASLMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	SASLMechanismSCRAMSHA512 = "SCRAM-SHA-512"
+
+	defaultWriteTimeout = 10 * time.Second
+)
+
+var (
+	ErrUnsupportedSASLMechanism = errors.New("unsupported sasl mechanism")
+)
+
+// AlertProvider is the configuration necessary for publishing alerts to a Kafka topic
+type AlertProvider struct {
+	// Brokers is the list of Kafka brokers to connect to (e.g. kafka-1:9092)
+	Brokers []string `yaml:"brokers"`
+
+	// Topic is the topic to publish the alerts to
+	Topic string `yaml:"topic"`
+
+	// SASL is the SASL authentication configuration (optional)
+	SASL *SASLConfig `yaml:"sasl,omitempty"`
+
+	// TLS is the TLS configuration (optional)
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+
+	writer writer
+	mutex  sync.Mutex
+}
+
+// SASLConfig is the configuration for authenticating with the Kafka brokers using SASL
+type SASLConfig struct {
+	// Mechanism is the SASL mechanism to use (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512)
+	Mechanism string `yaml:"mechanism"`
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+}
+
+// TLSConfig is the configuration for connecting to the Kafka brokers using TLS
+type TLSConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Insecure determines whether to skip verifying the brokers' certificate chain and host name
+	Insecure bool `yaml:"insecure,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group string `yaml:"group"`
+	Topic string `yaml:"topic"`
+}
+
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
+// writer is the subset of kafka-go's Writer used by the provider
+type writer interface {
+	WriteMessages(ctx context.Context, messages ...kafkago.Message) error
+	Close() error
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	registeredGroups := make(map[string]bool)
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.Topic) == 0 {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	if provider.SASL != nil {
+		if _, err := provider.SASL.mechanism(); err != nil {
+			return false
+		}
+	}
+	return len(provider.Brokers) > 0 && len(provider.Topic) > 0
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	w, err := provider.getWriter()
+	if err != nil {
+		return err
+	}
+	body, err := provider.buildMessageBody(ep, alert, result, resolved)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWriteTimeout)
+	defer cancel()
+	err = w.WriteMessages(ctx, kafkago.Message{
+		Topic: provider.getTopicForGroup(ep.Group),
+		Key:   []byte(ep.Key()),
+		Value: body,
+	})
+	if err != nil {
+		return fmt.Errorf("error publishing alert to kafka: %w", err)
+	}
+	return nil
+}
+
+// Close flushes any pending message and closes the connections to the brokers
+func (provider *AlertProvider) Close() error {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.writer == nil {
+		return nil
+	}
+	err := provider.writer.Close()
+	provider.writer = nil
+	return err
+}
+
+type Event struct {
+	Endpoint         string             `json:"endpoint"`
+	Group            string             `json:"group,omitempty"`
+	Key              string             `json:"key"`
+	State            string             `json:"state"`
+	Description      string             `json:"description,omitempty"`
+	ConditionResults []*ConditionResult `json:"conditionResults,omitempty"`
+	Timestamp        time.Time          `json:"timestamp"`
+}
+
+type ConditionResult struct {
+	Condition string `json:"condition"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+}
+
+// buildMessageBody builds the value of the message published to Kafka
+func (provider *AlertProvider) buildMessageBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) ([]byte, error) {
+	event := Event{
+		Endpoint:    ep.Name,
+		Group:       ep.Group,
+		Key:         ep.Key(),
+		State:       "triggered",
+		Description: alert.GetDescription(),
+		Timestamp:   result.Timestamp,
+	}
+	if resolved {
+		event.State = "resolved"
+	}
+	for _, conditionResult := range result.ConditionResults {
+		event.ConditionResults = append(event.ConditionResults, &ConditionResult{
+			Condition: conditionResult.Condition,
+			Success:   conditionResult.Success,
+			Message:   conditionResult.Message,
+		})
+	}
+	return json.Marshal(event)
+}
+
+// getTopicForGroup returns the appropriate topic for a given group
+func (provider *AlertProvider) getTopicForGroup(group string) string {
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.Topic
+	}
+	return provider.Topic
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
+
+// getWriter returns the provider's writer, creating it if it doesn't exist yet.
+// The same writer is reused for every alert so that connections to the brokers are not re-established every time.
+func (provider *AlertProvider) getWriter() (writer, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.writer != nil {
+		return provider.writer, nil
+	}
+	transport := &kafkago.Transport{}
+	if provider.SASL != nil {
+		mechanism, err := provider.SASL.mechanism()
+		if err != nil {
+			return nil, err
+		}
+		transport.SASL = mechanism
+	}
+	if provider.TLS != nil && provider.TLS.Enabled {
+		transport.TLS = &tls.Config{InsecureSkipVerify: provider.TLS.Insecure}
+	}
+	provider.writer = &kafkago.Writer{
+		Addr:         kafkago.TCP(provider.Brokers...),
+		Balancer:     &kafkago.Hash{},
+		RequiredAcks: kafkago.RequireAll,
+		WriteTimeout: defaultWriteTimeout,
+		Transport:    transport,
+	}
+	return provider.writer, nil
+}
+
+// mechanism returns the sasl.Mechanism matching the configuration
+func (c *SASLConfig) mechanism() (sasl.Mechanism, error) {
+	switch strings.ToUpper(c.Mechanism) {
+	case SASLMechanismPlain, "":
+		return plain.Mechanism{Username: c.Username, Password: c.Password}, nil
+	case SASLMechanismSCRAMSHA256:
+		return scram.Mechanism(scram.SHA256, c.Username, c.Password)
+	case SASLMechanismSCRAMSHA512:
+		return scram.Mechanism(scram.SHA512, c.Username, c.Password)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSASLMechanism, c.Mechanism)
+	}
+}
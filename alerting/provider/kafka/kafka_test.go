@@ -0,0 +1,234 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type mockWriter struct {
+	messages []kafkago.Message
+	err      error
+	closed   bool
+}
+
+func (m *mockWriter) WriteMessages(_ context.Context, messages ...kafkago.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, messages...)
+	return nil
+}
+
+func (m *mockWriter) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		provider *AlertProvider
+		expected bool
+	}{
+		{
+			name:     "empty",
+			provider: &AlertProvider{},
+			expected: false,
+		},
+		{
+			name:     "no-topic",
+			provider: &AlertProvider{Brokers: []string{"localhost:9092"}},
+			expected: false,
+		},
+		{
+			name:     "no-brokers",
+			provider: &AlertProvider{Topic: "alerts"},
+			expected: false,
+		},
+		{
+			name:     "valid",
+			provider: &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts"},
+			expected: true,
+		},
+		{
+			name:     "valid-with-sasl-and-tls",
+			provider: &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts", SASL: &SASLConfig{Mechanism: "scram-sha-512", Username: "user", Password: "pass"}, TLS: &TLSConfig{Enabled: true}},
+			expected: true,
+		},
+		{
+			name:     "invalid-sasl-mechanism",
+			provider: &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts", SASL: &SASLConfig{Mechanism: "GSSAPI"}},
+			expected: false,
+		},
+		{
+			name:     "invalid-override-group",
+			provider: &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts", Overrides: []Override{{Group: "", Topic: "core-alerts"}}},
+			expected: false,
+		},
+		{
+			name:     "invalid-override-topic",
+			provider: &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts", Overrides: []Override{{Group: "core", Topic: ""}}},
+			expected: false,
+		},
+		{
+			name:     "valid-override",
+			provider: &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts", Overrides: []Override{{Group: "core", Topic: "core-alerts"}}},
+			expected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.provider.IsValid() != scenario.expected {
+				t.Errorf("expected IsValid to return %v", scenario.expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	description := "description"
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	scenarios := []struct {
+		name          string
+		provider      *AlertProvider
+		group         string
+		resolved      bool
+		writeErr      error
+		expectedTopic string
+		expectedKey   string
+		expectedValue string
+		expectedErr   bool
+	}{
+		{
+			name:          "triggered",
+			provider:      &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts"},
+			resolved:      false,
+			expectedTopic: "alerts",
+			expectedKey:   "_endpoint-name",
+			expectedValue: `{"endpoint":"endpoint-name","key":"_endpoint-name","state":"triggered","description":"description","conditionResults":[{"condition":"[CONNECTED] == true","success":false},{"condition":"[STATUS] == 200","success":false}],"timestamp":"2024-01-02T03:04:05Z"}`,
+		},
+		{
+			name:          "resolved-with-group-override",
+			provider:      &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts", Overrides: []Override{{Group: "core", Topic: "core-alerts"}}},
+			group:         "core",
+			resolved:      true,
+			expectedTopic: "core-alerts",
+			expectedKey:   "core_endpoint-name",
+			expectedValue: `{"endpoint":"endpoint-name","group":"core","key":"core_endpoint-name","state":"resolved","description":"description","conditionResults":[{"condition":"[CONNECTED] == true","success":true},{"condition":"[STATUS] == 200","success":true}],"timestamp":"2024-01-02T03:04:05Z"}`,
+		},
+		{
+			name:        "write-error",
+			provider:    &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts"},
+			writeErr:    errors.New("leader not available"),
+			expectedErr: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			w := &mockWriter{err: scenario.writeErr}
+			scenario.provider.writer = w
+			err := scenario.provider.Send(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: scenario.group},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.resolved},
+					},
+					Timestamp: timestamp,
+				},
+				scenario.resolved,
+			)
+			if scenario.expectedErr {
+				if err == nil {
+					t.Error("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if len(w.messages) != 1 {
+				t.Fatalf("expected 1 message to have been produced, got %d", len(w.messages))
+			}
+			message := w.messages[0]
+			if message.Topic != scenario.expectedTopic {
+				t.Errorf("expected topic to be %s, got %s", scenario.expectedTopic, message.Topic)
+			}
+			if string(message.Key) != scenario.expectedKey {
+				t.Errorf("expected key to be %s, got %s", scenario.expectedKey, string(message.Key))
+			}
+			if string(message.Value) != scenario.expectedValue {
+				t.Errorf("expected value to be %s, got %s", scenario.expectedValue, string(message.Value))
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getWriterIsReused(t *testing.T) {
+	provider := &AlertProvider{Brokers: []string{"localhost:9092"}, Topic: "alerts"}
+	first, err := provider.getWriter()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	second, _ := provider.getWriter()
+	if first != second {
+		t.Error("expected the same writer to be reused")
+	}
+	if err := provider.Close(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if provider.writer != nil {
+		t.Error("expected writer to be nil after closing the provider")
+	}
+}
+
+func TestAlertProvider_Close(t *testing.T) {
+	w := &mockWriter{}
+	provider := &AlertProvider{writer: w}
+	if err := provider.Close(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if !w.closed {
+		t.Error("expected writer to have been closed")
+	}
+	if err := provider.Close(); err != nil {
+		t.Error("closing an already closed provider shouldn't return an error")
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
+
+func TestAlertProvider_getTopicForGroup(t *testing.T) {
+	provider := &AlertProvider{
+		Topic: "alerts",
+		Overrides: []Override{
+			{Group: "prod-*", Topic: "prod-alerts"},
+			{Group: "prod-eu", Topic: "prod-eu-alerts"},
+		},
+	}
+	scenarios := map[string]string{
+		"":        "alerts",
+		"staging": "alerts",
+		"prod-us": "prod-alerts",
+		"prod-eu": "prod-eu-alerts",
+	}
+	for group, expected := range scenarios {
+		if got := provider.getTopicForGroup(group); got != expected {
+			t.Errorf("expected topic for group %q to be %s, got %s", group, expected, got)
+		}
+	}
+}
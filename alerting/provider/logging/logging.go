@@ -0,0 +1,259 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	// DefaultNetwork is the network used to reach the syslog server if none is specified
+	DefaultNetwork = "udp"
+
+	syslogAppName = "gatus"
+
+	// syslogFacilityUser is the "user-level messages" facility, pre-multiplied by 8 as required by the PRI part
+	syslogFacilityUser    = 1 << 3
+	syslogSeverityError   = 3
+	syslogSeverityNotice  = 5
+	defaultDialTimeout    = 10 * time.Second
+	defaultFilePermission = 0o644
+)
+
+var (
+	// reopenGeneration is incremented every time SIGHUP is received, which lets every provider writing to a file know
+	// that it must reopen it (e.g. because it has been rotated by logrotate)
+	reopenGeneration atomic.Uint64
+
+	watchSIGHUPOnce sync.Once
+)
+
+// AlertProvider is the configuration necessary for writing alerts to a local file or to a syslog server
+type AlertProvider struct {
+	// Path is the path of the file to append the alerts to
+	Path string `yaml:"path,omitempty"`
+
+	// Network is the network used to reach the syslog server (udp, tcp, unix or unixgram)
+	Network string `yaml:"network,omitempty"`
+
+	// Address is the address of the syslog server (e.g. localhost:514 or /dev/log)
+	Address string `yaml:"address,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
+	destination io.WriteCloser
+	generation  uint64
+	mutex       sync.Mutex
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if len(provider.Path) > 0 {
+		// A file and a syslog server are mutually exclusive
+		return len(provider.Network) == 0 && len(provider.Address) == 0
+	}
+	if len(provider.Address) == 0 {
+		return false
+	}
+	if len(provider.Network) == 0 {
+		provider.Network = DefaultNetwork
+	}
+	switch provider.Network {
+	case "udp", "tcp", "unix", "unixgram":
+		return true
+	}
+	return false
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	record, err := provider.buildRecord(ep, alert, result, resolved)
+	if err != nil {
+		return err
+	}
+	if len(provider.Path) == 0 {
+		record = buildSyslogMessage(record, result.Timestamp, resolved)
+	}
+	err = provider.write(record)
+	if err != nil && len(provider.Path) == 0 {
+		// The connection may have been closed since it was last used (e.g. the syslog server restarted), so we'll
+		// reconnect and retry once
+		provider.reset()
+		err = provider.write(record)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing alert: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file or the connection to the syslog server
+func (provider *AlertProvider) Close() error {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.destination == nil {
+		return nil
+	}
+	err := provider.destination.Close()
+	provider.destination = nil
+	return err
+}
+
+type Record struct {
+	Timestamp        time.Time          `json:"timestamp"`
+	Endpoint         string             `json:"endpoint"`
+	Group            string             `json:"group,omitempty"`
+	Key              string             `json:"key"`
+	State            string             `json:"state"`
+	Message          string             `json:"message"`
+	Description      string             `json:"description,omitempty"`
+	ConditionResults []*ConditionResult `json:"conditionResults,omitempty"`
+}
+
+type ConditionResult struct {
+	Condition string `json:"condition"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+}
+
+// buildRecord builds the line written for each alert, without the trailing new line
+func (provider *AlertProvider) buildRecord(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) ([]byte, error) {
+	var message, state string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		state = "resolved"
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
+		state = "triggered"
+	}
+	record := Record{
+		Timestamp:   result.Timestamp,
+		Endpoint:    ep.Name,
+		Group:       ep.Group,
+		Key:         ep.Key(),
+		State:       state,
+		Message:     alert.GetMessage(resolved, message, ep.AlertMessageContext(result)),
+		Description: alert.GetDescription(),
+	}
+	for _, conditionResult := range result.ConditionResults {
+		record.ConditionResults = append(record.ConditionResults, &ConditionResult{
+			Condition: conditionResult.Condition,
+			Success:   conditionResult.Success,
+			Message:   conditionResult.Message,
+		})
+	}
+	return json.Marshal(record)
+}
+
+// buildSyslogMessage wraps a record in an RFC 5424 syslog message.
+// Triggered alerts are sent with the error severity, while resolved alerts are sent with the notice severity.
+func buildSyslogMessage(record []byte, timestamp time.Time, resolved bool) []byte {
+	priority := syslogFacilityUser + syslogSeverityError
+	if resolved {
+		priority = syslogFacilityUser + syslogSeverityNotice
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	hostname, err := os.Hostname()
+	if err != nil || len(hostname) == 0 {
+		hostname = "-"
+	}
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s %d - - %s", priority, timestamp.Format(time.RFC3339Nano), hostname, syslogAppName, os.Getpid(), record))
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
+
+// write appends a line to the destination, opening it first if necessary
+func (provider *AlertProvider) write(line []byte) error {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.destination != nil && len(provider.Path) > 0 && provider.generation != reopenGeneration.Load() {
+		// SIGHUP was received since the file was opened, so we'll close it and reopen it below
+		_ = provider.destination.Close()
+		provider.destination = nil
+	}
+	if provider.destination == nil {
+		if err := provider.open(); err != nil {
+			return err
+		}
+	}
+	_, err := provider.destination.Write(append(line, '\n'))
+	return err
+}
+
+// open opens the file or the connection to the syslog server
+func (provider *AlertProvider) open() error {
+	if len(provider.Path) > 0 {
+		HandleSIGHUP()
+		provider.generation = reopenGeneration.Load()
+		file, err := os.OpenFile(provider.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, defaultFilePermission)
+		if err != nil {
+			return err
+		}
+		provider.destination = file
+		return nil
+	}
+	if len(provider.Address) == 0 {
+		return errors.New("no destination configured")
+	}
+	network := provider.Network
+	if len(network) == 0 {
+		network = DefaultNetwork
+	}
+	connection, err := net.DialTimeout(network, provider.Address, defaultDialTimeout)
+	if err != nil {
+		return err
+	}
+	provider.destination = connection
+	return nil
+}
+
+// reset closes the current destination so that it is reopened on the next write
+func (provider *AlertProvider) reset() {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	if provider.destination != nil {
+		_ = provider.destination.Close()
+		provider.destination = nil
+	}
+}
+
+// HandleSIGHUP starts notifying every provider writing to a file that it must reopen it whenever SIGHUP is received.
+// Calling it more than once has no effect.
+//
+// It must be called as soon as Gatus starts with a provider writing to a file, rather than once the file is first
+// opened, because until SIGHUP is handled, it terminates the process, which would happen if the file were rotated
+// before the first alert is sent.
+func HandleSIGHUP() {
+	watchSIGHUPOnce.Do(watchSIGHUP)
+}
+
+// watchSIGHUP notifies every provider writing to a file that it must reopen it whenever SIGHUP is received
+func watchSIGHUP() {
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, syscall.SIGHUP)
+	go func() {
+		for range signalChannel {
+			reopenGeneration.Add(1)
+		}
+	}()
+}
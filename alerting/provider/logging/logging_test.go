@@ -0,0 +1,282 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		provider *AlertProvider
+		expected bool
+	}{
+		{
+			name:     "empty",
+			provider: &AlertProvider{},
+			expected: false,
+		},
+		{
+			name:     "path",
+			provider: &AlertProvider{Path: "/var/log/gatus/alerts.log"},
+			expected: true,
+		},
+		{
+			name:     "address",
+			provider: &AlertProvider{Address: "localhost:514"},
+			expected: true,
+		},
+		{
+			name:     "network-and-address",
+			provider: &AlertProvider{Network: "unix", Address: "/dev/log"},
+			expected: true,
+		},
+		{
+			name:     "network-without-address",
+			provider: &AlertProvider{Network: "tcp"},
+			expected: false,
+		},
+		{
+			name:     "invalid-network",
+			provider: &AlertProvider{Network: "http", Address: "localhost:514"},
+			expected: false,
+		},
+		{
+			name:     "path-and-address",
+			provider: &AlertProvider{Path: "/var/log/gatus/alerts.log", Address: "localhost:514"},
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.provider.IsValid() != scenario.expected {
+				t.Errorf("expected %t, got %t", scenario.expected, scenario.provider.IsValid())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_IsValidSetsDefaultNetwork(t *testing.T) {
+	provider := AlertProvider{Address: "localhost:514"}
+	if !provider.IsValid() {
+		t.Fatal("provider should've been valid")
+	}
+	if provider.Network != DefaultNetwork {
+		t.Errorf("expected network to default to %s, got %s", DefaultNetwork, provider.Network)
+	}
+}
+
+func TestAlertProvider_SendWithPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.log")
+	provider := &AlertProvider{Path: path}
+	defer provider.Close()
+	ep := &endpoint.Endpoint{Name: "endpoint-name", Group: "core"}
+	description := "description-1"
+	testAlert := &alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3}
+	if err := provider.Send(ep, testAlert, &endpoint.Result{ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}}, false); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := provider.Send(ep, testAlert, &endpoint.Result{ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: true}}}, true); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	records := readRecords(t, path)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].State != "triggered" || records[1].State != "resolved" {
+		t.Errorf("expected records to be triggered then resolved, got %s then %s", records[0].State, records[1].State)
+	}
+	for _, record := range records {
+		if record.Key != "core_endpoint-name" {
+			t.Errorf("expected key to be core_endpoint-name, got %s", record.Key)
+		}
+		if record.Description != description {
+			t.Errorf("expected description to be %s, got %s", description, record.Description)
+		}
+		if len(record.ConditionResults) != 1 || record.ConditionResults[0].Condition != "[STATUS] == 200" {
+			t.Errorf("expected one condition result for [STATUS] == 200, got %v", record.ConditionResults)
+		}
+	}
+	// Existing records must be preserved by subsequent providers writing to the same file
+	secondProvider := &AlertProvider{Path: path}
+	defer secondProvider.Close()
+	if err := secondProvider.Send(ep, testAlert, &endpoint.Result{}, false); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if records = readRecords(t, path); len(records) != 3 {
+		t.Errorf("expected 3 records, got %d", len(records))
+	}
+}
+
+func TestHandleSIGHUP(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not supported on Windows")
+	}
+	HandleSIGHUP()
+	// No file has been opened, so if SIGHUP wasn't handled, it would terminate the test binary
+	generation := reopenGeneration.Load()
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	for start := time.Now(); reopenGeneration.Load() == generation; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("timed out waiting for SIGHUP to be handled")
+		}
+	}
+}
+
+func TestAlertProvider_SendWithPathReopensFileOnSIGHUP(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not supported on Windows")
+	}
+	directory := t.TempDir()
+	path := filepath.Join(directory, "alerts.log")
+	rotatedPath := filepath.Join(directory, "alerts.log.1")
+	provider := &AlertProvider{Path: path}
+	defer provider.Close()
+	ep := &endpoint.Endpoint{Name: "endpoint-name"}
+	testAlert := &alert.Alert{SuccessThreshold: 5, FailureThreshold: 3}
+	if err := provider.Send(ep, testAlert, &endpoint.Result{}, false); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// Simulate a rotation
+	if err := os.Rename(path, rotatedPath); err != nil {
+		t.Fatal(err)
+	}
+	generation := reopenGeneration.Load()
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	for start := time.Now(); reopenGeneration.Load() == generation; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("timed out waiting for SIGHUP to be handled")
+		}
+	}
+	if err := provider.Send(ep, testAlert, &endpoint.Result{}, true); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if records := readRecords(t, rotatedPath); len(records) != 1 || records[0].State != "triggered" {
+		t.Errorf("expected the rotated file to contain only the triggered record, got %v", records)
+	}
+	if records := readRecords(t, path); len(records) != 1 || records[0].State != "resolved" {
+		t.Errorf("expected the new file to contain only the resolved record, got %v", records)
+	}
+}
+
+func TestAlertProvider_SendWithAddress(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	provider := &AlertProvider{Network: "udp", Address: listener.LocalAddr().String()}
+	defer provider.Close()
+	result := &endpoint.Result{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := provider.Send(&endpoint.Endpoint{Name: "endpoint-name"}, &alert.Alert{FailureThreshold: 3}, result, false); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buffer := make([]byte, 4096)
+	n, _, err := listener.ReadFrom(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := string(buffer[:n])
+	if !strings.HasPrefix(message, "<11>1 2024-01-01T00:00:00Z ") {
+		t.Errorf("expected message to start with the syslog header, got %s", message)
+	}
+	if !strings.Contains(message, " gatus ") {
+		t.Errorf("expected message to contain the app name, got %s", message)
+	}
+	if !strings.HasSuffix(message, `"state":"triggered","message":"An alert for endpoint-name has been triggered due to having failed 3 time(s) in a row"}`+"\n") {
+		t.Errorf("expected message to end with the record, got %s", message)
+	}
+}
+
+func TestAlertProvider_buildRecord(t *testing.T) {
+	description := "description-1"
+	scenarios := []struct {
+		Name           string
+		Resolved       bool
+		ExpectedRecord string
+	}{
+		{
+			Name:           "triggered",
+			Resolved:       false,
+			ExpectedRecord: `{"timestamp":"2024-01-01T00:00:00Z","endpoint":"endpoint-name","group":"core","key":"core_endpoint-name","state":"triggered","message":"An alert for core/endpoint-name has been triggered due to having failed 3 time(s) in a row","description":"description-1","conditionResults":[{"condition":"[CONNECTED] == true","success":false},{"condition":"[STATUS] == 200","success":false}]}`,
+		},
+		{
+			Name:           "resolved",
+			Resolved:       true,
+			ExpectedRecord: `{"timestamp":"2024-01-01T00:00:00Z","endpoint":"endpoint-name","group":"core","key":"core_endpoint-name","state":"resolved","message":"An alert for core/endpoint-name has been resolved after passing successfully 5 time(s) in a row","description":"description-1","conditionResults":[{"condition":"[CONNECTED] == true","success":true},{"condition":"[STATUS] == 200","success":true}]}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			record, err := (&AlertProvider{}).buildRecord(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: "core"},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{
+					Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if string(record) != scenario.ExpectedRecord {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedRecord, record)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
+
+func readRecords(t *testing.T, path string) []Record {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("expected each line to be a JSON record, got %s: %s", scanner.Text(), err.Error())
+		}
+		records = append(records, record)
+	}
+	return records
+}
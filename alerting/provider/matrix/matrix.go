@@ -11,8 +11,10 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
 // AlertProvider is the configuration necessary for sending an alert using Matrix
@@ -22,6 +24,9 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
 }
@@ -33,6 +38,16 @@ type Override struct {
 	ProviderConfig `yaml:",inline"`
 }
 
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
 const defaultServerURL = "https://matrix-client.matrix.org"
 
 type ProviderConfig struct {
@@ -51,7 +66,7 @@ func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.AccessToken) == 0 || len(override.InternalRoomID) == 0 {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.AccessToken) == 0 || len(override.InternalRoomID) == 0 {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -121,6 +136,7 @@ func buildPlaintextMessageBody(ep *endpoint.Endpoint, alert *alert.Alert, result
 	} else {
 		message = fmt.Sprintf("An alert for `%s` has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	var formattedConditionResults string
 	for _, conditionResult := range result.ConditionResults {
 		var prefix string
@@ -129,7 +145,7 @@ func buildPlaintextMessageBody(ep *endpoint.Endpoint, alert *alert.Alert, result
 		} else {
 			prefix = "✕"
 		}
-		formattedConditionResults += fmt.Sprintf("\n%s - %s", prefix, conditionResult.Condition)
+		formattedConditionResults += fmt.Sprintf("\n%s - %s%s", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 	var description string
 	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
@@ -146,6 +162,7 @@ func buildHTMLMessageBody(ep *endpoint.Endpoint, alert *alert.Alert, result *end
 	} else {
 		message = fmt.Sprintf("An alert for <code>%s</code> has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	var formattedConditionResults string
 	if len(result.ConditionResults) > 0 {
 		formattedConditionResults = "\n<h5>Condition results</h5><ul>"
@@ -156,7 +173,7 @@ func buildHTMLMessageBody(ep *endpoint.Endpoint, alert *alert.Alert, result *end
 			} else {
 				prefix = "❌"
 			}
-			formattedConditionResults += fmt.Sprintf("<li>%s - <code>%s</code></li>", prefix, conditionResult.Condition)
+			formattedConditionResults += fmt.Sprintf("<li>%s - <code>%s</code>%s</li>", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 		}
 		formattedConditionResults += "</ul>"
 	}
@@ -169,12 +186,8 @@ func buildHTMLMessageBody(ep *endpoint.Endpoint, alert *alert.Alert, result *end
 
 // getConfigForGroup returns the appropriate configuration for a given group
 func (provider *AlertProvider) getConfigForGroup(group string) ProviderConfig {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.ProviderConfig
-			}
-		}
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.ProviderConfig
 	}
 	return provider.ProviderConfig
 }
@@ -32,6 +32,9 @@ type AlertProvider struct {
 
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -98,6 +101,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		tag = "rotating_light"
 		message = "An alert has been triggered due to having failed " + strconv.Itoa(alert.FailureThreshold) + " time(s) in a row"
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	for _, conditionResult := range result.ConditionResults {
 		var prefix string
 		if conditionResult.Success {
@@ -105,7 +109,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		} else {
 			prefix = "🔴"
 		}
-		formattedConditionResults += fmt.Sprintf("\n%s %s", prefix, conditionResult.Condition)
+		formattedConditionResults += fmt.Sprintf("\n%s %s%s", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 	if len(alert.GetDescription()) > 0 {
 		message += " with the following description: " + alert.GetDescription()
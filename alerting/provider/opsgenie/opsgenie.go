@@ -49,6 +49,9 @@ type AlertProvider struct {
 
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -124,6 +127,7 @@ func (provider *AlertProvider) buildCreateRequestBody(ep *endpoint.Endpoint, ale
 		message = fmt.Sprintf("%s - %s", ep.Name, alert.GetDescription())
 		description = fmt.Sprintf("An alert for *%s* has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	description = alert.GetMessage(resolved, description, ep.AlertMessageContext(result))
 	if ep.Group != "" {
 		message = fmt.Sprintf("[%s] %s", ep.Group, message)
 	}
@@ -135,7 +139,7 @@ func (provider *AlertProvider) buildCreateRequestBody(ep *endpoint.Endpoint, ale
 		} else {
 			prefix = "▢"
 		}
-		formattedConditionResults += fmt.Sprintf("%s - `%s`\n", prefix, conditionResult.Condition)
+		formattedConditionResults += fmt.Sprintf("%s - `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 	description = description + "\n" + formattedConditionResults
 	key := buildKey(ep)
@@ -0,0 +1,41 @@
+package override
+
+import "github.com/TwiN/gatus/v5/pattern"
+
+// Override is implemented by the overrides of the alerting providers, which replace part of the configuration of a
+// provider for the endpoints they apply to
+type Override interface {
+	// GetGroup returns the group of the endpoints the override applies to, which may be a pattern supported by
+	// pattern.MatchGroup. May be empty if the override has labels.
+	GetGroup() string
+
+	// GetLabels returns the labels an endpoint must have for the override to apply, if any
+	GetLabels() map[string]string
+}
+
+// Resolve returns the override that applies to an endpoint with the given group and labels, and whether there is one.
+// If there is none, the provider's default configuration should be used.
+//
+// When several overrides apply, the first one in the following order wins:
+//  1. An override with labels that the endpoint all has, whose group, if any, also matches the endpoint's
+//  2. An override without labels whose group is exactly the endpoint's
+//  3. An override without labels whose group pattern matches the endpoint's
+func Resolve[T Override](overrides []T, group string, labels map[string]string) (T, bool) {
+	for _, override := range overrides {
+		if len(override.GetLabels()) > 0 && pattern.MatchLabels(override.GetLabels(), labels) && (len(override.GetGroup()) == 0 || pattern.MatchGroup(override.GetGroup(), group)) {
+			return override, true
+		}
+	}
+	for _, override := range overrides {
+		if len(override.GetLabels()) == 0 && override.GetGroup() == group {
+			return override, true
+		}
+	}
+	for _, override := range overrides {
+		if len(override.GetLabels()) == 0 && pattern.MatchGroup(override.GetGroup(), group) {
+			return override, true
+		}
+	}
+	var none T
+	return none, false
+}
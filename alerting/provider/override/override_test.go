@@ -0,0 +1,109 @@
+package override
+
+import "testing"
+
+type testOverride struct {
+	Group  string
+	Labels map[string]string
+	Value  string
+}
+
+func (o testOverride) GetGroup() string {
+	return o.Group
+}
+
+func (o testOverride) GetLabels() map[string]string {
+	return o.Labels
+}
+
+func TestResolve(t *testing.T) {
+	overrides := []testOverride{
+		{Group: "core/*", Value: "pattern"},
+		{Group: "core/api", Value: "exact"},
+		{Labels: map[string]string{"team": "payments"}, Value: "labels"},
+		{Group: "internal", Labels: map[string]string{"team": "platform"}, Value: "group-and-labels"},
+	}
+	scenarios := []struct {
+		name          string
+		overrides     []testOverride
+		group         string
+		labels        map[string]string
+		expectedFound bool
+		expectedValue string
+	}{
+		{
+			name:          "matched-group",
+			overrides:     overrides,
+			group:         "core/api",
+			expectedFound: true,
+			expectedValue: "exact",
+		},
+		{
+			name:          "exact-group-takes-precedence-over-earlier-pattern",
+			overrides:     overrides,
+			group:         "core/api",
+			labels:        map[string]string{"team": "search"},
+			expectedFound: true,
+			expectedValue: "exact",
+		},
+		{
+			name:          "matched-pattern",
+			overrides:     overrides,
+			group:         "core/web",
+			expectedFound: true,
+			expectedValue: "pattern",
+		},
+		{
+			name:          "labels-take-precedence-over-group",
+			overrides:     overrides,
+			group:         "core/api",
+			labels:        map[string]string{"team": "payments", "tier": "1"},
+			expectedFound: true,
+			expectedValue: "labels",
+		},
+		{
+			name:          "labels-with-group-require-both",
+			overrides:     overrides,
+			group:         "external",
+			labels:        map[string]string{"team": "platform"},
+			expectedFound: false,
+		},
+		{
+			name:          "labels-with-matching-group",
+			overrides:     overrides,
+			group:         "internal",
+			labels:        map[string]string{"team": "platform"},
+			expectedFound: true,
+			expectedValue: "group-and-labels",
+		},
+		{
+			name:          "unmatched-group-falls-back-to-default",
+			overrides:     overrides,
+			group:         "external",
+			expectedFound: false,
+		},
+		{
+			name:          "no-group",
+			overrides:     overrides,
+			group:         "",
+			expectedFound: false,
+		},
+		{
+			name:          "empty-overrides",
+			overrides:     nil,
+			group:         "core/api",
+			expectedFound: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			override, found := Resolve(scenario.overrides, scenario.group, scenario.labels)
+			if found != scenario.expectedFound {
+				t.Fatalf("expected found to be %v, got %v", scenario.expectedFound, found)
+			}
+			if override.Value != scenario.expectedValue {
+				t.Errorf("expected override %q, got %q", scenario.expectedValue, override.Value)
+			}
+		})
+	}
+}
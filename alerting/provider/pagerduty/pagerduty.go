@@ -9,8 +9,10 @@ import (
 	"net/http"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
 const (
@@ -24,14 +26,30 @@ type AlertProvider struct {
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
 }
 
 // Override is a case under which the default integration is overridden
 type Override struct {
-	Group          string `yaml:"group"`
-	IntegrationKey string `yaml:"integration-key"`
+	Group string `yaml:"group,omitempty"`
+	// Labels, if set, are the labels an endpoint must have for the override to apply. If Group is also set, the
+	// endpoint must match both.
+	Labels         map[string]string `yaml:"labels,omitempty"`
+	IntegrationKey string            `yaml:"integration-key"`
+}
+
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns the labels an endpoint must have for the override to apply
+func (override Override) GetLabels() map[string]string {
+	return override.Labels
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -39,7 +57,13 @@ func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.IntegrationKey) != 32 {
+			if len(override.Labels) > 0 {
+				if !pattern.IsValidGroup(override.Group) || len(override.IntegrationKey) != 32 {
+					return false
+				}
+				continue
+			}
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.IntegrationKey) != 32 {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -112,8 +136,9 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		eventAction = "trigger"
 		resolveKey = ""
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	body, _ := json.Marshal(Body{
-		RoutingKey:  provider.getIntegrationKeyForGroup(ep.Group),
+		RoutingKey:  provider.getIntegrationKeyForEndpoint(ep),
 		DedupKey:    resolveKey,
 		EventAction: eventAction,
 		Payload: Payload{
@@ -125,18 +150,21 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	return body
 }
 
-// getIntegrationKeyForGroup returns the appropriate pagerduty integration key for a given group
-func (provider *AlertProvider) getIntegrationKeyForGroup(group string) string {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.IntegrationKey
-			}
-		}
+// getIntegrationKeyForEndpoint returns the appropriate integration key for a given endpoint.
+// Overrides with labels take precedence over overrides that only have a group (see override.Resolve).
+func (provider *AlertProvider) getIntegrationKeyForEndpoint(ep *endpoint.Endpoint) string {
+	if o, found := override.Resolve(provider.Overrides, ep.Group, ep.Labels); found {
+		return o.IntegrationKey
 	}
 	return provider.IntegrationKey
 }
 
+// getIntegrationKeyForGroup returns the appropriate pagerduty integration key for a given group, ignoring overrides
+// with labels
+func (provider *AlertProvider) getIntegrationKeyForGroup(group string) string {
+	return provider.getIntegrationKeyForEndpoint(&endpoint.Endpoint{Group: group})
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
@@ -56,6 +56,21 @@ func TestAlertProvider_IsValidWithOverride(t *testing.T) {
 	if !providerWithValidOverride.IsValid() {
 		t.Error("provider should've been valid")
 	}
+	providerWithDuplicateOverrideGroup := AlertProvider{
+		Overrides: []Override{
+			{
+				IntegrationKey: "00000000000000000000000000000001",
+				Group:          "group",
+			},
+			{
+				IntegrationKey: "00000000000000000000000000000002",
+				Group:          "group",
+			},
+		},
+	}
+	if providerWithDuplicateOverrideGroup.IsValid() {
+		t.Error("provider shouldn't have been valid, because the same group is overridden twice")
+	}
 }
 
 func TestAlertProvider_Send(t *testing.T) {
@@ -140,6 +155,7 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	scenarios := []struct {
 		Name         string
 		Provider     AlertProvider
+		Group        string
 		Alert        alert.Alert
 		Resolved     bool
 		ExpectedBody string
@@ -158,10 +174,18 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     true,
 			ExpectedBody: "{\"routing_key\":\"00000000000000000000000000000000\",\"dedup_key\":\"key\",\"event_action\":\"resolve\",\"payload\":{\"summary\":\"RESOLVED: endpoint-name - test\",\"source\":\"Gatus\",\"severity\":\"critical\"}}",
 		},
+		{
+			Name:         "triggered-with-group-override",
+			Provider:     AlertProvider{IntegrationKey: "00000000000000000000000000000000", Overrides: []Override{{Group: "core", IntegrationKey: "00000000000000000000000000000001"}}},
+			Group:        "core",
+			Alert:        alert.Alert{Description: &description},
+			Resolved:     false,
+			ExpectedBody: "{\"routing_key\":\"00000000000000000000000000000001\",\"dedup_key\":\"\",\"event_action\":\"trigger\",\"payload\":{\"summary\":\"TRIGGERED: core/endpoint-name - test\",\"source\":\"Gatus\",\"severity\":\"critical\"}}",
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
-			body := scenario.Provider.buildRequestBody(&endpoint.Endpoint{Name: "endpoint-name"}, &scenario.Alert, &endpoint.Result{}, scenario.Resolved)
+			body := scenario.Provider.buildRequestBody(&endpoint.Endpoint{Name: "endpoint-name", Group: scenario.Group}, &scenario.Alert, &endpoint.Result{}, scenario.Resolved)
 			if string(body) != scenario.ExpectedBody {
 				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
 			}
@@ -244,3 +268,25 @@ func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 		t.Error("expected default alert to be nil")
 	}
 }
+
+func TestAlertProvider_getIntegrationKeyForEndpoint(t *testing.T) {
+	provider := AlertProvider{
+		IntegrationKey: "00000000000000000000000000000000",
+		Overrides: []Override{
+			{Group: "group", IntegrationKey: "00000000000000000000000000000001"},
+			{Labels: map[string]string{"team": "payments"}, IntegrationKey: "00000000000000000000000000000002"},
+		},
+	}
+	if !provider.IsValid() {
+		t.Fatal("provider should've been valid")
+	}
+	if key := provider.getIntegrationKeyForEndpoint(&endpoint.Endpoint{Group: "group", Labels: map[string]string{"team": "payments"}}); key != "00000000000000000000000000000002" {
+		t.Errorf("expected the label override to be used, got %s", key)
+	}
+	if key := provider.getIntegrationKeyForEndpoint(&endpoint.Endpoint{Group: "group", Labels: map[string]string{"team": "identity"}}); key != "00000000000000000000000000000001" {
+		t.Errorf("expected the group override to be used, got %s", key)
+	}
+	if key := provider.getIntegrationKeyForEndpoint(&endpoint.Endpoint{}); key != "00000000000000000000000000000000" {
+		t.Errorf("expected the default integration key to be used, got %s", key)
+	}
+}
@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/alerting/provider/awsses"
+	"github.com/TwiN/gatus/v5/alerting/provider/awssns"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
@@ -10,7 +13,12 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/github"
 	"github.com/TwiN/gatus/v5/alerting/provider/gitlab"
 	"github.com/TwiN/gatus/v5/alerting/provider/googlechat"
+	"github.com/TwiN/gatus/v5/alerting/provider/homeassistant"
+	"github.com/TwiN/gatus/v5/alerting/provider/ilert"
+	"github.com/TwiN/gatus/v5/alerting/provider/incidentio"
 	"github.com/TwiN/gatus/v5/alerting/provider/jetbrainsspace"
+	"github.com/TwiN/gatus/v5/alerting/provider/kafka"
+	"github.com/TwiN/gatus/v5/alerting/provider/logging"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
 	"github.com/TwiN/gatus/v5/alerting/provider/messagebird"
@@ -18,10 +26,14 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/opsgenie"
 	"github.com/TwiN/gatus/v5/alerting/provider/pagerduty"
 	"github.com/TwiN/gatus/v5/alerting/provider/pushover"
+	"github.com/TwiN/gatus/v5/alerting/provider/rabbitmq"
+	"github.com/TwiN/gatus/v5/alerting/provider/rocketchat"
 	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+	"github.com/TwiN/gatus/v5/alerting/provider/splunk"
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
+	"github.com/TwiN/gatus/v5/alerting/provider/webex"
 	"github.com/TwiN/gatus/v5/alerting/provider/zulip"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 )
@@ -34,6 +46,14 @@ type AlertProvider interface {
 	// GetDefaultAlert returns the provider's default alert configuration
 	GetDefaultAlert() *alert.Alert
 
+	// IsSendingOnResolved returns whether the provider sends a notification when an alert is resolved.
+	// Implemented by embedding alert.BaseProviderConfig.
+	IsSendingOnResolved() bool
+
+	// SendWithRetry calls send, retrying it according to the provider's retry configuration if it fails, until ctx is
+	// done. Implemented by embedding alert.BaseProviderConfig.
+	SendWithRetry(ctx context.Context, send func() error) error
+
 	// Send an alert using the provider
 	Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error
 }
@@ -58,11 +78,24 @@ func ParseWithDefaultAlert(providerDefaultAlert, endpointAlert *alert.Alert) {
 	if endpointAlert.SuccessThreshold == 0 {
 		endpointAlert.SuccessThreshold = providerDefaultAlert.SuccessThreshold
 	}
+	if endpointAlert.StabilizationWindow == 0 {
+		endpointAlert.StabilizationWindow = providerDefaultAlert.StabilizationWindow
+	}
+	if endpointAlert.SuccessRatio == nil {
+		endpointAlert.SuccessRatio = providerDefaultAlert.SuccessRatio
+	}
+	if len(endpointAlert.TriggerMessage) == 0 {
+		endpointAlert.TriggerMessage = providerDefaultAlert.TriggerMessage
+	}
+	if len(endpointAlert.ResolveMessage) == 0 {
+		endpointAlert.ResolveMessage = providerDefaultAlert.ResolveMessage
+	}
 }
 
 var (
 	// Validate interface implementation on compile
 	_ AlertProvider = (*awsses.AlertProvider)(nil)
+	_ AlertProvider = (*awssns.AlertProvider)(nil)
 	_ AlertProvider = (*custom.AlertProvider)(nil)
 	_ AlertProvider = (*discord.AlertProvider)(nil)
 	_ AlertProvider = (*email.AlertProvider)(nil)
@@ -70,7 +103,12 @@ var (
 	_ AlertProvider = (*gitlab.AlertProvider)(nil)
 	_ AlertProvider = (*gitea.AlertProvider)(nil)
 	_ AlertProvider = (*googlechat.AlertProvider)(nil)
+	_ AlertProvider = (*homeassistant.AlertProvider)(nil)
+	_ AlertProvider = (*ilert.AlertProvider)(nil)
+	_ AlertProvider = (*incidentio.AlertProvider)(nil)
 	_ AlertProvider = (*jetbrainsspace.AlertProvider)(nil)
+	_ AlertProvider = (*kafka.AlertProvider)(nil)
+	_ AlertProvider = (*logging.AlertProvider)(nil)
 	_ AlertProvider = (*matrix.AlertProvider)(nil)
 	_ AlertProvider = (*mattermost.AlertProvider)(nil)
 	_ AlertProvider = (*messagebird.AlertProvider)(nil)
@@ -78,9 +116,13 @@ var (
 	_ AlertProvider = (*opsgenie.AlertProvider)(nil)
 	_ AlertProvider = (*pagerduty.AlertProvider)(nil)
 	_ AlertProvider = (*pushover.AlertProvider)(nil)
+	_ AlertProvider = (*rabbitmq.AlertProvider)(nil)
+	_ AlertProvider = (*rocketchat.AlertProvider)(nil)
 	_ AlertProvider = (*slack.AlertProvider)(nil)
+	_ AlertProvider = (*splunk.AlertProvider)(nil)
 	_ AlertProvider = (*teams.AlertProvider)(nil)
 	_ AlertProvider = (*telegram.AlertProvider)(nil)
 	_ AlertProvider = (*twilio.AlertProvider)(nil)
+	_ AlertProvider = (*webex.AlertProvider)(nil)
 	_ AlertProvider = (*zulip.AlertProvider)(nil)
 )
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 )
@@ -110,6 +111,43 @@ func TestParseWithDefaultAlert(t *testing.T) {
 				SuccessThreshold: 10,
 			},
 		},
+		{
+			Name: "endpoint-alert-inherits-default-alert-messages",
+			DefaultAlert: &alert.Alert{
+				TriggerMessage:   "[ENDPOINT_NAME] is down",
+				ResolveMessage:   "[ENDPOINT_NAME] is up",
+				FailureThreshold: 5,
+				SuccessThreshold: 10,
+			},
+			EndpointAlert: &alert.Alert{
+				Type:           alert.TypeDiscord,
+				ResolveMessage: "[ENDPOINT_NAME] is back up",
+			},
+			ExpectedOutputAlert: &alert.Alert{
+				Type:             alert.TypeDiscord,
+				TriggerMessage:   "[ENDPOINT_NAME] is down",
+				ResolveMessage:   "[ENDPOINT_NAME] is back up",
+				FailureThreshold: 5,
+				SuccessThreshold: 10,
+			},
+		},
+		{
+			Name: "endpoint-alert-inherits-default-alert-stabilization-window",
+			DefaultAlert: &alert.Alert{
+				StabilizationWindow: 5 * time.Minute,
+				FailureThreshold:    5,
+				SuccessThreshold:    10,
+			},
+			EndpointAlert: &alert.Alert{
+				Type: alert.TypeDiscord,
+			},
+			ExpectedOutputAlert: &alert.Alert{
+				Type:                alert.TypeDiscord,
+				StabilizationWindow: 5 * time.Minute,
+				FailureThreshold:    5,
+				SuccessThreshold:    10,
+			},
+		},
 		{
 			Name: "no-default-alert",
 			DefaultAlert: &alert.Alert{
@@ -148,6 +186,15 @@ func TestParseWithDefaultAlert(t *testing.T) {
 			if scenario.EndpointAlert.SuccessThreshold != scenario.ExpectedOutputAlert.SuccessThreshold {
 				t.Errorf("expected EndpointAlert.SuccessThreshold to be %v, got %v", scenario.ExpectedOutputAlert.SuccessThreshold, scenario.EndpointAlert.SuccessThreshold)
 			}
+			if scenario.EndpointAlert.StabilizationWindow != scenario.ExpectedOutputAlert.StabilizationWindow {
+				t.Errorf("expected EndpointAlert.StabilizationWindow to be %v, got %v", scenario.ExpectedOutputAlert.StabilizationWindow, scenario.EndpointAlert.StabilizationWindow)
+			}
+			if scenario.EndpointAlert.TriggerMessage != scenario.ExpectedOutputAlert.TriggerMessage {
+				t.Errorf("expected EndpointAlert.TriggerMessage to be %v, got %v", scenario.ExpectedOutputAlert.TriggerMessage, scenario.EndpointAlert.TriggerMessage)
+			}
+			if scenario.EndpointAlert.ResolveMessage != scenario.ExpectedOutputAlert.ResolveMessage {
+				t.Errorf("expected EndpointAlert.ResolveMessage to be %v, got %v", scenario.ExpectedOutputAlert.ResolveMessage, scenario.EndpointAlert.ResolveMessage)
+			}
 		})
 	}
 }
@@ -40,6 +40,9 @@ type AlertProvider struct {
 
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -88,6 +91,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	} else {
 		message = fmt.Sprintf("TRIGGERED: %s - %s", ep.DisplayName(), alert.GetDescription())
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	body, _ := json.Marshal(Body{
 		Token:    provider.ApplicationToken,
 		User:     provider.UserKey,
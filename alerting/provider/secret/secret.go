@@ -0,0 +1,114 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const (
+	// EnvironmentVariablePrefix is the prefix of the values that reference an environment variable, e.g. $WEBHOOK_URL
+	EnvironmentVariablePrefix = "$"
+
+	// FilePrefix is the prefix of the values that reference a file whose content is the secret, such as a Docker or
+	// Kubernetes secret mounted in the container, e.g. file:/run/secrets/webhook-url
+	FilePrefix = "file:"
+)
+
+var (
+	// ErrUnsetEnvironmentVariable is the error returned when a value references an environment variable that is not set
+	ErrUnsetEnvironmentVariable = errors.New("environment variable is not set")
+
+	// ErrUnreadableFile is the error returned when a value references a file that cannot be read
+	ErrUnreadableFile = errors.New("unable to read secret file")
+)
+
+// Resolve returns the secret referenced by value, which is either the name of an environment variable prefixed by
+// EnvironmentVariablePrefix or the path of a file prefixed by FilePrefix. The content of a file is trimmed of leading
+// and trailing whitespaces, so that a trailing newline isn't mistaken for part of the secret.
+//
+// A value without either prefix is returned as is.
+func Resolve(value string) (string, error) {
+	if strings.HasPrefix(value, EnvironmentVariablePrefix) {
+		name := strings.TrimPrefix(value, EnvironmentVariablePrefix)
+		resolvedValue, found := os.LookupEnv(name)
+		if !found {
+			return "", fmt.Errorf("%w: %s", ErrUnsetEnvironmentVariable, name)
+		}
+		return resolvedValue, nil
+	}
+	return resolveFile(value)
+}
+
+// ResolveFiles replaces, in place, every string referencing a file through FilePrefix in the struct v points to by
+// the content of said file. Nested structs, pointers, slices and maps are traversed, so that the secrets of overrides
+// are resolved as well.
+//
+// Unlike Resolve, values prefixed by EnvironmentVariablePrefix are left untouched, because environment variables are
+// already expanded when the configuration is loaded, and a "$" remaining afterward is meant literally.
+func ResolveFiles(v any) error {
+	return resolveFilesInValue(reflect.ValueOf(v))
+}
+
+func resolveFilesInValue(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return resolveFilesInValue(value.Elem())
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !value.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := resolveFilesInValue(value.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := resolveFilesInValue(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		iterator := value.MapRange()
+		for iterator.Next() {
+			resolvedValue, err := resolveFile(iterator.Value().String())
+			if err != nil {
+				return err
+			}
+			value.SetMapIndex(iterator.Key(), reflect.ValueOf(resolvedValue).Convert(value.Type().Elem()))
+		}
+	case reflect.String:
+		if !value.CanSet() {
+			return nil
+		}
+		resolvedValue, err := resolveFile(value.String())
+		if err != nil {
+			return err
+		}
+		value.SetString(resolvedValue)
+	}
+	return nil
+}
+
+// resolveFile returns the trimmed content of the file referenced by value if it is prefixed by FilePrefix, or value
+// as is otherwise
+func resolveFile(value string) (string, error) {
+	if !strings.HasPrefix(value, FilePrefix) {
+		return value, nil
+	}
+	path := strings.TrimPrefix(value, FilePrefix)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %w", ErrUnreadableFile, path, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
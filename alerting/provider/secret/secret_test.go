@@ -0,0 +1,115 @@
+package secret
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secretFile, []byte("  s3cr3t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GATUS_TEST_SECRET", "from-env")
+	scenarios := []struct {
+		name          string
+		value         string
+		expectedValue string
+		expectedErr   error
+	}{
+		{
+			name:          "plain",
+			value:         "https://example.com/webhook",
+			expectedValue: "https://example.com/webhook",
+		},
+		{
+			name:          "environment-variable",
+			value:         "$GATUS_TEST_SECRET",
+			expectedValue: "from-env",
+		},
+		{
+			name:        "unset-environment-variable",
+			value:       "$GATUS_TEST_UNSET_SECRET",
+			expectedErr: ErrUnsetEnvironmentVariable,
+		},
+		{
+			name:          "file",
+			value:         "file:" + secretFile,
+			expectedValue: "s3cr3t",
+		},
+		{
+			name:        "missing-file",
+			value:       "file:" + secretFile + "-missing",
+			expectedErr: ErrUnreadableFile,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			value, err := Resolve(scenario.value)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if value != scenario.expectedValue {
+				t.Errorf("expected %q, got %q", scenario.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestResolveFiles(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "webhook-url"), []byte("https://example.com/webhook\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "token"), []byte("s3cr3t"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	type override struct {
+		Group      string
+		WebhookURL string
+	}
+	type provider struct {
+		WebhookURL string
+		Token      string
+		Headers    map[string]string
+		Overrides  []override
+		Body       string
+		secret     string
+	}
+	p := &provider{
+		WebhookURL: "file:" + filepath.Join(directory, "webhook-url"),
+		Token:      "$TOKEN",
+		Headers:    map[string]string{"Authorization": "file:" + filepath.Join(directory, "token")},
+		Overrides:  []override{{Group: "core", WebhookURL: "file:" + filepath.Join(directory, "webhook-url")}},
+		Body:       "plain",
+		secret:     "file:" + filepath.Join(directory, "token"),
+	}
+	if err := ResolveFiles(p); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if p.WebhookURL != "https://example.com/webhook" {
+		t.Errorf("expected webhook url to be resolved, got %q", p.WebhookURL)
+	}
+	if p.Token != "$TOKEN" {
+		t.Errorf("expected environment variable reference to be left untouched, got %q", p.Token)
+	}
+	if p.Headers["Authorization"] != "s3cr3t" {
+		t.Errorf("expected header to be resolved, got %q", p.Headers["Authorization"])
+	}
+	if p.Overrides[0].WebhookURL != "https://example.com/webhook" {
+		t.Errorf("expected override webhook url to be resolved, got %q", p.Overrides[0].WebhookURL)
+	}
+	if p.Body != "plain" {
+		t.Errorf("expected plain value to be left untouched, got %q", p.Body)
+	}
+	if !strings.HasPrefix(p.secret, "file:") {
+		t.Errorf("expected unexported field to be left untouched, got %q", p.secret)
+	}
+	p.Overrides[0].WebhookURL = "file:" + filepath.Join(directory, "missing")
+	if err := ResolveFiles(p); !errors.Is(err, ErrUnreadableFile) || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected error %v naming the file, got %v", ErrUnreadableFile, err)
+	}
+}
@@ -3,28 +3,59 @@ package slack
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
+// chatPostMessageURL is the URL of the Slack Web API method used to post messages with a bot token
+var chatPostMessageURL = "https://slack.com/api/chat.postMessage"
+
 // AlertProvider is the configuration necessary for sending an alert using Slack
 type AlertProvider struct {
-	WebhookURL string `yaml:"webhook-url"` // Slack webhook URL
+	WebhookURL string `yaml:"webhook-url,omitempty"` // Slack webhook URL
+
+	// Token is the bot token used to post messages through the Slack Web API instead of a webhook.
+	// When set, resolved alerts are sent as a reply in the thread of the message of the triggered alert.
+	Token string `yaml:"token,omitempty"`
+
+	// Channel is the ID of the channel the messages are posted to when Token is set
+	Channel string `yaml:"channel,omitempty"`
+
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
+	// IncludeDescriptionInTitle is whether the description of the alert should be appended to the title
+	IncludeDescriptionInTitle bool `yaml:"include-description-in-title,omitempty"`
 }
 
 // Override is a case under which the default integration is overridden
 type Override struct {
-	Group      string `yaml:"group"`
-	WebhookURL string `yaml:"webhook-url"`
+	Group string `yaml:"group,omitempty"`
+	// Labels, if set, are the labels an endpoint must have for the override to apply. If Group is also set, the
+	// endpoint must match both.
+	Labels     map[string]string `yaml:"labels,omitempty"`
+	WebhookURL string            `yaml:"webhook-url"`
+}
+
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns the labels an endpoint must have for the override to apply
+func (override Override) GetLabels() map[string]string {
+	return override.Labels
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -32,23 +63,41 @@ func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 {
+			if len(override.Labels) > 0 {
+				if !pattern.IsValidGroup(override.Group) || len(override.WebhookURL) == 0 {
+					return false
+				}
+				continue
+			}
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.WebhookURL) == 0 {
 				return false
 			}
 			registeredGroups[override.Group] = true
 		}
 	}
-	return len(provider.WebhookURL) > 0
+	return len(provider.WebhookURL) > 0 || (len(provider.Token) > 0 && len(provider.Channel) > 0)
 }
 
 // Send an alert using the provider
+//
+// If a bot token is configured and no override applies to the endpoint, the message is posted through the Slack Web
+// API, and the timestamp of the message of the triggered alert is kept as the alert's ResolveKey so that the message
+// of the resolved alert can be posted as a reply in its thread. Otherwise, the message is sent to the webhook.
 func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	useBotToken := provider.isUsingBotTokenForEndpoint(ep)
+	requestURL := provider.getWebhookURLForEndpoint(ep)
+	if useBotToken {
+		requestURL = chatPostMessageURL
+	}
 	buffer := bytes.NewBuffer(provider.buildRequestBody(ep, alert, result, resolved))
-	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForGroup(ep.Group), buffer)
+	request, err := http.NewRequest(http.MethodPost, requestURL, buffer)
 	if err != nil {
 		return err
 	}
 	request.Header.Set("Content-Type", "application/json")
+	if useBotToken {
+		request.Header.Set("Authorization", "Bearer "+provider.Token)
+	}
 	response, err := client.GetHTTPClient(nil).Do(request)
 	if err != nil {
 		return err
@@ -58,20 +107,46 @@ func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, r
 		body, _ := io.ReadAll(response.Body)
 		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
 	}
-	return err
+	if !useBotToken {
+		return nil
+	}
+	// The Web API responds with a 200 even if the message couldn't be posted, so the payload must be checked
+	var payload chatPostMessageResponse
+	if err = json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		return err
+	}
+	if !payload.OK {
+		return errors.New("call to provider alert returned an error: " + payload.Error)
+	}
+	if resolved {
+		// The incident is over, so the next trigger must start a new thread
+		alert.ResolveKey = ""
+	} else {
+		alert.ResolveKey = payload.TS
+	}
+	return nil
+}
+
+type chatPostMessageResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	TS    string `json:"ts,omitempty"`
 }
 
 type Body struct {
+	Channel     string       `json:"channel,omitempty"`
+	ThreadTS    string       `json:"thread_ts,omitempty"`
 	Text        string       `json:"text"`
 	Attachments []Attachment `json:"attachments"`
 }
 
 type Attachment struct {
-	Title  string  `json:"title"`
-	Text   string  `json:"text"`
-	Short  bool    `json:"short"`
-	Color  string  `json:"color"`
-	Fields []Field `json:"fields,omitempty"`
+	Title     string  `json:"title"`
+	TitleLink string  `json:"title_link,omitempty"`
+	Text      string  `json:"text"`
+	Short     bool    `json:"short"`
+	Color     string  `json:"color"`
+	Fields    []Field `json:"fields,omitempty"`
 }
 
 type Field struct {
@@ -90,6 +165,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		message = fmt.Sprintf("An alert for *%s* has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 		color = "#DD0000"
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	var formattedConditionResults string
 	for _, conditionResult := range result.ConditionResults {
 		var prefix string
@@ -98,23 +174,34 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		} else {
 			prefix = ":x:"
 		}
-		formattedConditionResults += fmt.Sprintf("%s - `%s`\n", prefix, conditionResult.Condition)
+		formattedConditionResults += fmt.Sprintf("%s - `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 	var description string
+	title := ":helmet_with_white_cross: Gatus"
 	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
 		description = ":\n> " + alertDescription
+		if provider.IncludeDescriptionInTitle {
+			title += " - " + alertDescription
+		}
 	}
 	body := Body{
 		Text: "",
 		Attachments: []Attachment{
 			{
-				Title: ":helmet_with_white_cross: Gatus",
-				Text:  message + description,
-				Short: false,
-				Color: color,
+				Title:     title,
+				TitleLink: ep.PageURL,
+				Text:      message + description,
+				Short:     false,
+				Color:     color,
 			},
 		},
 	}
+	if provider.isUsingBotTokenForEndpoint(ep) {
+		body.Channel = provider.Channel
+		if resolved {
+			body.ThreadTS = alert.ResolveKey
+		}
+	}
 	if len(formattedConditionResults) > 0 {
 		body.Attachments[0].Fields = append(body.Attachments[0].Fields, Field{
 			Title: "Condition results",
@@ -126,18 +213,26 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	return bodyAsJSON
 }
 
-// getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
-func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.WebhookURL
-			}
-		}
+// isUsingBotTokenForEndpoint returns whether the messages for a given endpoint are posted through the Slack Web API.
+// Endpoints to which an override applies always use the override's webhook.
+func (provider *AlertProvider) isUsingBotTokenForEndpoint(ep *endpoint.Endpoint) bool {
+	return len(provider.Token) > 0 && len(provider.Channel) > 0 && provider.getWebhookURLForEndpoint(ep) == provider.WebhookURL
+}
+
+// getWebhookURLForEndpoint returns the appropriate Webhook URL for a given endpoint.
+// Overrides with labels take precedence over overrides that only have a group (see override.Resolve).
+func (provider *AlertProvider) getWebhookURLForEndpoint(ep *endpoint.Endpoint) string {
+	if o, found := override.Resolve(provider.Overrides, ep.Group, ep.Labels); found {
+		return o.WebhookURL
 	}
 	return provider.WebhookURL
 }
 
+// getWebhookURLForGroup returns the appropriate Webhook URL for a given group, ignoring overrides with labels
+func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
+	return provider.getWebhookURLForEndpoint(&endpoint.Endpoint{Group: group})
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
@@ -2,7 +2,9 @@ package slack
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
@@ -20,6 +22,14 @@ func TestAlertDefaultProvider_IsValid(t *testing.T) {
 	if !validProvider.IsValid() {
 		t.Error("provider should've been valid")
 	}
+	providerWithTokenAndNoChannel := AlertProvider{Token: "xoxb-token"}
+	if providerWithTokenAndNoChannel.IsValid() {
+		t.Error("provider shouldn't have been valid")
+	}
+	validProviderWithToken := AlertProvider{Token: "xoxb-token", Channel: "C0123456789"}
+	if !validProviderWithToken.IsValid() {
+		t.Error("provider should've been valid")
+	}
 }
 
 func TestAlertProvider_IsValidWithOverride(t *testing.T) {
@@ -136,6 +146,75 @@ func TestAlertProvider_Send(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_SendWithTokenRepliesInThread(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	provider := AlertProvider{
+		Token:   "xoxb-token",
+		Channel: "C0123456789",
+		Overrides: []Override{
+			{Group: "webhook-only", WebhookURL: "https://hooks.slack.com/services/override"},
+		},
+	}
+	description := "description"
+	endpointAlert := &alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3}
+	result := &endpoint.Result{ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}}
+	var requestURL, authorization string
+	var body Body
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		requestURL, authorization = r.URL.String(), r.Header.Get("Authorization")
+		body = Body{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"ok":true,"ts":"1700000000.000100"}`))}
+	})})
+	ep := &endpoint.Endpoint{Name: "endpoint-name"}
+	if err := provider.Send(ep, endpointAlert, result, false); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if requestURL != chatPostMessageURL || authorization != "Bearer xoxb-token" {
+		t.Errorf("expected the message to be posted to %s with the bot token, got %s with %q", chatPostMessageURL, requestURL, authorization)
+	}
+	if body.Channel != "C0123456789" || body.ThreadTS != "" {
+		t.Errorf("expected the triggered alert to start a thread in channel C0123456789, got channel=%q thread_ts=%q", body.Channel, body.ThreadTS)
+	}
+	if endpointAlert.ResolveKey != "1700000000.000100" {
+		t.Errorf("expected the timestamp of the message to be kept as the resolve key, got %q", endpointAlert.ResolveKey)
+	}
+	result.ConditionResults[0].Success = true
+	if err := provider.Send(ep, endpointAlert, result, true); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if body.ThreadTS != "1700000000.000100" {
+		t.Errorf("expected the resolved alert to reply in the thread of the triggered alert, got thread_ts=%q", body.ThreadTS)
+	}
+	if endpointAlert.ResolveKey != "" {
+		t.Errorf("expected the resolve key to be cleared once resolved, got %q", endpointAlert.ResolveKey)
+	}
+	// Endpoints to which an override applies fall back to standalone webhook messages
+	endpointAlert.ResolveKey = "1700000000.000100"
+	if err := provider.Send(&endpoint.Endpoint{Name: "endpoint-name", Group: "webhook-only"}, endpointAlert, result, true); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if requestURL != "https://hooks.slack.com/services/override" || authorization != "" || body.Channel != "" || body.ThreadTS != "" {
+		t.Errorf("expected a standalone message to be sent to the webhook, got url=%s authorization=%q channel=%q thread_ts=%q", requestURL, authorization, body.Channel, body.ThreadTS)
+	}
+}
+
+func TestAlertProvider_SendWithTokenAndErrorPayload(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"ok":false,"error":"channel_not_found"}`))}
+	})})
+	provider := AlertProvider{Token: "xoxb-token", Channel: "C0123456789"}
+	endpointAlert := &alert.Alert{SuccessThreshold: 5, FailureThreshold: 3}
+	err := provider.Send(&endpoint.Endpoint{Name: "endpoint-name"}, endpointAlert, &endpoint.Result{}, false)
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("expected an error mentioning channel_not_found, got %v", err)
+	}
+	if endpointAlert.ResolveKey != "" {
+		t.Errorf("expected no resolve key, got %q", endpointAlert.ResolveKey)
+	}
+}
+
 func TestAlertProvider_buildRequestBody(t *testing.T) {
 	firstDescription := "description-1"
 	secondDescription := "description-2"
@@ -164,6 +243,22 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     false,
 			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"An alert for *group/name* has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false}]}]}",
 		},
+		{
+			Name:         "triggered-with-description-in-title",
+			Provider:     AlertProvider{IncludeDescriptionInTitle: true},
+			Endpoint:     endpoint.Endpoint{Name: "name"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus - description-1\",\"text\":\"An alert for *name* has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false}]}]}",
+		},
+		{
+			Name:         "triggered-with-description-in-title-but-no-description",
+			Provider:     AlertProvider{IncludeDescriptionInTitle: true},
+			Endpoint:     endpoint.Endpoint{Name: "name"},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"An alert for *name* has been triggered due to having failed 3 time(s) in a row\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false}]}]}",
+		},
 		{
 			Name:         "triggered-with-no-conditions",
 			NoConditions: true,
@@ -218,6 +313,56 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildRequestBodyWithConditionMessage(t *testing.T) {
+	body := (&AlertProvider{}).buildRequestBody(
+		&endpoint.Endpoint{Name: "name"},
+		&alert.Alert{FailureThreshold: 3},
+		&endpoint.Result{
+			ConditionResults: []*endpoint.ConditionResult{
+				{Condition: "[STATUS] == 200", Success: true},
+				{Condition: "[BODY].pool.available (0) > 0", Success: false, Message: "Database connection pool exhausted"},
+			},
+		},
+		false,
+	)
+	expectedConditionResults := ":white_check_mark: - `[STATUS] == 200`\n:x: - `[BODY].pool.available (0) > 0`: Database connection pool exhausted\n"
+	var out Body
+	if err := json.Unmarshal(body, &out); err != nil {
+		t.Fatal("expected body to be valid JSON, got error:", err.Error())
+	}
+	if value := out.Attachments[0].Fields[0].Value; value != expectedConditionResults {
+		t.Errorf("expected condition results to be %q, got %q", expectedConditionResults, value)
+	}
+}
+
+func TestAlertProvider_buildRequestBodyWithPageURL(t *testing.T) {
+	scenarios := []struct {
+		Name        string
+		PageURL     string
+		ExpectedURL string
+	}{
+		{Name: "with-page-url", PageURL: "https://status.example.org/endpoints/core_endpoint-name", ExpectedURL: "https://status.example.org/endpoints/core_endpoint-name"},
+		{Name: "without-page-url", PageURL: "", ExpectedURL: ""},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			bodyAsJSON := (&AlertProvider{}).buildRequestBody(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: "core", PageURL: scenario.PageURL},
+				&alert.Alert{FailureThreshold: 3},
+				&endpoint.Result{},
+				false,
+			)
+			var body Body
+			if err := json.Unmarshal(bodyAsJSON, &body); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if len(body.Attachments) != 1 || body.Attachments[0].TitleLink != scenario.ExpectedURL {
+				t.Errorf("expected attachment title_link to be %q, got %s", scenario.ExpectedURL, bodyAsJSON)
+			}
+		})
+	}
+}
+
 func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
 		t.Error("expected default alert to be not nil")
@@ -289,3 +434,55 @@ func TestAlertProvider_getWebhookURLForGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestAlertProvider_getWebhookURLForEndpoint(t *testing.T) {
+	provider := AlertProvider{
+		WebhookURL: "http://example.com",
+		Overrides: []Override{
+			{Group: "core", WebhookURL: "http://core.example.com"},
+			{Labels: map[string]string{"team": "payments"}, WebhookURL: "http://payments.example.com"},
+			{Group: "prod-*", Labels: map[string]string{"team": "search"}, WebhookURL: "http://prod-search.example.com"},
+		},
+	}
+	if !provider.IsValid() {
+		t.Fatal("provider should've been valid")
+	}
+	scenarios := []struct {
+		Name           string
+		Endpoint       *endpoint.Endpoint
+		ExpectedOutput string
+	}{
+		{
+			Name:           "no-labels-should-use-group-override",
+			Endpoint:       &endpoint.Endpoint{Group: "core"},
+			ExpectedOutput: "http://core.example.com",
+		},
+		{
+			Name:           "matching-labels-should-take-precedence-over-group-override",
+			Endpoint:       &endpoint.Endpoint{Group: "core", Labels: map[string]string{"team": "payments", "tier": "1"}},
+			ExpectedOutput: "http://payments.example.com",
+		},
+		{
+			Name:           "non-matching-labels-should-default",
+			Endpoint:       &endpoint.Endpoint{Labels: map[string]string{"team": "identity"}},
+			ExpectedOutput: "http://example.com",
+		},
+		{
+			Name:           "matching-labels-and-group-should-override",
+			Endpoint:       &endpoint.Endpoint{Group: "prod-eu", Labels: map[string]string{"team": "search"}},
+			ExpectedOutput: "http://prod-search.example.com",
+		},
+		{
+			Name:           "matching-labels-but-not-group-should-default",
+			Endpoint:       &endpoint.Endpoint{Group: "staging", Labels: map[string]string{"team": "search"}},
+			ExpectedOutput: "http://example.com",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if got := provider.getWebhookURLForEndpoint(scenario.Endpoint); got != scenario.ExpectedOutput {
+				t.Errorf("AlertProvider.getWebhookURLForEndpoint() = %v, want %v", got, scenario.ExpectedOutput)
+			}
+		})
+	}
+}
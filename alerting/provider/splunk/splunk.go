@@ -0,0 +1,144 @@
+package splunk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	// EventCollectorPath is the path of the HTTP Event Collector endpoint that events are sent to
+	EventCollectorPath = "/services/collector/event"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Splunk's HTTP Event Collector (HEC)
+type AlertProvider struct {
+	// URL is the base URL of the HTTP Event Collector (e.g. https://splunk.example.com:8088)
+	URL string `yaml:"url"`
+
+	// Token is the HTTP Event Collector token
+	Token string `yaml:"token"`
+
+	// Index is the index in which the events should be stored. If empty, the token's default index is used.
+	Index string `yaml:"index,omitempty"`
+
+	// Source is the source of the events. If empty, the token's default source is used.
+	Source string `yaml:"source,omitempty"`
+
+	// Sourcetype is the sourcetype of the events. If empty, the token's default sourcetype is used.
+	Sourcetype string `yaml:"sourcetype,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the provider's target
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.ClientConfig == nil {
+		provider.ClientConfig = client.GetDefaultConfig()
+	}
+	return len(provider.URL) > 0 && len(provider.Token) > 0
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	body, err := provider.buildRequestBody(ep, alert, result, resolved)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(provider.URL, "/")+EventCollectorPath, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Splunk "+provider.Token)
+	response, err := client.GetHTTPClient(provider.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Body is the envelope expected by the HTTP Event Collector
+type Body struct {
+	Time       float64 `json:"time,omitempty"`
+	Index      string  `json:"index,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	Sourcetype string  `json:"sourcetype,omitempty"`
+	Event      Event   `json:"event"`
+}
+
+type Event struct {
+	Endpoint         string             `json:"endpoint"`
+	Group            string             `json:"group,omitempty"`
+	Key              string             `json:"key"`
+	State            string             `json:"state"`
+	Message          string             `json:"message"`
+	Description      string             `json:"description,omitempty"`
+	ConditionResults []*ConditionResult `json:"conditionResults,omitempty"`
+}
+
+type ConditionResult struct {
+	Condition string `json:"condition"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) ([]byte, error) {
+	var message, state string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		state = "resolved"
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
+		state = "triggered"
+	}
+	body := Body{
+		Index:      provider.Index,
+		Source:     provider.Source,
+		Sourcetype: provider.Sourcetype,
+		Event: Event{
+			Endpoint:    ep.Name,
+			Group:       ep.Group,
+			Key:         ep.Key(),
+			State:       state,
+			Message:     alert.GetMessage(resolved, message, ep.AlertMessageContext(result)),
+			Description: alert.GetDescription(),
+		},
+	}
+	if !result.Timestamp.IsZero() {
+		body.Time = float64(result.Timestamp.UnixMilli()) / 1000
+	}
+	for _, conditionResult := range result.ConditionResults {
+		body.Event.ConditionResults = append(body.Event.ConditionResults, &ConditionResult{
+			Condition: conditionResult.Condition,
+			Success:   conditionResult.Success,
+			Message:   conditionResult.Message,
+		})
+	}
+	return json.Marshal(body)
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
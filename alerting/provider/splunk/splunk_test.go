@@ -0,0 +1,182 @@
+package splunk
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		provider AlertProvider
+		expected bool
+	}{
+		{
+			name:     "valid",
+			provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "00000000-0000-0000-0000-000000000000"},
+			expected: true,
+		},
+		{
+			name:     "valid-with-optional-fields",
+			provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "00000000-0000-0000-0000-000000000000", Index: "monitoring", Source: "gatus", Sourcetype: "_json"},
+			expected: true,
+		},
+		{
+			name:     "missing-url",
+			provider: AlertProvider{Token: "00000000-0000-0000-0000-000000000000"},
+			expected: false,
+		},
+		{
+			name:     "missing-token",
+			provider: AlertProvider{URL: "https://splunk.example.com:8088"},
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.provider.IsValid() != scenario.expected {
+				t.Errorf("expected %t, got %t", scenario.expected, scenario.provider.IsValid())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	description := "description-1"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Alert            alert.Alert
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088/", Token: "secret-token"},
+			Alert:    alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != "https://splunk.example.com:8088/services/collector/event" {
+					t.Errorf("expected request to be sent to the event collector, got %s", r.URL.String())
+				}
+				if authorization := r.Header.Get("Authorization"); authorization != "Splunk secret-token" {
+					t.Errorf("expected Authorization header to be 'Splunk secret-token', got '%s'", authorization)
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-error",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "invalid-token"},
+			Alert:    alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusForbidden, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "resolved",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "secret-token"},
+			Alert:    alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			err := scenario.Provider.Send(
+				&endpoint.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&endpoint.Result{
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	description := "description-1"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{URL: "https://splunk.example.com:8088", Token: "secret-token"},
+			Alert:        alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: `{"time":1704067200.5,"event":{"endpoint":"endpoint-name","group":"core","key":"core_endpoint-name","state":"triggered","message":"An alert for core/endpoint-name has been triggered due to having failed 3 time(s) in a row","description":"description-1","conditionResults":[{"condition":"[CONNECTED] == true","success":false},{"condition":"[STATUS] == 200","success":false}]}}`,
+		},
+		{
+			Name:         "resolved-with-index-source-and-sourcetype",
+			Provider:     AlertProvider{URL: "https://splunk.example.com:8088", Token: "secret-token", Index: "monitoring", Source: "gatus", Sourcetype: "_json"},
+			Alert:        alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: `{"time":1704067200.5,"index":"monitoring","source":"gatus","sourcetype":"_json","event":{"endpoint":"endpoint-name","group":"core","key":"core_endpoint-name","state":"resolved","message":"An alert for core/endpoint-name has been resolved after passing successfully 5 time(s) in a row","description":"description-1","conditionResults":[{"condition":"[CONNECTED] == true","success":true},{"condition":"[STATUS] == 200","success":true}]}}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body, err := scenario.Provider.buildRequestBody(
+				&endpoint.Endpoint{Name: "endpoint-name", Group: "core"},
+				&scenario.Alert,
+				&endpoint.Result{
+					Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 500*int(time.Millisecond), time.UTC),
+					ConditionResults: []*endpoint.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
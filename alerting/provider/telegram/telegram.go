@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
 const defaultAPIURL = "https://api.telegram.org"
@@ -20,12 +23,19 @@ type AlertProvider struct {
 	ID     string `yaml:"id"`
 	APIURL string `yaml:"api-url"`
 
+	// ConditionResultsAsCodeBlock is whether to render the condition results inside a monospaced code block, which
+	// keeps long lists of conditions aligned. When enabled, messages are sent using the MarkdownV2 parse mode.
+	ConditionResultsAsCodeBlock bool `yaml:"condition-results-as-code-block,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with the provider's target
 	ClientConfig *client.Config `yaml:"client,omitempty"`
 
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
 	// Overrides is a list of Overrid that may be prioritized over the default configuration
 	Overrides []*Override `yaml:"overrides,omitempty"`
 }
@@ -37,6 +47,16 @@ type Override struct {
 	id    string `yaml:"id"`
 }
 
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
 // IsValid returns whether the provider's configuration is valid
 func (provider *AlertProvider) IsValid() bool {
 	if provider.ClientConfig == nil {
@@ -45,7 +65,7 @@ func (provider *AlertProvider) IsValid() bool {
 
 	registerGroups := make(map[string]bool)
 	for _, override := range provider.Overrides {
-		if len(override.group) == 0 {
+		if len(override.group) == 0 || !pattern.IsValidGroup(override.group) {
 			return false
 		}
 		if _, ok := registerGroups[override.group]; ok {
@@ -82,10 +102,8 @@ func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, r
 }
 
 func (provider *AlertProvider) getTokenForGroup(group string) string {
-	for _, override := range provider.Overrides {
-		if override.group == group && len(override.token) > 0 {
-			return override.token
-		}
+	if o, found := override.Resolve(provider.Overrides, group, nil); found && len(o.token) > 0 {
+		return o.token
 	}
 	return provider.Token
 }
@@ -98,12 +116,16 @@ type Body struct {
 
 // buildRequestBody builds the request body for the provider
 func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
+	if provider.ConditionResultsAsCodeBlock {
+		return provider.buildMarkdownV2RequestBody(ep, alert, result, resolved)
+	}
 	var message string
 	if resolved {
 		message = fmt.Sprintf("An alert for *%s* has been resolved:\n—\n    _healthcheck passing successfully %d time(s) in a row_\n—  ", ep.DisplayName(), alert.SuccessThreshold)
 	} else {
 		message = fmt.Sprintf("An alert for *%s* has been triggered:\n—\n    _healthcheck failed %d time(s) in a row_\n—  ", ep.DisplayName(), alert.FailureThreshold)
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 	var formattedConditionResults string
 	if len(result.ConditionResults) > 0 {
 		formattedConditionResults = "\n*Condition results*\n"
@@ -114,7 +136,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 			} else {
 				prefix = "❌"
 			}
-			formattedConditionResults += fmt.Sprintf("%s - `%s`\n", prefix, conditionResult.Condition)
+			formattedConditionResults += fmt.Sprintf("%s - `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 		}
 	}
 	var text string
@@ -131,11 +153,68 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	return bodyAsJSON
 }
 
-func (provider *AlertProvider) getIDForGroup(group string) string {
-	for _, override := range provider.Overrides {
-		if override.group == group && len(override.id) > 0 {
-			return override.id
+// buildMarkdownV2RequestBody builds the request body for the provider with the condition results inside a code block.
+//
+// Unlike the legacy Markdown parse mode, MarkdownV2 requires every special character to be escaped, but the rules are
+// different inside a code block, where only ` and \ must be escaped.
+func (provider *AlertProvider) buildMarkdownV2RequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
+	var message string
+	if customMessage := alert.GetMessage(resolved, "", ep.AlertMessageContext(result)); len(customMessage) > 0 {
+		message = escapeMarkdownV2(customMessage)
+	} else if resolved {
+		message = fmt.Sprintf("An alert for *%s* has been resolved:\n—\n    _%s_\n—  ", escapeMarkdownV2(ep.DisplayName()), escapeMarkdownV2(fmt.Sprintf("healthcheck passing successfully %d time(s) in a row", alert.SuccessThreshold)))
+	} else {
+		message = fmt.Sprintf("An alert for *%s* has been triggered:\n—\n    _%s_\n—  ", escapeMarkdownV2(ep.DisplayName()), escapeMarkdownV2(fmt.Sprintf("healthcheck failed %d time(s) in a row", alert.FailureThreshold)))
+	}
+	var formattedConditionResults string
+	if len(result.ConditionResults) > 0 {
+		formattedConditionResults = "\n*Condition results*\n```\n"
+		for _, conditionResult := range result.ConditionResults {
+			var prefix string
+			if conditionResult.Success {
+				prefix = "✅"
+			} else {
+				prefix = "❌"
+			}
+			formattedConditionResults += fmt.Sprintf("%s %s\n", prefix, escapeMarkdownV2CodeBlock(conditionResult.Condition+conditionResult.MessageSuffix()))
 		}
+		formattedConditionResults += "```\n"
+	}
+	var text string
+	if len(alert.GetDescription()) > 0 {
+		text = fmt.Sprintf("⛑ *Gatus* \n%s \n*Description* \n_%s_  \n%s", message, escapeMarkdownV2(alert.GetDescription()), formattedConditionResults)
+	} else {
+		text = fmt.Sprintf("⛑ *Gatus* \n%s%s", message, formattedConditionResults)
+	}
+	bodyAsJSON, _ := json.Marshal(Body{
+		ChatID:    provider.getIDForGroup(ep.Group),
+		Text:      text,
+		ParseMode: "MarkdownV2",
+	})
+	return bodyAsJSON
+}
+
+// markdownV2Escaper escapes the characters that must be escaped in MarkdownV2 outside of code blocks.
+// See https://core.telegram.org/bots/api#markdownv2-style
+var markdownV2Escaper = strings.NewReplacer(
+	"\\", "\\\\", "_", "\\_", "*", "\\*", "[", "\\[", "]", "\\]", "(", "\\(", ")", "\\)", "~", "\\~", "`", "\\`",
+	">", "\\>", "#", "\\#", "+", "\\+", "-", "\\-", "=", "\\=", "|", "\\|", "{", "\\{", "}", "\\}", ".", "\\.", "!", "\\!",
+)
+
+// markdownV2CodeBlockEscaper escapes the characters that must be escaped in MarkdownV2 inside of code blocks
+var markdownV2CodeBlockEscaper = strings.NewReplacer("\\", "\\\\", "`", "\\`")
+
+func escapeMarkdownV2(s string) string {
+	return markdownV2Escaper.Replace(s)
+}
+
+func escapeMarkdownV2CodeBlock(s string) string {
+	return markdownV2CodeBlockEscaper.Replace(s)
+}
+
+func (provider *AlertProvider) getIDForGroup(group string) string {
+	if o, found := override.Resolve(provider.Overrides, group, nil); found && len(o.id) > 0 {
+		return o.id
 	}
 	return provider.ID
 }
@@ -232,6 +232,74 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildRequestBodyWithConditionResultsAsCodeBlock(t *testing.T) {
+	description := "description (with special characters)."
+	scenarios := []struct {
+		Name         string
+		Alert        alert.Alert
+		Resolved     bool
+		Conditions   []*endpoint.ConditionResult
+		ExpectedText string
+	}{
+		{
+			Name:     "triggered",
+			Alert:    alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			Conditions: []*endpoint.ConditionResult{
+				{Condition: "[CONNECTED] == true", Success: false},
+				{Condition: "[STATUS] == 200", Success: false},
+			},
+			ExpectedText: "⛑ *Gatus* \nAn alert for *endpoint\\-name* has been triggered:\n—\n    _healthcheck failed 3 time\\(s\\) in a row_\n—   \n*Description* \n_description \\(with special characters\\)\\._  \n\n*Condition results*\n```\n❌ [CONNECTED] == true\n❌ [STATUS] == 200\n```\n",
+		},
+		{
+			Name:     "resolved-without-description",
+			Alert:    alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			Conditions: []*endpoint.ConditionResult{
+				{Condition: "[BODY].name == pat(*john_doe*)", Success: true},
+				{Condition: "[RESPONSE_TIME] < 500", Success: true},
+			},
+			ExpectedText: "⛑ *Gatus* \nAn alert for *endpoint\\-name* has been resolved:\n—\n    _healthcheck passing successfully 5 time\\(s\\) in a row_\n—  \n*Condition results*\n```\n✅ [BODY].name == pat(*john_doe*)\n✅ [RESPONSE_TIME] < 500\n```\n",
+		},
+		{
+			Name:     "code-block-characters-are-escaped",
+			Alert:    alert.Alert{FailureThreshold: 3},
+			Resolved: false,
+			Conditions: []*endpoint.ConditionResult{
+				{Condition: "[BODY] == `a\\b`", Success: false},
+			},
+			ExpectedText: "⛑ *Gatus* \nAn alert for *endpoint\\-name* has been triggered:\n—\n    _healthcheck failed 3 time\\(s\\) in a row_\n—  \n*Condition results*\n```\n❌ [BODY] == \\`a\\\\b\\`\n```\n",
+		},
+		{
+			Name:         "custom-message-without-conditions",
+			Alert:        alert.Alert{FailureThreshold: 3, TriggerMessage: "[ENDPOINT_NAME] is down!"},
+			Resolved:     false,
+			ExpectedText: "⛑ *Gatus* \nendpoint\\-name is down\\!",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			provider := AlertProvider{ID: "123", ConditionResultsAsCodeBlock: true}
+			body := provider.buildRequestBody(
+				&endpoint.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&endpoint.Result{ConditionResults: scenario.Conditions},
+				scenario.Resolved,
+			)
+			var out Body
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if out.ParseMode != "MarkdownV2" {
+				t.Errorf("expected parse mode to be MarkdownV2, got %s", out.ParseMode)
+			}
+			if out.Text != scenario.ExpectedText {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedText, out.Text)
+			}
+		})
+	}
+}
+
 func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
 		t.Error("expected default alert to be not nil")
@@ -0,0 +1,140 @@
+package webex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
+)
+
+const restAPIURL = "https://webexapis.com/v1/messages"
+
+// AlertProvider is the configuration necessary for sending an alert using Webex
+type AlertProvider struct {
+	// BotToken is the access token of the Webex bot posting the messages
+	BotToken string `yaml:"bot-token"`
+
+	// RoomID is the ID of the room the messages are posted to. The bot must be a member of the room.
+	RoomID string `yaml:"room-id"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group  string `yaml:"group"`
+	RoomID string `yaml:"room-id"`
+}
+
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.Overrides != nil {
+		registeredGroups := make(map[string]bool)
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || len(override.RoomID) == 0 {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	return len(provider.BotToken) > 0 && len(provider.RoomID) > 0
+}
+
+// Send an alert using the provider
+//
+// Relevant: https://developer.webex.com/docs/api/v1/messages/create-a-message
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	buffer := bytes.NewBuffer(provider.buildRequestBody(ep, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, restAPIURL, buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+provider.BotToken)
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+type Body struct {
+	RoomID   string `json:"roomId"`
+	Markdown string `json:"markdown"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
+	var message, emoji string
+	if resolved {
+		message = fmt.Sprintf("An alert for **%s** has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		emoji = "✅"
+	} else {
+		message = fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
+		emoji = "🚨"
+	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
+	markdown := emoji + " " + message
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		markdown += "\n\n> " + alertDescription
+	}
+	if len(result.ConditionResults) > 0 {
+		markdown += "\n\n**Condition results**\n"
+		for _, conditionResult := range result.ConditionResults {
+			var prefix string
+			if conditionResult.Success {
+				prefix = "✅"
+			} else {
+				prefix = "❌"
+			}
+			markdown += fmt.Sprintf("- %s `%s`%s\n", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
+		}
+	}
+	body, _ := json.Marshal(Body{
+		RoomID:   provider.getRoomIDForGroup(ep.Group),
+		Markdown: markdown,
+	})
+	return body
+}
+
+// getRoomIDForGroup returns the appropriate room ID for a given group
+func (provider *AlertProvider) getRoomIDForGroup(group string) string {
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.RoomID
+	}
+	return provider.RoomID
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,165 @@
+package webex
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{Name: "valid", Provider: AlertProvider{BotToken: "token", RoomID: "room"}, Expected: true},
+		{Name: "no-token", Provider: AlertProvider{RoomID: "room"}, Expected: false},
+		{Name: "no-room", Provider: AlertProvider{BotToken: "token"}, Expected: false},
+		{Name: "valid-override", Provider: AlertProvider{BotToken: "token", RoomID: "room", Overrides: []Override{{Group: "core", RoomID: "core-room"}}}, Expected: true},
+		{Name: "override-without-group", Provider: AlertProvider{BotToken: "token", RoomID: "room", Overrides: []Override{{RoomID: "core-room"}}}, Expected: false},
+		{Name: "override-without-room", Provider: AlertProvider{BotToken: "token", RoomID: "room", Overrides: []Override{{Group: "core"}}}, Expected: false},
+		{Name: "duplicate-override", Provider: AlertProvider{BotToken: "token", RoomID: "room", Overrides: []Override{{Group: "core", RoomID: "a"}, {Group: "core", RoomID: "b"}}}, Expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, scenario.Provider.IsValid())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	scenarios := []struct {
+		Name          string
+		StatusCode    int
+		ExpectedError bool
+	}{
+		{Name: "success", StatusCode: http.StatusOK, ExpectedError: false},
+		{Name: "error", StatusCode: http.StatusUnauthorized, ExpectedError: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var body Body
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != restAPIURL {
+					t.Errorf("expected request to be sent to %s, got %s", restAPIURL, r.URL.String())
+				}
+				if authorization := r.Header.Get("Authorization"); authorization != "Bearer bot-token" {
+					t.Errorf("expected Authorization header to be %q, got %q", "Bearer bot-token", authorization)
+				}
+				if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+					t.Errorf("expected Content-Type header to be application/json, got %s", contentType)
+				}
+				rawBody, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(rawBody, &body); err != nil {
+					t.Error("expected body to be valid JSON, got error:", err.Error())
+				}
+				return &http.Response{StatusCode: scenario.StatusCode, Body: http.NoBody}
+			})})
+			err := (&AlertProvider{BotToken: "bot-token", RoomID: "room-id"}).Send(
+				&endpoint.Endpoint{Name: "endpoint-name"},
+				&alert.Alert{FailureThreshold: 3},
+				&endpoint.Result{},
+				false,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+			if body.RoomID != "room-id" {
+				t.Errorf("expected roomId to be room-id, got %s", body.RoomID)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Endpoint     endpoint.Endpoint
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{BotToken: "token", RoomID: "room"},
+			Endpoint:     endpoint.Endpoint{Name: "name"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"roomId\":\"room\",\"markdown\":\"🚨 An alert for **name** has been triggered due to having failed 3 time(s) in a row\\n\\n\\u003e description-1\\n\\n**Condition results**\\n- ❌ `[CONNECTED] == true`\\n- ❌ `[STATUS] == 200`: got 500\\n\"}",
+		},
+		{
+			Name:         "resolved",
+			Provider:     AlertProvider{BotToken: "token", RoomID: "room"},
+			Endpoint:     endpoint.Endpoint{Name: "name"},
+			Alert:        alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"roomId\":\"room\",\"markdown\":\"✅ An alert for **name** has been resolved after passing successfully 5 time(s) in a row\\n\\n\\u003e description-2\\n\\n**Condition results**\\n- ✅ `[CONNECTED] == true`\\n- ✅ `[STATUS] == 200`\\n\"}",
+		},
+		{
+			Name:         "triggered-with-group-override",
+			Provider:     AlertProvider{BotToken: "token", RoomID: "room", Overrides: []Override{{Group: "core", RoomID: "core-room"}}},
+			Endpoint:     endpoint.Endpoint{Name: "name", Group: "core"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"roomId\":\"core-room\",\"markdown\":\"🚨 An alert for **core/name** has been triggered due to having failed 3 time(s) in a row\\n\\n\\u003e description-1\\n\\n**Condition results**\\n- ❌ `[CONNECTED] == true`\\n- ❌ `[STATUS] == 200`: got 500\\n\"}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			conditionResults := []*endpoint.ConditionResult{
+				{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+				{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+			}
+			if !scenario.Resolved {
+				conditionResults[1].Message = "got 500"
+			}
+			body := scenario.Provider.buildRequestBody(
+				&scenario.Endpoint,
+				&scenario.Alert,
+				&endpoint.Result{ConditionResults: conditionResults},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getRoomIDForGroup(t *testing.T) {
+	provider := AlertProvider{BotToken: "token", RoomID: "room", Overrides: []Override{{Group: "core", RoomID: "core-room"}}}
+	if roomID := provider.getRoomIDForGroup("core"); roomID != "core-room" {
+		t.Errorf("expected core-room, got %s", roomID)
+	}
+	if roomID := provider.getRoomIDForGroup("other"); roomID != "room" {
+		t.Errorf("expected room, got %s", roomID)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
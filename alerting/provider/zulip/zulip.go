@@ -8,8 +8,10 @@ import (
 	"net/url"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/override"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
 )
 
 type Config struct {
@@ -28,6 +30,8 @@ type AlertProvider struct {
 	Config `yaml:",inline"`
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+	// BaseProviderConfig is the configuration shared by all alerting providers
+	alert.BaseProviderConfig `yaml:",inline"`
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
 }
@@ -38,6 +42,16 @@ type Override struct {
 	Group string `yaml:"group"`
 }
 
+// GetGroup returns the group of the endpoints the override applies to
+func (override Override) GetGroup() string {
+	return override.Group
+}
+
+// GetLabels returns nil, since the overrides of this provider can only apply to a group
+func (override Override) GetLabels() map[string]string {
+	return nil
+}
+
 func (provider *AlertProvider) validateConfig(conf *Config) bool {
 	return len(conf.BotEmail) > 0 && len(conf.BotAPIKey) > 0 && len(conf.Domain) > 0 && len(conf.ChannelID) > 0
 }
@@ -48,7 +62,7 @@ func (provider *AlertProvider) IsValid() bool {
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
 			isAlreadyRegistered := registeredGroups[override.Group]
-			if isAlreadyRegistered || override.Group == "" || !provider.validateConfig(&override.Config) {
+			if isAlreadyRegistered || override.Group == "" || !pattern.IsValidGroup(override.Group) || !provider.validateConfig(&override.Config) {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -59,10 +73,8 @@ func (provider *AlertProvider) IsValid() bool {
 
 // getChannelIdForGroup returns the channel ID for the provided group
 func (provider *AlertProvider) getChannelIdForGroup(group string) string {
-	for _, override := range provider.Overrides {
-		if override.Group == group {
-			return override.ChannelID
-		}
+	if o, found := override.Resolve(provider.Overrides, group, nil); found {
+		return o.ChannelID
 	}
 	return provider.ChannelID
 }
@@ -75,6 +87,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	} else {
 		message = fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
 	}
+	message = alert.GetMessage(resolved, message, ep.AlertMessageContext(result))
 
 	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
 		message += "\n> " + alertDescription + "\n"
@@ -87,7 +100,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		} else {
 			prefix = ":cross_mark:"
 		}
-		message += fmt.Sprintf("\n%s - `%s`", prefix, conditionResult.Condition)
+		message += fmt.Sprintf("\n%s - `%s`%s", prefix, conditionResult.Condition, conditionResult.MessageSuffix())
 	}
 
 	postData := map[string]string{
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MuteAlerts mutes all alerts until UnmuteAlerts is called.
+// Endpoints are still monitored while alerts are muted.
+func MuteAlerts(c *fiber.Ctx) error {
+	alerting.Mute()
+	log.Println("[api.MuteAlerts] Muted all alerts")
+	saveRuntimeState()
+	return alertingMuteState(c)
+}
+
+// UnmuteAlerts resumes sending alerts
+func UnmuteAlerts(c *fiber.Ctx) error {
+	alerting.Unmute()
+	log.Println("[api.UnmuteAlerts] Unmuted all alerts")
+	saveRuntimeState()
+	return alertingMuteState(c)
+}
+
+func alertingMuteState(c *fiber.Ctx) error {
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).SendString(fmt.Sprintf(`{"muted":%v}`, alerting.IsMuted()))
+}
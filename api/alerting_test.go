@@ -0,0 +1,73 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/config"
+)
+
+func TestMuteAndUnmuteAlerts(t *testing.T) {
+	defer alerting.Unmute()
+	api := New(&config.Config{})
+	router := api.Router()
+	scenarios := []struct {
+		Name          string
+		Path          string
+		ExpectedMuted bool
+		ExpectedBody  string
+	}{
+		{
+			Name:          "mute",
+			Path:          "/api/v1/alerting/mute",
+			ExpectedMuted: true,
+			ExpectedBody:  `{"muted":true}`,
+		},
+		{
+			Name:          "mute-when-already-muted",
+			Path:          "/api/v1/alerting/mute",
+			ExpectedMuted: true,
+			ExpectedBody:  `{"muted":true}`,
+		},
+		{
+			Name:          "unmute",
+			Path:          "/api/v1/alerting/unmute",
+			ExpectedMuted: false,
+			ExpectedBody:  `{"muted":false}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("POST", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != 200 {
+				t.Errorf("POST %s should have returned %d, but returned %d instead", scenario.Path, 200, response.StatusCode)
+			}
+			if body, _ := io.ReadAll(response.Body); string(body) != scenario.ExpectedBody {
+				t.Errorf("expected body to be %s, got %s", scenario.ExpectedBody, string(body))
+			}
+			if alerting.IsMuted() != scenario.ExpectedMuted {
+				t.Errorf("expected muted to be %v, got %v", scenario.ExpectedMuted, alerting.IsMuted())
+			}
+			// The mute state must also be reflected in the config endpoint
+			response, err = router.Test(httptest.NewRequest("GET", "/api/v1/config", http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			expectedConfigBody := `{"oidc":false,"authenticated":true,"alertingMuted":false}`
+			if scenario.ExpectedMuted {
+				expectedConfigBody = `{"oidc":false,"authenticated":true,"alertingMuted":true}`
+			}
+			if body, _ := io.ReadAll(response.Body); string(body) != expectedConfigBody {
+				t.Errorf("expected config body to be %s, got %s", expectedConfigBody, string(body))
+			}
+		})
+	}
+}
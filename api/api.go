@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/web"
@@ -12,8 +13,8 @@ import (
 	"github.com/TwiN/health"
 	fiber "github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
-	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 	fiberfs "github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/redirect"
@@ -56,16 +57,28 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	}
 	// Middlewares
 	app.Use(recover.New())
-	app.Use(compress.New())
+	if cfg.Web.Compression.IsEnabled() {
+		app.Use(Compress(cfg.Web.Compression))
+	}
+	// Every route is served under the context root, if any
+	contextRoot := cfg.Web.ContextRoot
+	router := app.Group(contextRoot)
 	// Define metrics handler, if necessary
 	if cfg.Metrics {
 		metricsHandler := promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
 			DisableCompression: true,
 		}))
-		app.Get("/metrics", adaptor.HTTPHandler(metricsHandler))
+		router.Get("/metrics", adaptor.HTTPHandler(metricsHandler))
 	}
 	// Define main router
-	apiRouter := app.Group("/api")
+	apiRouter := router.Group("/api")
+	if cfg.Web.CORS != nil {
+		apiRouter.Use("/v1", cors.New(cors.Config{
+			AllowOrigins: strings.Join(cfg.Web.CORS.AllowedOrigins, ","),
+			AllowMethods: strings.Join(cfg.Web.CORS.AllowedMethods, ","),
+			AllowHeaders: strings.Join(cfg.Web.CORS.AllowedHeaders, ","),
+		}))
+	}
 	////////////////////////
 	// UNPROTECTED ROUTES //
 	////////////////////////
@@ -77,20 +90,25 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/badge.svg", ResponseTimeBadge(cfg))
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/chart.svg", ResponseTimeChart)
 	// This endpoint requires authz with bearer token, so technically it is protected
-	unprotectedAPIRouter.Post("/v1/endpoints/:key/external", CreateExternalEndpointResult(cfg))
+	if cfg.Web.RateLimits != nil && cfg.Web.RateLimits.ExternalEndpointResults != nil {
+		unprotectedAPIRouter.Post("/v1/endpoints/:key/external", RateLimit(cfg.Web.RateLimits.ExternalEndpointResults), CreateExternalEndpointResult(cfg))
+	} else {
+		unprotectedAPIRouter.Post("/v1/endpoints/:key/external", CreateExternalEndpointResult(cfg))
+	}
 	// SPA
-	app.Get("/", SinglePageApplication(cfg.UI))
-	app.Get("/endpoints/:name", SinglePageApplication(cfg.UI))
-	// Health endpoint
-	healthHandler := health.Handler().WithJSON(true)
-	app.Get("/health", func(c *fiber.Ctx) error {
-		statusCode, body := healthHandler.GetResponseStatusCodeAndBody()
+	router.Get("/", SinglePageApplication(cfg.UI))
+	router.Get("/endpoints/:name", SinglePageApplication(cfg.UI))
+	// Health endpoints
+	router.Get("/health", Health(cfg))
+	livenessHandler := health.Handler().WithJSON(true)
+	router.Get("/health/live", func(c *fiber.Ctx) error {
+		statusCode, body := livenessHandler.GetResponseStatusCodeAndBody()
 		return c.Status(statusCode).Send(body)
 	})
 	// Everything else falls back on static content
-	app.Use(redirect.New(redirect.Config{
+	router.Use(redirect.New(redirect.Config{
 		Rules: map[string]string{
-			"/index.html": "/",
+			contextRoot + "/index.html": contextRoot + "/",
 		},
 		StatusCode: 301,
 	}))
@@ -98,7 +116,7 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	if err != nil {
 		panic(err)
 	}
-	app.Use("/", fiberfs.New(fiberfs.Config{
+	router.Use("/", fiberfs.New(fiberfs.Config{
 		Root:   http.FS(staticFileSystem),
 		Index:  "index.html",
 		Browse: true,
@@ -109,14 +127,26 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	// ORDER IS IMPORTANT: all routes applied AFTER the security middleware will require authn
 	protectedAPIRouter := apiRouter.Group("/")
 	if cfg.Security != nil {
-		if err := cfg.Security.RegisterHandlers(app); err != nil {
+		if err := cfg.Security.RegisterHandlers(router); err != nil {
 			panic(err)
 		}
 		if err := cfg.Security.ApplySecurityMiddleware(protectedAPIRouter); err != nil {
 			panic(err)
 		}
 	}
-	protectedAPIRouter.Get("/v1/endpoints/statuses", EndpointStatuses(cfg))
-	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", EndpointStatus)
+	// The statuses are polled frequently by dashboards, so they're sent with an ETag to let clients that already have
+	// the latest statuses get a 304 Not Modified instead
+	statusesETag := etag.New()
+	protectedAPIRouter.Get("/v1/endpoints/statuses", statusesETag, EndpointStatuses(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", statusesETag, EndpointStatus)
+	protectedAPIRouter.Get("/v1/endpoints/:key/results", EndpointResults)
+	protectedAPIRouter.Get("/v1/endpoints/:key/results/search", SearchEndpointResults)
+	protectedAPIRouter.Get("/v1/groups/statuses", statusesETag, GroupStatuses)
+	protectedAPIRouter.Post("/v1/maintenance", CreateMaintenanceWindow)
+	protectedAPIRouter.Delete("/v1/maintenance/:id", CancelMaintenanceWindow)
+	protectedAPIRouter.Post("/v1/alerting/mute", MuteAlerts)
+	protectedAPIRouter.Post("/v1/alerting/unmute", UnmuteAlerts)
+	protectedAPIRouter.Get("/v1/runtime-state", ExportRuntimeState)
+	protectedAPIRouter.Put("/v1/runtime-state", ImportRuntimeState)
 	return app
 }
@@ -1,12 +1,15 @@
 package api
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/gofiber/fiber/v2"
 )
@@ -25,6 +28,11 @@ func TestNew(t *testing.T) {
 			Path:         "/health",
 			ExpectedCode: fiber.StatusOK,
 		},
+		{
+			Name:         "health-live",
+			Path:         "/health/live",
+			ExpectedCode: fiber.StatusOK,
+		},
 		{
 			Name:         "metrics",
 			Path:         "/metrics",
@@ -119,3 +127,189 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_WithContextRoot(t *testing.T) {
+	cfg := &config.Config{
+		Metrics: true,
+		Web:     &web.Config{ContextRoot: "/status"},
+		UI:      &ui.Config{Title: "example-title", ContextRoot: "/status"},
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+	}{
+		{Name: "index", Path: "/status/", ExpectedCode: fiber.StatusOK},
+		{Name: "index-without-trailing-slash", Path: "/status", ExpectedCode: fiber.StatusOK},
+		{Name: "index-html-redirect", Path: "/status/index.html", ExpectedCode: fiber.StatusMovedPermanently},
+		{Name: "endpoint-details", Path: "/status/endpoints/core_frontend", ExpectedCode: fiber.StatusOK},
+		{Name: "app.js", Path: "/status/js/app.js", ExpectedCode: fiber.StatusOK},
+		{Name: "favicon.ico", Path: "/status/favicon.ico", ExpectedCode: fiber.StatusOK},
+		{Name: "health", Path: "/status/health", ExpectedCode: fiber.StatusOK},
+		{Name: "metrics", Path: "/status/metrics", ExpectedCode: fiber.StatusOK},
+		{Name: "config", Path: "/status/api/v1/config", ExpectedCode: fiber.StatusOK},
+		{Name: "statuses", Path: "/status/api/v1/endpoints/statuses", ExpectedCode: fiber.StatusOK},
+		{Name: "root-index", Path: "/", ExpectedCode: fiber.StatusNotFound},
+		{Name: "root-app.js", Path: "/js/app.js", ExpectedCode: fiber.StatusNotFound},
+		{Name: "root-health", Path: "/health", ExpectedCode: fiber.StatusNotFound},
+		{Name: "root-metrics", Path: "/metrics", ExpectedCode: fiber.StatusNotFound},
+		{Name: "root-config", Path: "/api/v1/config", ExpectedCode: fiber.StatusNotFound},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.Name == "index-html-redirect" && response.Header.Get("Location") != "/status/" {
+				t.Errorf("expected to be redirected to /status/, got %s", response.Header.Get("Location"))
+			}
+		})
+	}
+	t.Run("index-references-assets-under-context-root", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/status/", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		for _, expected := range []string{`src="/status/js/app.js"`, `href="/status/css/app.css"`, `contextRoot: "\/status"`} {
+			if !strings.Contains(string(body), expected) {
+				t.Errorf("expected index to contain %s, got %s", expected, body)
+			}
+		}
+	})
+	t.Run("app-loads-assets-under-context-root", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/status/js/app.js", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		if !strings.Contains(string(body), `window.config.contextRoot+"/"`) {
+			t.Error("expected the public path of the app to be derived from the context root at runtime")
+		}
+	})
+}
+
+func TestNew_WithCORS(t *testing.T) {
+	cfg := &config.Config{
+		Web: &web.Config{CORS: &web.CORSConfig{
+			AllowedOrigins: []string{"https://dashboard.example.org"},
+			AllowedMethods: web.DefaultCORSAllowedMethods,
+			AllowedHeaders: []string{"Content-Type", "Authorization"},
+		}},
+		UI: &ui.Config{},
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                 string
+		Method               string
+		Path                 string
+		Origin               string
+		RequestMethod        string
+		ExpectedCode         int
+		ExpectedAllowOrigin  string
+		ExpectedAllowMethods string
+		ExpectedAllowHeaders string
+	}{
+		{
+			Name:                "allowed-origin",
+			Method:              "GET",
+			Path:                "/api/v1/config",
+			Origin:              "https://dashboard.example.org",
+			ExpectedCode:        fiber.StatusOK,
+			ExpectedAllowOrigin: "https://dashboard.example.org",
+		},
+		{
+			Name:         "denied-origin",
+			Method:       "GET",
+			Path:         "/api/v1/config",
+			Origin:       "https://evil.example.com",
+			ExpectedCode: fiber.StatusOK,
+		},
+		{
+			Name:         "no-origin",
+			Method:       "GET",
+			Path:         "/api/v1/config",
+			ExpectedCode: fiber.StatusOK,
+		},
+		{
+			Name:                 "preflight-allowed-origin",
+			Method:               "OPTIONS",
+			Path:                 "/api/v1/endpoints/statuses",
+			Origin:               "https://dashboard.example.org",
+			RequestMethod:        "GET",
+			ExpectedCode:         fiber.StatusNoContent,
+			ExpectedAllowOrigin:  "https://dashboard.example.org",
+			ExpectedAllowMethods: "GET,HEAD,POST,DELETE",
+			ExpectedAllowHeaders: "Content-Type,Authorization",
+		},
+		{
+			// The browser rejects the preflight because of the missing Access-Control-Allow-Origin header
+			Name:                 "preflight-denied-origin",
+			Method:               "OPTIONS",
+			Path:                 "/api/v1/endpoints/statuses",
+			Origin:               "https://evil.example.com",
+			RequestMethod:        "GET",
+			ExpectedCode:         fiber.StatusNoContent,
+			ExpectedAllowMethods: "GET,HEAD,POST,DELETE",
+			ExpectedAllowHeaders: "Content-Type,Authorization",
+		},
+		{
+			Name:         "outside-of-api",
+			Method:       "GET",
+			Path:         "/health",
+			Origin:       "https://dashboard.example.org",
+			ExpectedCode: fiber.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			if len(scenario.Origin) > 0 {
+				request.Header.Set("Origin", scenario.Origin)
+			}
+			if len(scenario.RequestMethod) > 0 {
+				request.Header.Set("Access-Control-Request-Method", scenario.RequestMethod)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if allowOrigin := response.Header.Get("Access-Control-Allow-Origin"); allowOrigin != scenario.ExpectedAllowOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin to be %q, got %q", scenario.ExpectedAllowOrigin, allowOrigin)
+			}
+			if allowMethods := response.Header.Get("Access-Control-Allow-Methods"); allowMethods != scenario.ExpectedAllowMethods {
+				t.Errorf("expected Access-Control-Allow-Methods to be %q, got %q", scenario.ExpectedAllowMethods, allowMethods)
+			}
+			if allowHeaders := response.Header.Get("Access-Control-Allow-Headers"); allowHeaders != scenario.ExpectedAllowHeaders {
+				t.Errorf("expected Access-Control-Allow-Headers to be %q, got %q", scenario.ExpectedAllowHeaders, allowHeaders)
+			}
+		})
+	}
+	t.Run("disabled-by-default", func(t *testing.T) {
+		router := New(&config.Config{UI: &ui.Config{}}).Router()
+		request := httptest.NewRequest("GET", "/api/v1/config", http.NoBody)
+		request.Header.Set("Origin", "https://dashboard.example.org")
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if allowOrigin := response.Header.Get("Access-Control-Allow-Origin"); len(allowOrigin) > 0 {
+			t.Errorf("expected no Access-Control-Allow-Origin header, got %q", allowOrigin)
+		}
+	})
+}
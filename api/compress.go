@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// Compress returns a middleware compressing the responses with the encoding requested by the client through the
+// Accept-Encoding header, unless they are smaller than the minimum size of the configuration
+func Compress(cfg *web.CompressionConfig) fiber.Handler {
+	minimumSize := 0
+	if cfg != nil {
+		minimumSize = cfg.MinimumSize
+	}
+	compressor := fasthttp.CompressHandlerBrotliLevel(func(*fasthttp.RequestCtx) {}, fasthttp.CompressBrotliDefaultCompression, fasthttp.CompressDefaultCompression)
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+		// The size of a streamed body, such as a static file's, is unknown until it's read, so it's always compressed
+		if !c.Response().IsBodyStream() && len(c.Response().Body()) < minimumSize {
+			return nil
+		}
+		compressor(c.Context())
+		return nil
+	}
+}
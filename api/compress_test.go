@@ -0,0 +1,115 @@
+package api
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/ui"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestCompress(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	var endpoints []*endpoint.Endpoint
+	for i := 0; i < 25; i++ {
+		ep := &endpoint.Endpoint{Name: fmt.Sprintf("endpoint-%d", i), Group: "core", UIConfig: ui.GetDefaultConfig()}
+		watchdog.UpdateEndpointStatuses(ep, &endpoint.Result{Success: true, Connected: true, Duration: time.Millisecond, Timestamp: time.Now()})
+		endpoints = append(endpoints, ep)
+	}
+	disabled := false
+	scenarios := []struct {
+		name                    string
+		compression             *web.CompressionConfig
+		path                    string
+		acceptEncoding          string
+		expectedContentEncoding string
+	}{
+		{
+			name:                    "large-response-with-gzip",
+			compression:             &web.CompressionConfig{MinimumSize: web.DefaultCompressionMinimumSize},
+			path:                    "/api/v1/endpoints/statuses",
+			acceptEncoding:          "gzip",
+			expectedContentEncoding: "gzip",
+		},
+		{
+			name:                    "large-response-with-deflate",
+			compression:             &web.CompressionConfig{MinimumSize: web.DefaultCompressionMinimumSize},
+			path:                    "/api/v1/endpoints/statuses",
+			acceptEncoding:          "deflate",
+			expectedContentEncoding: "deflate",
+		},
+		{
+			name:                    "large-response-without-accept-encoding",
+			compression:             &web.CompressionConfig{MinimumSize: web.DefaultCompressionMinimumSize},
+			path:                    "/api/v1/endpoints/statuses",
+			acceptEncoding:          "",
+			expectedContentEncoding: "",
+		},
+		{
+			name:                    "large-response-with-compression-disabled",
+			compression:             &web.CompressionConfig{Enabled: &disabled, MinimumSize: web.DefaultCompressionMinimumSize},
+			path:                    "/api/v1/endpoints/statuses",
+			acceptEncoding:          "gzip",
+			expectedContentEncoding: "",
+		},
+		{
+			name:                    "badge-below-minimum-size",
+			compression:             &web.CompressionConfig{MinimumSize: web.DefaultCompressionMinimumSize},
+			path:                    "/api/v1/endpoints/core_endpoint-0/health/badge.svg",
+			acceptEncoding:          "gzip",
+			expectedContentEncoding: "",
+		},
+		{
+			name:                    "badge-above-lowered-minimum-size",
+			compression:             &web.CompressionConfig{MinimumSize: 1},
+			path:                    "/api/v1/endpoints/core_endpoint-0/health/badge.svg",
+			acceptEncoding:          "gzip",
+			expectedContentEncoding: "gzip",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cache.Clear()
+			cfg := &config.Config{Endpoints: endpoints, Web: &web.Config{Compression: scenario.compression}}
+			router := New(cfg).Router()
+			request := httptest.NewRequest("GET", scenario.path, http.NoBody)
+			if len(scenario.acceptEncoding) > 0 {
+				request.Header.Set("Accept-Encoding", scenario.acceptEncoding)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+			}
+			if contentEncoding := response.Header.Get("Content-Encoding"); contentEncoding != scenario.expectedContentEncoding {
+				t.Fatalf("expected Content-Encoding to be %q, got %q", scenario.expectedContentEncoding, contentEncoding)
+			}
+			var body io.Reader = response.Body
+			if scenario.expectedContentEncoding == "gzip" {
+				if body, err = gzip.NewReader(response.Body); err != nil {
+					t.Fatal(err)
+				}
+			}
+			uncompressedBody, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(uncompressedBody) == 0 {
+				t.Error("expected the response to have a body")
+			}
+		})
+	}
+}
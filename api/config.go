@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 
+	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/gofiber/fiber/v2"
 )
@@ -21,5 +22,5 @@ func (handler ConfigHandler) GetConfig(c *fiber.Ctx) error {
 	// Return the config
 	c.Set("Content-Type", "application/json")
 	return c.Status(200).
-		SendString(fmt.Sprintf(`{"oidc":%v,"authenticated":%v}`, hasOIDC, isAuthenticated))
+		SendString(fmt.Sprintf(`{"oidc":%v,"authenticated":%v,"alertingMuted":%v}`, hasOIDC, isAuthenticated, alerting.IsMuted()))
 }
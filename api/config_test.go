@@ -40,7 +40,7 @@ func TestConfigHandler_ServeHTTP(t *testing.T) {
 	if err != nil {
 		t.Error("expected err to be nil, but was", err)
 	}
-	if string(body) != `{"oidc":true,"authenticated":false}` {
-		t.Error("expected body to be `{\"oidc\":true,\"authenticated\":false}`, but was", string(body))
+	if string(body) != `{"oidc":true,"authenticated":false,"alertingMuted":false}` {
+		t.Error("expected body to be `{\"oidc\":true,\"authenticated\":false,\"alertingMuted\":false}`, but was", string(body))
 	}
 }
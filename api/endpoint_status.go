@@ -1,27 +1,54 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/remote"
+	"github.com/TwiN/gatus/v5/pattern"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/gofiber/fiber/v2"
 )
 
+// EndpointStatusesPage is the response of EndpointStatuses when the endpoints are explicitly paginated or filtered
+type EndpointStatusesPage struct {
+	// Total is the number of endpoints matching the filters, across all pages
+	Total int `json:"total"`
+
+	// Page is the page of endpoints returned
+	Page int `json:"page"`
+
+	// PageSize is the maximum number of endpoints per page
+	PageSize int `json:"pageSize"`
+
+	// Endpoints is the list of endpoint statuses in the page
+	Endpoints []*endpoint.Status `json:"endpoints"`
+}
+
 // EndpointStatuses handles requests to retrieve all EndpointStatus
 // Due to how intensive this operation can be on the storage, this function leverages a cache.
+//
+// If the page, pageSize, group, name or label.<key> query parameters are provided, the endpoint statuses are filtered,
+// paginated and wrapped in an EndpointStatusesPage. Otherwise, the first DefaultEndpointsPageSize endpoint statuses
+// are returned as a list, like they were before the endpoints could be paginated.
 func EndpointStatuses(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		page, pageSize := extractPageAndPageSizeFromRequest(c)
-		value, exists := cache.Get(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize))
+		page, pageSize := extractResultsPageAndPageSizeFromRequest(c)
+		query := extractEndpointStatusesQueryFromRequest(c)
+		cacheKey := fmt.Sprintf("endpoint-status-%d-%d-%s", page, pageSize, query.cacheKey())
+		value, exists := cache.Get(cacheKey)
 		var data []byte
 		if !exists {
 			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
@@ -35,13 +62,18 @@ func EndpointStatuses(cfg *config.Config) fiber.Handler {
 			} else if endpointStatusesFromRemote != nil {
 				endpointStatuses = append(endpointStatuses, endpointStatusesFromRemote...)
 			}
+			populateEndpointStatusesLabels(cfg, endpointStatuses)
 			// Marshal endpoint statuses to JSON
-			data, err = json.Marshal(endpointStatuses)
+			if endpointStatusesPage := query.apply(endpointStatuses); query.Requested {
+				data, err = json.Marshal(endpointStatusesPage)
+			} else {
+				data, err = json.Marshal(endpointStatusesPage.Endpoints)
+			}
 			if err != nil {
 				log.Printf("[api.EndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
 				return c.Status(500).SendString("unable to marshal object to JSON")
 			}
-			cache.SetWithTTL(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize), data, cacheTTL)
+			cache.SetWithTTL(cacheKey, data, cacheTTL)
 		} else {
 			data = value.([]byte)
 		}
@@ -50,6 +82,76 @@ func EndpointStatuses(cfg *config.Config) fiber.Handler {
 	}
 }
 
+// populateEndpointStatusesLabels sets the labels of each endpoint status from the configuration, since labels are not
+// persisted by the storage
+func populateEndpointStatusesLabels(cfg *config.Config, endpointStatuses []*endpoint.Status) {
+	labelsByKey := make(map[string]map[string]string)
+	for _, ep := range cfg.Endpoints {
+		if len(ep.Labels) > 0 {
+			labelsByKey[ep.Key()] = ep.Labels
+		}
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		if len(ee.Labels) > 0 {
+			labelsByKey[ee.Key()] = ee.Labels
+		}
+	}
+	for _, endpointStatus := range endpointStatuses {
+		if labels, exists := labelsByKey[endpointStatus.Key]; exists {
+			endpointStatus.Labels = labels
+		}
+	}
+}
+
+// cacheKey returns a string that uniquely identifies the query. The parameters are encoded like query parameters,
+// so that a group, a name or a label containing a separator cannot be mistaken for another query.
+func (q *endpointStatusesQuery) cacheKey() string {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(q.Page))
+	values.Set("pageSize", strconv.Itoa(q.PageSize))
+	values.Set("requested", strconv.FormatBool(q.Requested))
+	values.Set("group", q.Group)
+	values.Set("name", q.Name)
+	for key, value := range q.Labels {
+		values.Set(LabelQueryParameterPrefix+key, value)
+	}
+	// Encode sorts the parameters by key, which means the order of the labels doesn't matter
+	return values.Encode()
+}
+
+// apply filters the endpoint statuses by group, name and labels, and returns the requested page
+//
+// The group must match exactly, while the name only needs to contain the filter. Both are case-insensitive.
+// Every label of the query must be present on the endpoint with the exact same value.
+func (q *endpointStatusesQuery) apply(endpointStatuses []*endpoint.Status) *EndpointStatusesPage {
+	filteredEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
+	for _, endpointStatus := range endpointStatuses {
+		if len(q.Group) > 0 && !strings.EqualFold(endpointStatus.Group, q.Group) {
+			continue
+		}
+		if len(q.Name) > 0 && !strings.Contains(strings.ToLower(endpointStatus.Name), strings.ToLower(q.Name)) {
+			continue
+		}
+		if !pattern.MatchLabels(q.Labels, endpointStatus.Labels) {
+			continue
+		}
+		filteredEndpointStatuses = append(filteredEndpointStatuses, endpointStatus)
+	}
+	// The page is compared with the number of pages before being multiplied by the page size, since the product of
+	// a large enough page and page size would overflow
+	start := len(filteredEndpointStatuses)
+	if q.Page-1 <= len(filteredEndpointStatuses)/q.PageSize {
+		start = min((q.Page-1)*q.PageSize, len(filteredEndpointStatuses))
+	}
+	end := min(start+q.PageSize, len(filteredEndpointStatuses))
+	return &EndpointStatusesPage{
+		Total:     len(filteredEndpointStatuses),
+		Page:      q.Page,
+		PageSize:  q.PageSize,
+		Endpoints: filteredEndpointStatuses[start:end],
+	}
+}
+
 func getEndpointStatusesFromRemoteInstances(remoteConfig *remote.Config) ([]*endpoint.Status, error) {
 	if remoteConfig == nil || len(remoteConfig.Instances) == 0 {
 		return nil, nil
@@ -61,13 +163,12 @@ func getEndpointStatusesFromRemoteInstances(remoteConfig *remote.Config) ([]*end
 		if err != nil {
 			return nil, err
 		}
-		var endpointStatuses []*endpoint.Status
-		if err = json.NewDecoder(response.Body).Decode(&endpointStatuses); err != nil {
-			_ = response.Body.Close()
+		endpointStatuses, err := decodeEndpointStatuses(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
 			log.Printf("[api.getEndpointStatusesFromRemoteInstances] Silently failed to retrieve endpoint statuses from %s: %s", instance.URL, err.Error())
 			continue
 		}
-		_ = response.Body.Close()
 		for _, endpointStatus := range endpointStatuses {
 			endpointStatus.Name = instance.EndpointPrefix + endpointStatus.Name
 		}
@@ -99,3 +200,63 @@ func EndpointStatus(c *fiber.Ctx) error {
 	c.Set("Content-Type", "application/json")
 	return c.Status(200).Send(output)
 }
+
+// EndpointResults retrieves the most recent results of an endpoint, from newest to oldest, along with the result of
+// each of their conditions.
+//
+// Unlike EndpointStatus, the results are returned on their own, without the events and from newest to oldest, which
+// makes it possible to only fetch the last N results with the limit query parameter.
+func EndpointResults(c *fiber.Ctx) error {
+	page, pageSize := extractPageAndPageSizeFromRequest(c)
+	return sendEndpointResults(c, paging.NewResultSearchParams().WithResults(page, pageSize))
+}
+
+// SearchEndpointResults retrieves the results of an endpoint matching the condition and onlyFailures query parameters,
+// from newest to oldest, along with the result of each of their conditions.
+//
+// The condition query parameter only needs to be contained in one of the conditions of a result (e.g. [STATUS]).
+func SearchEndpointResults(c *fiber.Ctx) error {
+	page, pageSize := extractPageAndPageSizeFromRequest(c)
+	onlyFailures, _ := strconv.ParseBool(c.Query("onlyFailures"))
+	return sendEndpointResults(c, paging.NewResultSearchParams().WithCondition(c.Query("condition"), onlyFailures).WithResults(page, pageSize))
+}
+
+// sendEndpointResults responds with the results of the endpoint whose key is in the path that match params
+func sendEndpointResults(c *fiber.Ctx, params *paging.ResultSearchParams) error {
+	results, err := store.Get().SearchResultsByKey(c.Params("key"), params)
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api.sendEndpointResults] Failed to retrieve endpoint results: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	output, err := json.Marshal(results)
+	if err != nil {
+		log.Printf("[api.sendEndpointResults] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
+
+// decodeEndpointStatuses decodes the endpoint statuses returned by EndpointStatuses, whether they were returned as a
+// list or wrapped in an EndpointStatusesPage
+func decodeEndpointStatuses(body io.Reader) ([]*endpoint.Status, error) {
+	var payload json.RawMessage
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		return nil, err
+	}
+	if trimmedPayload := bytes.TrimSpace(payload); len(trimmedPayload) > 0 && trimmedPayload[0] == '{' {
+		var endpointStatusesPage EndpointStatusesPage
+		if err := json.Unmarshal(payload, &endpointStatusesPage); err != nil {
+			return nil, err
+		}
+		return endpointStatusesPage.Endpoints, nil
+	}
+	var endpointStatuses []*endpoint.Status
+	if err := json.Unmarshal(payload, &endpointStatuses); err != nil {
+		return nil, err
+	}
+	return endpointStatuses, nil
+}
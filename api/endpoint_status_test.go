@@ -1,15 +1,19 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/watchdog"
 )
 
@@ -173,25 +177,25 @@ func TestEndpointStatuses(t *testing.T) {
 		},
 		{
 			Name:         "pagination-first-result",
-			Path:         "/api/v1/endpoints/statuses?page=1&pageSize=1",
+			Path:         "/api/v1/endpoints/statuses?resultsPage=1&resultsPageSize=1",
 			ExpectedCode: http.StatusOK,
 			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}]}]`,
 		},
 		{
 			Name:         "pagination-second-result",
-			Path:         "/api/v1/endpoints/statuses?page=2&pageSize=1",
+			Path:         "/api/v1/endpoints/statuses?resultsPage=2&resultsPageSize=1",
 			ExpectedCode: http.StatusOK,
 			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"}]}]`,
 		},
 		{
 			Name:         "pagination-no-results",
-			Path:         "/api/v1/endpoints/statuses?page=5&pageSize=20",
+			Path:         "/api/v1/endpoints/statuses?resultsPage=5&resultsPageSize=20",
 			ExpectedCode: http.StatusOK,
 			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[]}]`,
 		},
 		{
 			Name:         "invalid-pagination-should-fall-back-to-default",
-			Path:         "/api/v1/endpoints/statuses?page=INVALID&pageSize=INVALID",
+			Path:         "/api/v1/endpoints/statuses?resultsPage=INVALID&resultsPageSize=INVALID",
 			ExpectedCode: http.StatusOK,
 			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"},{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}]}]`,
 		},
@@ -218,3 +222,507 @@ func TestEndpointStatuses(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointStatusesWithETag(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	store.Get().Insert(&testEndpoint, &testSuccessfulResult)
+	router := New(&config.Config{}).Router()
+	get := func(ifNoneMatch string) *http.Response {
+		request := httptest.NewRequest("GET", "/api/v1/endpoints/statuses", http.NoBody)
+		if len(ifNoneMatch) > 0 {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+	response := get("")
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	etag := response.Header.Get("ETag")
+	if len(etag) == 0 {
+		t.Fatal("expected the response to have an ETag")
+	}
+	response = get(etag)
+	if response.StatusCode != http.StatusNotModified {
+		t.Fatalf("expected status code %d with a matching ETag, got %d", http.StatusNotModified, response.StatusCode)
+	}
+	if body, _ := io.ReadAll(response.Body); len(body) != 0 {
+		t.Errorf("expected a 304 to have no body, got %s", string(body))
+	}
+	// Change the statuses, and clear the cache so that the change isn't hidden by it
+	store.Get().Insert(&testEndpoint, &testUnsuccessfulResult)
+	cache.Clear()
+	response = get(etag)
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d after the statuses changed, got %d", http.StatusOK, response.StatusCode)
+	}
+	if newETag := response.Header.Get("ETag"); len(newETag) == 0 || newETag == etag {
+		t.Errorf("expected a new ETag after the statuses changed, got %q", newETag)
+	}
+	if body, _ := io.ReadAll(response.Body); len(body) == 0 {
+		t.Error("expected the full statuses to be returned after the statuses changed")
+	}
+}
+
+func TestEndpointStatusesWithEndpointPaginationAndFilters(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	for _, ep := range []*endpoint.Endpoint{
+		{Name: "frontend", Group: "core"},
+		{Name: "backend", Group: "core"},
+		{Name: "backend-cache", Group: "core"},
+		{Name: "primary", Group: "database"},
+		{Name: "blog"},
+	} {
+		watchdog.UpdateEndpointStatuses(ep, &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	}
+	router := New(&config.Config{Metrics: true}).Router()
+	scenarios := []struct {
+		Name             string
+		Path             string
+		ExpectedTotal    int
+		ExpectedPage     int
+		ExpectedPageSize int
+		ExpectedKeys     []string
+	}{
+		{
+			Name:             "first-page",
+			Path:             "/api/v1/endpoints/statuses?pageSize=2",
+			ExpectedTotal:    5,
+			ExpectedPage:     1,
+			ExpectedPageSize: 2,
+			ExpectedKeys:     []string{"_blog", "core_backend"},
+		},
+		{
+			Name:             "last-page",
+			Path:             "/api/v1/endpoints/statuses?page=3&pageSize=2",
+			ExpectedTotal:    5,
+			ExpectedPage:     3,
+			ExpectedPageSize: 2,
+			ExpectedKeys:     []string{"database_primary"},
+		},
+		{
+			Name:             "page-out-of-bounds",
+			Path:             "/api/v1/endpoints/statuses?page=4&pageSize=2",
+			ExpectedTotal:    5,
+			ExpectedPage:     4,
+			ExpectedPageSize: 2,
+			ExpectedKeys:     []string{},
+		},
+		{
+			Name:             "page-large-enough-to-overflow",
+			Path:             "/api/v1/endpoints/statuses?page=9223372036854775807&pageSize=2",
+			ExpectedTotal:    5,
+			ExpectedPage:     9223372036854775807,
+			ExpectedPageSize: 2,
+			ExpectedKeys:     []string{},
+		},
+		{
+			Name:             "page-size-above-maximum",
+			Path:             "/api/v1/endpoints/statuses?pageSize=999999",
+			ExpectedTotal:    5,
+			ExpectedPage:     1,
+			ExpectedPageSize: MaximumEndpointsPageSize,
+			ExpectedKeys:     []string{"_blog", "core_backend", "core_backend-cache", "core_frontend", "database_primary"},
+		},
+		{
+			Name:             "invalid-pagination-should-fall-back-to-default",
+			Path:             "/api/v1/endpoints/statuses?page=INVALID&pageSize=-1",
+			ExpectedTotal:    5,
+			ExpectedPage:     DefaultPage,
+			ExpectedPageSize: DefaultEndpointsPageSize,
+			ExpectedKeys:     []string{"_blog", "core_backend", "core_backend-cache", "core_frontend", "database_primary"},
+		},
+		{
+			Name:             "group",
+			Path:             "/api/v1/endpoints/statuses?group=core",
+			ExpectedTotal:    3,
+			ExpectedPage:     DefaultPage,
+			ExpectedPageSize: DefaultEndpointsPageSize,
+			ExpectedKeys:     []string{"core_backend", "core_backend-cache", "core_frontend"},
+		},
+		{
+			Name:             "group-and-name-are-case-insensitive",
+			Path:             "/api/v1/endpoints/statuses?group=CORE&name=Backend",
+			ExpectedTotal:    2,
+			ExpectedPage:     DefaultPage,
+			ExpectedPageSize: DefaultEndpointsPageSize,
+			ExpectedKeys:     []string{"core_backend", "core_backend-cache"},
+		},
+		{
+			Name:             "name-contains",
+			Path:             "/api/v1/endpoints/statuses?name=cache",
+			ExpectedTotal:    1,
+			ExpectedPage:     DefaultPage,
+			ExpectedPageSize: DefaultEndpointsPageSize,
+			ExpectedKeys:     []string{"core_backend-cache"},
+		},
+		{
+			Name:             "group-must-match-exactly",
+			Path:             "/api/v1/endpoints/statuses?group=co",
+			ExpectedTotal:    0,
+			ExpectedPage:     DefaultPage,
+			ExpectedPageSize: DefaultEndpointsPageSize,
+			ExpectedKeys:     []string{},
+		},
+		{
+			Name:             "filtered-and-paginated",
+			Path:             "/api/v1/endpoints/statuses?group=core&page=2&pageSize=2",
+			ExpectedTotal:    3,
+			ExpectedPage:     2,
+			ExpectedPageSize: 2,
+			ExpectedKeys:     []string{"core_frontend"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, http.StatusOK, response.StatusCode)
+			}
+			var endpointStatusesPage EndpointStatusesPage
+			if err = json.NewDecoder(response.Body).Decode(&endpointStatusesPage); err != nil {
+				t.Fatal("failed to decode response:", err)
+			}
+			if endpointStatusesPage.Total != scenario.ExpectedTotal {
+				t.Errorf("expected total to be %d, got %d", scenario.ExpectedTotal, endpointStatusesPage.Total)
+			}
+			if endpointStatusesPage.Page != scenario.ExpectedPage {
+				t.Errorf("expected page to be %d, got %d", scenario.ExpectedPage, endpointStatusesPage.Page)
+			}
+			if endpointStatusesPage.PageSize != scenario.ExpectedPageSize {
+				t.Errorf("expected page size to be %d, got %d", scenario.ExpectedPageSize, endpointStatusesPage.PageSize)
+			}
+			if len(endpointStatusesPage.Endpoints) != len(scenario.ExpectedKeys) {
+				t.Fatalf("expected %d endpoints, got %d", len(scenario.ExpectedKeys), len(endpointStatusesPage.Endpoints))
+			}
+			for i, expectedKey := range scenario.ExpectedKeys {
+				if endpointStatusesPage.Endpoints[i].Key != expectedKey {
+					t.Errorf("expected endpoint at index %d to have key %s, got %s", i, expectedKey, endpointStatusesPage.Endpoints[i].Key)
+				}
+			}
+		})
+	}
+	t.Run("no-parameters-should-return-endpoints-without-payload", func(t *testing.T) {
+		response, err := router.Test(httptest.NewRequest("GET", "/api/v1/endpoints/statuses", http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		var endpointStatuses []*endpoint.Status
+		if err = json.NewDecoder(response.Body).Decode(&endpointStatuses); err != nil {
+			t.Fatal("failed to decode response:", err)
+		}
+		if len(endpointStatuses) != 5 {
+			t.Errorf("expected 5 endpoints, got %d", len(endpointStatuses))
+		}
+	})
+}
+
+func TestEndpointStatusesWithoutParametersIsCappedToDefaultEndpointsPageSize(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	for i := 0; i < DefaultEndpointsPageSize+1; i++ {
+		watchdog.UpdateEndpointStatuses(&endpoint.Endpoint{Name: fmt.Sprintf("endpoint-%03d", i)}, &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	}
+	router := New(&config.Config{}).Router()
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/endpoints/statuses", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var endpointStatuses []*endpoint.Status
+	if err = json.NewDecoder(response.Body).Decode(&endpointStatuses); err != nil {
+		t.Fatal("failed to decode response:", err)
+	}
+	if len(endpointStatuses) != DefaultEndpointsPageSize {
+		t.Errorf("expected %d endpoints, got %d", DefaultEndpointsPageSize, len(endpointStatuses))
+	}
+}
+
+func TestEndpointStatusesQuery_cacheKey(t *testing.T) {
+	queries := []*endpointStatusesQuery{
+		{Page: 1, PageSize: 50, Group: "core-api", Name: "health"},
+		{Page: 1, PageSize: 50, Group: "core", Name: "api-health"},
+		{Page: 1, PageSize: 50, Labels: map[string]string{"team": "payments-tier=1"}},
+		{Page: 1, PageSize: 50, Labels: map[string]string{"team": "payments", "tier": "1"}},
+		{Page: 1, PageSize: 50},
+		{Page: 1, PageSize: 50, Requested: true},
+	}
+	cacheKeys := make(map[string]bool)
+	for _, query := range queries {
+		cacheKey := query.cacheKey()
+		if cacheKeys[cacheKey] {
+			t.Errorf("expected each query to have a distinct cache key, got %s more than once", cacheKey)
+		}
+		cacheKeys[cacheKey] = true
+	}
+}
+
+func TestEndpointStatusesWithLabelFilters(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "checkout", Group: "core", Labels: map[string]string{"team": "payments", "tier": "1"}},
+			{Name: "refunds", Group: "core", Labels: map[string]string{"team": "payments", "tier": "2"}},
+			{Name: "search", Group: "core", Labels: map[string]string{"team": "search", "tier": "1"}},
+			{Name: "blog"},
+		},
+	}
+	for _, ep := range cfg.Endpoints {
+		watchdog.UpdateEndpointStatuses(ep, &endpoint.Result{Success: true, Duration: time.Millisecond, Timestamp: time.Now()})
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name         string
+		Path         string
+		ExpectedKeys []string
+	}{
+		{
+			Name:         "single-label",
+			Path:         "/api/v1/endpoints/statuses?label.team=payments",
+			ExpectedKeys: []string{"core_checkout", "core_refunds"},
+		},
+		{
+			Name:         "multiple-labels",
+			Path:         "/api/v1/endpoints/statuses?label.team=payments&label.tier=1",
+			ExpectedKeys: []string{"core_checkout"},
+		},
+		{
+			Name:         "label-and-name",
+			Path:         "/api/v1/endpoints/statuses?label.tier=1&name=sea",
+			ExpectedKeys: []string{"core_search"},
+		},
+		{
+			Name:         "unknown-label",
+			Path:         "/api/v1/endpoints/statuses?label.region=eu",
+			ExpectedKeys: []string{},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			var endpointStatusesPage EndpointStatusesPage
+			if err = json.NewDecoder(response.Body).Decode(&endpointStatusesPage); err != nil {
+				t.Fatal("failed to decode response:", err)
+			}
+			if len(endpointStatusesPage.Endpoints) != len(scenario.ExpectedKeys) {
+				t.Fatalf("expected %d endpoints, got %d", len(scenario.ExpectedKeys), len(endpointStatusesPage.Endpoints))
+			}
+			for i, expectedKey := range scenario.ExpectedKeys {
+				if endpointStatusesPage.Endpoints[i].Key != expectedKey {
+					t.Errorf("expected endpoint at index %d to have key %s, got %s", i, expectedKey, endpointStatusesPage.Endpoints[i].Key)
+				}
+				if endpointStatusesPage.Endpoints[i].Labels["team"] == "" {
+					t.Errorf("expected endpoint %s to have its labels in the response", expectedKey)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchEndpointResults(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics:   true,
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	now := time.Now().Truncate(time.Second)
+	for i := 0; i < 4; i++ {
+		statusConditionSucceeded := i%2 == 0
+		watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{
+			Success:   statusConditionSucceeded,
+			Duration:  time.Millisecond,
+			Timestamp: now.Add(time.Duration(i) * time.Minute),
+			ConditionResults: []*endpoint.ConditionResult{
+				{Condition: "[STATUS] == 200", Success: statusConditionSucceeded},
+				{Condition: "[RESPONSE_TIME] < 500", Success: true},
+			},
+		})
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name               string
+		Path               string
+		ExpectedCode       int
+		ExpectedTimestamps []time.Time
+	}{
+		{
+			Name:               "only-failures",
+			Path:               "/api/v1/endpoints/core_frontend/results/search?condition=[STATUS]&onlyFailures=true",
+			ExpectedCode:       http.StatusOK,
+			ExpectedTimestamps: []time.Time{now.Add(3 * time.Minute), now.Add(time.Minute)},
+		},
+		{
+			Name:               "condition-that-never-failed",
+			Path:               "/api/v1/endpoints/core_frontend/results/search?condition=[RESPONSE_TIME]&onlyFailures=true",
+			ExpectedCode:       http.StatusOK,
+			ExpectedTimestamps: []time.Time{},
+		},
+		{
+			Name:               "paginated",
+			Path:               "/api/v1/endpoints/core_frontend/results/search?condition=[RESPONSE_TIME]&page=2&pageSize=3",
+			ExpectedCode:       http.StatusOK,
+			ExpectedTimestamps: []time.Time{now},
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/results/search?condition=[STATUS]",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s should have returned %d, but returned %d instead", scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode != http.StatusOK {
+				return
+			}
+			var results []*endpoint.Result
+			if err = json.NewDecoder(response.Body).Decode(&results); err != nil {
+				t.Fatal("failed to decode response:", err)
+			}
+			if len(results) != len(scenario.ExpectedTimestamps) {
+				t.Fatalf("expected %d results, got %d", len(scenario.ExpectedTimestamps), len(results))
+			}
+			for i, result := range results {
+				if !result.Timestamp.Equal(scenario.ExpectedTimestamps[i]) {
+					t.Errorf("expected result #%d to have timestamp %s, got %s", i, scenario.ExpectedTimestamps[i], result.Timestamp)
+				}
+				if len(result.ConditionResults) != 2 {
+					t.Errorf("expected result #%d to have its 2 condition results, got %d", i, len(result.ConditionResults))
+				}
+			}
+		})
+	}
+}
+
+func TestEndpointResults(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics:   true,
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	now := time.Now().Truncate(time.Second)
+	numberOfResults := common.MaximumNumberOfResults + 20
+	for i := 0; i < numberOfResults; i++ {
+		watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{
+			Success:          true,
+			Duration:         time.Millisecond,
+			Timestamp:        now.Add(time.Duration(i) * time.Minute),
+			ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: true}},
+		})
+	}
+	newest := now.Add(time.Duration(numberOfResults-1) * time.Minute)
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                    string
+		Path                    string
+		ExpectedCode            int
+		ExpectedNumberOfResults int
+		ExpectedFirstTimestamp  time.Time
+	}{
+		{
+			Name:                    "default-limit",
+			Path:                    "/api/v1/endpoints/core_frontend/results",
+			ExpectedCode:            http.StatusOK,
+			ExpectedNumberOfResults: DefaultPageSize,
+			ExpectedFirstTimestamp:  newest,
+		},
+		{
+			Name:                    "limit",
+			Path:                    "/api/v1/endpoints/core_frontend/results?limit=5",
+			ExpectedCode:            http.StatusOK,
+			ExpectedNumberOfResults: 5,
+			ExpectedFirstTimestamp:  newest,
+		},
+		{
+			Name:                    "second-page",
+			Path:                    "/api/v1/endpoints/core_frontend/results?page=2&limit=5",
+			ExpectedCode:            http.StatusOK,
+			ExpectedNumberOfResults: 5,
+			ExpectedFirstTimestamp:  newest.Add(-5 * time.Minute),
+		},
+		{
+			Name:                    "limit-above-maximum",
+			Path:                    "/api/v1/endpoints/core_frontend/results?limit=100000",
+			ExpectedCode:            http.StatusOK,
+			ExpectedNumberOfResults: MaximumPageSize,
+			ExpectedFirstTimestamp:  newest,
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/results",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s should have returned %d, but returned %d instead", scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode != http.StatusOK {
+				return
+			}
+			var results []*endpoint.Result
+			if err = json.NewDecoder(response.Body).Decode(&results); err != nil {
+				t.Fatal("failed to decode response:", err)
+			}
+			if len(results) != scenario.ExpectedNumberOfResults {
+				t.Fatalf("expected %d results, got %d", scenario.ExpectedNumberOfResults, len(results))
+			}
+			for i, result := range results {
+				if expectedTimestamp := scenario.ExpectedFirstTimestamp.Add(-time.Duration(i) * time.Minute); !result.Timestamp.Equal(expectedTimestamp) {
+					t.Fatalf("expected results to be ordered from newest to oldest, but result #%d has timestamp %s instead of %s", i, result.Timestamp, expectedTimestamp)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeEndpointStatuses(t *testing.T) {
+	for _, payload := range []string{
+		`[{"name":"frontend","group":"core","key":"core_frontend"}]`,
+		`{"total":1,"page":1,"pageSize":50,"endpoints":[{"name":"frontend","group":"core","key":"core_frontend"}]}`,
+	} {
+		endpointStatuses, err := decodeEndpointStatuses(strings.NewReader(payload))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", payload, err)
+		}
+		if len(endpointStatuses) != 1 || endpointStatuses[0].Key != "core_frontend" {
+			t.Errorf("%s: expected a single endpoint status with key core_frontend, got %v", payload, endpointStatuses)
+		}
+	}
+	if _, err := decodeEndpointStatuses(strings.NewReader(`"not-endpoint-statuses"`)); err == nil {
+		t.Error("expected an error for a payload that is neither a list nor a page of endpoint statuses")
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/watchdog"
@@ -40,7 +41,6 @@ func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 			log.Printf("[api.CreateExternalEndpointResult] Invalid token for external endpoint with key=%s", key)
 			return c.Status(401).SendString("invalid token")
 		}
-		// Persist the result in the storage
 		result := &endpoint.Result{
 			Timestamp: time.Now(),
 			Success:   c.QueryBool("success"),
@@ -50,6 +50,14 @@ func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 			result.Errors = append(result.Errors, c.Query("error"))
 		}
 		convertedEndpoint := externalEndpoint.ToEndpoint()
+		// Check if an alert should be triggered or resolved
+		result.Maintenance = cfg.Maintenance.IsUnderMaintenance() || maintenance.IsGroupUnderMaintenance(externalEndpoint.Group)
+		if !result.Maintenance {
+			watchdog.HandleAlerting(convertedEndpoint, result, cfg.Alerting, cfg.Debug)
+			externalEndpoint.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
+			externalEndpoint.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
+		}
+		// Persist the result in the storage, along with the alerts that were sent, if any
 		if err := store.Get().Insert(convertedEndpoint, result); err != nil {
 			if errors.Is(err, common.ErrEndpointNotFound) {
 				return c.Status(404).SendString(err.Error())
@@ -58,12 +66,6 @@ func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 			return c.Status(500).SendString(err.Error())
 		}
 		log.Printf("[api.CreateExternalEndpointResult] Successfully inserted result for external endpoint with key=%s and success=%s", c.Params("key"), success)
-		// Check if an alert should be triggered or resolved
-		if !cfg.Maintenance.IsUnderMaintenance() {
-			watchdog.HandleAlerting(convertedEndpoint, result, cfg.Alerting, cfg.Debug)
-			externalEndpoint.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
-			externalEndpoint.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
-		}
 		// Return the result
 		return c.Status(200).SendString("")
 	}
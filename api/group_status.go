@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+// HealthStatusDegraded is the health status of a group in which at least one endpoint, but not all of them, is down
+const HealthStatusDegraded = "degraded"
+
+const groupStatusesCacheKey = "group-statuses"
+
+// groupUptimeWindows are the windows over which the aggregate uptime of each group is computed.
+// Because uptime metrics are stored by hour, 1h has to cheat a little, just like UptimeBadge.
+var groupUptimeWindows = map[string]time.Duration{
+	"30d": 30 * 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"24h": 24 * time.Hour,
+	"1h":  2 * time.Hour,
+}
+
+// GroupStatus is the rolled-up health of all endpoints sharing the same group
+type GroupStatus struct {
+	// Name of the group
+	Name string `json:"name"`
+
+	// Status is the health of the group:
+	//   - up if every endpoint is up
+	//   - degraded if at least one endpoint is down, but not all of them
+	//   - down if every endpoint is down
+	Status string `json:"status"`
+
+	// HealthyEndpoints is the number of endpoints whose most recent result was successful
+	HealthyEndpoints int `json:"healthyEndpoints"`
+
+	// TotalEndpoints is the number of endpoints that have been evaluated at least once
+	TotalEndpoints int `json:"totalEndpoints"`
+
+	// HealthyPercentage is the percentage of endpoints whose most recent result was successful
+	HealthyPercentage float64 `json:"healthyPercentage"`
+
+	// Uptime is the average uptime of the group's endpoints for each window (1h, 24h, 7d, 30d)
+	Uptime map[string]float64 `json:"uptime"`
+}
+
+// GroupStatuses handles requests to retrieve the rolled-up GroupStatus of every group.
+// Endpoints that aren't part of a group or that haven't been evaluated yet are ignored.
+// Due to how intensive this operation can be on the storage, this function leverages a cache.
+func GroupStatuses(c *fiber.Ctx) error {
+	value, exists := cache.Get(groupStatusesCacheKey)
+	var data []byte
+	if !exists {
+		endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 1))
+		if err != nil {
+			log.Printf("[api.GroupStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		groupStatuses, err := rollUpGroupStatuses(endpointStatuses, time.Now())
+		if err != nil {
+			log.Printf("[api.GroupStatuses] Failed to retrieve uptime: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		data, err = json.Marshal(groupStatuses)
+		if err != nil {
+			log.Printf("[api.GroupStatuses] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		cache.SetWithTTL(groupStatusesCacheKey, data, cacheTTL)
+	} else {
+		data = value.([]byte)
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(data)
+}
+
+// rollUpGroupStatuses aggregates the most recent result and the uptime of each endpoint into one GroupStatus per group.
+// Endpoint statuses are expected to contain at most their most recent result.
+func rollUpGroupStatuses(endpointStatuses []*endpoint.Status, now time.Time) ([]*GroupStatus, error) {
+	groupStatusByName := make(map[string]*GroupStatus)
+	for _, endpointStatus := range endpointStatuses {
+		if len(endpointStatus.Group) == 0 || len(endpointStatus.Results) == 0 {
+			continue
+		}
+		groupStatus, exists := groupStatusByName[endpointStatus.Group]
+		if !exists {
+			groupStatus = &GroupStatus{Name: endpointStatus.Group, Uptime: make(map[string]float64, len(groupUptimeWindows))}
+			groupStatusByName[endpointStatus.Group] = groupStatus
+		}
+		groupStatus.TotalEndpoints++
+		if endpointStatus.Results[len(endpointStatus.Results)-1].Success {
+			groupStatus.HealthyEndpoints++
+		}
+		for window, duration := range groupUptimeWindows {
+			uptime, err := store.Get().GetUptimeByKey(endpointStatus.Key, now.Add(-duration), now)
+			if err != nil {
+				return nil, err
+			}
+			// Summed for now, averaged once every endpoint of the group has been processed
+			groupStatus.Uptime[window] += uptime
+		}
+	}
+	groupStatuses := make([]*GroupStatus, 0, len(groupStatusByName))
+	for _, groupStatus := range groupStatusByName {
+		for window := range groupStatus.Uptime {
+			groupStatus.Uptime[window] /= float64(groupStatus.TotalEndpoints)
+		}
+		groupStatus.HealthyPercentage = float64(groupStatus.HealthyEndpoints) / float64(groupStatus.TotalEndpoints) * 100
+		switch groupStatus.HealthyEndpoints {
+		case groupStatus.TotalEndpoints:
+			groupStatus.Status = HealthStatusUp
+		case 0:
+			groupStatus.Status = HealthStatusDown
+		default:
+			groupStatus.Status = HealthStatusDegraded
+		}
+		groupStatuses = append(groupStatuses, groupStatus)
+	}
+	sort.Slice(groupStatuses, func(i, j int) bool {
+		return groupStatuses[i].Name < groupStatuses[j].Name
+	})
+	return groupStatuses, nil
+}
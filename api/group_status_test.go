@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestGroupStatuses(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+			{Name: "primary", Group: "database"},
+			{Name: "replica", Group: "database"},
+			{Name: "api", Group: "payments"},
+			{Name: "ungrouped"},
+		},
+	}
+	now := time.Now()
+	results := map[*endpoint.Endpoint][]bool{
+		cfg.Endpoints[0]: {true, true},
+		cfg.Endpoints[1]: {true, false},
+		cfg.Endpoints[2]: {true, true},
+		cfg.Endpoints[3]: {true, true},
+		cfg.Endpoints[4]: {false},
+		cfg.Endpoints[5]: {false},
+	}
+	for ep, successes := range results {
+		for i, success := range successes {
+			watchdog.UpdateEndpointStatuses(ep, &endpoint.Result{Success: success, Duration: time.Millisecond, Timestamp: now.Add(time.Duration(i-len(successes)) * time.Minute)})
+		}
+	}
+	router := New(cfg).Router()
+	request := httptest.NewRequest("GET", "/api/v1/groups/statuses", http.NoBody)
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	var groupStatuses []*GroupStatus
+	if err = json.NewDecoder(response.Body).Decode(&groupStatuses); err != nil {
+		t.Fatal("failed to decode response:", err)
+	}
+	expectedGroupStatuses := []*GroupStatus{
+		{Name: "core", Status: HealthStatusDegraded, HealthyEndpoints: 1, TotalEndpoints: 2, HealthyPercentage: 50, Uptime: map[string]float64{"1h": 0.75, "24h": 0.75, "7d": 0.75, "30d": 0.75}},
+		{Name: "database", Status: HealthStatusUp, HealthyEndpoints: 2, TotalEndpoints: 2, HealthyPercentage: 100, Uptime: map[string]float64{"1h": 1, "24h": 1, "7d": 1, "30d": 1}},
+		{Name: "payments", Status: HealthStatusDown, HealthyEndpoints: 0, TotalEndpoints: 1, HealthyPercentage: 0, Uptime: map[string]float64{"1h": 0, "24h": 0, "7d": 0, "30d": 0}},
+	}
+	if len(groupStatuses) != len(expectedGroupStatuses) {
+		t.Fatalf("expected %d groups, got %d", len(expectedGroupStatuses), len(groupStatuses))
+	}
+	for i, expected := range expectedGroupStatuses {
+		actual := groupStatuses[i]
+		if actual.Name != expected.Name || actual.Status != expected.Status || actual.HealthyEndpoints != expected.HealthyEndpoints || actual.TotalEndpoints != expected.TotalEndpoints || actual.HealthyPercentage != expected.HealthyPercentage {
+			t.Errorf("expected group %d to be %+v, got %+v", i, expected, actual)
+		}
+		for window, expectedUptime := range expected.Uptime {
+			if actual.Uptime[window] != expectedUptime {
+				t.Errorf("expected %s uptime of group %s to be %v, got %v", window, expected.Name, expectedUptime, actual.Uptime[window])
+			}
+		}
+	}
+}
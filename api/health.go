@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	healthStatusUp   = "UP"
+	healthStatusDown = "DOWN"
+)
+
+// HealthComponent is the health of a single component of Gatus
+type HealthComponent struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthStatus is the health of Gatus as a whole
+type HealthStatus struct {
+	Status        string           `json:"status"`
+	Storage       *HealthComponent `json:"storage"`
+	Configuration *HealthComponent `json:"configuration"`
+}
+
+// Health returns the health of Gatus, including whether the storage is reachable and whether the last attempt at
+// reloading the configuration succeeded.
+//
+// Responds with 503 if the storage is unreachable. A configuration that failed to reload does not make Gatus
+// unhealthy, because an invalid configuration is rejected before the previous one is stopped, which means the
+// previous configuration keeps being monitored.
+func Health(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		health := &HealthStatus{
+			Status:        healthStatusUp,
+			Storage:       &HealthComponent{Status: healthStatusUp},
+			Configuration: &HealthComponent{Status: healthStatusUp},
+		}
+		statusCode := 200
+		if err := store.Get().Ping(); err != nil {
+			log.Printf("[api.Health] Failed to ping storage: %s", err.Error())
+			health.Status = healthStatusDown
+			health.Storage = &HealthComponent{Status: healthStatusDown, Error: err.Error()}
+			statusCode = 503
+		}
+		if err := cfg.LastReloadError(); err != nil {
+			health.Configuration = &HealthComponent{Status: healthStatusDown, Error: err.Error()}
+		}
+		output, err := json.Marshal(health)
+		if err != nil {
+			log.Printf("[api.Health] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(statusCode).Send(output)
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestHealth(t *testing.T) {
+	defer store.Initialize(nil)
+	scenarios := []struct {
+		Name                        string
+		BreakStorage                bool
+		LastReloadError             error
+		ExpectedCode                int
+		ExpectedStatus              string
+		ExpectedStorageStatus       string
+		ExpectedConfigurationStatus string
+	}{
+		{
+			Name:                        "healthy",
+			ExpectedCode:                200,
+			ExpectedStatus:              "UP",
+			ExpectedStorageStatus:       "UP",
+			ExpectedConfigurationStatus: "UP",
+		},
+		{
+			Name:                        "failed-configuration-reload",
+			LastReloadError:             errors.New("invalid configuration"),
+			ExpectedCode:                200,
+			ExpectedStatus:              "UP",
+			ExpectedStorageStatus:       "UP",
+			ExpectedConfigurationStatus: "DOWN",
+		},
+		{
+			Name:                        "unreachable-storage",
+			BreakStorage:                true,
+			ExpectedCode:                503,
+			ExpectedStatus:              "DOWN",
+			ExpectedStorageStatus:       "DOWN",
+			ExpectedConfigurationStatus: "UP",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if err := store.Initialize(&storage.Config{Type: storage.TypeSQLite, Path: filepath.Join(t.TempDir(), "TestHealth.db")}); err != nil {
+				t.Fatal("failed to initialize store:", err.Error())
+			}
+			if scenario.BreakStorage {
+				// Closing the database simulates a storage backend that can no longer be reached
+				store.Get().Close()
+			}
+			cfg := &config.Config{}
+			cfg.SetLastReloadError(scenario.LastReloadError)
+			router := New(cfg).Router()
+			request := httptest.NewRequest("GET", "/health", http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			var health HealthStatus
+			if err := json.NewDecoder(response.Body).Decode(&health); err != nil {
+				t.Fatal("expected response body to be valid JSON, got error:", err.Error())
+			}
+			if health.Status != scenario.ExpectedStatus {
+				t.Errorf("expected status %s, got %s", scenario.ExpectedStatus, health.Status)
+			}
+			if health.Storage.Status != scenario.ExpectedStorageStatus {
+				t.Errorf("expected storage status %s, got %s", scenario.ExpectedStorageStatus, health.Storage.Status)
+			}
+			if health.Configuration.Status != scenario.ExpectedConfigurationStatus {
+				t.Errorf("expected configuration status %s, got %s", scenario.ExpectedConfigurationStatus, health.Configuration.Status)
+			}
+			if !scenario.BreakStorage {
+				store.Get().Close()
+			}
+		})
+	}
+}
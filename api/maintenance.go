@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateMaintenanceWindowRequest is the body of a request to create a runtime maintenance window
+type CreateMaintenanceWindowRequest struct {
+	// Duration of the maintenance window (e.g. 30m)
+	Duration string `json:"duration"`
+
+	// Group of the endpoints the maintenance window applies to. Applies to all endpoints if empty.
+	Group string `json:"group,omitempty"`
+}
+
+// CreateMaintenanceWindow creates a maintenance window starting now, during which alerts are not sent
+func CreateMaintenanceWindow(c *fiber.Ctx) error {
+	var request CreateMaintenanceWindowRequest
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return c.Status(400).SendString("invalid request body: " + err.Error())
+	}
+	duration, err := time.ParseDuration(request.Duration)
+	if err != nil {
+		return c.Status(400).SendString("invalid duration: " + err.Error())
+	}
+	window, err := maintenance.CreateWindow(duration, request.Group)
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	log.Printf("[api.CreateMaintenanceWindow] Created maintenance window with id=%s for group=%s until %s", window.ID, window.Group, window.End.Format(time.RFC3339))
+	saveRuntimeState()
+	output, err := json.Marshal(window)
+	if err != nil {
+		log.Printf("[api.CreateMaintenanceWindow] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(201).Send(output)
+}
+
+// CancelMaintenanceWindow cancels an active runtime maintenance window
+func CancelMaintenanceWindow(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !maintenance.CancelWindow(id) {
+		return c.Status(404).SendString("maintenance window not found")
+	}
+	log.Printf("[api.CancelMaintenanceWindow] Cancelled maintenance window with id=%s", id)
+	saveRuntimeState()
+	return c.SendStatus(204)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/security"
+)
+
+func TestCreateAndCancelMaintenanceWindow(t *testing.T) {
+	api := New(&config.Config{})
+	router := api.Router()
+	scenarios := []struct {
+		Name         string
+		Body         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "invalid-body",
+			Body:         "{",
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "invalid-duration",
+			Body:         `{"duration":"forever"}`,
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "negative-duration",
+			Body:         `{"duration":"-5m"}`,
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "invalid-group",
+			Body:         `{"duration":"5m","group":"regex:("}`,
+			ExpectedCode: 400,
+		},
+		{
+			Name:         "with-group",
+			Body:         `{"duration":"5m","group":"core"}`,
+			ExpectedCode: 201,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/maintenance", strings.NewReader(scenario.Body))
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != 201 {
+				return
+			}
+			var window maintenance.Window
+			if err := json.NewDecoder(response.Body).Decode(&window); err != nil {
+				t.Fatal("expected response body to be a maintenance window, got error:", err.Error())
+			}
+			if !maintenance.IsGroupUnderMaintenance("core") {
+				t.Error("expected group core to be under maintenance")
+			}
+			request = httptest.NewRequest("DELETE", "/api/v1/maintenance/"+window.ID, http.NoBody)
+			if response, err = router.Test(request); err != nil {
+				t.Fatal(err)
+			} else if response.StatusCode != 204 {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, 204, response.StatusCode)
+			}
+			if maintenance.IsGroupUnderMaintenance("core") {
+				t.Error("expected group core to no longer be under maintenance")
+			}
+			if response, err = router.Test(request); err != nil {
+				t.Fatal(err)
+			} else if response.StatusCode != 404 {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, 404, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCreateMaintenanceWindowWithSecurity(t *testing.T) {
+	api := New(&config.Config{
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	})
+	router := api.Router()
+	request := httptest.NewRequest("POST", "/api/v1/maintenance", strings.NewReader(`{"duration":"5m"}`))
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != 401 {
+		t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, 401, response.StatusCode)
+	}
+	if maintenance.IsGroupUnderMaintenance("") {
+		t.Error("no maintenance window should have been created")
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maximumNumberOfRateLimitBuckets is the number of buckets past which the buckets that are full, and are therefore
+// equivalent to a bucket that doesn't exist, are removed. This prevents a client from growing the map indefinitely by
+// calling a route with random endpoint keys.
+const maximumNumberOfRateLimitBuckets = 1000
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// rateLimiter is a token bucket rate limiter, with one bucket per key
+type rateLimiter struct {
+	capacity float64
+	interval time.Duration
+	buckets  map[string]*tokenBucket
+	now      func() time.Time
+	mutex    sync.Mutex
+}
+
+func newRateLimiter(cfg *web.RateLimitConfig, now func() time.Time) *rateLimiter {
+	return &rateLimiter{
+		capacity: float64(cfg.Requests),
+		interval: cfg.Interval,
+		buckets:  make(map[string]*tokenBucket),
+		now:      now,
+	}
+}
+
+// allow consumes a token from the bucket of the given key and returns whether there was one to consume.
+// If there wasn't, it also returns how long to wait until a token becomes available.
+func (rl *rateLimiter) allow(key string) (bool, time.Duration) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	now := rl.now()
+	bucket, exists := rl.buckets[key]
+	if !exists {
+		if len(rl.buckets) >= maximumNumberOfRateLimitBuckets {
+			rl.removeFullBuckets(now)
+		}
+		bucket = &tokenBucket{tokens: rl.capacity, lastRefill: now}
+		rl.buckets[key] = bucket
+	} else {
+		rl.refill(bucket, now)
+	}
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / rl.capacity * float64(rl.interval))
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+func (rl *rateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	elapsed := now.Sub(bucket.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+	bucket.tokens = math.Min(rl.capacity, bucket.tokens+rl.capacity*float64(elapsed)/float64(rl.interval))
+	bucket.lastRefill = now
+}
+
+func (rl *rateLimiter) removeFullBuckets(now time.Time) {
+	for key, bucket := range rl.buckets {
+		rl.refill(bucket, now)
+		if bucket.tokens >= rl.capacity {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+func (rl *rateLimiter) handler(perEndpoint bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var key string
+		if perEndpoint {
+			// The value returned by Params is only valid until the request is handled, so it must be copied before
+			// being kept as the key of a bucket
+			key = strings.Clone(c.Params("key"))
+		}
+		if allowed, retryAfter := rl.allow(key); !allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).SendString("rate limit exceeded")
+		}
+		return c.Next()
+	}
+}
+
+// RateLimit returns a handler that rejects requests exceeding the given rate limit with 429 Too Many Requests
+func RateLimit(cfg *web.RateLimitConfig) fiber.Handler {
+	return newRateLimiter(cfg, time.Now).handler(cfg.PerEndpoint)
+}
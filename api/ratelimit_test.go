@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRateLimiter_allow(t *testing.T) {
+	now := time.Now()
+	rl := newRateLimiter(&web.RateLimitConfig{Requests: 3, Interval: 3 * time.Second}, func() time.Time { return now })
+	for i := 0; i < 3; i++ {
+		if allowed, _ := rl.allow(""); !allowed {
+			t.Fatalf("expected request #%d to be allowed", i+1)
+		}
+	}
+	allowed, retryAfter := rl.allow("")
+	if allowed {
+		t.Fatal("expected request over the limit to be denied")
+	}
+	if retryAfter != time.Second {
+		t.Errorf("expected retry after to be %s, got %s", time.Second, retryAfter)
+	}
+	if allowed, _ := rl.allow("other"); !allowed {
+		t.Error("expected a different key to have its own bucket")
+	}
+	now = now.Add(500 * time.Millisecond)
+	if allowed, _ := rl.allow(""); allowed {
+		t.Error("expected request to still be denied before a token is refilled")
+	}
+	now = now.Add(500 * time.Millisecond)
+	if allowed, _ := rl.allow(""); !allowed {
+		t.Error("expected request to be allowed once a token is refilled")
+	}
+	if allowed, _ := rl.allow(""); allowed {
+		t.Error("expected request to be denied once the refilled token is consumed")
+	}
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		if allowed, _ := rl.allow(""); !allowed {
+			t.Fatalf("expected request #%d to be allowed after the window", i+1)
+		}
+	}
+	if allowed, _ := rl.allow(""); allowed {
+		t.Error("expected the bucket not to be refilled past its capacity")
+	}
+}
+
+func TestRateLimiter_allowRemovesFullBuckets(t *testing.T) {
+	now := time.Now()
+	rl := newRateLimiter(&web.RateLimitConfig{Requests: 1, Interval: time.Second}, func() time.Time { return now })
+	for i := 0; i < maximumNumberOfRateLimitBuckets; i++ {
+		rl.allow(fmt.Sprintf("key-%d", i))
+	}
+	rl.allow("not-full")
+	if len(rl.buckets) != maximumNumberOfRateLimitBuckets+1 {
+		t.Fatalf("expected no bucket to be removed while they're all empty, got %d buckets", len(rl.buckets))
+	}
+	now = now.Add(time.Second)
+	rl.allow("new")
+	if len(rl.buckets) != 1 {
+		t.Errorf("expected the full buckets to be removed, got %d buckets", len(rl.buckets))
+	}
+}
+
+func TestCreateExternalEndpointResult_WithRateLimit(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	scenarios := []struct {
+		Name        string
+		PerEndpoint bool
+		// ExpectedCodes are the status codes expected for each request, alternating between the endpoints n and o
+		ExpectedCodes []int
+	}{
+		{
+			Name:          "per-route",
+			PerEndpoint:   false,
+			ExpectedCodes: []int{200, 200, 429, 429},
+		},
+		{
+			Name:          "per-endpoint",
+			PerEndpoint:   true,
+			ExpectedCodes: []int{200, 200, 200, 200, 429, 429},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			cfg := &config.Config{
+				Web: &web.Config{RateLimits: &web.RateLimitsConfig{
+					ExternalEndpointResults: &web.RateLimitConfig{Requests: 2, Interval: time.Hour, PerEndpoint: scenario.PerEndpoint},
+				}},
+				ExternalEndpoints: []*endpoint.ExternalEndpoint{
+					{Name: "n", Group: "g", Token: "token"},
+					{Name: "o", Group: "g", Token: "token"},
+				},
+				Maintenance: &maintenance.Config{},
+			}
+			router := New(cfg).Router()
+			for i, expectedCode := range scenario.ExpectedCodes {
+				key := "g_n"
+				if i%2 == 1 {
+					key = "g_o"
+				}
+				request := httptest.NewRequest("POST", "/api/v1/endpoints/"+key+"/external?success=true", http.NoBody)
+				request.Header.Set("Authorization", "Bearer token")
+				response, err := router.Test(request)
+				if err != nil {
+					t.Fatal(err)
+				}
+				response.Body.Close()
+				if response.StatusCode != expectedCode {
+					t.Errorf("request #%d to %s should have returned %d, but returned %d instead", i+1, request.URL, expectedCode, response.StatusCode)
+				}
+				if response.StatusCode == fiber.StatusTooManyRequests && response.Header.Get("Retry-After") == "" {
+					t.Errorf("request #%d to %s should have returned a Retry-After header", i+1, request.URL)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/TwiN/gatus/v5/state"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExportRuntimeState returns the state created at runtime, such as maintenance windows and whether alerts are muted,
+// so that it can be backed up or imported in another instance through ImportRuntimeState
+func ExportRuntimeState(c *fiber.Ctx) error {
+	output, err := json.Marshal(state.Capture())
+	if err != nil {
+		log.Printf("[api.ExportRuntimeState] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
+
+// ImportRuntimeState replaces the state created at runtime by the one in the body of the request, which is expected to
+// have been returned by ExportRuntimeState
+func ImportRuntimeState(c *fiber.Ctx) error {
+	var runtimeState state.RuntimeState
+	if err := json.Unmarshal(c.Body(), &runtimeState); err != nil {
+		return c.Status(400).SendString("invalid request body: " + err.Error())
+	}
+	if err := runtimeState.Apply(); err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	log.Printf("[api.ImportRuntimeState] Imported runtime state with muted=%v and %d maintenance window(s)", runtimeState.Muted, len(runtimeState.MaintenanceWindows))
+	saveRuntimeState()
+	return ExportRuntimeState(c)
+}
+
+// saveRuntimeState persists the current runtime state, so that it isn't lost when Gatus restarts
+func saveRuntimeState() {
+	if err := store.Get().SaveRuntimeState(state.Capture()); err != nil {
+		log.Printf("[api.saveRuntimeState] Failed to persist runtime state: %s", err.Error())
+	}
+}
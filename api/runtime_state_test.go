@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/state"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestExportAndImportRuntimeState(t *testing.T) {
+	defer store.Get().Clear()
+	defer (&state.RuntimeState{}).Apply()
+	api := New(&config.Config{})
+	router := api.Router()
+	// Create some runtime state through the API, which must be persisted by the store
+	response, err := router.Test(httptest.NewRequest("POST", "/api/v1/maintenance", strings.NewReader(`{"duration":"1h","group":"core"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response, err = router.Test(httptest.NewRequest("POST", "/api/v1/alerting/mute", http.NoBody)); err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	persistedRuntimeState, err := store.Get().GetRuntimeState()
+	if err != nil || persistedRuntimeState == nil {
+		t.Fatalf("expected the runtime state to have been persisted, got %v and err=%v", persistedRuntimeState, err)
+	}
+	if !persistedRuntimeState.Muted || len(persistedRuntimeState.MaintenanceWindows) != 1 {
+		t.Errorf("expected persisted runtime state to be muted with 1 maintenance window, got muted=%v and %d maintenance windows", persistedRuntimeState.Muted, len(persistedRuntimeState.MaintenanceWindows))
+	}
+	// Export it
+	if response, err = router.Test(httptest.NewRequest("GET", "/api/v1/runtime-state", http.NoBody)); err != nil {
+		t.Fatal(err)
+	}
+	exported, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != 200 {
+		t.Fatalf("expected status code 200, got %d", response.StatusCode)
+	}
+	// Reset it, then import what was exported
+	if err = (&state.RuntimeState{}).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	request := httptest.NewRequest("PUT", "/api/v1/runtime-state", strings.NewReader(string(exported)))
+	if response, err = router.Test(request); err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != 200 {
+		t.Fatalf("expected status code 200, got %d", response.StatusCode)
+	}
+	if !alerting.IsMuted() || !maintenance.IsGroupUnderMaintenance("core") {
+		t.Error("expected the imported runtime state to have been applied")
+	}
+	var imported state.RuntimeState
+	if err = json.Unmarshal(exported, &imported); err != nil {
+		t.Fatal(err)
+	}
+	if windows := maintenance.GetWindows(); len(windows) != 1 || windows[0].ID != imported.MaintenanceWindows[0].ID {
+		t.Errorf("expected the maintenance window to have kept its ID, got %+v", windows)
+	}
+	// Invalid runtime states must be rejected
+	for _, body := range []string{"{", `{"maintenanceWindows":[{"group":"regex:(","start":"2024-01-01T00:00:00Z","end":"2999-01-01T00:00:00Z"}]}`} {
+		if response, err = router.Test(httptest.NewRequest("PUT", "/api/v1/runtime-state", strings.NewReader(body))); err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != 400 {
+			t.Errorf("expected status code 400 for body %s, got %d", body, response.StatusCode)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/gofiber/fiber/v2"
@@ -16,11 +17,86 @@ const (
 
 	// MaximumPageSize is the maximum page size allowed
 	MaximumPageSize = common.MaximumNumberOfResults
+
+	// DefaultEndpointsPageSize is the default number of endpoints per page to use if the endpoints are paginated or
+	// filtered, but no page size or an invalid value is provided
+	DefaultEndpointsPageSize = 50
+
+	// MaximumEndpointsPageSize is the maximum number of endpoints per page allowed
+	MaximumEndpointsPageSize = 500
+
+	// LabelQueryParameterPrefix is the prefix of the query parameters used to filter endpoints by label
+	//
+	// Usage: ?label.team=payments
+	LabelQueryParameterPrefix = "label."
 )
 
+// endpointStatusesQuery is the pagination and filtering of the endpoints returned by EndpointStatuses.
+//
+// Not to be confused with the resultsPage and resultsPageSize query parameters, which paginate the results of each
+// endpoint.
+type endpointStatusesQuery struct {
+	Page     int
+	PageSize int
+	Group    string
+	Name     string
+	// Labels are the labels an endpoint must have, extracted from the label.<key>=<value> query parameters
+	Labels map[string]string
+	// Requested is whether any of the page, pageSize, group, name and label.<key> query parameters were provided
+	Requested bool
+}
+
+// extractEndpointStatusesQueryFromRequest returns the endpointStatusesQuery of the request.
+//
+// If none of the page, pageSize, group, name and label.<key> query parameters were provided, the query still limits
+// the endpoints to the first DefaultEndpointsPageSize endpoints.
+func extractEndpointStatusesQueryFromRequest(c *fiber.Ctx) *endpointStatusesQuery {
+	labels := extractLabelsFromRequest(c)
+	query := &endpointStatusesQuery{
+		Page:      DefaultPage,
+		PageSize:  DefaultEndpointsPageSize,
+		Group:     c.Query("group"),
+		Name:      c.Query("name"),
+		Labels:    labels,
+		Requested: len(c.Query("page")) > 0 || len(c.Query("pageSize")) > 0 || len(c.Query("group")) > 0 || len(c.Query("name")) > 0 || len(labels) > 0,
+	}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page >= 1 {
+		query.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.Query("pageSize")); err == nil && pageSize >= 1 {
+		query.PageSize = min(pageSize, MaximumEndpointsPageSize)
+	}
+	return query
+}
+
+// extractLabelsFromRequest returns the labels passed as label.<key>=<value> query parameters
+func extractLabelsFromRequest(c *fiber.Ctx) map[string]string {
+	var labels map[string]string
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if labelKey, found := strings.CutPrefix(string(key), LabelQueryParameterPrefix); found && len(labelKey) > 0 {
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[labelKey] = string(value)
+		}
+	})
+	return labels
+}
+
 func extractPageAndPageSizeFromRequest(c *fiber.Ctx) (page, pageSize int) {
+	// limit is accepted as an alias of pageSize, which reads better when only the first page is requested
+	return extractPageAndPageSize(c.Query("page"), c.Query("pageSize", c.Query("limit")))
+}
+
+// extractResultsPageAndPageSizeFromRequest returns the page and page size of the results of each endpoint returned by
+// EndpointStatuses, since its page and pageSize query parameters paginate the endpoints themselves
+func extractResultsPageAndPageSizeFromRequest(c *fiber.Ctx) (page, pageSize int) {
+	return extractPageAndPageSize(c.Query("resultsPage"), c.Query("resultsPageSize"))
+}
+
+func extractPageAndPageSize(pageParameter, pageSizeParameter string) (page, pageSize int) {
 	var err error
-	if pageParameter := c.Query("page"); len(pageParameter) == 0 {
+	if len(pageParameter) == 0 {
 		page = DefaultPage
 	} else {
 		page, err = strconv.Atoi(pageParameter)
@@ -31,7 +107,7 @@ func extractPageAndPageSizeFromRequest(c *fiber.Ctx) (page, pageSize int) {
 			page = DefaultPage
 		}
 	}
-	if pageSizeParameter := c.Query("pageSize"); len(pageSizeParameter) == 0 {
+	if len(pageSizeParameter) == 0 {
 		pageSize = DefaultPageSize
 	} else {
 		pageSize, err = strconv.Atoi(pageSizeParameter)
@@ -71,3 +71,15 @@ func TestExtractPageAndPageSizeFromRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractPageAndPageSizeFromRequestWithLimit(t *testing.T) {
+	app := fiber.New()
+	for query, expectedPageSize := range map[string]int{"limit=5": 5, "limit=999999": MaximumPageSize, "limit=5&pageSize=10": 10} {
+		c := app.AcquireCtx(&fasthttp.RequestCtx{})
+		c.Request().SetRequestURI("/api/v1/endpoints/core_frontend/results?" + query)
+		if _, actualPageSize := extractPageAndPageSizeFromRequest(c); actualPageSize != expectedPageSize {
+			t.Errorf("%s: expected page size %d, got %d", query, expectedPageSize, actualPageSize)
+		}
+		app.ReleaseCtx(c)
+	}
+}
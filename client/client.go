@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/smtp"
@@ -76,7 +77,7 @@ func GetDomainExpiration(hostname string) (domainExpiration time.Duration, err e
 
 // CanCreateTCPConnection checks whether a connection can be established with a TCP endpoint
 func CanCreateTCPConnection(address string, config *Config) bool {
-	conn, err := net.DialTimeout("tcp", address, config.Timeout)
+	conn, err := config.dial("tcp", address)
 	if err != nil {
 		return false
 	}
@@ -84,9 +85,41 @@ func CanCreateTCPConnection(address string, config *Config) bool {
 	return true
 }
 
+// QueryTCP opens a TCP connection, writes `body` if it isn't empty and returns the data read from the server, along
+// with the time it took to establish the connection
+//
+// If the server doesn't send anything before the timeout is reached, the connection is still considered as
+// successfully established, and the returned response is empty.
+func QueryTCP(address, body string, config *Config) (bool, time.Duration, []byte, error) {
+	const MaximumMessageSize = 1024 // in bytes
+	connectStart := time.Now()
+	conn, err := config.dial("tcp", address)
+	if err != nil {
+		return false, 0, nil, fmt.Errorf("error dialing tcp: %w", err)
+	}
+	connectTime := time.Since(connectStart)
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(config.Timeout))
+	if len(body) > 0 {
+		if _, err = conn.Write([]byte(body)); err != nil {
+			return true, connectTime, nil, fmt.Errorf("error writing tcp body: %w", err)
+		}
+	}
+	response := make([]byte, MaximumMessageSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		var netErr net.Error
+		if (errors.As(err, &netErr) && netErr.Timeout()) || errors.Is(err, io.EOF) {
+			return true, connectTime, response[:n], nil
+		}
+		return true, connectTime, nil, fmt.Errorf("error reading tcp response: %w", err)
+	}
+	return true, connectTime, response[:n], nil
+}
+
 // CanCreateUDPConnection checks whether a connection can be established with a UDP endpoint
 func CanCreateUDPConnection(address string, config *Config) bool {
-	conn, err := net.DialTimeout("udp", address, config.Timeout)
+	conn, err := config.dial("udp", address)
 	if err != nil {
 		return false
 	}
@@ -94,6 +127,33 @@ func CanCreateUDPConnection(address string, config *Config) bool {
 	return true
 }
 
+// QueryUDP sends `body` to a UDP endpoint and returns the data read from the server
+//
+// Because UDP is connectionless, not receiving a response before the timeout is reached is only considered as a
+// failure if expectResponse is true.
+func QueryUDP(address, body string, expectResponse bool, config *Config) (bool, []byte, error) {
+	const MaximumMessageSize = 1024 // in bytes
+	conn, err := config.dial("udp", address)
+	if err != nil {
+		return false, nil, fmt.Errorf("error dialing udp: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(config.Timeout))
+	if _, err = conn.Write([]byte(body)); err != nil {
+		return false, nil, fmt.Errorf("error writing udp body: %w", err)
+	}
+	response := make([]byte, MaximumMessageSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() && !expectResponse {
+			return true, nil, nil
+		}
+		return false, nil, fmt.Errorf("error reading udp response: %w", err)
+	}
+	return true, response[:n], nil
+}
+
 // CanCreateSCTPConnection checks whether a connection can be established with a SCTP endpoint
 func CanCreateSCTPConnection(address string, config *Config) bool {
 	ch := make(chan bool)
@@ -125,7 +185,7 @@ func CanPerformStartTLS(address string, config *Config) (connected bool, certifi
 	if len(hostAndPort) != 2 {
 		return false, nil, errors.New("invalid address for starttls, format must be host:port")
 	}
-	connection, err := net.DialTimeout("tcp", address, config.Timeout)
+	connection, err := config.dial("tcp", address)
 	if err != nil {
 		return
 	}
@@ -133,10 +193,9 @@ func CanPerformStartTLS(address string, config *Config) (connected bool, certifi
 	if err != nil {
 		return
 	}
-	err = smtpClient.StartTLS(&tls.Config{
-		InsecureSkipVerify: config.Insecure,
-		ServerName:         hostAndPort[0],
-	})
+	tlsConfig := config.newTLSConfig()
+	tlsConfig.ServerName = hostAndPort[0]
+	err = smtpClient.StartTLS(tlsConfig)
 	if err != nil {
 		return
 	}
@@ -150,9 +209,11 @@ func CanPerformStartTLS(address string, config *Config) (connected bool, certifi
 
 // CanPerformTLS checks whether a connection can be established to an address using the TLS protocol
 func CanPerformTLS(address string, config *Config) (connected bool, certificate *x509.Certificate, err error) {
-	connection, err := tls.DialWithDialer(&net.Dialer{Timeout: config.Timeout}, "tcp", address, &tls.Config{
-		InsecureSkipVerify: config.Insecure,
-	})
+	dialer, err := config.dialer("tcp")
+	if err != nil {
+		return
+	}
+	connection, err := tls.DialWithDialer(dialer, config.network("tcp"), address, config.newTLSConfig())
 	if err != nil {
 		return
 	}
@@ -183,7 +244,12 @@ func CanCreateSSHConnection(address, username, password string, config *Config)
 		port = "22"
 	}
 
-	cli, err := ssh.Dial("tcp", strings.Join([]string{address, port}, ":"), &ssh.ClientConfig{
+	address = strings.Join([]string{address, port}, ":")
+	conn, err := config.dial("tcp", address)
+	if err != nil {
+		return false, nil, err
+	}
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, address, &ssh.ClientConfig{
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 		User:            username,
 		Auth: []ssh.AuthMethod{
@@ -192,10 +258,11 @@ func CanCreateSSHConnection(address, username, password string, config *Config)
 		Timeout: config.Timeout,
 	})
 	if err != nil {
+		_ = conn.Close()
 		return false, nil, err
 	}
 
-	return true, cli, nil
+	return true, ssh.NewClient(sshConn, channels, requests), nil
 }
 
 // ExecuteSSHCommand executes a command to an address using the SSH protocol.
@@ -250,6 +317,11 @@ func Ping(address string, config *Config) (bool, time.Duration) {
 	// See https://github.com/prometheus-community/pro-bing#linux
 	pinger.SetPrivileged(runtime.GOOS != "darwin")
 	pinger.SetNetwork(config.Network)
+	if sourceIP, err := config.localIP(); err != nil {
+		return false, 0
+	} else if sourceIP != nil {
+		pinger.Source = sourceIP.String()
+	}
 	err := pinger.Run()
 	if err != nil {
 		return false, 0
@@ -275,7 +347,9 @@ func QueryWebSocket(address, body string, config *Config) (bool, []byte, error)
 		return false, nil, fmt.Errorf("error configuring websocket connection: %w", err)
 	}
 	if config != nil {
-		wsConfig.Dialer = &net.Dialer{Timeout: config.Timeout}
+		if wsConfig.Dialer, err = config.dialer("tcp"); err != nil {
+			return false, nil, fmt.Errorf("error configuring websocket connection: %w", err)
+		}
 	}
 	// Dial URL
 	ws, err := websocket.DialConfig(wsConfig)
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"crypto/tls"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -225,6 +227,232 @@ func TestCanCreateTCPConnection(t *testing.T) {
 	}
 }
 
+func TestQueryTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start tcp server:", err.Error())
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				buffer := make([]byte, 64)
+				_ = conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+				n, _ := conn.Read(buffer)
+				switch string(buffer[:n]) {
+				case "":
+					// Behaves like an SMTP server, which sends a banner as soon as the connection is established
+					_, _ = conn.Write([]byte("220 localhost ESMTP"))
+				case "PING\r\n":
+					_, _ = conn.Write([]byte("+PONG\r\n"))
+				case "SILENT":
+					time.Sleep(500 * time.Millisecond)
+				}
+			}(conn)
+		}
+	}()
+	scenarios := []struct {
+		name              string
+		address           string
+		body              string
+		expectedConnected bool
+		expectedResponse  string
+		expectedErr       bool
+	}{
+		{
+			name:              "banner",
+			address:           listener.Addr().String(),
+			expectedConnected: true,
+			expectedResponse:  "220 localhost ESMTP",
+		},
+		{
+			name:              "send-and-expect",
+			address:           listener.Addr().String(),
+			body:              "PING\r\n",
+			expectedConnected: true,
+			expectedResponse:  "+PONG\r\n",
+		},
+		{
+			name:              "read-timeout",
+			address:           listener.Addr().String(),
+			body:              "SILENT",
+			expectedConnected: true,
+			expectedResponse:  "",
+		},
+		{
+			name:              "no-port",
+			address:           "127.0.0.1",
+			expectedConnected: false,
+			expectedErr:       true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, connectTime, response, err := QueryTCP(scenario.address, scenario.body, &Config{Timeout: 200 * time.Millisecond})
+			if (err != nil) != scenario.expectedErr {
+				t.Errorf("expected error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if !connected && connectTime != 0 {
+				t.Errorf("expected no connect time if the connection couldn't be established, got %s", connectTime)
+			}
+			if string(response) != scenario.expectedResponse {
+				t.Errorf("expected response to be %q, got %q", scenario.expectedResponse, string(response))
+			}
+		})
+	}
+}
+
+func TestCanCreateTCPConnectionWithIPVersion(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal("failed to start dual-stack server:", err.Error())
+	}
+	defer listener.Close()
+	if probe, err := net.Listen("tcp6", "[::1]:0"); err != nil {
+		t.Skip("IPv6 is not available:", err.Error())
+	} else {
+		probe.Close()
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	scenarios := []struct {
+		ipVersion         string
+		host              string
+		expectedConnected bool
+	}{
+		{ipVersion: IPVersionAuto, host: "127.0.0.1", expectedConnected: true},
+		{ipVersion: IPVersionAuto, host: "::1", expectedConnected: true},
+		{ipVersion: IPVersion4, host: "127.0.0.1", expectedConnected: true},
+		{ipVersion: IPVersion4, host: "::1", expectedConnected: false},
+		{ipVersion: IPVersion6, host: "127.0.0.1", expectedConnected: false},
+		{ipVersion: IPVersion6, host: "::1", expectedConnected: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.ipVersion+"-"+scenario.host, func(t *testing.T) {
+			config := &Config{IPVersion: scenario.ipVersion, Timeout: time.Second}
+			if connected := CanCreateTCPConnection(net.JoinHostPort(scenario.host, port), config); connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %t, got %t", scenario.expectedConnected, connected)
+			}
+		})
+	}
+}
+
+func TestCanCreateTCPConnectionWithSourceIP(t *testing.T) {
+	// The whole 127.0.0.0/8 block is routed to the loopback interface on Linux, but not necessarily on other systems
+	if probe, err := net.Listen("tcp4", "127.0.0.2:0"); err != nil {
+		t.Skip("127.0.0.2 is not available:", err.Error())
+	} else {
+		probe.Close()
+	}
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start server:", err.Error())
+	}
+	defer listener.Close()
+	remoteAddresses := make(chan net.Addr, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			remoteAddresses <- conn.RemoteAddr()
+			_ = conn.Close()
+		}
+	}()
+	config := &Config{SourceIP: "127.0.0.2", Timeout: time.Second}
+	if !CanCreateTCPConnection(listener.Addr().String(), config) {
+		t.Fatal("expected to be able to create a connection")
+	}
+	if remoteAddress := (<-remoteAddresses).(*net.TCPAddr); remoteAddress.IP.String() != "127.0.0.2" {
+		t.Errorf("expected the connection to come from 127.0.0.2, got %s", remoteAddress.IP)
+	}
+	config.SourceIP = "192.0.2.1" // not assigned to any interface
+	if CanCreateTCPConnection(listener.Addr().String(), config) {
+		t.Error("expected not to be able to create a connection from an address that isn't assigned to the host")
+	}
+}
+
+func TestQueryUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to start udp server:", err.Error())
+	}
+	defer conn.Close()
+	go func() {
+		buffer := make([]byte, 64)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			// Echo everything back, except for messages starting with "SILENT"
+			if !strings.HasPrefix(string(buffer[:n]), "SILENT") {
+				_, _ = conn.WriteTo(buffer[:n], addr)
+			}
+		}
+	}()
+	scenarios := []struct {
+		name              string
+		body              string
+		expectResponse    bool
+		expectedConnected bool
+		expectedResponse  string
+		expectedErr       bool
+	}{
+		{
+			name:              "echo",
+			body:              "hello",
+			expectResponse:    true,
+			expectedConnected: true,
+			expectedResponse:  "hello",
+		},
+		{
+			name:              "no-response-with-expect",
+			body:              "SILENT",
+			expectResponse:    true,
+			expectedConnected: false,
+			expectedErr:       true,
+		},
+		{
+			name:              "no-response-without-expect",
+			body:              "SILENT",
+			expectResponse:    false,
+			expectedConnected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, response, err := QueryUDP(conn.LocalAddr().String(), scenario.body, scenario.expectResponse, &Config{Timeout: 200 * time.Millisecond})
+			if (err != nil) != scenario.expectedErr {
+				t.Errorf("expected error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if string(response) != scenario.expectedResponse {
+				t.Errorf("expected response to be %q, got %q", scenario.expectedResponse, string(response))
+			}
+		})
+	}
+}
+
 // This test checks if a HTTP client configured with `configureOAuth2()` automatically
 // performs a Client Credentials OAuth2 flow and adds the obtained token as a `Authorization`
 // header to all outgoing HTTP calls.
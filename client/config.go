@@ -4,12 +4,14 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -19,14 +21,38 @@ import (
 
 const (
 	defaultTimeout = 10 * time.Second
+
+	// IPVersionAuto lets the operating system pick the address family to connect with
+	IPVersionAuto = "auto"
+
+	// IPVersion4 forces connections to be established over IPv4
+	IPVersion4 = "4"
+
+	// IPVersion6 forces connections to be established over IPv6
+	IPVersion6 = "6"
 )
 
 var (
-	ErrInvalidDNSResolver        = errors.New("invalid DNS resolver specified. Required format is {proto}://{ip}:{port}")
-	ErrInvalidDNSResolverPort    = errors.New("invalid DNS resolver port")
-	ErrInvalidClientOAuth2Config = errors.New("invalid oauth2 configuration: must define all fields for client credentials flow (token-url, client-id, client-secret, scopes)")
-	ErrInvalidClientIAPConfig    = errors.New("invalid Identity-Aware-Proxy configuration: must define all fields for Google Identity-Aware-Proxy programmatic authentication (audience)")
-	ErrInvalidClientTLSConfig    = errors.New("invalid TLS configuration: certificate-file and private-key-file must be specified")
+	ErrInvalidDNSResolver          = errors.New("invalid DNS resolver specified. Required format is {proto}://{ip}:{port}")
+	ErrInvalidDNSResolverPort      = errors.New("invalid DNS resolver port")
+	ErrInvalidClientOAuth2Config   = errors.New("invalid oauth2 configuration: must define all fields for client credentials flow (token-url, client-id, client-secret, scopes)")
+	ErrInvalidClientIAPConfig      = errors.New("invalid Identity-Aware-Proxy configuration: must define all fields for Google Identity-Aware-Proxy programmatic authentication (audience)")
+	ErrInvalidClientTLSConfig      = errors.New("invalid TLS configuration: certificate-file and private-key-file must be specified")
+	ErrInvalidMaxRedirects         = errors.New("invalid max-redirects: must be greater than or equal to 0")
+	ErrInvalidClientTLSMinVersion  = errors.New("invalid TLS configuration: min-version must be one of 1.0, 1.1, 1.2 or 1.3")
+	ErrInvalidClientTLSCipherSuite = errors.New("invalid TLS configuration: unknown cipher suite")
+	ErrInvalidIPVersion            = errors.New("invalid ip-version: must be one of 4, 6 or auto")
+	ErrInvalidSourceIP             = errors.New("invalid source-ip: must be an IP address of the address family configured by ip-version")
+	ErrInvalidInterface            = errors.New("invalid interface: must be the name of a network interface with an IP address of the address family configured by ip-version")
+	ErrSourceIPAndInterface        = errors.New("source-ip and interface cannot both be specified")
+
+	// tlsVersions maps the supported values of TLSConfig.MinVersion to their crypto/tls counterpart
+	tlsVersions = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
 
 	defaultConfig = Config{
 		Insecure:       false,
@@ -53,6 +79,15 @@ type Config struct {
 	// IgnoreRedirect determines whether to ignore redirects (true) or follow them (false, default)
 	IgnoreRedirect bool `yaml:"ignore-redirect,omitempty"`
 
+	// MaxRedirects is the maximum number of redirects to follow before using the last response.
+	// If set to 0 (default), there is no limit.
+	// Has no effect if IgnoreRedirect is true.
+	MaxRedirects int `yaml:"max-redirects,omitempty"`
+
+	// DisableKeepAlive determines whether to open a new connection for every request instead of reusing idle ones.
+	// Useful to make sure that connection-setup failures aren't masked by a connection established earlier.
+	DisableKeepAlive bool `yaml:"disable-keepalive,omitempty"`
+
 	// Timeout for the client
 	Timeout time.Duration `yaml:"timeout"`
 
@@ -74,6 +109,18 @@ type Config struct {
 	// Network (ip, ip4 or ip6) for the ICMP client
 	Network string `yaml:"network"`
 
+	// IPVersion is the address family (4, 6 or auto) used to connect to the endpoint.
+	// If set to 4 or 6, connections are only attempted over IPv4 or IPv6 respectively, even on a dual-stack host.
+	IPVersion string `yaml:"ip-version,omitempty"`
+
+	// SourceIP is the local IP address connections to the endpoint are established from.
+	// Useful on multi-homed hosts where probes must egress from a specific network (e.g. a monitoring VLAN).
+	SourceIP string `yaml:"source-ip,omitempty"`
+
+	// Interface is the name of the local network interface (e.g. eth1) whose IP address connections to the endpoint
+	// are established from. Cannot be used with SourceIP.
+	Interface string `yaml:"interface,omitempty"`
+
 	// TLS configuration (optional)
 	TLS *TLSConfig `yaml:"tls,omitempty"`
 }
@@ -107,6 +154,14 @@ type TLSConfig struct {
 	PrivateKeyFile string `yaml:"private-key-file,omitempty"`
 
 	RenegotiationSupport string `yaml:"renegotiation,omitempty"`
+
+	// MinVersion is the minimum TLS version that the client will accept (1.0, 1.1, 1.2 or 1.3).
+	// If the server cannot negotiate at least this version, the handshake fails.
+	MinVersion string `yaml:"min-version,omitempty"`
+
+	// CipherSuites is the list of cipher suites that the client will offer, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256.
+	// If empty, Go's default cipher suites are used. Note that TLS 1.3 cipher suites are not configurable.
+	CipherSuites []string `yaml:"cipher-suites,omitempty"`
 }
 
 // ValidateAndSetDefaults validates the client configuration and sets the default values if necessary
@@ -114,6 +169,9 @@ func (c *Config) ValidateAndSetDefaults() error {
 	if c.Timeout < time.Millisecond {
 		c.Timeout = 10 * time.Second
 	}
+	if c.MaxRedirects < 0 {
+		return ErrInvalidMaxRedirects
+	}
 	if c.HasCustomDNSResolver() {
 		// Validate the DNS resolver now to make sure it will not return an error later.
 		if _, err := c.parseDNSResolver(); err != nil {
@@ -131,6 +189,31 @@ func (c *Config) ValidateAndSetDefaults() error {
 			return err
 		}
 	}
+	switch c.IPVersion {
+	case "", IPVersionAuto:
+	case IPVersion4, IPVersion6:
+		if len(c.Network) == 0 || c.Network == "ip" {
+			c.Network = "ip" + c.IPVersion
+		}
+	default:
+		return ErrInvalidIPVersion
+	}
+	if len(c.SourceIP) > 0 && len(c.Interface) > 0 {
+		return ErrSourceIPAndInterface
+	}
+	if _, err := c.localIP(); err != nil {
+		return err
+	}
+	if c.TLS != nil {
+		if len(c.TLS.MinVersion) > 0 {
+			if _, ok := tlsVersions[c.TLS.MinVersion]; !ok {
+				return ErrInvalidClientTLSMinVersion
+			}
+		}
+		if _, err := c.TLS.cipherSuiteIDs(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -166,6 +249,101 @@ func (c *Config) parseDNSResolver() (*DNSResolverConfig, error) {
 	}, nil
 }
 
+// network returns the network (e.g. tcp) restricted to the address family configured by IPVersion (e.g. tcp6)
+func (c *Config) network(network string) string {
+	if c.IPVersion == IPVersion4 || c.IPVersion == IPVersion6 {
+		return strings.TrimRight(network, "46") + c.IPVersion
+	}
+	return network
+}
+
+// SelectIP returns the first IP of the address family configured by IPVersion, or the first IP if IPVersion isn't
+// restricted to a single address family. Returns nil if there's no such IP.
+func (c *Config) SelectIP(ips []net.IP) net.IP {
+	for _, ip := range ips {
+		switch c.IPVersion {
+		case IPVersion4:
+			if ip.To4() == nil {
+				continue
+			}
+		case IPVersion6:
+			if ip.To4() != nil {
+				continue
+			}
+		}
+		return ip
+	}
+	return nil
+}
+
+// localIP returns the local IP address configured by SourceIP or Interface, or nil if neither is configured.
+//
+// The address of an interface is looked up every time so that a change of address doesn't require a restart.
+func (c *Config) localIP() (net.IP, error) {
+	if len(c.SourceIP) > 0 {
+		ip := net.ParseIP(c.SourceIP)
+		if ip == nil || c.SelectIP([]net.IP{ip}) == nil {
+			return nil, ErrInvalidSourceIP
+		}
+		return ip, nil
+	}
+	if len(c.Interface) > 0 {
+		networkInterface, err := net.InterfaceByName(c.Interface)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInterface, err.Error())
+		}
+		addresses, err := networkInterface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInterface, err.Error())
+		}
+		var ips []net.IP
+		for _, address := range addresses {
+			if ipNet, ok := address.(*net.IPNet); ok && !ipNet.IP.IsLinkLocalUnicast() {
+				ips = append(ips, ipNet.IP)
+			}
+		}
+		ip := c.SelectIP(ips)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: %s has no usable IP address", ErrInvalidInterface, c.Interface)
+		}
+		return ip, nil
+	}
+	return nil, nil
+}
+
+// localAddr returns the local address to bind to when dialing the given network (e.g. tcp), or nil if neither
+// SourceIP nor Interface is configured
+func (c *Config) localAddr(network string) (net.Addr, error) {
+	ip, err := c.localIP()
+	if err != nil || ip == nil {
+		return nil, err
+	}
+	if strings.HasPrefix(network, "udp") {
+		return &net.UDPAddr{IP: ip}, nil
+	}
+	return &net.TCPAddr{IP: ip}, nil
+}
+
+// dialer returns a dialer for the given network that times out after Timeout and binds to the local address
+// configured by SourceIP or Interface, if any
+func (c *Config) dialer(network string) (*net.Dialer, error) {
+	localAddr, err := c.localAddr(network)
+	if err != nil {
+		return nil, err
+	}
+	return &net.Dialer{Timeout: c.Timeout, LocalAddr: localAddr}, nil
+}
+
+// dial connects to the address on the given network (e.g. tcp) restricted to the address family configured by
+// IPVersion, from the local address configured by SourceIP or Interface, if any
+func (c *Config) dial(network, address string) (net.Conn, error) {
+	dialer, err := c.dialer(network)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.Dial(c.network(network), address)
+}
+
 // HasOAuth2Config returns true if the client has OAuth2 configuration parameters
 func (c *Config) HasOAuth2Config() bool {
 	return c.OAuth2Config != nil
@@ -203,15 +381,65 @@ func (t *TLSConfig) isValid() error {
 	return ErrInvalidClientTLSConfig
 }
 
-// GetHTTPClient return an HTTP client matching the Config's parameters.
-func (c *Config) getHTTPClient() *http.Client {
+// cipherSuiteIDs returns the IDs of the configured cipher suites, or an error if one of them is unknown
+func (t *TLSConfig) cipherSuiteIDs() ([]uint16, error) {
+	if len(t.CipherSuites) == 0 {
+		return nil, nil
+	}
+	supportedCipherSuites := append(tls.CipherSuites(), tls.InsecureCipherSuites()...)
+	var ids []uint16
+	for _, name := range t.CipherSuites {
+		found := false
+		for _, cipherSuite := range supportedCipherSuites {
+			if cipherSuite.Name == name {
+				ids = append(ids, cipherSuite.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidClientTLSCipherSuite, name)
+		}
+	}
+	return ids, nil
+}
+
+// TLSVersionName returns the name of a TLS version in the same format as TLSConfig.MinVersion (e.g. 1.3),
+// or an empty string if the version is unknown
+func TLSVersionName(version uint16) string {
+	for name, value := range tlsVersions {
+		if value == version {
+			return name
+		}
+	}
+	return ""
+}
+
+// newTLSConfig returns a TLS Config matching the Config's parameters
+func (c *Config) newTLSConfig() *tls.Config {
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: c.Insecure,
 	}
+	if c.TLS != nil {
+		// Both values have been validated in ValidateAndSetDefaults
+		tlsConfig.MinVersion = tlsVersions[c.TLS.MinVersion]
+		tlsConfig.CipherSuites, _ = c.TLS.cipherSuiteIDs()
+	}
+	return tlsConfig
+}
+
+// GetHTTPClient return an HTTP client matching the Config's parameters.
+func (c *Config) getHTTPClient() *http.Client {
+	tlsConfig := c.newTLSConfig()
 	if c.HasTlsConfig() && c.TLS.isValid() == nil {
 		tlsConfig = configureTLS(tlsConfig, *c.TLS)
 	}
 	if c.httpClient == nil {
+		localAddr, err := c.localAddr("tcp")
+		if err != nil {
+			// This should have been validated on startup by ValidateAndSetDefaults, but the interface may be gone.
+			log.Println("[client.getHTTPClient] Ignoring source address due to error:", err.Error())
+		}
 		c.httpClient = &http.Client{
 			Timeout: c.Timeout,
 			Transport: &http.Transport{
@@ -219,12 +447,17 @@ func (c *Config) getHTTPClient() *http.Client {
 				MaxIdleConnsPerHost: 20,
 				Proxy:               http.ProxyFromEnvironment,
 				TLSClientConfig:     tlsConfig,
+				DisableKeepAlives:   c.DisableKeepAlive,
 			},
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				if c.IgnoreRedirect {
 					// Don't follow redirects
 					return http.ErrUseLastResponse
 				}
+				if c.MaxRedirects > 0 && len(via) > c.MaxRedirects {
+					// Stop following redirects and use the last response so that its status can be evaluated
+					return http.ErrUseLastResponse
+				}
 				// Follow redirects
 				return nil
 			},
@@ -245,6 +478,7 @@ func (c *Config) getHTTPClient() *http.Client {
 				log.Println("[client.getHTTPClient] THIS SHOULD NOT HAPPEN. Silently ignoring invalid DNS resolver due to error:", err.Error())
 			} else {
 				dialer := &net.Dialer{
+					LocalAddr: localAddr,
 					Resolver: &net.Resolver{
 						PreferGo: true,
 						Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
@@ -258,6 +492,18 @@ func (c *Config) getHTTPClient() *http.Client {
 				}
 			}
 		}
+		if localAddr != nil && c.httpClient.Transport.(*http.Transport).DialContext == nil {
+			c.httpClient.Transport.(*http.Transport).DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, LocalAddr: localAddr}).DialContext
+		}
+		if c.IPVersion == IPVersion4 || c.IPVersion == IPVersion6 {
+			dialContext := c.httpClient.Transport.(*http.Transport).DialContext
+			if dialContext == nil {
+				dialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+			}
+			c.httpClient.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialContext(ctx, c.network(network), addr)
+			}
+		}
 		if c.HasOAuth2Config() && c.HasIAPConfig() {
 			log.Println("[client.getHTTPClient] Error: Both Identity-Aware-Proxy and Oauth2 configuration are present.")
 		} else if c.HasOAuth2Config() {
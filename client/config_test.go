@@ -1,8 +1,16 @@
 package client
 
 import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -37,6 +45,59 @@ func TestConfig_getHTTPClient(t *testing.T) {
 	}
 }
 
+func TestConfig_getHTTPClient_withDisableKeepAlive(t *testing.T) {
+	var numberOfConnections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			numberOfConnections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+	scenarios := []struct {
+		name                        string
+		disableKeepAlive            bool
+		expectedNumberOfConnections int32
+	}{
+		{
+			name:                        "keep-alive",
+			disableKeepAlive:            false,
+			expectedNumberOfConnections: 1,
+		},
+		{
+			name:                        "keep-alive-disabled",
+			disableKeepAlive:            true,
+			expectedNumberOfConnections: 3,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			numberOfConnections.Store(0)
+			cfg := &Config{DisableKeepAlive: scenario.disableKeepAlive}
+			_ = cfg.ValidateAndSetDefaults()
+			httpClient := cfg.getHTTPClient()
+			defer httpClient.CloseIdleConnections()
+			if httpClient.Transport.(*http.Transport).DisableKeepAlives != scenario.disableKeepAlive {
+				t.Errorf("expected DisableKeepAlives to be %v", scenario.disableKeepAlive)
+			}
+			for i := 0; i < 3; i++ {
+				response, err := httpClient.Get(server.URL)
+				if err != nil {
+					t.Fatal("expected no error, got", err.Error())
+				}
+				_, _ = io.Copy(io.Discard, response.Body)
+				_ = response.Body.Close()
+			}
+			if actual := numberOfConnections.Load(); actual != scenario.expectedNumberOfConnections {
+				t.Errorf("expected %d connections to have been opened, got %d", scenario.expectedNumberOfConnections, actual)
+			}
+		})
+	}
+}
+
 func TestConfig_ValidateAndSetDefaults_withCustomDNSResolver(t *testing.T) {
 	type args struct {
 		dnsResolver string
@@ -107,6 +168,75 @@ func TestConfig_getHTTPClient_withCustomProxyURL(t *testing.T) {
 	}
 }
 
+func TestConfig_getHTTPClient_withRedirects(t *testing.T) {
+	// Every request to /{n} redirects to /{n+1}, and /5 returns a 200
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/"))
+		if n >= 5 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, "/"+strconv.Itoa(n+1), http.StatusFound)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name           string
+		config         *Config
+		expectedStatus int
+		expectedPath   string
+	}{
+		{
+			name:           "follow-redirects",
+			config:         &Config{},
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/5",
+		},
+		{
+			name:           "ignore-redirect",
+			config:         &Config{IgnoreRedirect: true},
+			expectedStatus: http.StatusFound,
+			expectedPath:   "/0",
+		},
+		{
+			name:           "max-redirects",
+			config:         &Config{MaxRedirects: 2},
+			expectedStatus: http.StatusFound,
+			expectedPath:   "/2",
+		},
+		{
+			name:           "max-redirects-not-reached",
+			config:         &Config{MaxRedirects: 5},
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/5",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			response, err := scenario.config.getHTTPClient().Get(server.URL + "/0")
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.expectedStatus {
+				t.Errorf("expected status to be %d, got %d", scenario.expectedStatus, response.StatusCode)
+			}
+			if response.Request.URL.Path != scenario.expectedPath {
+				t.Errorf("expected last request path to be %s, got %s", scenario.expectedPath, response.Request.URL.Path)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaults_withInvalidMaxRedirects(t *testing.T) {
+	cfg := &Config{MaxRedirects: -1}
+	if err := cfg.ValidateAndSetDefaults(); err != ErrInvalidMaxRedirects {
+		t.Errorf("expected error %v, got %v", ErrInvalidMaxRedirects, err)
+	}
+}
+
 func TestConfig_TlsIsValid(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -169,3 +299,241 @@ func TestConfig_TlsIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ValidateAndSetDefaults_withTLSMinVersionAndCipherSuites(t *testing.T) {
+	scenarios := []struct {
+		name                 string
+		tls                  *TLSConfig
+		expectedErr          error
+		expectedMinVersion   uint16
+		expectedCipherSuites []uint16
+	}{
+		{
+			name:               "min-version-1.2",
+			tls:                &TLSConfig{MinVersion: "1.2"},
+			expectedMinVersion: tls.VersionTLS12,
+		},
+		{
+			name:               "min-version-1.3",
+			tls:                &TLSConfig{MinVersion: "1.3"},
+			expectedMinVersion: tls.VersionTLS13,
+		},
+		{
+			name:        "invalid-min-version",
+			tls:         &TLSConfig{MinVersion: "TLS1.2"},
+			expectedErr: ErrInvalidClientTLSMinVersion,
+		},
+		{
+			name:                 "cipher-suites",
+			tls:                  &TLSConfig{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}},
+			expectedCipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+		},
+		{
+			name:        "unknown-cipher-suite",
+			tls:         &TLSConfig{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_DOES_NOT_EXIST"}},
+			expectedErr: ErrInvalidClientTLSCipherSuite,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{TLS: scenario.tls}
+			if err := cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr != nil {
+				return
+			}
+			tlsConfig := cfg.getHTTPClient().Transport.(*http.Transport).TLSClientConfig
+			if tlsConfig.MinVersion != scenario.expectedMinVersion {
+				t.Errorf("expected min version to be %d, got %d", scenario.expectedMinVersion, tlsConfig.MinVersion)
+			}
+			if len(tlsConfig.CipherSuites) != len(scenario.expectedCipherSuites) {
+				t.Fatalf("expected %d cipher suites, got %d", len(scenario.expectedCipherSuites), len(tlsConfig.CipherSuites))
+			}
+			for i, cipherSuite := range scenario.expectedCipherSuites {
+				if tlsConfig.CipherSuites[i] != cipherSuite {
+					t.Errorf("expected cipher suite at index %d to be %d, got %d", i, cipherSuite, tlsConfig.CipherSuites[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTLSVersionName(t *testing.T) {
+	if name := TLSVersionName(tls.VersionTLS12); name != "1.2" {
+		t.Errorf("expected 1.2, got %s", name)
+	}
+	if name := TLSVersionName(tls.VersionTLS13); name != "1.3" {
+		t.Errorf("expected 1.3, got %s", name)
+	}
+	if name := TLSVersionName(0); name != "" {
+		t.Errorf("expected an empty string, got %s", name)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaults_withIPVersion(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		ipVersion       string
+		network         string
+		expectedNetwork string
+		expectedErr     error
+	}{
+		{name: "default", ipVersion: "", network: "ip", expectedNetwork: "ip"},
+		{name: "auto", ipVersion: IPVersionAuto, network: "ip", expectedNetwork: "ip"},
+		{name: "4", ipVersion: IPVersion4, network: "ip", expectedNetwork: "ip4"},
+		{name: "6", ipVersion: IPVersion6, expectedNetwork: "ip6"},
+		{name: "6-with-explicit-network", ipVersion: IPVersion6, network: "ip4", expectedNetwork: "ip4"},
+		{name: "invalid", ipVersion: "5", expectedErr: ErrInvalidIPVersion},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{IPVersion: scenario.ipVersion, Network: scenario.network}
+			if err := cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && cfg.Network != scenario.expectedNetwork {
+				t.Errorf("expected network to be %s, got %s", scenario.expectedNetwork, cfg.Network)
+			}
+		})
+	}
+}
+
+func TestConfig_SelectIP(t *testing.T) {
+	ips := []net.IP{net.ParseIP("2001:db8::1"), net.ParseIP("192.0.2.1"), net.ParseIP("192.0.2.2")}
+	scenarios := map[string]string{
+		"":            "2001:db8::1",
+		IPVersionAuto: "2001:db8::1",
+		IPVersion4:    "192.0.2.1",
+		IPVersion6:    "2001:db8::1",
+	}
+	for ipVersion, expectedIP := range scenarios {
+		if ip := (&Config{IPVersion: ipVersion}).SelectIP(ips); ip.String() != expectedIP {
+			t.Errorf("expected %s to be selected with ip-version=%s, got %s", expectedIP, ipVersion, ip)
+		}
+	}
+	if ip := (&Config{IPVersion: IPVersion6}).SelectIP(ips[1:]); ip != nil {
+		t.Errorf("expected no IP to be selected, got %s", ip)
+	}
+}
+
+func TestConfig_getHTTPClient_withIPVersion(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal("failed to start dual-stack server:", err.Error())
+	}
+	if probe, err := net.Listen("tcp6", "[::1]:0"); err != nil {
+		listener.Close()
+		t.Skip("IPv6 is not available:", err.Error())
+	} else {
+		probe.Close()
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.RemoteAddr))
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	scenarios := []struct {
+		ipVersion   string
+		host        string
+		expectedErr bool
+	}{
+		{ipVersion: IPVersionAuto, host: "127.0.0.1"},
+		{ipVersion: IPVersionAuto, host: "[::1]"},
+		{ipVersion: IPVersion4, host: "127.0.0.1"},
+		{ipVersion: IPVersion4, host: "[::1]", expectedErr: true},
+		{ipVersion: IPVersion6, host: "127.0.0.1", expectedErr: true},
+		{ipVersion: IPVersion6, host: "[::1]"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.ipVersion+"-"+scenario.host, func(t *testing.T) {
+			cfg := &Config{IPVersion: scenario.ipVersion, Timeout: time.Second}
+			if err := cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			response, err := cfg.getHTTPClient().Get("http://" + scenario.host + ":" + port)
+			if scenario.expectedErr {
+				if err == nil {
+					response.Body.Close()
+					t.Error("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			remoteAddress, _ := io.ReadAll(response.Body)
+			if host, _, _ := net.SplitHostPort(string(remoteAddress)); host != strings.Trim(scenario.host, "[]") {
+				t.Errorf("expected the connection to come from %s, got %s", scenario.host, remoteAddress)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaults_withSourceIPAndInterface(t *testing.T) {
+	var loopbackInterface string
+	if interfaces, err := net.Interfaces(); err == nil {
+		for _, networkInterface := range interfaces {
+			if networkInterface.Flags&net.FlagLoopback != 0 {
+				loopbackInterface = networkInterface.Name
+				break
+			}
+		}
+	}
+	scenarios := []struct {
+		name        string
+		sourceIP    string
+		iface       string
+		ipVersion   string
+		expectedErr error
+	}{
+		{name: "none"},
+		{name: "ipv4-source-ip", sourceIP: "127.0.0.1"},
+		{name: "ipv6-source-ip", sourceIP: "::1", ipVersion: IPVersion6},
+		{name: "invalid-source-ip", sourceIP: "not-an-ip", expectedErr: ErrInvalidSourceIP},
+		{name: "source-ip-of-other-address-family", sourceIP: "127.0.0.1", ipVersion: IPVersion6, expectedErr: ErrInvalidSourceIP},
+		{name: "source-ip-and-interface", sourceIP: "127.0.0.1", iface: "lo", expectedErr: ErrSourceIPAndInterface},
+		{name: "nonexistent-interface", iface: "nonexistent0", expectedErr: ErrInvalidInterface},
+		{name: "loopback-interface", iface: loopbackInterface, ipVersion: IPVersion4},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{SourceIP: scenario.sourceIP, Interface: scenario.iface, IPVersion: scenario.ipVersion}
+			if err := cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_getHTTPClient_withSourceIP(t *testing.T) {
+	if probe, err := net.Listen("tcp4", "127.0.0.2:0"); err != nil {
+		t.Skip("127.0.0.2 is not available:", err.Error())
+	} else {
+		probe.Close()
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.RemoteAddr))
+	}))
+	defer server.Close()
+	for _, sourceIP := range []string{"127.0.0.1", "127.0.0.2"} {
+		t.Run(sourceIP, func(t *testing.T) {
+			cfg := &Config{SourceIP: sourceIP, Timeout: time.Second}
+			if err := cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			response, err := cfg.getHTTPClient().Get(server.URL)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			remoteAddress, _ := io.ReadAll(response.Body)
+			if host, _, _ := net.SplitHostPort(string(remoteAddress)); host != sourceIP {
+				t.Errorf("expected the connection to come from %s, got %s", sourceIP, remoteAddress)
+			}
+		})
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/deepmerge"
@@ -16,8 +19,12 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider"
 	"github.com/TwiN/gatus/v5/config/connectivity"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/resultwebhook"
+	"github.com/TwiN/gatus/v5/config/group"
+	"github.com/TwiN/gatus/v5/config/heartbeat"
 	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/remote"
+	"github.com/TwiN/gatus/v5/config/shard"
 	"github.com/TwiN/gatus/v5/config/ui"
 	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/security"
@@ -33,6 +40,10 @@ const (
 	// DefaultFallbackConfigurationFilePath is the default fallback path that will be used to search for the
 	// configuration file if DefaultConfigurationFilePath didn't work
 	DefaultFallbackConfigurationFilePath = "config/config.yml"
+
+	// DefaultShutdownGracePeriod is the default maximum amount of time to wait for in-flight checks to complete
+	// when shutting down
+	DefaultShutdownGracePeriod = 10 * time.Second
 )
 
 var (
@@ -45,6 +56,29 @@ var (
 	// ErrInvalidSecurityConfig is an error returned when the security configuration is invalid
 	ErrInvalidSecurityConfig = errors.New("invalid security configuration")
 
+	// ErrUnknownEndpointDependency is an error returned when an endpoint depends on an endpoint that does not exist
+	ErrUnknownEndpointDependency = errors.New("endpoint depends on an endpoint that does not exist")
+
+	// ErrEndpointDependencyCycle is an error returned when the dependencies of an endpoint lead back to the endpoint
+	ErrEndpointDependencyCycle = errors.New("endpoint dependencies must not form a cycle")
+
+	// ErrInvalidShutdownGracePeriod is an error returned when the shutdown grace period is negative
+	ErrInvalidShutdownGracePeriod = errors.New("shutdown-grace-period must not be negative")
+
+	// ErrInvalidMissingSecretPolicy is an error returned when alerting.missing-secret-policy is neither warn nor fail
+	ErrInvalidMissingSecretPolicy = errors.New("invalid alerting.missing-secret-policy, must be one of: warn, fail")
+
+	// ErrMissingAlertingProviderSecret is an error returned when an alerting provider references an environment
+	// variable that is not set while alerting.missing-secret-policy is set to fail
+	ErrMissingAlertingProviderSecret = errors.New("alerting provider references an environment variable that is not set")
+
+	// ErrUnreadableAlertingProviderSecretFile is an error returned when an alerting provider references a secret file
+	// (e.g. file:/run/secrets/webhook-url) that cannot be read
+	ErrUnreadableAlertingProviderSecretFile = errors.New("alerting provider references a secret file that cannot be read")
+
+	// ErrDuplicateGroupName is an error returned when several groups have the same name
+	ErrDuplicateGroupName = errors.New("groups must not have the same name")
+
 	// errEarlyReturn is returned to break out of a loop from a callback early
 	errEarlyReturn = errors.New("early escape")
 )
@@ -66,15 +100,43 @@ type Config struct {
 	// Disabling this may lead to inaccurate response times
 	DisableMonitoringLock bool `yaml:"disable-monitoring-lock,omitempty"`
 
+	// ShutdownGracePeriod is the maximum amount of time to wait for in-flight checks to complete when shutting down
+	//
+	// Defaults to DefaultShutdownGracePeriod
+	ShutdownGracePeriod time.Duration `yaml:"shutdown-grace-period,omitempty"`
+
+	// UserAgent is the User-Agent header sent with the requests of every endpoint that doesn't specify its own.
+	// If empty, endpoint.GatusUserAgent is used.
+	UserAgent string `yaml:"user-agent,omitempty"`
+
+	// DefaultHeaders are headers sent with the requests of every endpoint.
+	// Headers configured on an endpoint take precedence over these.
+	DefaultHeaders map[string]string `yaml:"default-headers,omitempty"`
+
 	// Security is the configuration for securing access to Gatus
 	Security *security.Config `yaml:"security,omitempty"`
 
 	// Alerting is the configuration for alerting providers
 	Alerting *alerting.Config `yaml:"alerting,omitempty"`
 
+	// Groups is the configuration shared by the endpoints of each group, which is applied to every endpoint of the group
+	// that doesn't override it
+	Groups []*group.Config `yaml:"groups,omitempty"`
+
 	// Endpoints is the list of endpoints to monitor
 	Endpoints []*endpoint.Endpoint `yaml:"endpoints,omitempty"`
 
+	// RemoteConfigURL is the URL (http://, https:// or s3://) of a list of endpoints to monitor in addition to the
+	// endpoints of the configuration file. It is fetched every time the configuration is loaded.
+	RemoteConfigURL string `yaml:"remote-config-url,omitempty"`
+
+	// RemoteConfigHeaders are the headers sent when fetching RemoteConfigURL over HTTP(S), e.g. Authorization
+	RemoteConfigHeaders map[string]string `yaml:"remote-config-headers,omitempty"`
+
+	// RemoteConfigCachePath is the path of the file in which the last remote configuration successfully fetched is
+	// cached, so that it can be used if RemoteConfigURL cannot be fetched
+	RemoteConfigCachePath string `yaml:"remote-config-cache-path,omitempty"`
+
 	// ExternalEndpoints is the list of all external endpoints
 	ExternalEndpoints []*endpoint.ExternalEndpoint `yaml:"external-endpoints,omitempty"`
 
@@ -97,8 +159,25 @@ type Config struct {
 	// Connectivity is the configuration for connectivity
 	Connectivity *connectivity.Config `yaml:"connectivity,omitempty"`
 
+	// Heartbeat is the configuration of the heartbeats sent while Gatus is healthy, so that a dead man's switch can
+	// alert when Gatus itself stops working
+	Heartbeat *heartbeat.Config `yaml:"heartbeat,omitempty"`
+
+	// Shard is the configuration of the sharding of the endpoints between several instances of Gatus sharing the same
+	// storage. If set, this instance only monitors the endpoints assigned to it.
+	Shard *shard.Config `yaml:"shard,omitempty"`
+
+	// ResultWebhook is the configuration of the webhook every result of the endpoints that don't have a result webhook
+	// of their own is sent to
+	ResultWebhook *resultwebhook.Config `yaml:"result-webhook,omitempty"`
+
 	configPath      string    // path to the file or directory from which config was loaded
 	lastFileModTime time.Time // last modification time
+
+	referencedEnvironmentVariables []string // environment variables referenced by the configuration before expansion
+
+	lastReloadError      error        // error that occurred the last time the configuration was reloaded, if any
+	lastReloadErrorMutex sync.RWMutex // protects lastReloadError, which is read by the API
 }
 
 func (config *Config) GetEndpointByKey(key string) *endpoint.Endpoint {
@@ -146,9 +225,52 @@ func (config *Config) UpdateLastFileModTime() {
 	config.lastFileModTime = time.Now()
 }
 
+// SetLastReloadError records the error that occurred while trying to reload the configuration, or nil if the
+// configuration was reloaded successfully
+func (config *Config) SetLastReloadError(err error) {
+	config.lastReloadErrorMutex.Lock()
+	defer config.lastReloadErrorMutex.Unlock()
+	config.lastReloadError = err
+}
+
+// LastReloadError returns the error that occurred the last time the configuration was reloaded, if any
+func (config *Config) LastReloadError() error {
+	config.lastReloadErrorMutex.RLock()
+	defer config.lastReloadErrorMutex.RUnlock()
+	return config.lastReloadError
+}
+
 // LoadConfiguration loads the full configuration composed of the main configuration file
 // and all composed configuration files
 func LoadConfiguration(configPath string) (*Config, error) {
+	configBytes, usedConfigPath, err := readConfigurationBytes(configPath)
+	if err != nil {
+		return nil, err
+	}
+	configBytes, err = mergeRemoteConfig(configBytes)
+	if err != nil {
+		return nil, err
+	}
+	config, err := parseAndValidateConfigBytes(configBytes)
+	if err != nil {
+		return nil, err
+	}
+	config.configPath = usedConfigPath
+	config.referencedEnvironmentVariables = findReferencedEnvironmentVariables(configBytes)
+	config.UpdateLastFileModTime()
+	return config, err
+}
+
+// ReferencedEnvironmentVariables returns the names of the environment variables referenced by the configuration it was
+// loaded from. Since environment variables are typically used for secrets, this allows the values they were replaced
+// by to be redacted.
+func (config *Config) ReferencedEnvironmentVariables() []string {
+	return config.referencedEnvironmentVariables
+}
+
+// readConfigurationBytes reads the configuration file, or merges all configuration files if the configuration path is
+// a directory, and returns the resulting bytes as well as the configuration path that was used
+func readConfigurationBytes(configPath string) ([]byte, string, error) {
 	var configBytes []byte
 	var fileInfo os.FileInfo
 	var usedConfigPath string
@@ -166,9 +288,8 @@ func LoadConfiguration(configPath string) (*Config, error) {
 		break
 	}
 	if len(usedConfigPath) == 0 {
-		return nil, ErrConfigFileNotFound
+		return nil, "", ErrConfigFileNotFound
 	}
-	var config *Config
 	if fileInfo.IsDir() {
 		err := walkConfigDir(configPath, func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
@@ -185,26 +306,20 @@ func LoadConfiguration(configPath string) (*Config, error) {
 			return err
 		})
 		if err != nil {
-			return nil, fmt.Errorf("error reading configuration from directory %s: %w", usedConfigPath, err)
+			return nil, "", fmt.Errorf("error reading configuration from directory %s: %w", usedConfigPath, err)
 		}
 	} else {
 		log.Printf("[config.LoadConfiguration] Reading configuration from configFile=%s", usedConfigPath)
 		if data, err := os.ReadFile(usedConfigPath); err != nil {
-			return nil, err
+			return nil, "", err
 		} else {
 			configBytes = data
 		}
 	}
 	if len(configBytes) == 0 {
-		return nil, ErrConfigFileNotFound
-	}
-	config, err := parseAndValidateConfigBytes(configBytes)
-	if err != nil {
-		return nil, err
+		return nil, "", ErrConfigFileNotFound
 	}
-	config.configPath = usedConfigPath
-	config.UpdateLastFileModTime()
-	return config, err
+	return configBytes, usedConfigPath, nil
 }
 
 // walkConfigDir is a wrapper for filepath.WalkDir that strips directories and non-config files
@@ -230,48 +345,201 @@ func walkConfigDir(path string, fn fs.WalkDirFunc) error {
 
 // parseAndValidateConfigBytes parses a Gatus configuration file into a Config struct and validates its parameters
 func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
-	// Replace $$ with __GATUS_LITERAL_DOLLAR_SIGN__ to prevent os.ExpandEnv from treating "$$" as if it was an
-	// environment variable. This allows Gatus to support literal "$" in the configuration file.
-	yamlBytes = []byte(strings.ReplaceAll(string(yamlBytes), "$$", "__GATUS_LITERAL_DOLLAR_SIGN__"))
-	// Expand environment variables
-	yamlBytes = []byte(os.ExpandEnv(string(yamlBytes)))
-	// Replace __GATUS_LITERAL_DOLLAR_SIGN__ with "$" to restore the literal "$" in the configuration file
-	yamlBytes = []byte(strings.ReplaceAll(string(yamlBytes), "__GATUS_LITERAL_DOLLAR_SIGN__", "$"))
 	// Parse configuration file
-	if err = yaml.Unmarshal(yamlBytes, &config); err != nil {
+	if err = yaml.Unmarshal(expandEnvironmentVariables(yamlBytes), &config); err != nil {
 		return
 	}
 	// Check if the configuration file at least has endpoints configured
 	if config == nil || config.Endpoints == nil || len(config.Endpoints) == 0 {
 		err = ErrNoEndpointInConfig
 	} else {
-		validateAlertingConfig(config.Alerting, config.Endpoints, config.ExternalEndpoints, config.Debug)
-		if err := validateSecurityConfig(config); err != nil {
-			return nil, err
-		}
-		if err := validateEndpointsConfig(config); err != nil {
-			return nil, err
+		for _, validator := range configValidators {
+			if err := validator.validate(config, yamlBytes); err != nil {
+				return nil, err
+			}
 		}
-		if err := validateWebConfig(config); err != nil {
-			return nil, err
+	}
+	return
+}
+
+// configValidator validates, and sets the defaults of, a part of the configuration
+type configValidator struct {
+	// field is the configuration field the validator is about, which ValidateConfiguration reports along with the error
+	field string
+
+	// validate validates the part of the configuration the validator is about. yamlBytes is the configuration as it
+	// was before its environment variables were expanded.
+	validate func(config *Config, yamlBytes []byte) error
+
+	// reportEveryProblem, if set, is used by ValidateConfiguration instead of validate in order to report every
+	// problem found rather than only the first one
+	reportEveryProblem func(config *Config, result *ValidationResult)
+}
+
+// configValidators are the validators of the configuration, in the order in which they must run. They are shared by
+// parseAndValidateConfigBytes and ValidateConfiguration, so that both always validate the configuration the same way.
+var configValidators = []configValidator{
+	{field: "alerting", validate: func(config *Config, yamlBytes []byte) error {
+		return validateAlertingSecrets(config.Alerting, yamlBytes)
+	}},
+	{field: "alerting", validate: func(config *Config, _ []byte) error {
+		return validateAlertingTimestampConfig(config.Alerting)
+	}},
+	// Groups must be applied before the default alerts of the alerting providers, so that the thresholds of a group
+	// take precedence over those of the default alerts
+	{field: "groups", validate: ignoringYAMLBytes(validateGroupsConfig)},
+	{field: "alerting", validate: func(config *Config, _ []byte) error {
+		validateAlertingConfig(config.Alerting, config.Endpoints, config.ExternalEndpoints, config.Debug)
+		return nil
+	}},
+	{field: "security", validate: ignoringYAMLBytes(validateSecurityConfig)},
+	{field: "result-webhook", validate: ignoringYAMLBytes(validateResultWebhookConfig)},
+	{field: "endpoints", validate: ignoringYAMLBytes(validateEndpointsConfig), reportEveryProblem: func(config *Config, result *ValidationResult) {
+		validateEveryEndpoint(config, func(problem endpointProblem) bool {
+			result.addProblem(problem.endpointKey, problem.field, problem.err)
+			return true
+		})
+	}},
+	{field: "web", validate: ignoringYAMLBytes(validateWebConfig)},
+	{field: "ui", validate: func(config *Config, _ []byte) error {
+		// The page URLs depend on the web configuration, which must therefore have been validated first
+		applyEndpointPageURLs(config)
+		return validateUIConfig(config)
+	}},
+	{field: "maintenance", validate: ignoringYAMLBytes(validateMaintenanceConfig)},
+	{field: "storage", validate: ignoringYAMLBytes(validateStorageConfig)},
+	{field: "remote", validate: ignoringYAMLBytes(validateRemoteConfig)},
+	{field: "connectivity", validate: ignoringYAMLBytes(validateConnectivityConfig)},
+	{field: "shard", validate: ignoringYAMLBytes(validateShardConfig)},
+	{field: "heartbeat", validate: ignoringYAMLBytes(validateHeartbeatConfig)},
+	{field: "shutdown-grace-period", validate: ignoringYAMLBytes(validateShutdownGracePeriod)},
+}
+
+// ignoringYAMLBytes adapts a validator that only needs the parsed configuration to the signature of
+// configValidator.validate
+func ignoringYAMLBytes(validate func(config *Config) error) func(config *Config, yamlBytes []byte) error {
+	return func(config *Config, _ []byte) error {
+		return validate(config)
+	}
+}
+
+// expandEnvironmentVariables replaces the environment variables referenced in the configuration by their values
+func expandEnvironmentVariables(yamlBytes []byte) []byte {
+	// Replace $$ with __GATUS_LITERAL_DOLLAR_SIGN__ to prevent os.ExpandEnv from treating "$$" as if it was an
+	// environment variable. This allows Gatus to support literal "$" in the configuration file.
+	yamlBytes = []byte(strings.ReplaceAll(string(yamlBytes), "$$", "__GATUS_LITERAL_DOLLAR_SIGN__"))
+	// Expand environment variables
+	yamlBytes = []byte(os.ExpandEnv(string(yamlBytes)))
+	// Replace __GATUS_LITERAL_DOLLAR_SIGN__ with "$" to restore the literal "$" in the configuration file
+	return []byte(strings.ReplaceAll(string(yamlBytes), "__GATUS_LITERAL_DOLLAR_SIGN__", "$"))
+}
+
+// findReferencedEnvironmentVariables returns the environment variables referenced in yamlBytes, which
+// expandEnvironmentVariables replaces by their value
+func findReferencedEnvironmentVariables(yamlBytes []byte) []string {
+	var referencedEnvironmentVariables []string
+	os.Expand(strings.ReplaceAll(string(yamlBytes), "$$", ""), func(name string) string {
+		if !slices.Contains(referencedEnvironmentVariables, name) {
+			referencedEnvironmentVariables = append(referencedEnvironmentVariables, name)
 		}
-		if err := validateUIConfig(config); err != nil {
-			return nil, err
+		return ""
+	})
+	return referencedEnvironmentVariables
+}
+
+// findUnsetEnvironmentVariables returns the environment variables referenced in yamlBytes that are not set, which
+// expandEnvironmentVariables would silently replace by an empty string
+func findUnsetEnvironmentVariables(yamlBytes []byte) []string {
+	var unsetEnvironmentVariables []string
+	for _, name := range findReferencedEnvironmentVariables(yamlBytes) {
+		if _, exists := os.LookupEnv(name); !exists {
+			unsetEnvironmentVariables = append(unsetEnvironmentVariables, name)
 		}
-		if err := validateMaintenanceConfig(config); err != nil {
-			return nil, err
+	}
+	return unsetEnvironmentVariables
+}
+
+// validateAlertingTimestampConfig validates the configuration of how the [TIMESTAMP] placeholder is rendered
+func validateAlertingTimestampConfig(alertingConfig *alerting.Config) error {
+	if timestampConfig := alertingConfig.GetTimestampConfig(); timestampConfig != nil {
+		return timestampConfig.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+// validateAlertingSecrets checks that every environment variable referenced by the alerting providers is set, so that
+// a misconfigured secret is caught on startup rather than when the first alert is sent.
+// Whether a missing secret fails the validation or only logs a warning depends on alerting.missing-secret-policy.
+//
+// It also resolves the secret files referenced by the alerting providers, which always fails the validation if one of
+// them cannot be read.
+func validateAlertingSecrets(alertingConfig *alerting.Config, yamlBytes []byte) error {
+	if alertingConfig == nil {
+		return nil
+	}
+	switch alertingConfig.MissingSecretPolicy {
+	case "":
+		alertingConfig.MissingSecretPolicy = alerting.MissingSecretPolicyWarn
+	case alerting.MissingSecretPolicyWarn, alerting.MissingSecretPolicyFail:
+	default:
+		return ErrInvalidMissingSecretPolicy
+	}
+	// The environment variables must be looked up in the configuration before it was expanded
+	var rawConfig struct {
+		Alerting map[string]interface{} `yaml:"alerting"`
+	}
+	if err := yaml.Unmarshal(yamlBytes, &rawConfig); err != nil {
+		return fmt.Errorf("failed to look up the environment variables referenced by the alerting providers: %w", err)
+	}
+	providerNames := make([]string, 0, len(rawConfig.Alerting))
+	for providerName := range rawConfig.Alerting {
+		providerNames = append(providerNames, providerName)
+	}
+	sort.Strings(providerNames)
+	for _, providerName := range providerNames {
+		providerBytes, err := yaml.Marshal(rawConfig.Alerting[providerName])
+		if err != nil {
+			continue
 		}
-		if err := validateStorageConfig(config); err != nil {
-			return nil, err
+		unsetEnvironmentVariables := findUnsetEnvironmentVariables(providerBytes)
+		if len(unsetEnvironmentVariables) == 0 {
+			continue
 		}
-		if err := validateRemoteConfig(config); err != nil {
-			return nil, err
+		if alertingConfig.MissingSecretPolicy == alerting.MissingSecretPolicyFail {
+			return fmt.Errorf("%w: provider=%s; variables=%s", ErrMissingAlertingProviderSecret, providerName, strings.Join(unsetEnvironmentVariables, ","))
 		}
-		if err := validateConnectivityConfig(config); err != nil {
-			return nil, err
+		log.Printf("[config.validateAlertingSecrets] WARNING: provider=%s references environment variables that are not set: %s", providerName, strings.Join(unsetEnvironmentVariables, ","))
+	}
+	if err := alertingConfig.ResolveSecretFiles(); err != nil {
+		return fmt.Errorf("%w: %w", ErrUnreadableAlertingProviderSecretFile, err)
+	}
+	return nil
+}
+
+func validateShutdownGracePeriod(config *Config) error {
+	if config.ShutdownGracePeriod < 0 {
+		return ErrInvalidShutdownGracePeriod
+	} else if config.ShutdownGracePeriod == 0 {
+		config.ShutdownGracePeriod = DefaultShutdownGracePeriod
+	}
+	return nil
+}
+
+// validateResultWebhookConfig validates the global result webhook, if there is one, and makes it the result webhook of
+// every endpoint that doesn't have one of its own
+func validateResultWebhookConfig(config *Config) error {
+	if config.ResultWebhook == nil {
+		return nil
+	}
+	if err := config.ResultWebhook.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	for _, ep := range config.Endpoints {
+		if ep.ResultWebhook == nil {
+			ep.ResultWebhook = config.ResultWebhook
 		}
 	}
-	return
+	return nil
 }
 
 func validateConnectivityConfig(config *Config) error {
@@ -281,6 +549,48 @@ func validateConnectivityConfig(config *Config) error {
 	return nil
 }
 
+// validateGroupsConfig validates the configuration of the groups and applies it to the endpoints of each group
+func validateGroupsConfig(config *Config) error {
+	names := make(map[string]bool)
+	for _, g := range config.Groups {
+		if err := g.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid group %s: %w", g.Name, err)
+		}
+		if names[g.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateGroupName, g.Name)
+		}
+		names[g.Name] = true
+	}
+	if len(config.Groups) == 0 {
+		return nil
+	}
+	for _, ep := range config.Endpoints {
+		if g := group.Find(config.Groups, ep.Group); g != nil {
+			g.ApplyToEndpoint(ep)
+		}
+	}
+	for _, ee := range config.ExternalEndpoints {
+		if g := group.Find(config.Groups, ee.Group); g != nil {
+			g.ApplyToExternalEndpoint(ee)
+		}
+	}
+	return nil
+}
+
+func validateHeartbeatConfig(config *Config) error {
+	if config.Heartbeat != nil {
+		return config.Heartbeat.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+func validateShardConfig(config *Config) error {
+	if config.Shard != nil {
+		return config.Shard.ValidateAndSetDefaults(config.Endpoints)
+	}
+	return nil
+}
+
 func validateRemoteConfig(config *Config) error {
 	if config.Remote != nil {
 		if err := config.Remote.ValidateAndSetDefaults(); err != nil {
@@ -322,6 +632,9 @@ func validateUIConfig(config *Config) error {
 			return err
 		}
 	}
+	if config.Web != nil {
+		config.UI.ContextRoot = config.Web.ContextRoot
+	}
 	return nil
 }
 
@@ -334,38 +647,203 @@ func validateWebConfig(config *Config) error {
 	return nil
 }
 
+// endpointProblem is a problem found while validating the endpoints and external endpoints of the configuration
+type endpointProblem struct {
+	// endpointKey is the key of the endpoint or external endpoint the problem is about, if it's about a single one
+	endpointKey string
+
+	// isExternal is whether the problem is about an external endpoint
+	isExternal bool
+
+	// field is the field of the endpoint the problem is about, if known
+	field string
+
+	err error
+}
+
+func (problem endpointProblem) asError() error {
+	if len(problem.endpointKey) == 0 {
+		return problem.err
+	}
+	if problem.isExternal {
+		return fmt.Errorf("invalid external endpoint %s: %w", problem.endpointKey, problem.err)
+	}
+	return fmt.Errorf("invalid endpoint %s: %w", problem.endpointKey, problem.err)
+}
+
+// validateEndpointsConfig validates the endpoints and external endpoints of the configuration, and returns the first
+// problem found
 func validateEndpointsConfig(config *Config) error {
+	var err error
+	validateEveryEndpoint(config, func(problem endpointProblem) bool {
+		err = problem.asError()
+		return false
+	})
+	return err
+}
+
+// validateEveryEndpoint validates the endpoints and external endpoints of the configuration, and passes each problem
+// found to report, which returns whether the validation should go on
+func validateEveryEndpoint(config *Config, report func(problem endpointProblem) bool) {
 	duplicateValidationMap := make(map[string]bool)
+	duplicateIDValidationMap := make(map[string]bool)
 	// Validate endpoints
 	for _, ep := range config.Endpoints {
 		if config.Debug {
-			log.Printf("[config.validateEndpointsConfig] Validating endpoint '%s'", ep.Name)
+			log.Printf("[config.validateEveryEndpoint] Validating endpoint '%s'", ep.Name)
 		}
 		if endpointKey := ep.Key(); duplicateValidationMap[endpointKey] {
-			return fmt.Errorf("invalid endpoint %s: name and group combination must be unique", ep.Key())
+			if !report(endpointProblem{endpointKey: endpointKey, field: "name", err: errDuplicateEndpointKey}) {
+				return
+			}
+			continue
 		} else {
 			duplicateValidationMap[endpointKey] = true
 		}
+		if len(ep.ID) > 0 {
+			if duplicateIDValidationMap[ep.ID] {
+				if !report(endpointProblem{endpointKey: ep.Key(), field: "id", err: fmt.Errorf("id %s must be unique", ep.ID)}) {
+					return
+				}
+				continue
+			}
+			duplicateIDValidationMap[ep.ID] = true
+		}
+		applyDefaultHeaders(ep, config.UserAgent, config.DefaultHeaders)
 		if err := ep.ValidateAndSetDefaults(); err != nil {
-			return fmt.Errorf("invalid endpoint %s: %w", ep.Key(), err)
+			if !report(endpointProblem{endpointKey: ep.Key(), field: endpointFieldOf(err), err: err}) {
+				return
+			}
 		}
 	}
-	log.Printf("[config.validateEndpointsConfig] Validated %d endpoints", len(config.Endpoints))
+	log.Printf("[config.validateEveryEndpoint] Validated %d endpoints", len(config.Endpoints))
+	if err := validateEndpointDependencies(config.Endpoints); err != nil {
+		if !report(endpointProblem{field: "depends-on", err: err}) {
+			return
+		}
+	}
+	warnAboutInsecureEndpoints(config.Endpoints)
 	// Validate external endpoints
 	for _, ee := range config.ExternalEndpoints {
 		if config.Debug {
-			log.Printf("[config.validateEndpointsConfig] Validating external endpoint '%s'", ee.Name)
+			log.Printf("[config.validateEveryEndpoint] Validating external endpoint '%s'", ee.Name)
 		}
 		if endpointKey := ee.Key(); duplicateValidationMap[endpointKey] {
-			return fmt.Errorf("invalid external endpoint %s: name and group combination must be unique", ee.Key())
+			if !report(endpointProblem{endpointKey: endpointKey, isExternal: true, field: "name", err: errDuplicateEndpointKey}) {
+				return
+			}
+			continue
 		} else {
 			duplicateValidationMap[endpointKey] = true
 		}
+		if len(ee.ID) > 0 {
+			if duplicateIDValidationMap[ee.ID] {
+				if !report(endpointProblem{endpointKey: ee.Key(), isExternal: true, field: "id", err: fmt.Errorf("id %s must be unique", ee.ID)}) {
+					return
+				}
+				continue
+			}
+			duplicateIDValidationMap[ee.ID] = true
+		}
 		if err := ee.ValidateAndSetDefaults(); err != nil {
-			return fmt.Errorf("invalid external endpoint %s: %w", ee.Key(), err)
+			if !report(endpointProblem{endpointKey: ee.Key(), isExternal: true, field: endpointFieldOf(err), err: err}) {
+				return
+			}
+		}
+	}
+	log.Printf("[config.validateEveryEndpoint] Validated %d external endpoints", len(config.ExternalEndpoints))
+}
+
+// applyEndpointPageURLs sets the URL of the page of every endpoint and external endpoint on the dashboard, so that
+// alerts can link back to it
+func applyEndpointPageURLs(config *Config) {
+	for _, ep := range config.Endpoints {
+		ep.PageURL = config.Web.EndpointPageURL(ep.Key())
+	}
+	for _, ee := range config.ExternalEndpoints {
+		ee.PageURL = config.Web.EndpointPageURL(ee.Key())
+	}
+}
+
+// applyDefaultHeaders adds the global user agent and default headers to the headers of an endpoint, unless the
+// endpoint already has a header with the same name. Header names are case-insensitive.
+func applyDefaultHeaders(ep *endpoint.Endpoint, userAgent string, defaultHeaders map[string]string) {
+	if len(userAgent) == 0 && len(defaultHeaders) == 0 {
+		return
+	}
+	if ep.Headers == nil {
+		ep.Headers = make(map[string]string)
+	}
+	if len(userAgent) > 0 && !ep.HasHeader(endpoint.UserAgentHeader) {
+		ep.Headers[endpoint.UserAgentHeader] = userAgent
+	}
+	for name, value := range defaultHeaders {
+		if !ep.HasHeader(name) {
+			ep.Headers[name] = value
+		}
+	}
+}
+
+// warnAboutInsecureEndpoints logs a warning listing the endpoints that skip verifying the server's certificate chain
+// and host name, so that a temporary workaround for a self-signed certificate doesn't go unnoticed
+func warnAboutInsecureEndpoints(endpoints []*endpoint.Endpoint) {
+	var insecureEndpoints []string
+	for _, ep := range endpoints {
+		if ep.ClientConfig != nil && ep.ClientConfig.Insecure {
+			insecureEndpoints = append(insecureEndpoints, ep.Key())
+		}
+	}
+	if len(insecureEndpoints) > 0 {
+		log.Printf("[config.warnAboutInsecureEndpoints] WARNING: TLS certificate verification is disabled (client.insecure) for %d endpoint(s): %s", len(insecureEndpoints), strings.Join(insecureEndpoints, ", "))
+	}
+}
+
+// validateEndpointDependencies resolves the DependsOn of each endpoint into its Dependencies and makes sure that
+// the resulting dependency graph has no cycle
+func validateEndpointDependencies(endpoints []*endpoint.Endpoint) error {
+	endpointsByDisplayName := make(map[string]*endpoint.Endpoint, len(endpoints))
+	for _, ep := range endpoints {
+		endpointsByDisplayName[ep.DisplayName()] = ep
+	}
+	for _, ep := range endpoints {
+		ep.Dependencies = nil
+		for _, dependsOn := range ep.DependsOn {
+			dependency, exists := endpointsByDisplayName[dependsOn]
+			if !exists {
+				return fmt.Errorf("invalid endpoint %s: %w: %s", ep.Key(), ErrUnknownEndpointDependency, dependsOn)
+			}
+			ep.Dependencies = append(ep.Dependencies, dependency)
+		}
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make(map[*endpoint.Endpoint]int, len(endpoints))
+	var visit func(ep *endpoint.Endpoint, path []string) error
+	visit = func(ep *endpoint.Endpoint, path []string) error {
+		path = append(path, ep.DisplayName())
+		switch states[ep] {
+		case visiting:
+			return fmt.Errorf("invalid endpoint %s: %w: %s", ep.Key(), ErrEndpointDependencyCycle, strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+		states[ep] = visiting
+		for _, dependency := range ep.Dependencies {
+			if err := visit(dependency, path); err != nil {
+				return err
+			}
+		}
+		states[ep] = visited
+		return nil
+	}
+	for _, ep := range endpoints {
+		if err := visit(ep, nil); err != nil {
+			return err
 		}
 	}
-	log.Printf("[config.validateEndpointsConfig] Validated %d external endpoints", len(config.ExternalEndpoints))
 	return nil
 }
 
@@ -395,6 +873,7 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 	}
 	alertTypes := []alert.Type{
 		alert.TypeAWSSES,
+		alert.TypeAWSSNS,
 		alert.TypeCustom,
 		alert.TypeDiscord,
 		alert.TypeEmail,
@@ -403,7 +882,12 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 		alert.TypeGitea,
 		alert.TypeGoogleChat,
 		alert.TypeGotify,
+		alert.TypeHomeAssistant,
+		alert.TypeIlert,
+		alert.TypeIncidentIO,
 		alert.TypeJetBrainsSpace,
+		alert.TypeKafka,
+		alert.TypeLog,
 		alert.TypeMatrix,
 		alert.TypeMattermost,
 		alert.TypeMessagebird,
@@ -411,10 +895,14 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 		alert.TypeOpsgenie,
 		alert.TypePagerDuty,
 		alert.TypePushover,
+		alert.TypeRabbitMQ,
+		alert.TypeRocketChat,
 		alert.TypeSlack,
+		alert.TypeSplunk,
 		alert.TypeTeams,
 		alert.TypeTelegram,
 		alert.TypeTwilio,
+		alert.TypeWebex,
 		alert.TypeZulip,
 	}
 	var validProviders, invalidProviders []alert.Type
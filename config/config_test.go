@@ -1,10 +1,14 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"log"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,8 +35,11 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/resultwebhook"
+	"github.com/TwiN/gatus/v5/config/shard"
 	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/test"
 	"gopkg.in/yaml.v3"
 )
 
@@ -1677,6 +1684,21 @@ endpoints:
     url: https://twin.sh/health
     conditions:
       - "[STATUS] == 200"`,
+		},
+		{
+			name:        "same-id-different-name",
+			shouldError: true,
+			config: `
+endpoints:
+  - id: website
+    name: ep1
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - id: website
+    name: ep2
+    token: "token"`,
 		},
 		{
 			name:        "same-name-different-group",
@@ -1751,6 +1773,99 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithEndpointDependencies(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		expectedError error
+		config        string
+	}{
+		{
+			name: "chain",
+			config: `
+endpoints:
+  - name: gateway
+    group: core
+    url: https://example.org/gateway
+    conditions:
+      - "[STATUS] == 200"
+  - name: api
+    url: https://example.org/api
+    depends-on: ["core/gateway"]
+    conditions:
+      - "[STATUS] == 200"
+  - name: frontend
+    url: https://example.org
+    depends-on: ["api"]
+    conditions:
+      - "[STATUS] == 200"`,
+		},
+		{
+			name:          "unknown-dependency",
+			expectedError: ErrUnknownEndpointDependency,
+			config: `
+endpoints:
+  - name: api
+    url: https://example.org/api
+    depends-on: ["gateway"]
+    conditions:
+      - "[STATUS] == 200"`,
+		},
+		{
+			name:          "self-dependency",
+			expectedError: ErrEndpointDependencyCycle,
+			config: `
+endpoints:
+  - name: api
+    url: https://example.org/api
+    depends-on: ["api"]
+    conditions:
+      - "[STATUS] == 200"`,
+		},
+		{
+			name:          "cycle",
+			expectedError: ErrEndpointDependencyCycle,
+			config: `
+endpoints:
+  - name: gateway
+    url: https://example.org/gateway
+    depends-on: ["frontend"]
+    conditions:
+      - "[STATUS] == 200"
+  - name: api
+    url: https://example.org/api
+    depends-on: ["gateway"]
+    conditions:
+      - "[STATUS] == 200"
+  - name: frontend
+    url: https://example.org
+    depends-on: ["api"]
+    conditions:
+      - "[STATUS] == 200"`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg, err := parseAndValidateConfigBytes([]byte(scenario.config))
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			for _, ep := range cfg.Endpoints {
+				if len(ep.Dependencies) != len(ep.DependsOn) {
+					t.Errorf("expected endpoint %s to have %d dependencies, got %d", ep.Key(), len(ep.DependsOn), len(ep.Dependencies))
+				}
+				for i, dependency := range ep.Dependencies {
+					if dependency.DisplayName() != ep.DependsOn[i] {
+						t.Errorf("expected dependency %s, got %s", ep.DependsOn[i], dependency.DisplayName())
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestParseAndValidateConfigBytesWithInvalidStorageConfig(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(`
 storage:
@@ -1766,6 +1881,488 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithShutdownGracePeriod(t *testing.T) {
+	scenarios := []struct {
+		name                        string
+		shutdownGracePeriod         string
+		expectedShutdownGracePeriod time.Duration
+		expectedErr                 error
+	}{
+		{
+			name:                        "default",
+			shutdownGracePeriod:         "",
+			expectedShutdownGracePeriod: DefaultShutdownGracePeriod,
+		},
+		{
+			name:                        "custom",
+			shutdownGracePeriod:         "shutdown-grace-period: 30s",
+			expectedShutdownGracePeriod: 30 * time.Second,
+		},
+		{
+			name:                "negative",
+			shutdownGracePeriod: "shutdown-grace-period: -1s",
+			expectedErr:         ErrInvalidShutdownGracePeriod,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config, err := parseAndValidateConfigBytes([]byte(scenario.shutdownGracePeriod + `
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && config.ShutdownGracePeriod != scenario.expectedShutdownGracePeriod {
+				t.Errorf("expected shutdown grace period to be %s, got %s", scenario.expectedShutdownGracePeriod, config.ShutdownGracePeriod)
+			}
+		})
+	}
+}
+
+func TestParseAndValidateConfigBytesWithGroups(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "https://example.com"
+    default-alert:
+      failure-threshold: 7
+      success-threshold: 6
+groups:
+  - name: core
+    interval: 5m
+    failure-threshold: 10
+  - name: "internal/*"
+    success-threshold: 4
+endpoints:
+  - name: inherits
+    group: core
+    url: https://example.org
+    alerts:
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"
+  - name: overrides
+    group: core
+    url: https://example.org
+    interval: 30s
+    alerts:
+      - type: slack
+        failure-threshold: 2
+    conditions:
+      - "[STATUS] == 200"
+  - name: pattern
+    group: internal/nas
+    url: https://example.org
+    alerts:
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"
+  - name: no-group
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	scenarios := []struct {
+		endpoint                 *endpoint.Endpoint
+		expectedInterval         time.Duration
+		expectedFailureThreshold int
+		expectedSuccessThreshold int
+	}{
+		// The success threshold comes from the provider's default alert, since the group doesn't have one
+		{endpoint: config.Endpoints[0], expectedInterval: 5 * time.Minute, expectedFailureThreshold: 10, expectedSuccessThreshold: 6},
+		{endpoint: config.Endpoints[1], expectedInterval: 30 * time.Second, expectedFailureThreshold: 2, expectedSuccessThreshold: 6},
+		{endpoint: config.Endpoints[2], expectedInterval: time.Minute, expectedFailureThreshold: 7, expectedSuccessThreshold: 4},
+		{endpoint: config.Endpoints[3], expectedInterval: time.Minute},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.endpoint.Name, func(t *testing.T) {
+			if scenario.endpoint.Interval != scenario.expectedInterval {
+				t.Errorf("expected interval to be %s, got %s", scenario.expectedInterval, scenario.endpoint.Interval)
+			}
+			if len(scenario.endpoint.Alerts) == 0 {
+				return
+			}
+			if alert := scenario.endpoint.Alerts[0]; alert.FailureThreshold != scenario.expectedFailureThreshold || alert.SuccessThreshold != scenario.expectedSuccessThreshold {
+				t.Errorf("expected thresholds to be %d and %d, got %d and %d", scenario.expectedFailureThreshold, scenario.expectedSuccessThreshold, alert.FailureThreshold, alert.SuccessThreshold)
+			}
+		})
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+groups:
+  - name: core
+  - name: core
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrDuplicateGroupName) {
+		t.Errorf("expected error %v, got %v", ErrDuplicateGroupName, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithShard(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+shard:
+  instance: us-east
+  instances: [us-east, eu-west]
+  label: region
+endpoints:
+  - name: east
+    url: https://example.org
+    labels:
+      region: us-east
+    conditions:
+      - "[STATUS] == 200"
+  - name: west
+    url: https://example.org
+    labels:
+      region: eu-west
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !config.Shard.IsResponsibleFor(config.Endpoints[0]) {
+		t.Error("expected us-east to be responsible for the endpoint labeled with its name")
+	}
+	if config.Shard.IsResponsibleFor(config.Endpoints[1]) {
+		t.Error("expected us-east not to be responsible for the endpoint labeled with eu-west")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+shard:
+  instance: ap-south
+  instances: [us-east, eu-west]
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, shard.ErrInstanceNotInInstances) {
+		t.Errorf("expected error %v, got %v", shard.ErrInstanceNotInInstances, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithAuthFromEnvironmentVariables(t *testing.T) {
+	t.Setenv("GATUS_TEST_BASIC_AUTH_PASSWORD", "hunter2")
+	t.Setenv("GATUS_TEST_DIGEST_AUTH_PASSWORD", "hunter3")
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: basic
+    url: https://example.org
+    basic-auth:
+      username: john.doe
+      password: "${GATUS_TEST_BASIC_AUTH_PASSWORD}"
+    conditions:
+      - "[STATUS] == 200"
+  - name: digest
+    url: https://example.org
+    digest-auth:
+      username: jane.doe
+      password: "${GATUS_TEST_DIGEST_AUTH_PASSWORD}"
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].BasicAuth == nil || config.Endpoints[0].BasicAuth.Username != "john.doe" || config.Endpoints[0].BasicAuth.Password != "hunter2" {
+		t.Errorf("expected basic-auth credentials to be john.doe:hunter2, got %+v", config.Endpoints[0].BasicAuth)
+	}
+	if config.Endpoints[1].DigestAuth == nil || config.Endpoints[1].DigestAuth.Username != "jane.doe" || config.Endpoints[1].DigestAuth.Password != "hunter3" {
+		t.Errorf("expected digest-auth credentials to be jane.doe:hunter3, got %+v", config.Endpoints[1].DigestAuth)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithMissingAlertingProviderSecret(t *testing.T) {
+	t.Setenv("GATUS_TEST_SLACK_WEBHOOK_URL", "https://example.com/slack")
+	buildConfigBytes := func(missingSecretPolicy string) []byte {
+		return []byte(`
+alerting:
+  missing-secret-policy: "` + missingSecretPolicy + `"
+  slack:
+    webhook-url: "${GATUS_TEST_SLACK_WEBHOOK_URL}"
+  discord:
+    webhook-url: "https://discord.com/api/webhooks/${GATUS_TEST_MISSING_DISCORD_WEBHOOK_ID}"
+  custom:
+    url: "https://example.com/$$escaped"
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`)
+	}
+	t.Run("fail", func(t *testing.T) {
+		_, err := parseAndValidateConfigBytes(buildConfigBytes("fail"))
+		if !errors.Is(err, ErrMissingAlertingProviderSecret) {
+			t.Fatalf("expected error %v, got %v", ErrMissingAlertingProviderSecret, err)
+		}
+		if !strings.Contains(err.Error(), "provider=discord") || !strings.Contains(err.Error(), "GATUS_TEST_MISSING_DISCORD_WEBHOOK_ID") {
+			t.Errorf("expected error to name the provider and the missing variable, got %q", err.Error())
+		}
+		if strings.Contains(err.Error(), "slack") || strings.Contains(err.Error(), "escaped") {
+			t.Errorf("expected error to only name the provider with a missing variable, got %q", err.Error())
+		}
+	})
+	t.Run("warn", func(t *testing.T) {
+		config, err := parseAndValidateConfigBytes(buildConfigBytes(""))
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if config.Alerting.MissingSecretPolicy != alerting.MissingSecretPolicyWarn {
+			t.Errorf("expected missing secret policy to default to %s, got %s", alerting.MissingSecretPolicyWarn, config.Alerting.MissingSecretPolicy)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := parseAndValidateConfigBytes(buildConfigBytes("ignore")); !errors.Is(err, ErrInvalidMissingSecretPolicy) {
+			t.Errorf("expected error %v, got %v", ErrInvalidMissingSecretPolicy, err)
+		}
+	})
+	t.Run("unparsable", func(t *testing.T) {
+		// The secrets must not be considered to be set just because the configuration they're looked up in is invalid
+		if err := validateAlertingSecrets(&alerting.Config{}, []byte("alerting: [")); err == nil {
+			t.Error("expected an error, because the configuration could not be parsed")
+		}
+	})
+}
+
+func TestParseAndValidateConfigBytesWithAlertingProviderSecretFile(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "slack-webhook-url")
+	if err := os.WriteFile(secretFile, []byte("https://example.com/slack\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	buildConfigBytes := func(path string) []byte {
+		return []byte(`
+alerting:
+  slack:
+    webhook-url: "file:` + path + `"
+endpoints:
+  - name: website
+    url: https://example.org
+    alerts:
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"
+`)
+	}
+	t.Run("readable", func(t *testing.T) {
+		config, err := parseAndValidateConfigBytes(buildConfigBytes(secretFile))
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if config.Alerting.Slack.WebhookURL != "https://example.com/slack" {
+			t.Errorf("expected webhook-url to be read from the secret file, got %q", config.Alerting.Slack.WebhookURL)
+		}
+	})
+	t.Run("missing", func(t *testing.T) {
+		_, err := parseAndValidateConfigBytes(buildConfigBytes(secretFile + "-missing"))
+		if !errors.Is(err, ErrUnreadableAlertingProviderSecretFile) {
+			t.Fatalf("expected error %v, got %v", ErrUnreadableAlertingProviderSecretFile, err)
+		}
+		if !strings.Contains(err.Error(), "provider=slack") || !strings.Contains(err.Error(), secretFile+"-missing") {
+			t.Errorf("expected error to name the provider and the file, got %q", err.Error())
+		}
+	})
+}
+
+func TestParseAndValidateConfigBytesWithAlertingTimestamp(t *testing.T) {
+	buildConfigBytes := func(timezone string) []byte {
+		return []byte(`
+alerting:
+  timestamp:
+    format: "2006-01-02 15:04 MST"
+    timezone: "` + timezone + `"
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`)
+	}
+	config, err := parseAndValidateConfigBytes(buildConfigBytes("America/Toronto"))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Alerting.GetTimestampConfig().Format != "2006-01-02 15:04 MST" {
+		t.Errorf("expected the timestamp format to be parsed, got %q", config.Alerting.GetTimestampConfig().Format)
+	}
+	if _, err := parseAndValidateConfigBytes(buildConfigBytes("Toronto")); !errors.Is(err, alert.ErrInvalidTimestampTimezone) {
+		t.Errorf("expected error %v, got %v", alert.ErrInvalidTimestampTimezone, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithResultWebhook(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+result-webhook:
+  url: https://example.org/results
+  queue-size: 10
+endpoints:
+  - name: global
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: own
+    url: https://example.org
+    result-webhook:
+      url: https://example.com/results
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].ResultWebhook != config.ResultWebhook {
+		t.Error("expected the endpoint without a result webhook to use the global result webhook")
+	}
+	if config.ResultWebhook.QueueSize != 10 {
+		t.Errorf("expected the queue size to be 10, got %d", config.ResultWebhook.QueueSize)
+	}
+	if config.Endpoints[1].ResultWebhook.URL != "https://example.com/results" || config.Endpoints[1].ResultWebhook.QueueSize != resultwebhook.DefaultQueueSize {
+		t.Error("expected the endpoint with a result webhook of its own to keep it, with the default queue size")
+	}
+	if _, err := parseAndValidateConfigBytes([]byte(`
+result-webhook:
+  url: example.org/results
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`)); !errors.Is(err, resultwebhook.ErrInvalidURL) {
+		t.Errorf("expected error %v, got %v", resultwebhook.ErrInvalidURL, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithUserAgentAndDefaultHeaders(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+user-agent: "Gatus/5.0 (+https://status.example.org)"
+default-headers:
+  X-Monitored-By: gatus
+  Accept: application/json
+endpoints:
+  - name: defaults
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: overrides
+    url: https://example.org
+    headers:
+      user-agent: custom-agent
+      Accept: text/html
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		endpoint        *endpoint.Endpoint
+		expectedHeaders map[string]string
+	}{
+		{
+			endpoint: config.Endpoints[0],
+			expectedHeaders: map[string]string{
+				"User-Agent":     "Gatus/5.0 (+https://status.example.org)",
+				"X-Monitored-By": "gatus",
+				"Accept":         "application/json",
+			},
+		},
+		{
+			endpoint: config.Endpoints[1],
+			expectedHeaders: map[string]string{
+				"User-Agent":     "custom-agent",
+				"X-Monitored-By": "gatus",
+				"Accept":         "text/html",
+			},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.endpoint.Name, func(t *testing.T) {
+			var requestHeaders http.Header
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				requestHeaders = r.Header
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			})})
+			defer client.InjectHTTPClient(nil)
+			scenario.endpoint.EvaluateHealth()
+			for name, expectedValue := range scenario.expectedHeaders {
+				if actualValue := requestHeaders.Get(name); actualValue != expectedValue {
+					t.Errorf("expected header %s to be %q, got %q", name, expectedValue, actualValue)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAndValidateConfigBytesWithoutUserAgent(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if userAgent := config.Endpoints[0].Headers[endpoint.UserAgentHeader]; userAgent != endpoint.GatusUserAgent {
+		t.Errorf("expected the user agent to default to %s, got %s", endpoint.GatusUserAgent, userAgent)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithInsecureEndpoint(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: self-signed
+    group: internal
+    url: https://self-signed.internal
+    client:
+      insecure: true
+    conditions:
+      - "[STATUS] == 200"
+  - name: public
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: public-with-client
+    url: https://example.com
+    client:
+      timeout: 5s
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for _, ep := range config.Endpoints {
+		transport, ok := client.GetHTTPClient(ep.ClientConfig).Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("expected the transport of endpoint %s to be an *http.Transport", ep.Name)
+		}
+		expectedInsecureSkipVerify := ep.Name == "self-signed"
+		if transport.TLSClientConfig.InsecureSkipVerify != expectedInsecureSkipVerify {
+			t.Errorf("expected InsecureSkipVerify of endpoint %s to be %v, got %v", ep.Name, expectedInsecureSkipVerify, transport.TLSClientConfig.InsecureSkipVerify)
+		}
+	}
+	if !strings.Contains(logs.String(), "TLS certificate verification is disabled (client.insecure) for 1 endpoint(s): internal_self-signed") {
+		t.Errorf("expected a warning listing the insecure endpoint, got logs:\n%s", logs.String())
+	}
+}
+
 func TestParseAndValidateConfigBytesWithInvalidYAML(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(`
 storage:
@@ -1862,6 +2459,51 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithPublicURL(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+web:
+  public-url: https://example.org/status/
+endpoints:
+  - name: website
+    group: core
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - name: ext-ep-test
+    group: core
+    token: "potato"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if expected := "https://example.org/status/endpoints/core_website"; config.Endpoints[0].PageURL != expected {
+		t.Errorf("expected page URL of endpoint to be %s, got %s", expected, config.Endpoints[0].PageURL)
+	}
+	if expected := "https://example.org/status/endpoints/core_ext-ep-test"; config.ExternalEndpoints[0].PageURL != expected {
+		t.Errorf("expected page URL of external endpoint to be %s, got %s", expected, config.ExternalEndpoints[0].PageURL)
+	}
+	if expected := "https://example.org/status/endpoints/core_ext-ep-test"; config.ExternalEndpoints[0].ToEndpoint().AlertMessageContext(nil).EndpointURL != expected {
+		t.Errorf("expected page URL to be passed to the alert message context, got %s", config.ExternalEndpoints[0].ToEndpoint().AlertMessageContext(nil).EndpointURL)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithoutPublicURL(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints[0].PageURL) != 0 {
+		t.Errorf("expected page URL of endpoint to be empty, got %s", config.Endpoints[0].PageURL)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithNoEndpoints(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(``))
 	if !errors.Is(err, ErrNoEndpointInConfig) {
@@ -0,0 +1,137 @@
+package endpoint
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+const (
+	// WWWAuthenticateHeader is the name of the header used by a server to challenge a client to authenticate
+	WWWAuthenticateHeader = "WWW-Authenticate"
+
+	digestAuthScheme = "Digest"
+)
+
+var (
+	// ErrEndpointWithInvalidBasicAuth is the error with which Gatus will panic if an endpoint has a basic-auth
+	// configuration without a username
+	ErrEndpointWithInvalidBasicAuth = errors.New("invalid basic-auth configuration: username must be specified")
+
+	// ErrEndpointWithInvalidDigestAuth is the error with which Gatus will panic if an endpoint has a digest-auth
+	// configuration without a username
+	ErrEndpointWithInvalidDigestAuth = errors.New("invalid digest-auth configuration: username must be specified")
+
+	// ErrEndpointWithMultipleAuthSchemes is the error with which Gatus will panic if an endpoint has both a
+	// basic-auth and a digest-auth configuration
+	ErrEndpointWithMultipleAuthSchemes = errors.New("basic-auth and digest-auth cannot be used at the same time")
+
+	// ErrInvalidDigestChallenge is the error returned when the digest challenge sent by a server cannot be answered
+	ErrInvalidDigestChallenge = errors.New("invalid digest challenge")
+)
+
+// BasicAuthConfig is the configuration for authenticating with the HTTP Basic authentication scheme
+type BasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// DigestAuthConfig is the configuration for authenticating with the HTTP Digest authentication scheme
+type DigestAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// authorization returns the value of the Authorization header answering the digest challenge passed as parameter
+func (c *DigestAuthConfig) authorization(challenge, method, uri string) (string, error) {
+	if !strings.HasPrefix(challenge, digestAuthScheme+" ") {
+		return "", ErrInvalidDigestChallenge
+	}
+	params := parseDigestChallenge(strings.TrimPrefix(challenge, digestAuthScheme+" "))
+	realm, nonce, algorithm := params["realm"], params["nonce"], params["algorithm"]
+	if len(nonce) == 0 {
+		return "", fmt.Errorf("%w: missing nonce", ErrInvalidDigestChallenge)
+	}
+	isSessionAlgorithm := strings.HasSuffix(strings.ToUpper(algorithm), "-SESS")
+	var newHash func() hash.Hash
+	switch strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS") {
+	case "", "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", fmt.Errorf("%w: unsupported algorithm %s", ErrInvalidDigestChallenge, algorithm)
+	}
+	h := func(s string) string {
+		hasher := newHash()
+		hasher.Write([]byte(s))
+		return hex.EncodeToString(hasher.Sum(nil))
+	}
+	cnonceBytes := make([]byte, 16)
+	if _, err := rand.Read(cnonceBytes); err != nil {
+		return "", err
+	}
+	cnonce, nc := hex.EncodeToString(cnonceBytes), "00000001"
+	ha1 := h(c.Username + ":" + realm + ":" + c.Password)
+	if isSessionAlgorithm {
+		ha1 = h(ha1 + ":" + nonce + ":" + cnonce)
+	}
+	ha2 := h(method + ":" + uri)
+	supportsQOPAuth := false
+	for _, qop := range strings.Split(params["qop"], ",") {
+		if strings.TrimSpace(qop) == "auth" {
+			supportsQOPAuth = true
+		}
+	}
+	var response string
+	if supportsQOPAuth {
+		response = h(ha1 + ":" + nonce + ":" + nc + ":" + cnonce + ":auth:" + ha2)
+	} else {
+		response = h(ha1 + ":" + nonce + ":" + ha2)
+	}
+	authorization := fmt.Sprintf(`%s username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`, digestAuthScheme, c.Username, realm, nonce, uri, response)
+	if len(algorithm) > 0 {
+		authorization += ", algorithm=" + algorithm
+	}
+	if opaque, exists := params["opaque"]; exists {
+		authorization += fmt.Sprintf(`, opaque="%s"`, opaque)
+	}
+	if supportsQOPAuth {
+		authorization += fmt.Sprintf(`, qop=auth, nc=%s, cnonce="%s"`, nc, cnonce)
+	}
+	return authorization, nil
+}
+
+// parseDigestChallenge parses the comma-separated key=value pairs of a digest challenge, whose values may be quoted
+func parseDigestChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+	for len(challenge) > 0 {
+		challenge = strings.TrimLeft(challenge, " ,")
+		separatorIndex := strings.Index(challenge, "=")
+		if separatorIndex == -1 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(challenge[:separatorIndex]))
+		challenge = strings.TrimSpace(challenge[separatorIndex+1:])
+		var value string
+		if strings.HasPrefix(challenge, `"`) {
+			closingQuoteIndex := strings.Index(challenge[1:], `"`)
+			if closingQuoteIndex == -1 {
+				value, challenge = challenge[1:], ""
+			} else {
+				value, challenge = challenge[1:closingQuoteIndex+1], challenge[closingQuoteIndex+2:]
+			}
+		} else if commaIndex := strings.Index(challenge, ","); commaIndex != -1 {
+			value, challenge = strings.TrimSpace(challenge[:commaIndex]), challenge[commaIndex+1:]
+		} else {
+			value, challenge = strings.TrimSpace(challenge), ""
+		}
+		params[key] = value
+	}
+	return params
+}
@@ -0,0 +1,150 @@
+package endpoint
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEndpoint_EvaluateHealthWithBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "john.doe" || password != "hunter2" {
+			w.Header().Set(WWWAuthenticateHeader, `Basic realm="gatus"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name           string
+		basicAuth      *BasicAuthConfig
+		expectedStatus int
+	}{
+		{name: "valid-credentials", basicAuth: &BasicAuthConfig{Username: "john.doe", Password: "hunter2"}, expectedStatus: http.StatusOK},
+		{name: "invalid-credentials", basicAuth: &BasicAuthConfig{Username: "john.doe", Password: "wrong"}, expectedStatus: http.StatusUnauthorized},
+		{name: "no-credentials", basicAuth: nil, expectedStatus: http.StatusUnauthorized},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{Name: "basic-auth", URL: server.URL, BasicAuth: scenario.basicAuth, Conditions: []Condition{"[STATUS] == 200"}}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			if result := endpoint.EvaluateHealth(); result.HTTPStatus != scenario.expectedStatus {
+				t.Errorf("expected status %d, got %d", scenario.expectedStatus, result.HTTPStatus)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithDigestAuth(t *testing.T) {
+	const realm, nonce, opaque = "gatus", "dcd98b7102dd2f0e8b11d0f600bfb0c093", "5ccc069c403ebaf9f0171e9517f40e41"
+	md5Hex := func(s string) string {
+		sum := md5.Sum([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+	numberOfRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfRequests++
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "Digest ") {
+			w.Header().Set(WWWAuthenticateHeader, `Digest realm="`+realm+`", qop="auth,auth-int", nonce="`+nonce+`", opaque="`+opaque+`"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		params := parseDigestChallenge(strings.TrimPrefix(authorization, "Digest "))
+		ha1 := md5Hex("john.doe:" + realm + ":hunter2")
+		ha2 := md5Hex(r.Method + ":" + r.URL.RequestURI())
+		expectedResponse := md5Hex(ha1 + ":" + nonce + ":" + params["nc"] + ":" + params["cnonce"] + ":" + params["qop"] + ":" + ha2)
+		if params["response"] != expectedResponse || params["opaque"] != opaque || params["uri"] != r.URL.RequestURI() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                     string
+		digestAuth               *DigestAuthConfig
+		expectedStatus           int
+		expectedNumberOfRequests int
+	}{
+		{name: "valid-credentials", digestAuth: &DigestAuthConfig{Username: "john.doe", Password: "hunter2"}, expectedStatus: http.StatusOK, expectedNumberOfRequests: 2},
+		{name: "invalid-credentials", digestAuth: &DigestAuthConfig{Username: "john.doe", Password: "wrong"}, expectedStatus: http.StatusUnauthorized, expectedNumberOfRequests: 2},
+		{name: "no-credentials", digestAuth: nil, expectedStatus: http.StatusUnauthorized, expectedNumberOfRequests: 1},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			numberOfRequests = 0
+			endpoint := Endpoint{Name: "digest-auth", URL: server.URL + "/protected?verbose=true", Method: http.MethodPost, Body: `{"hello":"world"}`, DigestAuth: scenario.digestAuth, Conditions: []Condition{"[STATUS] == 200"}}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			if result := endpoint.EvaluateHealth(); result.HTTPStatus != scenario.expectedStatus {
+				t.Errorf("expected status %d, got %d", scenario.expectedStatus, result.HTTPStatus)
+			}
+			if numberOfRequests != scenario.expectedNumberOfRequests {
+				t.Errorf("expected %d requests, got %d", scenario.expectedNumberOfRequests, numberOfRequests)
+			}
+		})
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithAuth(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		basicAuth   *BasicAuthConfig
+		digestAuth  *DigestAuthConfig
+		expectedErr error
+	}{
+		{name: "basic-auth", basicAuth: &BasicAuthConfig{Username: "john.doe", Password: "hunter2"}},
+		{name: "digest-auth", digestAuth: &DigestAuthConfig{Username: "john.doe", Password: "hunter2"}},
+		{name: "basic-auth-without-username", basicAuth: &BasicAuthConfig{Password: "hunter2"}, expectedErr: ErrEndpointWithInvalidBasicAuth},
+		{name: "digest-auth-without-username", digestAuth: &DigestAuthConfig{Password: "hunter2"}, expectedErr: ErrEndpointWithInvalidDigestAuth},
+		{name: "both", basicAuth: &BasicAuthConfig{Username: "john.doe"}, digestAuth: &DigestAuthConfig{Username: "john.doe"}, expectedErr: ErrEndpointWithMultipleAuthSchemes},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{Name: "auth", URL: "https://example.com", BasicAuth: scenario.basicAuth, DigestAuth: scenario.digestAuth, Conditions: []Condition{"[STATUS] == 200"}}
+			if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDigestAuthConfig_authorization(t *testing.T) {
+	config := &DigestAuthConfig{Username: "Mufasa", Password: "Circle Of Life"}
+	// Example from RFC 2069, which has no qop. Note that the response in the RFC itself is wrong (see errata).
+	authorization, err := config.authorization(`Digest realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", opaque="5ccc069c403ebaf9f0171e9517f40e41"`, http.MethodGet, "/dir/index.html")
+	if err != nil {
+		t.Fatal("did not expect an error, got", err)
+	}
+	if params := parseDigestChallenge(strings.TrimPrefix(authorization, "Digest ")); params["response"] != "670fd8c2df070c60b045671b8b24ff02" {
+		t.Errorf("expected response to be 670fd8c2df070c60b045671b8b24ff02, got %s", params["response"])
+	}
+	if _, err = config.authorization(`Basic realm="testrealm@host.com"`, http.MethodGet, "/"); !errors.Is(err, ErrInvalidDigestChallenge) {
+		t.Errorf("expected %v, got %v", ErrInvalidDigestChallenge, err)
+	}
+	if _, err = config.authorization(`Digest realm="testrealm@host.com"`, http.MethodGet, "/"); !errors.Is(err, ErrInvalidDigestChallenge) {
+		t.Errorf("expected %v, because the nonce is missing, got %v", ErrInvalidDigestChallenge, err)
+	}
+	if _, err = config.authorization(`Digest realm="testrealm@host.com", nonce="abc", algorithm=SHA-512-256`, http.MethodGet, "/"); !errors.Is(err, ErrInvalidDigestChallenge) {
+		t.Errorf("expected %v, because the algorithm is not supported, got %v", ErrInvalidDigestChallenge, err)
+	}
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	params := parseDigestChallenge(`realm="a, b", qop="auth,auth-int", nonce=abc, algorithm=MD5`)
+	expected := map[string]string{"realm": "a, b", "qop": "auth,auth-int", "nonce": "abc", "algorithm": "MD5"}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, params[key])
+		}
+	}
+}
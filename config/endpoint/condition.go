@@ -3,8 +3,10 @@ package endpoint
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/jsonpath"
@@ -18,6 +20,11 @@ const (
 	// Values that could replace the placeholder: 200, 404, 500, ...
 	StatusPlaceholder = "[STATUS]"
 
+	// StatusTextPlaceholder is a placeholder for the reason phrase of a HTTP status.
+	//
+	// Values that could replace the placeholder: OK, Not Found, Service Unavailable, ...
+	StatusTextPlaceholder = "[STATUS_TEXT]"
+
 	// IPPlaceholder is a placeholder for an IP.
 	//
 	// Values that could replace the placeholder: 127.0.0.1, 10.0.0.1, ...
@@ -33,11 +40,70 @@ const (
 	// Values that could replace the placeholder: 1, 500, 1000, ...
 	ResponseTimePlaceholder = "[RESPONSE_TIME]"
 
+	// ResponseTimeP50Placeholder is a placeholder for the median response time, in milliseconds, over the endpoint's
+	// last Endpoint.ResponseTimeWindow evaluations, including the current one.
+	//
+	// Values that could replace the placeholder: 1, 500, 1000, ...
+	ResponseTimeP50Placeholder = "[RESPONSE_TIME_P50]"
+
+	// ResponseTimeP95Placeholder is a placeholder for the 95th percentile of the response time, in milliseconds, over
+	// the endpoint's last Endpoint.ResponseTimeWindow evaluations, including the current one.
+	ResponseTimeP95Placeholder = "[RESPONSE_TIME_P95]"
+
+	// ResponseTimeP99Placeholder is a placeholder for the 99th percentile of the response time, in milliseconds, over
+	// the endpoint's last Endpoint.ResponseTimeWindow evaluations, including the current one.
+	ResponseTimeP99Placeholder = "[RESPONSE_TIME_P99]"
+
+	// DNSTimePlaceholder is a placeholder for the time it took to resolve the host of an HTTP request, in milliseconds.
+	//
+	// Values that could replace the placeholder: 0 (if the connection was reused), 12
+	DNSTimePlaceholder = "[DNS_TIME]"
+
+	// ConnectTimePlaceholder is a placeholder for the time it took to establish the TCP connection of an HTTP request
+	// or of a TCP endpoint, in milliseconds.
+	//
+	// Values that could replace the placeholder: 0 (if the connection was reused), 25
+	ConnectTimePlaceholder = "[CONNECT_TIME]"
+
+	// TLSTimePlaceholder is a placeholder for the time it took to perform the TLS handshake of an HTTP request, in
+	// milliseconds.
+	//
+	// Values that could replace the placeholder: 0 (if the connection was reused or TLS isn't used), 48
+	TLSTimePlaceholder = "[TLS_TIME]"
+
+	// TTFBPlaceholder is a placeholder for the time between the moment an HTTP request was sent and the moment the
+	// first byte of the response was received, in milliseconds.
+	//
+	// Values that could replace the placeholder: 105
+	TTFBPlaceholder = "[TTFB]"
+
+	// TLSVersionPlaceholder is a placeholder for the TLS version negotiated with the server of an HTTP request.
+	//
+	// Values that could replace the placeholder: 1.2, 1.3
+	TLSVersionPlaceholder = "[TLS_VERSION]"
+
 	// BodyPlaceholder is a placeholder for the Body of the response
 	//
 	// Values that could replace the placeholder: {}, {"data":{"name":"john"}}, ...
 	BodyPlaceholder = "[BODY]"
 
+	// BodyIsValidJSONPlaceholder is a placeholder for whether the Body of the response is valid JSON.
+	//
+	// Values that could replace the placeholder: true, false
+	BodyIsValidJSONPlaceholder = "[BODY].is_valid_json"
+
+	// BodyIsValidXMLPlaceholder is a placeholder for whether the Body of the response is well-formed XML.
+	//
+	// Values that could replace the placeholder: true, false
+	BodyIsValidXMLPlaceholder = "[BODY].is_valid_xml"
+
+	// BodySizePlaceholder is a placeholder for the size of the Body of the response, in bytes.
+	// For HTTP endpoints, this is the number of bytes read before any decompression, or the Content-Length of the
+	// response if the method is HEAD.
+	//
+	// Values that could replace the placeholder: 0, 1048576, ...
+	BodySizePlaceholder = "[BODY_SIZE]"
+
 	// ConnectedPlaceholder is a placeholder for whether a connection was successfully established.
 	//
 	// Values that could replace the placeholder: true, false
@@ -50,6 +116,22 @@ const (
 
 	// DomainExpirationPlaceholder is a placeholder for the duration before the domain expires, in milliseconds.
 	DomainExpirationPlaceholder = "[DOMAIN_EXPIRATION]"
+
+	// RedirectURLPlaceholder is a placeholder for the absolute URL in the Location header of the response.
+	// Only populated if the redirect wasn't followed.
+	//
+	// Values that could replace the placeholder: https://example.com/login
+	RedirectURLPlaceholder = "[REDIRECT_URL]"
+
+	// PreviousStatusPlaceholder is a placeholder for the HTTP status of the endpoint's previous evaluation.
+	//
+	// Values that could replace the placeholder: 200, 404, 500, 0 (if the endpoint has never been evaluated)
+	PreviousStatusPlaceholder = "[PREVIOUS_STATUS]"
+
+	// PreviousSuccessPlaceholder is a placeholder for whether the endpoint's previous evaluation was successful.
+	//
+	// Values that could replace the placeholder: true, false, "" (if the endpoint has never been evaluated)
+	PreviousSuccessPlaceholder = "[PREVIOUS_SUCCESS]"
 )
 
 // Functions
@@ -64,6 +146,12 @@ const (
 	// Usage: has([BODY].errors) == true
 	HasFunctionPrefix = "has("
 
+	// TypeFunctionPrefix is the prefix for the type function, which returns the JSON type of the value at the given path:
+	// object, array, string, number or bool
+	//
+	// Usage: type([BODY].count) == number
+	TypeFunctionPrefix = "type("
+
 	// PatternFunctionPrefix is the prefix for the pattern function
 	//
 	// Usage: [IP] == pat(192.168.*.*)
@@ -74,6 +162,34 @@ const (
 	// Usage: [IP] == any(1.1.1.1, 1.0.0.1)
 	AnyFunctionPrefix = "any("
 
+	// StartsWithFunctionPrefix is the prefix for the startsWith function
+	//
+	// Usage: [BODY] == startsWith(<!DOCTYPE html>)
+	StartsWithFunctionPrefix = "startsWith("
+
+	// EndsWithFunctionPrefix is the prefix for the endsWith function
+	//
+	// Usage: [BODY].version == endsWith(-stable)
+	EndsWithFunctionPrefix = "endsWith("
+
+	// ContainsFunctionPrefix is the prefix for the contains function
+	//
+	// Usage: [BODY] == contains(<h1>Example Domain</h1>)
+	ContainsFunctionPrefix = "contains("
+
+	// BodyRegexFunctionPrefix is the prefix for the regex function, which extracts a capture group from the first match
+	// of a regular expression in the body. The group can be selected by name or number, and defaults to the first
+	// capture group, or to the whole match if the regular expression has no capture group.
+	//
+	// Usage: [BODY].regex(version (?P<major>\d+)\.(?P<minor>\d+)).major == 2, [BODY].regex(build-(\d+)) > 100
+	BodyRegexFunctionPrefix = "[BODY].regex("
+
+	// NowFunction is the function for the current time, in milliseconds since the Unix epoch. In a numerical comparison,
+	// it may be followed by a subtraction to get the time elapsed since a timestamp, in milliseconds.
+	//
+	// Usage: now() - [BODY].lastUpdated < 5m
+	NowFunction = "now()"
+
 	// FunctionSuffix is the suffix for all functions
 	FunctionSuffix = ")"
 )
@@ -89,6 +205,38 @@ const (
 	// This is only used for aesthetic purposes; it does not influence whether the condition evaluation results in a
 	// success or a failure
 	maximumLengthBeforeTruncatingWhenComparedWithPattern = 25
+
+	// elapsedSinceFunctionPrefix is the prefix of an element resolving to the time elapsed since a timestamp
+	elapsedSinceFunctionPrefix = NowFunction + " - "
+)
+
+// stringMatchingFunctions maps the prefix of each function that matches a string against the function's argument to
+// the function used to perform said match
+var stringMatchingFunctions = map[string]func(argument, s string) bool{
+	PatternFunctionPrefix: pattern.Match,
+	StartsWithFunctionPrefix: func(prefix, s string) bool {
+		return strings.HasPrefix(s, prefix)
+	},
+	EndsWithFunctionPrefix: func(suffix, s string) bool {
+		return strings.HasSuffix(s, suffix)
+	},
+	ContainsFunctionPrefix: func(substring, s string) bool {
+		return strings.Contains(s, substring)
+	},
+}
+
+var (
+	// bodyRegexGroupPattern is the pattern of the group selected after a call to the regex function, i.e. a group name or
+	// number
+	bodyRegexGroupPattern = regexp.MustCompile(`^\w+$`)
+
+	// bodyRegexes caches the compiled regular expressions of the regex function, since each of them is evaluated every
+	// time the condition it is part of is
+	bodyRegexes sync.Map
+
+	// timestampLayouts are the ISO 8601 layouts of the timestamps that can be used in numerical comparisons.
+	// Timestamps without a timezone are assumed to be in UTC.
+	timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"}
 )
 
 // Condition is a condition that needs to be met in order for an Endpoint to be considered healthy.
@@ -162,12 +310,36 @@ func (c Condition) hasBodyPlaceholder() bool {
 	return strings.Contains(string(c), BodyPlaceholder)
 }
 
+// hasBodySizePlaceholder checks whether the condition has a BodySizePlaceholder
+// Used for determining whether the size of the response body should be retrieved
+func (c Condition) hasBodySizePlaceholder() bool {
+	return strings.Contains(string(c), BodySizePlaceholder)
+}
+
 // hasDomainExpirationPlaceholder checks whether the condition has a DomainExpirationPlaceholder
 // Used for determining whether a whois operation is necessary
 func (c Condition) hasDomainExpirationPlaceholder() bool {
 	return strings.Contains(string(c), DomainExpirationPlaceholder)
 }
 
+// hasResponseTimePercentilePlaceholder checks whether the condition has a ResponseTimeP50Placeholder,
+// ResponseTimeP95Placeholder or ResponseTimeP99Placeholder
+// Used for determining whether the endpoint needs to keep track of its recent response times
+func (c Condition) hasResponseTimePercentilePlaceholder() bool {
+	return strings.Contains(string(c), ResponseTimeP50Placeholder) || strings.Contains(string(c), ResponseTimeP95Placeholder) || strings.Contains(string(c), ResponseTimeP99Placeholder)
+}
+
+// hasRedirectURLPlaceholder checks whether the condition has a RedirectURLPlaceholder
+func (c Condition) hasRedirectURLPlaceholder() bool {
+	return strings.Contains(string(c), RedirectURLPlaceholder)
+}
+
+// hasPreviousResultPlaceholder checks whether the condition has a PreviousStatusPlaceholder or a
+// PreviousSuccessPlaceholder
+func (c Condition) hasPreviousResultPlaceholder() bool {
+	return strings.Contains(string(c), PreviousStatusPlaceholder) || strings.Contains(string(c), PreviousSuccessPlaceholder)
+}
+
 // hasIPPlaceholder checks whether the condition has an IPPlaceholder
 // Used for determining whether an IP lookup is necessary
 func (c Condition) hasIPPlaceholder() bool {
@@ -176,26 +348,21 @@ func (c Condition) hasIPPlaceholder() bool {
 
 // isEqual compares two strings.
 //
-// Supports the "pat" and the "any" functions.
+// Supports the "pat", "startsWith", "endsWith", "contains" and "any" functions.
 // i.e. if one of the parameters starts with PatternFunctionPrefix and ends with FunctionSuffix, it will be treated like
 // a pattern.
 func isEqual(first, second string) bool {
 	firstHasFunctionSuffix := strings.HasSuffix(first, FunctionSuffix)
 	secondHasFunctionSuffix := strings.HasSuffix(second, FunctionSuffix)
 	if firstHasFunctionSuffix || secondHasFunctionSuffix {
-		var isFirstPattern, isSecondPattern bool
-		if strings.HasPrefix(first, PatternFunctionPrefix) && firstHasFunctionSuffix {
-			isFirstPattern = true
-			first = strings.TrimSuffix(strings.TrimPrefix(first, PatternFunctionPrefix), FunctionSuffix)
-		}
-		if strings.HasPrefix(second, PatternFunctionPrefix) && secondHasFunctionSuffix {
-			isSecondPattern = true
-			second = strings.TrimSuffix(strings.TrimPrefix(second, PatternFunctionPrefix), FunctionSuffix)
-		}
-		if isFirstPattern && !isSecondPattern {
-			return pattern.Match(first, second)
-		} else if !isFirstPattern && isSecondPattern {
-			return pattern.Match(second, first)
+		firstMatcher, firstArgument, isFirstStringMatchingFunction := parseStringMatchingFunction(first)
+		secondMatcher, secondArgument, isSecondStringMatchingFunction := parseStringMatchingFunction(second)
+		if isFirstStringMatchingFunction && !isSecondStringMatchingFunction {
+			return firstMatcher(firstArgument, second)
+		} else if !isFirstStringMatchingFunction && isSecondStringMatchingFunction {
+			return secondMatcher(secondArgument, first)
+		} else if isFirstStringMatchingFunction && isSecondStringMatchingFunction {
+			first, second = firstArgument, secondArgument
 		}
 		var isFirstAny, isSecondAny bool
 		if strings.HasPrefix(first, AnyFunctionPrefix) && firstHasFunctionSuffix {
@@ -235,6 +402,67 @@ func isEqual(first, second string) bool {
 	return first == second
 }
 
+// parseStringMatchingFunction returns the function used to perform the match as well as the argument of the string
+// matching function (e.g. pat, contains) that s is a call to, if any
+func parseStringMatchingFunction(s string) (func(argument, s string) bool, string, bool) {
+	if !strings.HasSuffix(s, FunctionSuffix) {
+		return nil, "", false
+	}
+	for prefix, matcher := range stringMatchingFunctions {
+		if strings.HasPrefix(s, prefix) {
+			return matcher, strings.TrimSuffix(strings.TrimPrefix(s, prefix), FunctionSuffix), true
+		}
+	}
+	return nil, "", false
+}
+
+// resolveBodyRegex resolves an element calling the regex function, i.e. [BODY].regex(EXPRESSION) or
+// [BODY].regex(EXPRESSION).GROUP, to the value of the selected capture group in the first match of EXPRESSION in the body.
+//
+// If the expression is invalid or has no such group, an error is added to the result, which makes the condition fail
+// validation. If the body has no match, the element is returned as invalid.
+func resolveBodyRegex(element, body string, result *Result) string {
+	invalidElement := element + " " + InvalidConditionElementSuffix
+	expression, group := strings.TrimPrefix(element, BodyRegexFunctionPrefix), ""
+	if i := strings.LastIndex(expression, FunctionSuffix+"."); i != -1 && bodyRegexGroupPattern.MatchString(expression[i+2:]) {
+		expression, group = expression[:i], expression[i+2:]
+	} else if strings.HasSuffix(expression, FunctionSuffix) {
+		expression = strings.TrimSuffix(expression, FunctionSuffix)
+	} else {
+		return invalidElement
+	}
+	var re *regexp.Regexp
+	if cached, ok := bodyRegexes.Load(expression); ok {
+		re = cached.(*regexp.Regexp)
+	} else {
+		var err error
+		if re, err = regexp.Compile(expression); err != nil {
+			result.AddError(fmt.Sprintf("invalid regex %s: %s", expression, err.Error()))
+			return invalidElement
+		}
+		bodyRegexes.Store(expression, re)
+	}
+	groupIndex := 0
+	if len(group) == 0 {
+		if re.NumSubexp() > 0 {
+			groupIndex = 1
+		}
+	} else if number, err := strconv.Atoi(group); err == nil {
+		groupIndex = number
+	} else {
+		groupIndex = re.SubexpIndex(group)
+	}
+	if groupIndex < 0 || groupIndex > re.NumSubexp() {
+		result.AddError(fmt.Sprintf("regex %s has no capture group %s", expression, group))
+		return invalidElement
+	}
+	match := re.FindStringSubmatch(body)
+	if match == nil {
+		return invalidElement
+	}
+	return match[groupIndex]
+}
+
 // sanitizeAndResolve sanitizes and resolves a list of elements and returns the list of parameters as well as a list
 // of resolved parameters
 func sanitizeAndResolve(elements []string, result *Result) ([]string, []string) {
@@ -247,12 +475,36 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 		switch strings.ToUpper(element) {
 		case StatusPlaceholder:
 			element = strconv.Itoa(result.HTTPStatus)
+		case StatusTextPlaceholder:
+			element = result.HTTPStatusText
 		case IPPlaceholder:
 			element = result.IP
 		case ResponseTimePlaceholder:
 			element = strconv.Itoa(int(result.Duration.Milliseconds()))
+		case ResponseTimeP50Placeholder:
+			element = strconv.FormatInt(result.responseTimePercentile(50).Milliseconds(), 10)
+		case ResponseTimeP95Placeholder:
+			element = strconv.FormatInt(result.responseTimePercentile(95).Milliseconds(), 10)
+		case ResponseTimeP99Placeholder:
+			element = strconv.FormatInt(result.responseTimePercentile(99).Milliseconds(), 10)
+		case DNSTimePlaceholder:
+			element = strconv.FormatInt(result.DNSTime.Milliseconds(), 10)
+		case ConnectTimePlaceholder:
+			element = strconv.FormatInt(result.ConnectTime.Milliseconds(), 10)
+		case TLSTimePlaceholder:
+			element = strconv.FormatInt(result.TLSTime.Milliseconds(), 10)
+		case TTFBPlaceholder:
+			element = strconv.FormatInt(result.TTFB.Milliseconds(), 10)
+		case TLSVersionPlaceholder:
+			element = result.TLSVersion
 		case BodyPlaceholder:
 			element = body
+		case BodySizePlaceholder:
+			element = strconv.FormatInt(result.BodySize, 10)
+		case strings.ToUpper(BodyIsValidJSONPlaceholder):
+			element = strconv.FormatBool(result.bodyAsJSON().IsValid())
+		case strings.ToUpper(BodyIsValidXMLPlaceholder):
+			element = strconv.FormatBool(result.isBodyValidXML())
 		case DNSRCodePlaceholder:
 			element = result.DNSRCode
 		case ConnectedPlaceholder:
@@ -261,11 +513,28 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 			element = strconv.FormatInt(result.CertificateExpiration.Milliseconds(), 10)
 		case DomainExpirationPlaceholder:
 			element = strconv.FormatInt(result.DomainExpiration.Milliseconds(), 10)
+		case RedirectURLPlaceholder:
+			element = result.RedirectURL
+		case PreviousStatusPlaceholder:
+			if result.previousResult != nil {
+				element = strconv.Itoa(result.previousResult.HTTPStatus)
+			} else {
+				element = "0"
+			}
+		case PreviousSuccessPlaceholder:
+			if result.previousResult != nil {
+				element = strconv.FormatBool(result.previousResult.Success)
+			} else {
+				element = ""
+			}
 		default:
-			// if contains the BodyPlaceholder, then evaluate json path
-			if strings.Contains(element, BodyPlaceholder) {
+			if strings.HasPrefix(element, BodyRegexFunctionPrefix) {
+				element = resolveBodyRegex(element, body, result)
+			} else if strings.Contains(element, BodyPlaceholder) {
+				// if contains the BodyPlaceholder, then evaluate json path
 				checkingForLength := false
 				checkingForExistence := false
+				checkingForType := false
 				if strings.HasPrefix(element, LengthFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
 					checkingForLength = true
 					element = strings.TrimSuffix(strings.TrimPrefix(element, LengthFunctionPrefix), FunctionSuffix)
@@ -274,28 +543,48 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 					checkingForExistence = true
 					element = strings.TrimSuffix(strings.TrimPrefix(element, HasFunctionPrefix), FunctionSuffix)
 				}
-				resolvedElement, resolvedElementLength, err := jsonpath.Eval(strings.TrimPrefix(strings.TrimPrefix(element, BodyPlaceholder), "."), result.Body)
-				if checkingForExistence {
-					if err != nil {
-						element = "false"
+				if strings.HasPrefix(element, TypeFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
+					checkingForType = true
+					element = strings.TrimSuffix(strings.TrimPrefix(element, TypeFunctionPrefix), FunctionSuffix)
+				}
+				path := strings.TrimPrefix(strings.TrimPrefix(element, BodyPlaceholder), ".")
+				if checkingForType {
+					if resolvedType, err := result.bodyAsJSON().Type(path); err != nil {
+						if errors.Is(err, jsonpath.ErrInvalidFilterExpression) {
+							result.AddError(err.Error())
+						}
+						element = TypeFunctionPrefix + element + FunctionSuffix + " " + InvalidConditionElementSuffix
 					} else {
-						element = "true"
+						element = resolvedType
 					}
 				} else {
-					if err != nil {
-						if err.Error() != "unexpected end of JSON input" {
-							result.AddError(err.Error())
-						}
-						if checkingForLength {
-							element = LengthFunctionPrefix + element + FunctionSuffix + " " + InvalidConditionElementSuffix
+					resolvedElement, resolvedElementLength, err := result.bodyAsJSON().Eval(path)
+					if checkingForExistence {
+						if err != nil {
+							// A malformed filter expression is a mistake in the condition, not a missing value
+							if errors.Is(err, jsonpath.ErrInvalidFilterExpression) {
+								result.AddError(err.Error())
+							}
+							element = "false"
 						} else {
-							element = element + " " + InvalidConditionElementSuffix
+							element = "true"
 						}
 					} else {
-						if checkingForLength {
-							element = strconv.Itoa(resolvedElementLength)
+						if err != nil {
+							if err.Error() != "unexpected end of JSON input" {
+								result.AddError(err.Error())
+							}
+							if checkingForLength {
+								element = LengthFunctionPrefix + element + FunctionSuffix + " " + InvalidConditionElementSuffix
+							} else {
+								element = element + " " + InvalidConditionElementSuffix
+							}
 						} else {
-							element = resolvedElement
+							if checkingForLength {
+								element = strconv.Itoa(resolvedElementLength)
+							} else {
+								element = resolvedElement
+							}
 						}
 					}
 				}
@@ -307,28 +596,58 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 }
 
 func sanitizeAndResolveNumerical(list []string, result *Result) (parameters []string, resolvedNumericalParameters []int64) {
-	parameters, resolvedParameters := sanitizeAndResolve(list, result)
-	for _, element := range resolvedParameters {
-		if duration, err := time.ParseDuration(element); duration != 0 && err == nil {
-			// If the string is a duration, convert it to milliseconds
-			resolvedNumericalParameters = append(resolvedNumericalParameters, duration.Milliseconds())
-		} else if number, err := strconv.ParseInt(element, 0, 64); err != nil {
-			// It's not an int, so we'll check if it's a float
-			if f, err := strconv.ParseFloat(element, 64); err == nil {
-				// It's a float, but we'll convert it to an int. We're losing precision here, but it's better than
-				// just returning 0.
-				resolvedNumericalParameters = append(resolvedNumericalParameters, int64(f))
-			} else {
-				// Default to 0 if the string couldn't be converted to an integer or a float
-				resolvedNumericalParameters = append(resolvedNumericalParameters, 0)
-			}
+	// The elapsedSinceFunctionPrefix is removed so that the timestamp it's followed by can be resolved like any other
+	// element, and is added back once the time elapsed since that timestamp has been computed
+	isElapsedSince := make([]bool, len(list))
+	elements := make([]string, len(list))
+	for i, element := range list {
+		element = strings.TrimSpace(element)
+		if strings.HasPrefix(element, elapsedSinceFunctionPrefix) {
+			isElapsedSince[i] = true
+			element = strings.TrimSpace(strings.TrimPrefix(element, elapsedSinceFunctionPrefix))
+		}
+		elements[i] = element
+	}
+	parameters, resolvedParameters := sanitizeAndResolve(elements, result)
+	now := time.Now().UnixMilli()
+	for i, element := range resolvedParameters {
+		var resolvedNumericalParameter int64
+		if element == NowFunction {
+			resolvedNumericalParameter = now
 		} else {
-			resolvedNumericalParameters = append(resolvedNumericalParameters, number)
+			resolvedNumericalParameter = resolveNumerical(element)
 		}
+		if isElapsedSince[i] {
+			parameters[i] = elapsedSinceFunctionPrefix + parameters[i]
+			resolvedNumericalParameter = now - resolvedNumericalParameter
+		}
+		resolvedNumericalParameters = append(resolvedNumericalParameters, resolvedNumericalParameter)
 	}
 	return parameters, resolvedNumericalParameters
 }
 
+// resolveNumerical converts a resolved element to a number. Durations are converted to milliseconds and timestamps
+// to milliseconds since the Unix epoch. Elements that can't be converted default to 0.
+func resolveNumerical(element string) int64 {
+	if duration, err := time.ParseDuration(element); duration != 0 && err == nil {
+		// If the string is a duration, convert it to milliseconds
+		return duration.Milliseconds()
+	} else if number, err := strconv.ParseInt(element, 0, 64); err == nil {
+		return number
+	} else if f, err := strconv.ParseFloat(element, 64); err == nil {
+		// It's a float, but we'll convert it to an int. We're losing precision here, but it's better than
+		// just returning 0.
+		return int64(f)
+	}
+	for _, layout := range timestampLayouts {
+		if timestamp, err := time.Parse(layout, element); err == nil {
+			return timestamp.UnixMilli()
+		}
+	}
+	// Default to 0 if the string couldn't be converted to an integer, a float or a timestamp
+	return 0
+}
+
 func prettifyNumericalParameters(parameters []string, resolvedParameters []int64, operator string) string {
 	return prettify(parameters, []string{strconv.Itoa(int(resolvedParameters[0])), strconv.Itoa(int(resolvedParameters[1]))}, operator)
 }
@@ -340,11 +659,11 @@ func prettify(parameters []string, resolvedParameters []string, operator string)
 	if strings.HasSuffix(resolvedParameters[0], InvalidConditionElementSuffix) || strings.HasSuffix(resolvedParameters[1], InvalidConditionElementSuffix) {
 		return resolvedParameters[0] + " " + operator + " " + resolvedParameters[1]
 	}
-	// If using the pattern function, truncate the parameter it's being compared to if said parameter is long enough
-	if strings.HasPrefix(parameters[0], PatternFunctionPrefix) && strings.HasSuffix(parameters[0], FunctionSuffix) && len(resolvedParameters[1]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
+	// If using a string matching function, truncate the parameter it's being compared to if said parameter is long enough
+	if _, _, ok := parseStringMatchingFunction(parameters[0]); ok && len(resolvedParameters[1]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
 		resolvedParameters[1] = fmt.Sprintf("%.25s...(truncated)", resolvedParameters[1])
 	}
-	if strings.HasPrefix(parameters[1], PatternFunctionPrefix) && strings.HasSuffix(parameters[1], FunctionSuffix) && len(resolvedParameters[0]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
+	if _, _, ok := parseStringMatchingFunction(parameters[1]); ok && len(resolvedParameters[0]) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
 		resolvedParameters[0] = fmt.Sprintf("%.25s...(truncated)", resolvedParameters[0])
 	}
 	// First element is a placeholder
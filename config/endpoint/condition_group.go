@@ -0,0 +1,40 @@
+package endpoint
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrConditionGroupWithNoCondition is the error with which Gatus will panic if a condition group has no conditions
+	ErrConditionGroupWithNoCondition = errors.New("you must specify at least one condition per condition group")
+)
+
+// ConditionGroup is a group of conditions evaluated together as a single condition
+type ConditionGroup struct {
+	// AnyOf is the list of conditions of which at least one must succeed for the group to succeed
+	AnyOf []Condition `yaml:"any-of"`
+}
+
+// evaluate the ConditionGroup with the Result of the health check
+//
+// Each condition of the group is evaluated, but they are added to the result's ConditionResults as a single
+// ConditionResult whose Condition is the conditions of the group joined by ||.
+func (g *ConditionGroup) evaluate(result *Result, dontResolveFailedConditions bool) bool {
+	numberOfConditionResults := len(result.ConditionResults)
+	success := false
+	for _, condition := range g.AnyOf {
+		if condition.evaluate(result, dontResolveFailedConditions) {
+			success = true
+		}
+	}
+	conditionsToDisplay := make([]string, 0, len(g.AnyOf))
+	for _, conditionResult := range result.ConditionResults[numberOfConditionResults:] {
+		conditionsToDisplay = append(conditionsToDisplay, conditionResult.Condition)
+	}
+	result.ConditionResults = append(result.ConditionResults[:numberOfConditionResults], &ConditionResult{
+		Condition: strings.Join(conditionsToDisplay, " || "),
+		Success:   success,
+	})
+	return success
+}
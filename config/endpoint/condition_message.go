@@ -0,0 +1,116 @@
+package endpoint
+
+import (
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrConditionWithMessageWithoutCondition is the error returned when a condition configured with a message
+	// doesn't have a condition
+	ErrConditionWithMessageWithoutCondition = errors.New("a condition configured with a message must have a condition")
+)
+
+// UnmarshalYAML unmarshals an Endpoint.
+//
+// In addition to plain strings, each condition, including those of condition groups, may be configured as a mapping
+// with the condition and the message to show when it fails:
+//
+//	conditions:
+//	  - "[STATUS] == 200"
+//	  - condition: "[BODY].pool.available > 0"
+//	    message: "Database connection pool exhausted"
+//
+// Such conditions are unmarshalled as regular conditions, and their messages are stored in ConditionMessages.
+func (e *Endpoint) UnmarshalYAML(node *yaml.Node) error {
+	type rawEndpoint Endpoint
+	messages := make(map[Condition]string)
+	if err := extractConditionMessages(node, messages); err != nil {
+		return err
+	}
+	if err := node.Decode((*rawEndpoint)(e)); err != nil {
+		return err
+	}
+	if len(messages) > 0 {
+		e.ConditionMessages = messages
+	}
+	return nil
+}
+
+// extractConditionMessages replaces every condition of the endpoint node that is configured as a mapping by the
+// condition itself, and adds the message of said condition to messages
+func extractConditionMessages(node *yaml.Node, messages map[Condition]string) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case "conditions":
+			if err := extractConditionMessagesFromSequence(node.Content[i+1], messages); err != nil {
+				return err
+			}
+		case "condition-groups":
+			if node.Content[i+1].Kind != yaml.SequenceNode {
+				continue
+			}
+			for _, conditionGroupNode := range node.Content[i+1].Content {
+				if conditionGroupNode.Kind != yaml.MappingNode {
+					continue
+				}
+				for j := 0; j+1 < len(conditionGroupNode.Content); j += 2 {
+					if conditionGroupNode.Content[j].Value == "any-of" {
+						if err := extractConditionMessagesFromSequence(conditionGroupNode.Content[j+1], messages); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func extractConditionMessagesFromSequence(node *yaml.Node, messages map[Condition]string) error {
+	if node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, conditionNode := range node.Content {
+		if conditionNode.Kind != yaml.MappingNode {
+			continue
+		}
+		var conditionWithMessage struct {
+			Condition string `yaml:"condition"`
+			Message   string `yaml:"message"`
+		}
+		if err := conditionNode.Decode(&conditionWithMessage); err != nil {
+			return err
+		}
+		if len(conditionWithMessage.Condition) == 0 {
+			return ErrConditionWithMessageWithoutCondition
+		}
+		if len(conditionWithMessage.Message) > 0 {
+			messages[Condition(conditionWithMessage.Condition)] = conditionWithMessage.Message
+		}
+		*conditionNode = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: conditionWithMessage.Condition}
+	}
+	return nil
+}
+
+// conditionMessage returns the messages configured for the conditions passed as parameter, joined by a semicolon
+func (e *Endpoint) conditionMessage(conditions ...Condition) string {
+	if len(e.ConditionMessages) == 0 {
+		return ""
+	}
+	var messages []string
+	for _, condition := range conditions {
+		if message := e.ConditionMessages[condition]; len(message) > 0 {
+			messages = append(messages, message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
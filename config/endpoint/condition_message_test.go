@@ -0,0 +1,104 @@
+package endpoint
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/test"
+	"gopkg.in/yaml.v3"
+)
+
+func TestEndpoint_UnmarshalYAMLWithConditionMessages(t *testing.T) {
+	var ep Endpoint
+	err := yaml.Unmarshal([]byte(`
+name: website
+url: https://example.org
+conditions:
+  - "[STATUS] == 200"
+  - condition: "[BODY].pool.available > 0"
+    message: "Database connection pool exhausted"
+  - condition: "[RESPONSE_TIME] < 500"
+condition-groups:
+  - any-of:
+      - "[BODY].status == UP"
+      - condition: "[BODY].status == DEGRADED"
+        message: "Service is degraded"
+`), &ep)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if ep.Name != "website" || ep.URL != "https://example.org" {
+		t.Errorf("expected the other fields to be unmarshalled, got name=%s and url=%s", ep.Name, ep.URL)
+	}
+	expectedConditions := []Condition{"[STATUS] == 200", "[BODY].pool.available > 0", "[RESPONSE_TIME] < 500"}
+	if len(ep.Conditions) != len(expectedConditions) {
+		t.Fatalf("expected %d conditions, got %d", len(expectedConditions), len(ep.Conditions))
+	}
+	for i, condition := range expectedConditions {
+		if ep.Conditions[i] != condition {
+			t.Errorf("expected condition #%d to be %s, got %s", i, condition, ep.Conditions[i])
+		}
+	}
+	if len(ep.ConditionGroups) != 1 || len(ep.ConditionGroups[0].AnyOf) != 2 || ep.ConditionGroups[0].AnyOf[1] != "[BODY].status == DEGRADED" {
+		t.Errorf("expected the conditions of the condition group to be unmarshalled, got %v", ep.ConditionGroups)
+	}
+	if len(ep.ConditionMessages) != 2 {
+		t.Fatalf("expected 2 condition messages, got %d", len(ep.ConditionMessages))
+	}
+	if message := ep.ConditionMessages["[BODY].pool.available > 0"]; message != "Database connection pool exhausted" {
+		t.Errorf("expected message of condition to be %q, got %q", "Database connection pool exhausted", message)
+	}
+	if message := ep.ConditionMessages["[BODY].status == DEGRADED"]; message != "Service is degraded" {
+		t.Errorf("expected message of condition to be %q, got %q", "Service is degraded", message)
+	}
+	err = yaml.Unmarshal([]byte(`
+name: website
+conditions:
+  - message: "Database connection pool exhausted"
+`), &Endpoint{})
+	if !errors.Is(err, ErrConditionWithMessageWithoutCondition) {
+		t.Errorf("expected error %v, got %v", ErrConditionWithMessageWithoutCondition, err)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithConditionMessages(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"pool":{"available":0},"status":"DOWN"}`))}
+	})})
+	ep := Endpoint{
+		Name:            "website",
+		URL:             "https://example.org/health",
+		Conditions:      []Condition{"[STATUS] == 200", "[BODY].pool.available > 0"},
+		ConditionGroups: []*ConditionGroup{{AnyOf: []Condition{"[BODY].status == UP", "[BODY].status == DEGRADED"}}},
+		ConditionMessages: map[Condition]string{
+			"[STATUS] == 200":           "Unexpected status",
+			"[BODY].pool.available > 0": "Database connection pool exhausted",
+			"[BODY].status == UP":       "Service is down",
+			"[BODY].status == DEGRADED": "Service is not degraded either",
+		},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := ep.EvaluateHealth()
+	if len(result.ConditionResults) != 3 {
+		t.Fatalf("expected 3 condition results, got %d", len(result.ConditionResults))
+	}
+	if result.ConditionResults[0].Message != "" {
+		t.Errorf("expected the message of a successful condition not to be set, got %q", result.ConditionResults[0].Message)
+	}
+	if result.ConditionResults[1].Message != "Database connection pool exhausted" {
+		t.Errorf("expected the message of the failed condition to be set, got %q", result.ConditionResults[1].Message)
+	}
+	if result.ConditionResults[2].Message != "Service is down; Service is not degraded either" {
+		t.Errorf("expected the messages of the failed condition group to be set, got %q", result.ConditionResults[2].Message)
+	}
+	if suffix := result.ConditionResults[1].MessageSuffix(); suffix != ": Database connection pool exhausted" {
+		t.Errorf("expected message suffix to be %q, got %q", ": Database connection pool exhausted", suffix)
+	}
+}
@@ -7,4 +7,16 @@ type ConditionResult struct {
 
 	// Success whether the condition was met (successful) or not (failed)
 	Success bool `json:"success"`
+
+	// Message is the human-readable message configured for the condition, if any. Only set if the condition failed.
+	Message string `json:"message,omitempty"`
+}
+
+// MessageSuffix returns the Message preceded by a separator, so that it can be appended to the Condition when
+// formatting it, or an empty string if there is no Message
+func (cr *ConditionResult) MessageSuffix() string {
+	if len(cr.Message) == 0 {
+		return ""
+	}
+	return ": " + cr.Message
 }
@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/TwiN/gatus/v5/jsonpath"
 )
 
 func TestCondition_Validate(t *testing.T) {
@@ -33,7 +36,18 @@ func TestCondition_Validate(t *testing.T) {
 		{condition: "len([BODY].data) < 5", expectedErr: nil},
 		{condition: "has([BODY].errors) == false", expectedErr: nil},
 		{condition: "has([BODY].users[0].name) == true", expectedErr: nil},
+		{condition: "type([BODY].count) == number", expectedErr: nil},
 		{condition: "[BODY].name == pat(john*)", expectedErr: nil},
+		{condition: "[BODY] == startsWith(<!DOCTYPE html>)", expectedErr: nil},
+		{condition: "[BODY].version == endsWith(-stable)", expectedErr: nil},
+		{condition: "[BODY] != contains(error)", expectedErr: nil},
+		{condition: "[BODY_SIZE] > 1000", expectedErr: nil},
+		{condition: "[BODY].regex(v(?P<major>\\d+)\\.(?P<minor>\\d+)).minor >= 2", expectedErr: nil},
+		{condition: "[BODY].regex(build-(\\d+)).1 > 100", expectedErr: nil},
+		{condition: "now() - [BODY].lastUpdated < 5m", expectedErr: nil},
+		{condition: "[BODY].regex(v(?P<major>\\d+)).patch == 1", expectedErr: errors.New("regex v(?P<major>\\d+) has no capture group patch")},
+		{condition: "[BODY].regex(v(\\d+)).2 == 1", expectedErr: errors.New("regex v(\\d+) has no capture group 2")},
+		{condition: "[BODY].regex(v(\\d+) == 1", expectedErr: errors.New("invalid regex v(\\d+: error parsing regexp: missing closing ): `v(\\d+`")},
 		{condition: "[CERTIFICATE_EXPIRATION] > 48h", expectedErr: nil},
 		{condition: "[DOMAIN_EXPIRATION] > 720h", expectedErr: nil},
 		{condition: "raw == raw", expectedErr: nil},
@@ -119,6 +133,160 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "[RESPONSE_TIME] (50) < potato (0)", // Non-numerical values automatically resolve to 0
 		},
+		{
+			Name:            "response-time-p50",
+			Condition:       Condition("[RESPONSE_TIME_P50] < 100"),
+			Result:          &Result{Duration: 50 * time.Millisecond, recentResponseTimes: []time.Duration{10 * time.Millisecond, 50 * time.Millisecond, 200 * time.Millisecond, 20 * time.Millisecond}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME_P50] < 100",
+		},
+		{
+			Name:            "response-time-p95-failure",
+			Condition:       Condition("[RESPONSE_TIME_P95] < 100"),
+			Result:          &Result{Duration: 50 * time.Millisecond, recentResponseTimes: []time.Duration{10 * time.Millisecond, 50 * time.Millisecond, 200 * time.Millisecond, 20 * time.Millisecond}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME_P95] (200) < 100",
+		},
+		{
+			Name:            "response-time-p99-without-history",
+			Condition:       Condition("[RESPONSE_TIME_P99] < 1s"),
+			Result:          &Result{Duration: 50 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME_P99] < 1s",
+		},
+		{
+			Name:            "dns-time",
+			Condition:       Condition("[DNS_TIME] < 100"),
+			Result:          &Result{DNSTime: 12 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[DNS_TIME] < 100",
+		},
+		{
+			Name:            "connect-time-failure",
+			Condition:       Condition("[CONNECT_TIME] < 100"),
+			Result:          &Result{ConnectTime: 150 * time.Millisecond},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CONNECT_TIME] (150) < 100",
+		},
+		{
+			Name:            "tls-time",
+			Condition:       Condition("[TLS_TIME] < 1s"),
+			Result:          &Result{TLSTime: 48 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[TLS_TIME] < 1s",
+		},
+		{
+			Name:            "ttfb-failure",
+			Condition:       Condition("[TTFB] < 200"),
+			Result:          &Result{TTFB: 250 * time.Millisecond},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[TTFB] (250) < 200",
+		},
+		{
+			Name:            "status-text",
+			Condition:       Condition("[STATUS_TEXT] == Service Unavailable"),
+			Result:          &Result{HTTPStatus: 503, HTTPStatusText: "Service Unavailable"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[STATUS_TEXT] == Service Unavailable",
+		},
+		{
+			Name:            "status-text-failure",
+			Condition:       Condition("[STATUS_TEXT] == OK"),
+			Result:          &Result{HTTPStatus: 404, HTTPStatusText: "Not Found"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[STATUS_TEXT] (Not Found) == OK",
+		},
+		{
+			Name:            "body-regex-named-groups",
+			Condition:       Condition("[BODY].regex(version (?P<major>\\d+)\\.(?P<minor>\\d+)).minor >= 2"),
+			Result:          &Result{Body: []byte("gatus version 5.12 (build 42)")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].regex(version (?P<major>\\d+)\\.(?P<minor>\\d+)).minor >= 2",
+		},
+		{
+			Name:            "body-regex-named-groups-failure",
+			Condition:       Condition("[BODY].regex(version (?P<major>\\d+)\\.(?P<minor>\\d+)).major == 4"),
+			Result:          &Result{Body: []byte("gatus version 5.12 (build 42)")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].regex(version (?P<major>\\d+)\\.(?P<minor>\\d+)).major (5) == 4",
+		},
+		{
+			Name:            "body-regex-numbered-group",
+			Condition:       Condition("[BODY].regex(version (\\d+)\\.(\\d+)).2 == 12"),
+			Result:          &Result{Body: []byte("gatus version 5.12 (build 42)")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].regex(version (\\d+)\\.(\\d+)).2 == 12",
+		},
+		{
+			Name:            "body-regex-defaults-to-first-group",
+			Condition:       Condition("[BODY].regex(build (\\d+)\\)) > 40"),
+			Result:          &Result{Body: []byte("gatus version 5.12 (build 42)")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].regex(build (\\d+)\\)) > 40",
+		},
+		{
+			Name:            "body-regex-without-group",
+			Condition:       Condition("[BODY].regex(\\d+\\.\\d+) == 5.12"),
+			Result:          &Result{Body: []byte("gatus version 5.12 (build 42)")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].regex(\\d+\\.\\d+) == 5.12",
+		},
+		{
+			Name:            "body-regex-unmatched-optional-group",
+			Condition:       Condition("[BODY].regex(version (?P<major>\\d+)(-(?P<suffix>\\w+))?).suffix == "),
+			Result:          &Result{Body: []byte("gatus version 5.12 (build 42)")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].regex(version (?P<major>\\d+)(-(?P<suffix>\\w+))?).suffix == ",
+		},
+		{
+			Name:            "body-regex-no-match",
+			Condition:       Condition("[BODY].regex(release (?P<major>\\d+)).major == 5"),
+			Result:          &Result{Body: []byte("gatus version 5.12 (build 42)")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].regex(release (?P<major>\\d+)).major (INVALID) == 5",
+		},
+		{
+			Name:            "status-text-using-pat",
+			Condition:       Condition("[STATUS_TEXT] == pat(*Unavailable)"),
+			Result:          &Result{HTTPStatus: 503, HTTPStatusText: "Service Unavailable"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[STATUS_TEXT] == pat(*Unavailable)",
+		},
+		{
+			Name:            "tls-version",
+			Condition:       Condition("[TLS_VERSION] == 1.3"),
+			Result:          &Result{TLSVersion: "1.2"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[TLS_VERSION] (1.2) == 1.3",
+		},
+		{
+			Name:            "previous-success",
+			Condition:       Condition("[PREVIOUS_SUCCESS] == false"),
+			Result:          &Result{previousResult: &Result{Success: false}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[PREVIOUS_SUCCESS] == false",
+		},
+		{
+			Name:            "previous-success-without-previous-result",
+			Condition:       Condition("[PREVIOUS_SUCCESS] == false"),
+			Result:          &Result{},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[PREVIOUS_SUCCESS] () == false",
+		},
+		{
+			Name:            "previous-status",
+			Condition:       Condition("[PREVIOUS_STATUS] != [STATUS]"),
+			Result:          &Result{HTTPStatus: 200, previousResult: &Result{HTTPStatus: 503}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[PREVIOUS_STATUS] != [STATUS]",
+		},
+		{
+			Name:            "previous-status-without-previous-result",
+			Condition:       Condition("[PREVIOUS_STATUS] == 0"),
+			Result:          &Result{HTTPStatus: 200},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[PREVIOUS_STATUS] == 0",
+		},
 		{
 			Name:            "response-time-using-greater-than",
 			Condition:       Condition("[RESPONSE_TIME] > 500"),
@@ -238,6 +406,111 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "len([BODY].data.name) (INVALID) == john",
 		},
+		{
+			Name:            "body-jsonpath-len-with-filter",
+			Condition:       Condition("len([BODY].services[?(@.healthy==true)]) == 2"),
+			Result:          &Result{Body: []byte("{\"services\": [{\"healthy\": true}, {\"healthy\": false}, {\"healthy\": true}]}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "len([BODY].services[?(@.healthy==true)]) == 2",
+		},
+		{
+			Name:            "body-jsonpath-with-filter-and-index",
+			Condition:       Condition("[BODY].services[?(@.name=='db')][0].status == down"),
+			Result:          &Result{Body: []byte("{\"services\": [{\"name\": \"api\", \"status\": \"up\"}, {\"name\": \"db\", \"status\": \"up\"}]}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].services[?(@.name=='db')][0].status (up) == down",
+		},
+		{
+			Name:            "body-is-valid-json",
+			Condition:       Condition("[BODY].is_valid_json == true"),
+			Result:          &Result{Body: []byte("{\"data\": {\"id\": 1}}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].is_valid_json == true",
+		},
+		{
+			Name:            "body-is-valid-json-with-array",
+			Condition:       Condition("[BODY].is_valid_json == true"),
+			Result:          &Result{Body: []byte("[1, 2, 3]")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].is_valid_json == true",
+		},
+		{
+			Name:            "body-is-valid-json-with-malformed-json",
+			Condition:       Condition("[BODY].is_valid_json == true"),
+			Result:          &Result{Body: []byte("{\"data\": {\"id\": 1}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].is_valid_json (false) == true",
+		},
+		{
+			Name:            "body-is-valid-json-with-html",
+			Condition:       Condition("[BODY].is_valid_json == true"),
+			Result:          &Result{Body: []byte("<!DOCTYPE html><html><head><meta charset=\"utf-8\"><title>Error</title></head><body>Internal Server Error<br></body></html>")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].is_valid_json (false) == true",
+		},
+		{
+			Name:            "body-is-valid-json-with-empty-body",
+			Condition:       Condition("[BODY].is_valid_json == true"),
+			Result:          &Result{Body: []byte("")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].is_valid_json (false) == true",
+		},
+		{
+			Name:            "body-is-valid-xml",
+			Condition:       Condition("[BODY].is_valid_xml == true"),
+			Result:          &Result{Body: []byte("<?xml version=\"1.0\"?><status><name>gatus</name><healthy>true</healthy></status>")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].is_valid_xml == true",
+		},
+		{
+			Name:            "body-is-valid-xml-with-malformed-xml",
+			Condition:       Condition("[BODY].is_valid_xml == true"),
+			Result:          &Result{Body: []byte("<status><name>gatus</name>")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].is_valid_xml (false) == true",
+		},
+		{
+			Name:            "body-is-valid-xml-with-html",
+			Condition:       Condition("[BODY].is_valid_xml == true"),
+			Result:          &Result{Body: []byte("<!DOCTYPE html><html><head><meta charset=\"utf-8\"><title>Error</title></head><body>Internal Server Error<br></body></html>")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].is_valid_xml (false) == true",
+		},
+		{
+			Name:            "body-is-valid-xml-with-json",
+			Condition:       Condition("[BODY].is_valid_xml == false"),
+			Result:          &Result{Body: []byte("{\"data\": {\"id\": 1}}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].is_valid_xml == false",
+		},
+		{
+			Name:            "body-is-valid-xml-with-multiple-root-elements",
+			Condition:       Condition("[BODY].is_valid_xml == true"),
+			Result:          &Result{Body: []byte("<a></a><b></b>")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].is_valid_xml (false) == true",
+		},
+		{
+			Name:            "body-size",
+			Condition:       Condition("[BODY_SIZE] > 1000"),
+			Result:          &Result{BodySize: 1048576},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_SIZE] > 1000",
+		},
+		{
+			Name:            "body-size-failure",
+			Condition:       Condition("[BODY_SIZE] > 1000"),
+			Result:          &Result{BodySize: 512},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_SIZE] (512) > 1000",
+		},
+		{
+			Name:            "body-size-equals",
+			Condition:       Condition("[BODY_SIZE] == 0"),
+			Result:          &Result{},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_SIZE] == 0",
+		},
 		{
 			Name:            "body-jsonpath-double-placeholder",
 			Condition:       Condition("[BODY].user.firstName != [BODY].user.lastName"),
@@ -663,6 +936,128 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "[STATUS] (200) == pat(4*)",
 		},
+		// startsWith
+		{
+			Name:            "startsWith-body",
+			Condition:       Condition("[BODY] == startsWith(<!DOCTYPE html>)"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] == startsWith(<!DOCTYPE html>)",
+		},
+		{
+			Name:            "startsWith-body-failure",
+			Condition:       Condition("[BODY] == startsWith(<html>)"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (<!DOCTYPE html><html lang...(truncated)) == startsWith(<html>)",
+		},
+		{
+			Name:            "startsWith-body-json-path",
+			Condition:       Condition("[BODY].name == startsWith(john)"),
+			Result:          &Result{Body: []byte("{\"name\": \"john.doe\"}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].name == startsWith(john)",
+		},
+		{
+			Name:            "startsWith-body-json-path-failure",
+			Condition:       Condition("[BODY].name == startsWith(doe)"),
+			Result:          &Result{Body: []byte("{\"name\": \"john.doe\"}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].name (john.doe) == startsWith(doe)",
+		},
+		{
+			Name:            "startsWith-redirect-url-with-not-equals",
+			Condition:       Condition("[REDIRECT_URL] != startsWith(http://)"),
+			Result:          &Result{RedirectURL: "https://example.org/login"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[REDIRECT_URL] != startsWith(http://)",
+		},
+		{
+			Name:            "startsWith-redirect-url-with-not-equals-failure",
+			Condition:       Condition("[REDIRECT_URL] != startsWith(http://)"),
+			Result:          &Result{RedirectURL: "http://example.org/login"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[REDIRECT_URL] (http://example.org/login) != startsWith(http://)",
+		},
+		// endsWith
+		{
+			Name:            "endsWith-body",
+			Condition:       Condition("[BODY] == endsWith(</html>)"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] == endsWith(</html>)",
+		},
+		{
+			Name:            "endsWith-body-failure",
+			Condition:       Condition("[BODY] == endsWith(</body>)"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (<!DOCTYPE html><html lang...(truncated)) == endsWith(</body>)",
+		},
+		{
+			Name:            "endsWith-body-json-path",
+			Condition:       Condition("[BODY].version == endsWith(-stable)"),
+			Result:          &Result{Body: []byte("{\"version\": \"1.2.3-stable\"}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].version == endsWith(-stable)",
+		},
+		{
+			Name:            "endsWith-body-json-path-failure",
+			Condition:       Condition("[BODY].version == endsWith(-stable)"),
+			Result:          &Result{Body: []byte("{\"version\": \"1.2.3-rc1\"}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].version (1.2.3-rc1) == endsWith(-stable)",
+		},
+		// contains
+		{
+			Name:            "contains-body",
+			Condition:       Condition("[BODY] == contains(<div id=\"user\">jane.doe</div>)"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] == contains(<div id=\"user\">jane.doe</div>)",
+		},
+		{
+			Name:            "contains-body-failure",
+			Condition:       Condition("[BODY] == contains(<div id=\"user\">john.doe</div>)"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (<!DOCTYPE html><html lang...(truncated)) == contains(<div id=\"user\">john.doe</div>)",
+		},
+		{
+			Name:            "contains-body-failure-alt",
+			Condition:       Condition("contains(<div id=\"user\">john.doe</div>) == [BODY]"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "contains(<div id=\"user\">john.doe</div>) == [BODY] (<!DOCTYPE html><html lang...(truncated))",
+		},
+		{
+			Name:            "contains-body-with-not-equals",
+			Condition:       Condition("[BODY] != contains(error)"),
+			Result:          &Result{Body: []byte("{\"status\": \"ok\"}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] != contains(error)",
+		},
+		{
+			Name:            "contains-body-with-not-equals-failure",
+			Condition:       Condition("[BODY] != contains(error)"),
+			Result:          &Result{Body: []byte("{\"error\": \"oops\"}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] ({\"error\": \"oops\"}) != contains(error)",
+		},
+		{
+			Name:            "contains-body-does-not-treat-argument-as-pattern",
+			Condition:       Condition("[BODY] == contains(*)"),
+			Result:          &Result{Body: []byte("{\"status\": \"ok\"}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] ({\"status\": \"ok\"}) == contains(*)",
+		},
+		{
+			Name:            "contains-body-same-as-pat",
+			Condition:       Condition("[BODY] == pat(*jane.doe*)"),
+			Result:          &Result{Body: []byte(`<!DOCTYPE html><html lang="en"><head><meta http-equiv="Content-Type" content="text/html; charset=UTF-8" /></head><body><div id="user">jane.doe</div></body></html>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] == pat(*jane.doe*)",
+		},
 		// any
 		{
 			Name:            "any-body-1",
@@ -721,6 +1116,63 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess:             false,
 			ExpectedOutput:              "[STATUS] == any(200, 429)",
 		},
+		// type
+		{
+			Name:            "type-number",
+			Condition:       Condition("type([BODY].count) == number"),
+			Result:          &Result{Body: []byte(`{"count": 5}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "type([BODY].count) == number",
+		},
+		{
+			Name:            "type-number-but-string",
+			Condition:       Condition("type([BODY].count) == number"),
+			Result:          &Result{Body: []byte(`{"count": "5"}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "type([BODY].count) (string) == number",
+		},
+		{
+			Name:            "type-string",
+			Condition:       Condition("type([BODY].name) == string"),
+			Result:          &Result{Body: []byte(`{"name": "john"}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "type([BODY].name) == string",
+		},
+		{
+			Name:            "type-bool-but-string",
+			Condition:       Condition("type([BODY].enabled) == bool"),
+			Result:          &Result{Body: []byte(`{"enabled": "true"}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "type([BODY].enabled) (string) == bool",
+		},
+		{
+			Name:            "type-array",
+			Condition:       Condition("type([BODY].users) == array"),
+			Result:          &Result{Body: []byte(`{"users": []}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "type([BODY].users) == array",
+		},
+		{
+			Name:            "type-object-but-array",
+			Condition:       Condition("type([BODY].user) == object"),
+			Result:          &Result{Body: []byte(`{"user": [{"id": 1}]}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "type([BODY].user) (array) == object",
+		},
+		{
+			Name:            "type-not-equal",
+			Condition:       Condition("type([BODY].count) != string"),
+			Result:          &Result{Body: []byte(`{"count": 5}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "type([BODY].count) != string",
+		},
+		{
+			Name:            "type-of-missing-path",
+			Condition:       Condition("type([BODY].count) == number"),
+			Result:          &Result{Body: []byte(`{}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "type([BODY].count) (INVALID) == number",
+		},
 		// has
 		{
 			Name:            "has",
@@ -766,6 +1218,100 @@ func TestCondition_evaluate(t *testing.T) {
 	}
 }
 
+func TestCondition_evaluateWithNow(t *testing.T) {
+	now := time.Now()
+	scenarios := []struct {
+		Name            string
+		Condition       Condition
+		Body            string
+		ExpectedSuccess bool
+	}{
+		{
+			Name:            "fresh-timestamp",
+			Condition:       Condition("now() - [BODY].lastUpdated < 5m"),
+			Body:            `{"lastUpdated": "` + now.Add(-time.Minute).UTC().Format(time.RFC3339) + `"}`,
+			ExpectedSuccess: true,
+		},
+		{
+			Name:            "stale-timestamp",
+			Condition:       Condition("now() - [BODY].lastUpdated < 5m"),
+			Body:            `{"lastUpdated": "` + now.Add(-10*time.Minute).UTC().Format(time.RFC3339) + `"}`,
+			ExpectedSuccess: false,
+		},
+		{
+			Name:            "fresh-timestamp-with-offset-and-fractional-seconds",
+			Condition:       Condition("now() - [BODY].lastUpdated < 5m"),
+			Body:            `{"lastUpdated": "` + now.Add(-time.Minute).In(time.FixedZone("EST", -5*3600)).Format(time.RFC3339Nano) + `"}`,
+			ExpectedSuccess: true,
+		},
+		{
+			Name:            "stale-timestamp-without-timezone",
+			Condition:       Condition("now() - [BODY].lastUpdated <= 1h"),
+			Body:            `{"lastUpdated": "` + now.Add(-2*time.Hour).UTC().Format("2006-01-02T15:04:05") + `"}`,
+			ExpectedSuccess: false,
+		},
+		{
+			Name:            "fresh-unix-milliseconds",
+			Condition:       Condition("now() - [BODY].lastUpdated < 5m"),
+			Body:            `{"lastUpdated": ` + strconv.FormatInt(now.Add(-time.Minute).UnixMilli(), 10) + `}`,
+			ExpectedSuccess: true,
+		},
+		{
+			Name:            "missing-timestamp",
+			Condition:       Condition("now() - [BODY].lastUpdated < 5m"),
+			Body:            `{}`,
+			ExpectedSuccess: false,
+		},
+		{
+			Name:            "timestamp-compared-with-now",
+			Condition:       Condition("[BODY].expiresAt > now()"),
+			Body:            `{"expiresAt": "` + now.Add(time.Hour).UTC().Format(time.RFC3339) + `"}`,
+			ExpectedSuccess: true,
+		},
+		{
+			Name:            "timestamp-compared-with-timestamp",
+			Condition:       Condition("[BODY].date >= 2024-01-01"),
+			Body:            `{"date": "2023-12-31T23:59:59Z"}`,
+			ExpectedSuccess: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			result := &Result{Body: []byte(scenario.Body)}
+			scenario.Condition.evaluate(result, false)
+			if result.ConditionResults[0].Success != scenario.ExpectedSuccess {
+				t.Errorf("Condition '%s' should have been success=%v, got '%s'", scenario.Condition, scenario.ExpectedSuccess, result.ConditionResults[0].Condition)
+			}
+		})
+	}
+	t.Run("output", func(t *testing.T) {
+		result := &Result{Body: []byte(`{"lastUpdated": "` + now.Add(-10*time.Minute).UTC().Format(time.RFC3339) + `"}`)}
+		Condition("now() - [BODY].lastUpdated < 5m").evaluate(result, false)
+		if output := result.ConditionResults[0].Condition; !strings.HasPrefix(output, "now() - [BODY].lastUpdated (6") || !strings.HasSuffix(output, ") < 5m (300000)") {
+			t.Errorf("expected the time elapsed since the timestamp to be displayed, got '%s'", output)
+		}
+	})
+}
+
+func TestCondition_evaluateWithIsValidJSONAndJSONPathParsesBodyOnce(t *testing.T) {
+	result := &Result{Body: []byte(`{"status": "UP", "data": {"id": 1}}`)}
+	Condition("[BODY].is_valid_json == true").evaluate(result, false)
+	document := result.jsonBody
+	if document == nil {
+		t.Fatal("expected the parsed body to be cached")
+	}
+	Condition("[BODY].status == UP").evaluate(result, false)
+	Condition("[BODY].data.id == 1").evaluate(result, false)
+	if result.jsonBody != document {
+		t.Error("expected the parsed body to be shared by all conditions")
+	}
+	for _, conditionResult := range result.ConditionResults {
+		if !conditionResult.Success {
+			t.Errorf("expected condition '%s' to be successful", conditionResult.Condition)
+		}
+	}
+}
+
 func TestCondition_evaluateWithInvalidOperator(t *testing.T) {
 	condition := Condition("[STATUS] ? 201")
 	result := &Result{HTTPStatus: 201}
@@ -777,3 +1323,23 @@ func TestCondition_evaluateWithInvalidOperator(t *testing.T) {
 		t.Error("condition was invalid, result should've had an error")
 	}
 }
+
+func TestCondition_evaluateWithMalformedFilterExpression(t *testing.T) {
+	for _, condition := range []Condition{
+		"[BODY].services[?(@.name=db)][0].status == up",
+		"len([BODY].services[?(@.healthy=true)]) == 1",
+		"has([BODY].services[?(@.name==db)]) == true",
+		"type([BODY].services[?(@.name==db)]) == array",
+	} {
+		t.Run(string(condition), func(t *testing.T) {
+			result := &Result{Body: []byte(`{"services": [{"name": "db", "healthy": true, "status": "up"}]}`)}
+			condition.evaluate(result, false)
+			if result.Success {
+				t.Error("filter expression was malformed, result should've been a failure")
+			}
+			if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], jsonpath.ErrInvalidFilterExpression.Error()) {
+				t.Errorf("filter expression was malformed, result should've had an error explaining why, got %v", result.Errors)
+			}
+		})
+	}
+}
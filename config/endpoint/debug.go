@@ -0,0 +1,65 @@
+package endpoint
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	// maximumDebugBodyLength is the maximum number of bytes of a response body that will be logged
+	maximumDebugBodyLength = 1024
+
+	redactedHeaderValue = "<redacted>"
+)
+
+// defaultDebugRedactedHeaders are the headers whose values are always redacted from the logs
+var defaultDebugRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token"}
+
+// logHTTPRequest logs the request line and the headers of the request passed as parameter
+func (e *Endpoint) logHTTPRequest(request *http.Request) {
+	log.Printf("[endpoint.logHTTPRequest] group=%s; endpoint=%s; request=%s %s %s; host=%s; headers=%s", e.Group, e.Name, request.Method, request.URL.RequestURI(), request.Proto, request.Host, e.formatHeadersForDebug(request.Header))
+}
+
+// logHTTPResponse logs the status, the headers and the body of the response passed as parameter.
+// The body is truncated to maximumDebugBodyLength bytes.
+func (e *Endpoint) logHTTPResponse(response *http.Response, body []byte) {
+	truncatedBody := string(body)
+	if len(body) > maximumDebugBodyLength {
+		truncatedBody = string(body[:maximumDebugBodyLength]) + "...(truncated)"
+	}
+	log.Printf("[endpoint.logHTTPResponse] group=%s; endpoint=%s; status=%s; headers=%s; body=%s", e.Group, e.Name, response.Status, e.formatHeadersForDebug(response.Header), truncatedBody)
+}
+
+// formatHeadersForDebug returns the headers sorted by name, with the values of the redacted headers replaced
+func (e *Endpoint) formatHeadersForDebug(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	formattedHeaders := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(headers[name], ",")
+		if e.isDebugRedactedHeader(name) {
+			value = redactedHeaderValue
+		}
+		formattedHeaders = append(formattedHeaders, name+": "+value)
+	}
+	return "{" + strings.Join(formattedHeaders, "; ") + "}"
+}
+
+func (e *Endpoint) isDebugRedactedHeader(name string) bool {
+	for _, redactedHeader := range defaultDebugRedactedHeaders {
+		if strings.EqualFold(name, redactedHeader) {
+			return true
+		}
+	}
+	for _, redactedHeader := range e.DebugRedactedHeaders {
+		if strings.EqualFold(name, redactedHeader) {
+			return true
+		}
+	}
+	return false
+}
@@ -18,12 +18,18 @@ type ExternalEndpoint struct {
 	// Enabled defines whether to enable the monitoring of the endpoint
 	Enabled *bool `yaml:"enabled,omitempty"`
 
+	// ID is an optional stable identifier of the endpoint, used to preserve its history when it is renamed
+	ID string `yaml:"id,omitempty"`
+
 	// Name of the endpoint. Can be anything.
 	Name string `yaml:"name"`
 
 	// Group the endpoint is a part of. Used for grouping multiple endpoints together on the front end.
 	Group string `yaml:"group,omitempty"`
 
+	// Labels are arbitrary key/value pairs used to categorize the endpoint beyond its group
+	Labels map[string]string `yaml:"labels,omitempty"`
+
 	// Token is the bearer token that must be provided through the Authorization header to push results to the endpoint
 	Token string `yaml:"token,omitempty"`
 
@@ -35,6 +41,10 @@ type ExternalEndpoint struct {
 
 	// NumberOfSuccessesInARow is the number of successful evaluations in a row
 	NumberOfSuccessesInARow int `yaml:"-"`
+
+	// PageURL is the URL of the external endpoint's page on the dashboard. Populated when the configuration is
+	// validated, if web.public-url is set.
+	PageURL string `yaml:"-"`
 }
 
 // ValidateAndSetDefaults validates the ExternalEndpoint and sets the default values
@@ -73,11 +83,14 @@ func (externalEndpoint *ExternalEndpoint) Key() string {
 func (externalEndpoint *ExternalEndpoint) ToEndpoint() *Endpoint {
 	endpoint := &Endpoint{
 		Enabled:                 externalEndpoint.Enabled,
+		ID:                      externalEndpoint.ID,
 		Name:                    externalEndpoint.Name,
 		Group:                   externalEndpoint.Group,
+		Labels:                  externalEndpoint.Labels,
 		Alerts:                  externalEndpoint.Alerts,
 		NumberOfFailuresInARow:  externalEndpoint.NumberOfFailuresInARow,
 		NumberOfSuccessesInARow: externalEndpoint.NumberOfSuccessesInARow,
+		PageURL:                 externalEndpoint.PageURL,
 	}
 	return endpoint
 }
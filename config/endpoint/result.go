@@ -1,7 +1,15 @@
 package endpoint
 
 import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"sort"
 	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/jsonpath"
 )
 
 // Result of the evaluation of a Endpoint
@@ -9,6 +17,9 @@ type Result struct {
 	// HTTPStatus is the HTTP response status code
 	HTTPStatus int `json:"status,omitempty"`
 
+	// HTTPStatusText is the reason phrase of the HTTP response status line (e.g. Service Unavailable)
+	HTTPStatusText string `json:"-"`
+
 	// DNSRCode is the response code of a DNS query in a human-readable format
 	//
 	// Possible values: NOERROR, FORMERR, SERVFAIL, NXDOMAIN, NOTIMP, REFUSED
@@ -26,6 +37,22 @@ type Result struct {
 	// Duration time that the request took
 	Duration time.Duration `json:"duration"`
 
+	// DNSTime is the time it took to resolve the host of an HTTP request
+	DNSTime time.Duration `json:"-"`
+
+	// ConnectTime is the time it took to establish the TCP connection of an HTTP request or of a TCP endpoint
+	ConnectTime time.Duration `json:"-"`
+
+	// TLSTime is the time it took to perform the TLS handshake of an HTTP request
+	TLSTime time.Duration `json:"-"`
+
+	// TTFB is the time between the moment an HTTP request was sent and the moment the first byte of the response was
+	// received
+	TTFB time.Duration `json:"-"`
+
+	// TLSVersion is the TLS version negotiated with the server (e.g. 1.3)
+	TLSVersion string `json:"-"`
+
 	// Errors encountered during the evaluation of the Endpoint's health
 	Errors []string `json:"errors,omitempty"`
 
@@ -38,17 +65,50 @@ type Result struct {
 	// Timestamp when the request was sent
 	Timestamp time.Time `json:"timestamp"`
 
+	// Alerts are the alerts that were triggered or resolved as a consequence of this result
+	Alerts []*ResultAlert `json:"alerts,omitempty"`
+
+	// Maintenance is whether the result was recorded during a maintenance window
+	Maintenance bool `json:"maintenance,omitempty"`
+
+	// Count is the number of consecutive identical results this result represents, in which case Timestamp is the
+	// timestamp of the most recent one. Only set if the storage deduplicates results.
+	Count int `json:"count,omitempty"`
+
 	// CertificateExpiration is the duration before the certificate expires
 	CertificateExpiration time.Duration `json:"-"`
 
+	// CertificateNotAfter is the time at which the certificate expires
+	CertificateNotAfter time.Time `json:"-"`
+
 	// DomainExpiration is the duration before the domain expires
 	DomainExpiration time.Duration `json:"-"`
 
+	// RedirectURL is the absolute URL in the Location header of the response, if the redirect wasn't followed
+	RedirectURL string `json:"-"`
+
 	// Body is the response body
 	//
 	// Note that this field is not persisted in the storage.
 	// It is used for health evaluation as well as debugging purposes.
 	Body []byte `json:"-"`
+
+	// BodySize is the size of the response body, in bytes
+	BodySize int64 `json:"-"`
+
+	// recentResponseTimes are the response times of the endpoint's most recent evaluations, including this one.
+	// Used to resolve the response time percentile placeholders.
+	recentResponseTimes []time.Duration
+
+	// previousResult is the result of the endpoint's previous evaluation, if any.
+	// Used to resolve the previous result placeholders.
+	previousResult *Result
+
+	// jsonBody is the Body as a JSON document, which is shared by all conditions so that the body is parsed only once
+	jsonBody *jsonpath.Document
+
+	// isValidXMLBody is whether the Body is well-formed XML, or nil if it hasn't been checked yet
+	isValidXMLBody *bool
 }
 
 // AddError adds an error to the result's list of errors.
@@ -62,3 +122,106 @@ func (r *Result) AddError(error string) {
 	}
 	r.Errors = append(r.Errors, error)
 }
+
+// AddAlert records that an alert of the given type was sent as a consequence of this result
+func (r *Result) AddAlert(alertType alert.Type, state ResultAlertState) {
+	r.Alerts = append(r.Alerts, &ResultAlert{Type: alertType, State: state})
+}
+
+// IsIdenticalTo returns whether the result is identical to another result for the purpose of deduplication, which is
+// the case if both have the same outcome, status, errors and condition outcomes, if both were or weren't recorded
+// during a maintenance window, and if their durations fall in the same bucket.
+// A result that triggered or resolved alerts is never identical to another result.
+func (r *Result) IsIdenticalTo(other *Result, durationBucket time.Duration) bool {
+	if r.Success != other.Success || r.HTTPStatus != other.HTTPStatus || r.Maintenance != other.Maintenance || len(r.Alerts) > 0 || len(other.Alerts) > 0 {
+		return false
+	}
+	if durationBucket <= 0 {
+		durationBucket = 1
+	}
+	if r.Duration/durationBucket != other.Duration/durationBucket {
+		return false
+	}
+	if len(r.Errors) != len(other.Errors) {
+		return false
+	}
+	for i := range r.Errors {
+		if r.Errors[i] != other.Errors[i] {
+			return false
+		}
+	}
+	// Two failed results may fail for different reasons, in which case merging them would hide which conditions
+	// failed in the result that was merged into the other
+	if len(r.ConditionResults) != len(other.ConditionResults) {
+		return false
+	}
+	for i := range r.ConditionResults {
+		if r.ConditionResults[i].Condition != other.ConditionResults[i].Condition || r.ConditionResults[i].Success != other.ConditionResults[i].Success {
+			return false
+		}
+	}
+	return true
+}
+
+// bodyAsJSON returns the Body as a JSON document, creating it if it hasn't been created yet
+func (r *Result) bodyAsJSON() *jsonpath.Document {
+	if r.jsonBody == nil {
+		r.jsonBody = jsonpath.NewDocument(r.Body)
+	}
+	return r.jsonBody
+}
+
+// isBodyValidXML returns whether the Body is well-formed XML with a single root element
+func (r *Result) isBodyValidXML() bool {
+	if r.isValidXMLBody == nil {
+		isValid := isValidXML(r.Body)
+		r.isValidXMLBody = &isValid
+	}
+	return *r.isValidXMLBody
+}
+
+func isValidXML(b []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	depth, roots := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return roots == 1 && depth == 0
+		}
+		if err != nil {
+			return false
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				// Text outside the root element
+				return false
+			}
+		}
+	}
+}
+
+// responseTimePercentile returns the nth percentile of the recent response times using the nearest-rank method.
+// If there are no recent response times, the result's own duration is returned.
+func (r *Result) responseTimePercentile(n float64) time.Duration {
+	if len(r.recentResponseTimes) == 0 {
+		return r.Duration
+	}
+	sorted := make([]time.Duration, len(r.recentResponseTimes))
+	copy(sorted, r.recentResponseTimes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := int(math.Ceil(n / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
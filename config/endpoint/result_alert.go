@@ -0,0 +1,25 @@
+package endpoint
+
+import (
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+// ResultAlert is an alert that was sent as a consequence of a Result
+type ResultAlert struct {
+	// Type is the type of the alert, which is also the type of the provider that sent it
+	Type alert.Type `json:"type"`
+
+	// State is whether the alert was triggered or resolved
+	State ResultAlertState `json:"state"`
+}
+
+// ResultAlertState is the state an alert transitioned to when it was sent
+type ResultAlertState string
+
+var (
+	// ResultAlertTriggered is the state of an alert that was sent because it has been triggered
+	ResultAlertTriggered ResultAlertState = "triggered"
+
+	// ResultAlertResolved is the state of an alert that was sent because it has been resolved
+	ResultAlertResolved ResultAlertState = "resolved"
+)
@@ -2,6 +2,7 @@ package endpoint
 
 import (
 	"testing"
+	"time"
 )
 
 func TestResult_AddError(t *testing.T) {
@@ -19,3 +20,107 @@ func TestResult_AddError(t *testing.T) {
 		t.Error("should've had 2 error")
 	}
 }
+
+func TestResult_responseTimePercentile(t *testing.T) {
+	result := &Result{Duration: 5 * time.Millisecond}
+	if percentile := result.responseTimePercentile(95); percentile != 5*time.Millisecond {
+		t.Errorf("expected the result's duration to be used when there are no recent response times, got %s", percentile)
+	}
+	// 1ms, 2ms, ..., 100ms in reverse order to make sure the response times are sorted
+	for i := 100; i > 0; i-- {
+		result.recentResponseTimes = append(result.recentResponseTimes, time.Duration(i)*time.Millisecond)
+	}
+	scenarios := map[float64]time.Duration{
+		50: 50 * time.Millisecond,
+		95: 95 * time.Millisecond,
+		99: 99 * time.Millisecond,
+	}
+	for n, expected := range scenarios {
+		if percentile := result.responseTimePercentile(n); percentile != expected {
+			t.Errorf("expected p%v to be %s, got %s", n, expected, percentile)
+		}
+	}
+	if result.recentResponseTimes[0] != 100*time.Millisecond {
+		t.Error("expected the recent response times not to be modified")
+	}
+}
+
+func TestResult_IsIdenticalTo(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		result   *Result
+		other    *Result
+		bucket   time.Duration
+		expected bool
+	}{
+		{
+			name:     "same-outcome-and-duration-bucket",
+			result:   &Result{Success: true, HTTPStatus: 200, Duration: 31 * time.Millisecond},
+			other:    &Result{Success: true, HTTPStatus: 200, Duration: 48 * time.Millisecond},
+			bucket:   50 * time.Millisecond,
+			expected: true,
+		},
+		{
+			name:     "different-duration-bucket",
+			result:   &Result{Success: true, HTTPStatus: 200, Duration: 31 * time.Millisecond},
+			other:    &Result{Success: true, HTTPStatus: 200, Duration: 51 * time.Millisecond},
+			bucket:   50 * time.Millisecond,
+			expected: false,
+		},
+		{
+			name:     "no-bucket-requires-same-duration",
+			result:   &Result{Success: true, Duration: 31 * time.Millisecond},
+			other:    &Result{Success: true, Duration: 32 * time.Millisecond},
+			expected: false,
+		},
+		{
+			name:     "different-outcome",
+			result:   &Result{Success: true, Duration: 30 * time.Millisecond},
+			other:    &Result{Success: false, Duration: 30 * time.Millisecond},
+			bucket:   50 * time.Millisecond,
+			expected: false,
+		},
+		{
+			name:     "different-status",
+			result:   &Result{Success: false, HTTPStatus: 500, Duration: 30 * time.Millisecond},
+			other:    &Result{Success: false, HTTPStatus: 502, Duration: 30 * time.Millisecond},
+			bucket:   50 * time.Millisecond,
+			expected: false,
+		},
+		{
+			name:     "different-errors",
+			result:   &Result{Success: false, Errors: []string{"timeout"}, Duration: 30 * time.Millisecond},
+			other:    &Result{Success: false, Errors: []string{"connection refused"}, Duration: 30 * time.Millisecond},
+			bucket:   50 * time.Millisecond,
+			expected: false,
+		},
+		{
+			name:     "same-condition-outcomes",
+			result:   &Result{Success: false, Duration: 30 * time.Millisecond, ConditionResults: []*ConditionResult{{Condition: "[STATUS] == 200", Success: true}, {Condition: "[RESPONSE_TIME] < 500", Success: false, Message: "[RESPONSE_TIME] (501) < 500"}}},
+			other:    &Result{Success: false, Duration: 30 * time.Millisecond, ConditionResults: []*ConditionResult{{Condition: "[STATUS] == 200", Success: true}, {Condition: "[RESPONSE_TIME] < 500", Success: false, Message: "[RESPONSE_TIME] (502) < 500"}}},
+			bucket:   50 * time.Millisecond,
+			expected: true,
+		},
+		{
+			name:     "different-condition-outcomes",
+			result:   &Result{Success: false, Duration: 30 * time.Millisecond, ConditionResults: []*ConditionResult{{Condition: "[STATUS] == 200", Success: true}, {Condition: "[BODY].status == UP", Success: false}}},
+			other:    &Result{Success: false, Duration: 30 * time.Millisecond, ConditionResults: []*ConditionResult{{Condition: "[STATUS] == 200", Success: false}, {Condition: "[BODY].status == UP", Success: true}}},
+			bucket:   50 * time.Millisecond,
+			expected: false,
+		},
+		{
+			name:     "with-alerts",
+			result:   &Result{Success: false, Duration: 30 * time.Millisecond},
+			other:    &Result{Success: false, Duration: 30 * time.Millisecond, Alerts: []*ResultAlert{{Type: "slack", State: ResultAlertTriggered}}},
+			bucket:   50 * time.Millisecond,
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.result.IsIdenticalTo(scenario.other, scenario.bucket); actual != scenario.expected {
+				t.Errorf("expected %t, got %t", scenario.expected, actual)
+			}
+		})
+	}
+}
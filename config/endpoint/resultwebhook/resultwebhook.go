@@ -0,0 +1,147 @@
+package resultwebhook
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+const (
+	// DefaultQueueSize is the default number of results that may be waiting to be sent to the webhook before new
+	// results are dropped
+	DefaultQueueSize = 100
+
+	// dropLogInterval is the minimum duration between two logs about results dropped because the queue was full
+	dropLogInterval = time.Minute
+)
+
+var (
+	ErrInvalidURL       = errors.New("result-webhook.url must start with http:// or https://")
+	ErrInvalidQueueSize = errors.New("result-webhook.queue-size must not be negative")
+)
+
+// Config is the configuration of a webhook to which every result of an endpoint is sent, as opposed to alerting
+// providers, which are only notified when an alert is triggered or resolved.
+//
+// Results are sent in the background, one at a time and in the order they were queued, so that a slow webhook
+// doesn't delay the monitoring of endpoints. If QueueSize results are already waiting to be sent, new results are
+// dropped.
+type Config struct {
+	// URL is the URL the results are sent to with a POST request
+	URL string `yaml:"url"`
+
+	// Headers are the headers sent with each request
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// QueueSize is the maximum number of results waiting to be sent.
+	// Defaults to DefaultQueueSize.
+	QueueSize int `yaml:"queue-size,omitempty"`
+
+	queue     chan []byte
+	startOnce sync.Once
+	mutex     sync.RWMutex // protects closed and prevents queue from being closed while a result is being queued
+	closed    bool
+
+	dropMutex      sync.Mutex // protects droppedResults and lastDropLog
+	droppedResults int        // number of results dropped since the last time drops were logged
+	lastDropLog    time.Time
+}
+
+// ValidateAndSetDefaults validates the configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
+		return ErrInvalidURL
+	}
+	if c.QueueSize < 0 {
+		return ErrInvalidQueueSize
+	} else if c.QueueSize == 0 {
+		c.QueueSize = DefaultQueueSize
+	}
+	return nil
+}
+
+// Enqueue queues a request body to be sent to the webhook without waiting for it to be sent.
+//
+// Returns false if the body was dropped, either because the queue is full or because the webhook has been closed.
+func (c *Config) Enqueue(body []byte) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.closed {
+		return false
+	}
+	c.startOnce.Do(func() {
+		c.queue = make(chan []byte, c.QueueSize)
+		go c.deliver(c.queue)
+	})
+	select {
+	case c.queue <- body:
+		return true
+	default:
+		c.recordDrop()
+		return false
+	}
+}
+
+// recordDrop counts a result dropped because the queue was full and logs the number of dropped results, at most once
+// every dropLogInterval so that a webhook that can't keep up doesn't flood the logs
+func (c *Config) recordDrop() {
+	c.dropMutex.Lock()
+	defer c.dropMutex.Unlock()
+	c.droppedResults++
+	if time.Since(c.lastDropLog) < dropLogInterval {
+		return
+	}
+	log.Printf("[resultwebhook.Enqueue] Dropped %d result(s) because the queue of the result webhook to %s is full", c.droppedResults, c.URL)
+	c.droppedResults = 0
+	c.lastDropLog = time.Now()
+}
+
+// Close stops accepting new results. Results that have already been queued are still sent.
+func (c *Config) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	if c.queue != nil {
+		close(c.queue)
+	}
+}
+
+// deliver sends the queued request bodies to the webhook until the queue is closed
+func (c *Config) deliver(queue <-chan []byte) {
+	for body := range queue {
+		if err := c.send(body); err != nil {
+			log.Printf("[resultwebhook.deliver] Failed to send result to %s: %s", c.URL, err.Error())
+		}
+	}
+}
+
+func (c *Config) send(body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range c.Headers {
+		request.Header.Set(name, value)
+	}
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return errors.New("webhook returned status code " + response.Status + ": " + string(responseBody))
+	}
+	return nil
+}
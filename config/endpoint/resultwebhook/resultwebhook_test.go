@@ -0,0 +1,167 @@
+package resultwebhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		config            *Config
+		expectedErr       error
+		expectedQueueSize int
+	}{
+		{
+			name:              "default-queue-size",
+			config:            &Config{URL: "https://example.org/results"},
+			expectedQueueSize: DefaultQueueSize,
+		},
+		{
+			name:              "custom-queue-size",
+			config:            &Config{URL: "http://example.org/results", QueueSize: 5},
+			expectedQueueSize: 5,
+		},
+		{
+			name:        "no-url",
+			config:      &Config{},
+			expectedErr: ErrInvalidURL,
+		},
+		{
+			name:        "invalid-url",
+			config:      &Config{URL: "example.org/results"},
+			expectedErr: ErrInvalidURL,
+		},
+		{
+			name:        "negative-queue-size",
+			config:      &Config{URL: "https://example.org/results", QueueSize: -1},
+			expectedErr: ErrInvalidQueueSize,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && scenario.config.QueueSize != scenario.expectedQueueSize {
+				t.Errorf("expected queue size %d, got %d", scenario.expectedQueueSize, scenario.config.QueueSize)
+			}
+		})
+	}
+}
+
+func TestConfig_Enqueue(t *testing.T) {
+	var mutex sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		bodies = append(bodies, string(body))
+		mutex.Unlock()
+	}))
+	defer server.Close()
+	webhook := &Config{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}}
+	if err := webhook.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer webhook.Close()
+	for i := 0; i < 10; i++ {
+		if !webhook.Enqueue([]byte(strconv.Itoa(i))) {
+			t.Fatalf("expected result %d to be queued", i)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mutex.Lock()
+		numberOfBodies := len(bodies)
+		mutex.Unlock()
+		if numberOfBodies == 10 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 10 results to be delivered, got %d", numberOfBodies)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i, body := range bodies {
+		if body != strconv.Itoa(i) {
+			t.Errorf("expected results to be delivered in order, got %s at position %d", body, i)
+		}
+	}
+}
+
+func TestConfig_EnqueueDropsWhenQueueIsFull(t *testing.T) {
+	release := make(chan struct{})
+	var mutex sync.Mutex
+	numberOfRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		numberOfRequests++
+		mutex.Unlock()
+		<-release
+	}))
+	defer server.Close()
+	webhook := &Config{URL: server.URL, QueueSize: 2}
+	if err := webhook.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	start := time.Now()
+	numberOfQueuedResults := 0
+	for i := 0; i < 50; i++ {
+		if webhook.Enqueue([]byte(strconv.Itoa(i))) {
+			numberOfQueuedResults++
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected queueing results not to wait for the webhook, took %s", elapsed)
+	}
+	// At most one result may be in flight in addition to the ones waiting in the queue
+	if numberOfQueuedResults < 2 || numberOfQueuedResults > 3 {
+		t.Errorf("expected 2 or 3 results to be queued and the others to be dropped, got %d queued", numberOfQueuedResults)
+	}
+	// Only the first drop is logged right away, the others are counted until the next log
+	webhook.dropMutex.Lock()
+	droppedResults := webhook.droppedResults
+	webhook.dropMutex.Unlock()
+	if expected := 50 - numberOfQueuedResults - 1; droppedResults != expected {
+		t.Errorf("expected %d dropped results to be waiting to be logged, got %d", expected, droppedResults)
+	}
+	close(release)
+	webhook.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mutex.Lock()
+		delivered := numberOfRequests
+		mutex.Unlock()
+		if delivered == numberOfQueuedResults {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the %d queued results to be delivered, got %d", numberOfQueuedResults, delivered)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestConfig_Close(t *testing.T) {
+	webhook := &Config{URL: "http://127.0.0.1:1/results"}
+	if err := webhook.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	webhook.Close()
+	webhook.Close()
+	if webhook.Enqueue([]byte("{}")) {
+		t.Error("expected results not to be queued once the webhook is closed")
+	}
+}
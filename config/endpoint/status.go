@@ -8,6 +8,9 @@ type Status struct {
 	// Group the endpoint is a part of. Used for grouping multiple endpoints together on the front end.
 	Group string `json:"group,omitempty"`
 
+	// Labels of the endpoint. Not persisted by the storage; populated by the API from the configuration.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Key of the Endpoint
 	Key string `json:"key"`
 
@@ -0,0 +1,163 @@
+package endpoint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// CapturePlaceholder is the prefix of the placeholders replaced by the values captured by the steps of an
+	// endpoint, followed by the name of the value (e.g. [CAPTURE].token).
+	//
+	// Supported in the URL, headers and body of the steps following the one that captured the value, as well as in
+	// those of the endpoint itself.
+	CapturePlaceholder = "[CAPTURE]"
+)
+
+var (
+	// ErrStepWithInvalidURL is the error with which Gatus will panic if a step has a URL that isn't an HTTP URL
+	ErrStepWithInvalidURL = errors.New("invalid step: url must start with http:// or https://")
+
+	// ErrStepWithInvalidCapture is the error with which Gatus will panic if a step captures a value with an invalid
+	// name or without specifying what to capture
+	ErrStepWithInvalidCapture = errors.New("invalid step capture: name must only contain letters, digits and underscores, and the value must not be empty")
+
+	// ErrEndpointWithStepsAndNonHTTPType is the error with which Gatus will panic if an endpoint that isn't an HTTP
+	// endpoint has steps
+	ErrEndpointWithStepsAndNonHTTPType = errors.New("steps are only supported by HTTP endpoints")
+
+	captureNamePattern = regexp.MustCompile(`^\w+$`)
+)
+
+// Step is a request sent before the request of an endpoint, such as a login request, whose response is used to build
+// the requests that follow it.
+//
+// Cookies set by the responses of the steps are sent with the following requests, and values captured from them
+// can be used through the CapturePlaceholder.
+type Step struct {
+	// Name of the step, used in errors
+	Name string `yaml:"name,omitempty"`
+
+	// URL to send the request to
+	URL string `yaml:"url"`
+
+	// Method of the request. Defaults to GET.
+	Method string `yaml:"method,omitempty"`
+
+	// Body of the request
+	Body string `yaml:"body,omitempty"`
+
+	// Headers of the request
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Capture maps the name of each value to capture to what it is captured from, which may be any placeholder
+	// supported by conditions (e.g. [BODY].token, [BODY].regex(csrf=(\w+)), [STATUS]).
+	Capture map[string]string `yaml:"capture,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the step's configuration and sets the default value of fields that have one
+func (s *Step) ValidateAndSetDefaults() error {
+	if !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://") {
+		return ErrStepWithInvalidURL
+	}
+	if len(s.Method) == 0 {
+		s.Method = http.MethodGet
+	}
+	if len(s.Headers) == 0 {
+		s.Headers = make(map[string]string)
+	}
+	hasUserAgent := false
+	for name := range s.Headers {
+		hasUserAgent = hasUserAgent || strings.EqualFold(name, UserAgentHeader)
+	}
+	if !hasUserAgent {
+		s.Headers[UserAgentHeader] = GatusUserAgent
+	}
+	for name, element := range s.Capture {
+		if !captureNamePattern.MatchString(name) || len(strings.TrimSpace(element)) == 0 {
+			return fmt.Errorf("%w: %s", ErrStepWithInvalidCapture, name)
+		}
+	}
+	return nil
+}
+
+// executeSteps sends the requests of the endpoint's steps in order, and returns the values they captured as well as
+// a client sending the cookies set by their responses, which must be used to send the endpoint's own request.
+func (e *Endpoint) executeSteps(httpClient *http.Client) (*http.Client, map[string]string, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	stepClient := *httpClient
+	stepClient.Jar = jar
+	captures := make(map[string]string)
+	for i, step := range e.Steps {
+		name := step.Name
+		if len(name) == 0 {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		request, err := http.NewRequest(step.Method, replaceCaptures(step.URL, captures), bytes.NewBufferString(replaceCaptures(step.Body, captures)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("step %s: %w", name, err)
+		}
+		for k, v := range step.Headers {
+			request.Header.Set(k, replaceCaptures(v, captures))
+		}
+		if e.Debug {
+			e.logHTTPRequest(request)
+		}
+		response, err := stepClient.Do(request)
+		if err != nil {
+			return nil, nil, fmt.Errorf("step %s: %w", name, err)
+		}
+		body, err := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("step %s: error reading response body: %w", name, err)
+		}
+		if body, err = decompressBody(body, response.Header.Get(ContentEncodingHeader)); err != nil {
+			return nil, nil, fmt.Errorf("step %s: error decompressing response body: %w", name, err)
+		}
+		if e.Debug {
+			e.logHTTPResponse(response, body)
+		}
+		if response.StatusCode >= 400 {
+			return nil, nil, fmt.Errorf("step %s: returned status code %d", name, response.StatusCode)
+		}
+		stepResult := &Result{HTTPStatus: response.StatusCode, HTTPStatusText: statusText(response), Body: body}
+		for captureName, element := range step.Capture {
+			_, resolvedElements := sanitizeAndResolve([]string{element}, stepResult)
+			if strings.HasSuffix(resolvedElements[0], InvalidConditionElementSuffix) {
+				return nil, nil, fmt.Errorf("step %s: failed to capture %s from %s", name, captureName, element)
+			}
+			captures[captureName] = resolvedElements[0]
+		}
+	}
+	return &stepClient, captures, nil
+}
+
+// replaceCaptures replaces the CapturePlaceholder of each captured value in s by said value
+func replaceCaptures(s string, captures map[string]string) string {
+	if len(captures) == 0 || !strings.Contains(s, CapturePlaceholder) {
+		return s
+	}
+	// Replace the longest names first so that a name that is a prefix of another doesn't replace part of the latter
+	names := make([]string, 0, len(captures))
+	for name := range captures {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+	for _, name := range names {
+		s = strings.ReplaceAll(s, CapturePlaceholder+"."+name, captures[name])
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package endpoint
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStep_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		step        *Step
+		expectedErr error
+	}{
+		{name: "valid", step: &Step{URL: "https://example.org/login", Capture: map[string]string{"token": "[BODY].token"}}},
+		{name: "no-url", step: &Step{}, expectedErr: ErrStepWithInvalidURL},
+		{name: "non-http-url", step: &Step{URL: "tcp://example.org:80"}, expectedErr: ErrStepWithInvalidURL},
+		{name: "invalid-capture-name", step: &Step{URL: "https://example.org/login", Capture: map[string]string{"the token": "[BODY].token"}}, expectedErr: ErrStepWithInvalidCapture},
+		{name: "empty-capture", step: &Step{URL: "https://example.org/login", Capture: map[string]string{"token": " "}}, expectedErr: ErrStepWithInvalidCapture},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.step.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && (scenario.step.Method != http.MethodGet || scenario.step.Headers[UserAgentHeader] != GatusUserAgent) {
+				t.Errorf("expected the method and user agent to default to GET and %s, got %s and %s", GatusUserAgent, scenario.step.Method, scenario.step.Headers[UserAgentHeader])
+			}
+		})
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithStepsAndNonHTTPType(t *testing.T) {
+	endpoint := Endpoint{
+		Name:       "tcp-with-steps",
+		URL:        "tcp://127.0.0.1:80",
+		Steps:      []*Step{{URL: "https://example.org/login"}},
+		Conditions: []Condition{"[CONNECTED] == true"},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, ErrEndpointWithStepsAndNonHTTPType) {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithStepsAndNonHTTPType, err)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithSteps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || string(body) != `{"username":"john.doe","password":"hunter2"}` {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3ss10n", Path: "/"})
+			_, _ = w.Write([]byte(`{"token":"t0k3n","user":{"id":42}}`))
+		case "/users/42":
+			if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "s3ss10n" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer t0k3n" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"John Doe"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                string
+		password            string
+		capture             map[string]string
+		expectedSuccess     bool
+		expectedStatus      int
+		expectedErrorPrefix string
+	}{
+		{
+			name:            "authenticated",
+			password:        "hunter2",
+			capture:         map[string]string{"token": "[BODY].token", "user_id": "[BODY].user.id"},
+			expectedSuccess: true,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:                "login-rejected",
+			password:            "wrong",
+			capture:             map[string]string{"token": "[BODY].token", "user_id": "[BODY].user.id"},
+			expectedSuccess:     false,
+			expectedErrorPrefix: "step login: returned status code 401",
+		},
+		{
+			name:                "capture-failed",
+			password:            "hunter2",
+			capture:             map[string]string{"token": "[BODY].access_token", "user_id": "[BODY].user.id"},
+			expectedSuccess:     false,
+			expectedErrorPrefix: "step login: failed to capture token from [BODY].access_token",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name: "login-flow",
+				URL:  server.URL + "/users/[CAPTURE].user_id",
+				Steps: []*Step{
+					{
+						Name:    "login",
+						URL:     server.URL + "/login",
+						Method:  http.MethodPost,
+						Body:    `{"username":"john.doe","password":"` + scenario.password + `"}`,
+						Capture: scenario.capture,
+					},
+				},
+				Headers:    map[string]string{"Authorization": "Bearer [CAPTURE].token"},
+				Conditions: []Condition{"[STATUS] == 200", "[BODY].name == John Doe"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if result.HTTPStatus != scenario.expectedStatus {
+				t.Errorf("expected status %d, got %d", scenario.expectedStatus, result.HTTPStatus)
+			}
+			if len(scenario.expectedErrorPrefix) > 0 && (len(result.Errors) == 0 || !strings.HasPrefix(result.Errors[0], scenario.expectedErrorPrefix)) {
+				t.Errorf("expected error starting with %q, got %v", scenario.expectedErrorPrefix, result.Errors)
+			}
+		})
+	}
+}
+
+func TestReplaceCaptures(t *testing.T) {
+	captures := map[string]string{"id": "1", "id_token": "abc"}
+	if replaced := replaceCaptures("[CAPTURE].id/[CAPTURE].id_token/[CAPTURE].unknown", captures); replaced != "1/abc/[CAPTURE].unknown" {
+		t.Errorf("expected 1/abc/[CAPTURE].unknown, got %s", replaced)
+	}
+	if replaced := replaceCaptures("[CAPTURE].id", nil); replaced != "[CAPTURE].id" {
+		t.Errorf("expected the placeholder to be left as is without captures, got %s", replaced)
+	}
+}
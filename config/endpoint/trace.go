@@ -0,0 +1,60 @@
+package endpoint
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// traceHTTPRequest returns a copy of the request that records the duration of each phase of the request in the
+// result passed as parameter.
+//
+// If the request is redirected, the durations are those of the last request. If a connection is reused, no DNS
+// lookup, TCP connection or TLS handshake takes place, so their durations are 0.
+func traceHTTPRequest(request *http.Request, result *Result) *http.Request {
+	var mutex sync.Mutex // The hooks may be called concurrently, e.g. when dialing multiple addresses
+	var dnsStart, connectStart, tlsStart time.Time
+	start := time.Now()
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			result.DNSTime = time.Since(dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			connectStart = time.Now()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err == nil {
+				result.ConnectTime = time.Since(connectStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			result.TLSTime = time.Since(tlsStart)
+		},
+		GotFirstResponseByte: func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			result.TTFB = time.Since(start)
+		},
+	}
+	return request.WithContext(httptrace.WithClientTrace(request.Context(), trace))
+}
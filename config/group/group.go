@@ -0,0 +1,96 @@
+package group
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/pattern"
+)
+
+var (
+	// ErrGroupWithNoName is the error returned when a group has no name
+	ErrGroupWithNoName = errors.New("group must have a name")
+
+	// ErrGroupWithInvalidName is the error returned when the name of a group is an invalid regex pattern
+	ErrGroupWithInvalidName = errors.New("group name must be a valid group, wildcard or regex pattern")
+
+	// ErrGroupWithNegativeValue is the error returned when a group has a negative interval or threshold
+	ErrGroupWithNegativeValue = errors.New("group interval, failure-threshold and success-threshold must not be negative")
+)
+
+// Config is the configuration shared by the endpoints of a group, which each endpoint inherits unless it sets its own
+type Config struct {
+	// Name of the group the configuration applies to. Like the groups of the overrides of alerting providers, it may be
+	// a wildcard (e.g. core/*) or a regex pattern (e.g. regex:^core-.+$).
+	Name string `yaml:"name"`
+
+	// Interval is the interval of the endpoints of the group that don't have one
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// FailureThreshold is the failure threshold of the alerts of the endpoints of the group that don't have one
+	FailureThreshold int `yaml:"failure-threshold,omitempty"`
+
+	// SuccessThreshold is the success threshold of the alerts of the endpoints of the group that don't have one
+	SuccessThreshold int `yaml:"success-threshold,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the group's configuration
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Name) == 0 {
+		return ErrGroupWithNoName
+	}
+	if !pattern.IsValidGroup(c.Name) {
+		return ErrGroupWithInvalidName
+	}
+	if c.Interval < 0 || c.FailureThreshold < 0 || c.SuccessThreshold < 0 {
+		return ErrGroupWithNegativeValue
+	}
+	return nil
+}
+
+// Find returns the configuration of the given group, or nil if there is none.
+// A configuration whose name is the exact group takes precedence over configurations with a pattern matching it.
+func Find(groups []*Config, group string) *Config {
+	if len(group) == 0 {
+		return nil
+	}
+	for _, g := range groups {
+		if g.Name == group {
+			return g
+		}
+	}
+	for _, g := range groups {
+		if pattern.MatchGroup(g.Name, group) {
+			return g
+		}
+	}
+	return nil
+}
+
+// ApplyToEndpoint sets the interval of the endpoint and the thresholds of its alerts to those of the group, unless
+// they were explicitly set. Endpoints with a schedule don't inherit the interval, since they cannot have both.
+func (c *Config) ApplyToEndpoint(ep *endpoint.Endpoint) {
+	if ep.Interval == 0 && len(ep.Schedule) == 0 {
+		ep.Interval = c.Interval
+	}
+	c.applyToAlerts(ep.Alerts)
+}
+
+// ApplyToExternalEndpoint sets the thresholds of the alerts of the external endpoint to those of the group, unless
+// they were explicitly set
+func (c *Config) ApplyToExternalEndpoint(ee *endpoint.ExternalEndpoint) {
+	c.applyToAlerts(ee.Alerts)
+}
+
+func (c *Config) applyToAlerts(alerts []*alert.Alert) {
+	for _, a := range alerts {
+		if a.FailureThreshold == 0 {
+			a.FailureThreshold = c.FailureThreshold
+		}
+		if a.SuccessThreshold == 0 {
+			a.SuccessThreshold = c.SuccessThreshold
+		}
+	}
+}
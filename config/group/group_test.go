@@ -0,0 +1,90 @@
+package group
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{name: "valid", cfg: &Config{Name: "core", Interval: time.Minute, FailureThreshold: 5, SuccessThreshold: 2}},
+		{name: "valid-pattern", cfg: &Config{Name: "regex:^core-.+$"}},
+		{name: "no-name", cfg: &Config{Interval: time.Minute}, expectedErr: ErrGroupWithNoName},
+		{name: "invalid-regex", cfg: &Config{Name: "regex:("}, expectedErr: ErrGroupWithInvalidName},
+		{name: "negative-interval", cfg: &Config{Name: "core", Interval: -time.Minute}, expectedErr: ErrGroupWithNegativeValue},
+		{name: "negative-failure-threshold", cfg: &Config{Name: "core", FailureThreshold: -1}, expectedErr: ErrGroupWithNegativeValue},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	groups := []*Config{
+		{Name: "core/*"},
+		{Name: "core/api"},
+		{Name: "internal"},
+	}
+	scenarios := []struct {
+		group    string
+		expected *Config
+	}{
+		{group: "core/api", expected: groups[1]},
+		{group: "core/web", expected: groups[0]},
+		{group: "internal", expected: groups[2]},
+		{group: "external", expected: nil},
+		{group: "", expected: nil},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.group, func(t *testing.T) {
+			if actual := Find(groups, scenario.group); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyToEndpoint(t *testing.T) {
+	g := &Config{Name: "core", Interval: 5 * time.Minute, FailureThreshold: 10, SuccessThreshold: 4}
+	ep := &endpoint.Endpoint{
+		Name:     "name",
+		Group:    "core",
+		Interval: 30 * time.Second,
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeSlack},
+			{Type: alert.TypePagerDuty, FailureThreshold: 2},
+		},
+	}
+	g.ApplyToEndpoint(ep)
+	if ep.Interval != 30*time.Second {
+		t.Errorf("expected the interval of the endpoint to take precedence, got %s", ep.Interval)
+	}
+	if ep.Alerts[0].FailureThreshold != 10 || ep.Alerts[0].SuccessThreshold != 4 {
+		t.Errorf("expected the thresholds of the group to be inherited, got %d and %d", ep.Alerts[0].FailureThreshold, ep.Alerts[0].SuccessThreshold)
+	}
+	if ep.Alerts[1].FailureThreshold != 2 || ep.Alerts[1].SuccessThreshold != 4 {
+		t.Errorf("expected the failure threshold of the alert to take precedence, got %d and %d", ep.Alerts[1].FailureThreshold, ep.Alerts[1].SuccessThreshold)
+	}
+	ep = &endpoint.Endpoint{Name: "name", Group: "core"}
+	g.ApplyToEndpoint(ep)
+	if ep.Interval != 5*time.Minute {
+		t.Errorf("expected the interval of the group to be inherited, got %s", ep.Interval)
+	}
+	ep = &endpoint.Endpoint{Name: "name", Group: "core", Schedule: "0 3 * * *"}
+	g.ApplyToEndpoint(ep)
+	if ep.Interval != 0 {
+		t.Errorf("expected the interval of the group not to be inherited by an endpoint with a schedule, got %s", ep.Interval)
+	}
+}
@@ -0,0 +1,156 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+const (
+	// DefaultInterval is the default interval at which heartbeats are sent
+	DefaultInterval = time.Minute
+
+	opsGenieHeartbeatAPIURL = "https://api.opsgenie.com/v2/heartbeats/"
+)
+
+var (
+	ErrURLAndOpsGenie        = errors.New("heartbeat must have either a url or an opsgenie configuration, but not both")
+	ErrInvalidURL            = errors.New("heartbeat.url must start with http:// or https://")
+	ErrInvalidOpsGenieConfig = errors.New("heartbeat.opsgenie.api-key and heartbeat.opsgenie.name must be specified")
+	ErrInvalidInterval       = errors.New("heartbeat.interval must not be negative")
+)
+
+// Config is the configuration of the heartbeats Gatus sends to a dead man's switch (e.g. an OpsGenie heartbeat) while
+// it is healthy, so that the service on the other end can alert when Gatus itself stops working.
+type Config struct {
+	// URL is the URL the heartbeats are sent to. Mutually exclusive with OpsGenie.
+	URL string `yaml:"url,omitempty"`
+
+	// Method is the method of the heartbeat requests. Defaults to GET.
+	Method string `yaml:"method,omitempty"`
+
+	// Headers are the headers sent with each heartbeat
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// OpsGenie is the configuration of an OpsGenie heartbeat to ping. Mutually exclusive with URL.
+	OpsGenie *OpsGenieConfig `yaml:"opsgenie,omitempty"`
+
+	// Interval is the interval at which heartbeats are sent. Defaults to DefaultInterval.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// OpsGenieConfig is the configuration of an OpsGenie heartbeat
+type OpsGenieConfig struct {
+	// APIKey is the key of an OpsGenie API integration
+	APIKey string `yaml:"api-key"`
+
+	// Name is the name of the heartbeat, as configured in OpsGenie
+	Name string `yaml:"name"`
+}
+
+// ValidateAndSetDefaults validates the configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if (len(c.URL) > 0) == (c.OpsGenie != nil) {
+		return ErrURLAndOpsGenie
+	}
+	if c.OpsGenie != nil {
+		if len(c.OpsGenie.APIKey) == 0 || len(c.OpsGenie.Name) == 0 {
+			return ErrInvalidOpsGenieConfig
+		}
+	} else if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
+		return ErrInvalidURL
+	}
+	if len(c.Method) == 0 {
+		c.Method = http.MethodGet
+	}
+	if c.Interval < 0 {
+		return ErrInvalidInterval
+	} else if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	return nil
+}
+
+// Start sends a heartbeat immediately and then every Interval in the background until Stop is called.
+//
+// No heartbeat is sent while healthCheck returns an error, which lets the service on the other end alert about it.
+func (c *Config) Start(healthCheck func() error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel, c.done = cancel, make(chan struct{})
+	go c.run(ctx, healthCheck, c.done)
+}
+
+// Stop stops sending heartbeats, and waits for the heartbeat being sent, if any, to be canceled
+func (c *Config) Stop() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+	c.cancel, c.done = nil, nil
+}
+
+func (c *Config) run(ctx context.Context, healthCheck func() error, done chan struct{}) {
+	defer close(done)
+	for {
+		if err := healthCheck(); err != nil {
+			log.Printf("[heartbeat.run] Not sending heartbeat, because Gatus is unhealthy: %s", err.Error())
+		} else if err := c.send(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[heartbeat.run] Failed to send heartbeat: %s", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.Interval):
+		}
+	}
+}
+
+// send sends a single heartbeat
+//
+// Relevant: https://docs.opsgenie.com/docs/heartbeat-api#ping-heartbeat-request
+func (c *Config) send(ctx context.Context) error {
+	request, err := c.buildHTTPRequest(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("heartbeat returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (c *Config) buildHTTPRequest(ctx context.Context) (*http.Request, error) {
+	heartbeatURL := c.URL
+	if c.OpsGenie != nil {
+		heartbeatURL = opsGenieHeartbeatAPIURL + url.PathEscape(c.OpsGenie.Name) + "/ping"
+	}
+	request, err := http.NewRequestWithContext(ctx, c.Method, heartbeatURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range c.Headers {
+		request.Header.Set(name, value)
+	}
+	if c.OpsGenie != nil {
+		request.Header.Set("Authorization", "GenieKey "+c.OpsGenie.APIKey)
+	}
+	return request, nil
+}
@@ -0,0 +1,142 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		cfg              *Config
+		expectedErr      error
+		expectedInterval time.Duration
+	}{
+		{
+			name:             "url",
+			cfg:              &Config{URL: "https://example.org/ping"},
+			expectedInterval: DefaultInterval,
+		},
+		{
+			name:             "opsgenie",
+			cfg:              &Config{OpsGenie: &OpsGenieConfig{APIKey: "key", Name: "gatus"}, Interval: 30 * time.Second},
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			name:        "neither-url-nor-opsgenie",
+			cfg:         &Config{},
+			expectedErr: ErrURLAndOpsGenie,
+		},
+		{
+			name:        "both-url-and-opsgenie",
+			cfg:         &Config{URL: "https://example.org/ping", OpsGenie: &OpsGenieConfig{APIKey: "key", Name: "gatus"}},
+			expectedErr: ErrURLAndOpsGenie,
+		},
+		{
+			name:        "invalid-url",
+			cfg:         &Config{URL: "example.org/ping"},
+			expectedErr: ErrInvalidURL,
+		},
+		{
+			name:        "opsgenie-without-name",
+			cfg:         &Config{OpsGenie: &OpsGenieConfig{APIKey: "key"}},
+			expectedErr: ErrInvalidOpsGenieConfig,
+		},
+		{
+			name:        "negative-interval",
+			cfg:         &Config{URL: "https://example.org/ping", Interval: -time.Second},
+			expectedErr: ErrInvalidInterval,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && scenario.cfg.Interval != scenario.expectedInterval {
+				t.Errorf("expected interval to be %s, got %s", scenario.expectedInterval, scenario.cfg.Interval)
+			}
+		})
+	}
+}
+
+func TestConfig_buildHTTPRequestWithOpsGenie(t *testing.T) {
+	cfg := &Config{OpsGenie: &OpsGenieConfig{APIKey: "key", Name: "gatus prod"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	request, err := cfg.buildHTTPRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request.Method != http.MethodGet {
+		t.Errorf("expected method %s, got %s", http.MethodGet, request.Method)
+	}
+	if expectedURL := "https://api.opsgenie.com/v2/heartbeats/gatus%20prod/ping"; request.URL.String() != expectedURL {
+		t.Errorf("expected URL %s, got %s", expectedURL, request.URL.String())
+	}
+	if authorization := request.Header.Get("Authorization"); authorization != "GenieKey key" {
+		t.Errorf("expected Authorization header to be %q, got %q", "GenieKey key", authorization)
+	}
+}
+
+func TestConfig_StartAndStop(t *testing.T) {
+	var numberOfHeartbeats atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			t.Errorf("expected the configured headers to be sent")
+		}
+		numberOfHeartbeats.Add(1)
+	}))
+	defer server.Close()
+	cfg := &Config{URL: server.URL, Headers: map[string]string{"X-Token": "secret"}, Interval: 10 * time.Millisecond}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Start(func() error { return nil })
+	deadline := time.Now().Add(5 * time.Second)
+	for numberOfHeartbeats.Load() < 3 {
+		if time.Now().After(deadline) {
+			cfg.Stop()
+			t.Fatalf("expected heartbeats to be sent every interval, got %d", numberOfHeartbeats.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cfg.Stop()
+	numberOfHeartbeatsAtStop := numberOfHeartbeats.Load()
+	time.Sleep(50 * time.Millisecond)
+	if numberOfHeartbeats.Load() != numberOfHeartbeatsAtStop {
+		t.Errorf("expected no heartbeat to be sent after stopping, got %d more", numberOfHeartbeats.Load()-numberOfHeartbeatsAtStop)
+	}
+	// Stopping twice must not block or panic
+	cfg.Stop()
+}
+
+func TestConfig_StartWhileUnhealthy(t *testing.T) {
+	var numberOfHeartbeats atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfHeartbeats.Add(1)
+	}))
+	defer server.Close()
+	cfg := &Config{URL: server.URL, Interval: 10 * time.Millisecond}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	var healthChecks atomic.Int32
+	cfg.Start(func() error {
+		healthChecks.Add(1)
+		return errors.New("storage is unreachable")
+	})
+	for healthChecks.Load() < 3 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cfg.Stop()
+	if numberOfHeartbeats.Load() != 0 {
+		t.Errorf("expected no heartbeat to be sent while unhealthy, got %d", numberOfHeartbeats.Load())
+	}
+}
@@ -0,0 +1,158 @@
+package maintenance
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/pattern"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidWindowDuration is the error returned when creating a maintenance window with a duration that isn't
+	// greater than 0
+	ErrInvalidWindowDuration = errors.New("invalid maintenance window duration: must be bigger than 0 (e.g. 30m)")
+
+	// ErrInvalidWindowGroup is the error returned when creating a maintenance window with an invalid group filter
+	ErrInvalidWindowGroup = errors.New("invalid maintenance window group")
+
+	// ErrInvalidWindowEnd is the error returned when restoring a maintenance window that doesn't end after it starts
+	ErrInvalidWindowEnd = errors.New("invalid maintenance window end: must be after its start")
+
+	windows      = make(map[string]*Window)
+	windowsMutex sync.RWMutex
+)
+
+// Window is a maintenance window created at runtime (e.g. through the API) rather than through the configuration.
+// During this window, no alerts will be sent for the endpoints it applies to.
+type Window struct {
+	// ID is the unique identifier of the window, used to cancel it
+	ID string `json:"id"`
+
+	// Group is the group of the endpoints the window applies to. Supports the same syntax as alert provider override
+	// groups (e.g. prod-* or regex:^prod-(eu|us)$). Applies to all endpoints if empty.
+	Group string `json:"group,omitempty"`
+
+	// Start is the time at which the window was created
+	Start time.Time `json:"start"`
+
+	// End is the time at which the window expires
+	End time.Time `json:"end"`
+}
+
+// IsActive returns whether the window has not expired yet
+func (w *Window) IsActive() bool {
+	return time.Now().Before(w.End)
+}
+
+// AppliesTo returns whether the window applies to endpoints of the given group
+func (w *Window) AppliesTo(group string) bool {
+	return len(w.Group) == 0 || pattern.MatchGroup(w.Group, group)
+}
+
+// CreateWindow creates a maintenance window starting now and lasting for the given duration
+func CreateWindow(duration time.Duration, group string) (*Window, error) {
+	if duration <= 0 {
+		return nil, ErrInvalidWindowDuration
+	}
+	if !pattern.IsValidGroup(group) {
+		return nil, ErrInvalidWindowGroup
+	}
+	now := time.Now()
+	window := &Window{
+		ID:    uuid.NewString(),
+		Group: group,
+		Start: now,
+		End:   now.Add(duration),
+	}
+	windowsMutex.Lock()
+	defer windowsMutex.Unlock()
+	removeExpiredWindows()
+	windows[window.ID] = window
+	return window, nil
+}
+
+// CancelWindow cancels the maintenance window with the given ID.
+// Returns false if there is no active window with that ID.
+func CancelWindow(id string) bool {
+	windowsMutex.Lock()
+	defer windowsMutex.Unlock()
+	removeExpiredWindows()
+	if _, exists := windows[id]; !exists {
+		return false
+	}
+	delete(windows, id)
+	return true
+}
+
+// GetWindows returns all active maintenance windows, sorted by start time
+func GetWindows() []*Window {
+	windowsMutex.RLock()
+	defer windowsMutex.RUnlock()
+	activeWindows := make([]*Window, 0, len(windows))
+	for _, window := range windows {
+		if window.IsActive() {
+			activeWindows = append(activeWindows, window)
+		}
+	}
+	sort.Slice(activeWindows, func(i, j int) bool {
+		if activeWindows[i].Start.Equal(activeWindows[j].Start) {
+			return activeWindows[i].ID < activeWindows[j].ID
+		}
+		return activeWindows[i].Start.Before(activeWindows[j].Start)
+	})
+	return activeWindows
+}
+
+// RestoreWindows replaces all maintenance windows by the ones provided, which is used to restore the windows created
+// before a restart or imported from a backup. Windows that have already expired are ignored, and windows without an
+// ID are assigned one.
+//
+// If one of the windows is invalid, an error is returned and the existing windows are left untouched.
+func RestoreWindows(restoredWindows []*Window) error {
+	newWindows := make(map[string]*Window, len(restoredWindows))
+	for _, window := range restoredWindows {
+		if !pattern.IsValidGroup(window.Group) {
+			return ErrInvalidWindowGroup
+		}
+		if !window.End.After(window.Start) {
+			return ErrInvalidWindowEnd
+		}
+		if !window.IsActive() {
+			continue
+		}
+		restoredWindow := *window
+		if len(restoredWindow.ID) == 0 {
+			restoredWindow.ID = uuid.NewString()
+		}
+		newWindows[restoredWindow.ID] = &restoredWindow
+	}
+	windowsMutex.Lock()
+	windows = newWindows
+	windowsMutex.Unlock()
+	return nil
+}
+
+// IsGroupUnderMaintenance checks whether an active maintenance window applies to the endpoints of the given group
+func IsGroupUnderMaintenance(group string) bool {
+	windowsMutex.RLock()
+	defer windowsMutex.RUnlock()
+	for _, window := range windows {
+		if window.IsActive() && window.AppliesTo(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeExpiredWindows removes all windows that are no longer active.
+// The caller must hold windowsMutex.
+func removeExpiredWindows() {
+	for id, window := range windows {
+		if !window.IsActive() {
+			delete(windows, id)
+		}
+	}
+}
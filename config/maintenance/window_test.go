@@ -0,0 +1,124 @@
+package maintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCreateWindow(t *testing.T) {
+	defer clearWindows()
+	if _, err := CreateWindow(0, ""); !errors.Is(err, ErrInvalidWindowDuration) {
+		t.Errorf("expected error %v, got %v", ErrInvalidWindowDuration, err)
+	}
+	if _, err := CreateWindow(time.Minute, "regex:("); !errors.Is(err, ErrInvalidWindowGroup) {
+		t.Errorf("expected error %v, got %v", ErrInvalidWindowGroup, err)
+	}
+	window, err := CreateWindow(time.Hour, "prod-*")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(window.ID) == 0 {
+		t.Error("expected window to have an ID")
+	}
+	if window.End.Sub(window.Start) != time.Hour {
+		t.Errorf("expected window to last %s, got %s", time.Hour, window.End.Sub(window.Start))
+	}
+	if !IsGroupUnderMaintenance("prod-eu") {
+		t.Error("expected group prod-eu to be under maintenance")
+	}
+	if IsGroupUnderMaintenance("staging-eu") {
+		t.Error("expected group staging-eu not to be under maintenance")
+	}
+	if IsGroupUnderMaintenance("") {
+		t.Error("expected endpoints without a group not to be under maintenance")
+	}
+	if _, err = CreateWindow(time.Hour, ""); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !IsGroupUnderMaintenance("staging-eu") || !IsGroupUnderMaintenance("") {
+		t.Error("expected a window without a group to apply to all endpoints")
+	}
+}
+
+func TestWindowExpiry(t *testing.T) {
+	defer clearWindows()
+	window, err := CreateWindow(50*time.Millisecond, "")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !IsGroupUnderMaintenance("core") {
+		t.Error("expected group core to be under maintenance")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if IsGroupUnderMaintenance("core") {
+		t.Error("expected group core to no longer be under maintenance, because the window expired")
+	}
+	if CancelWindow(window.ID) {
+		t.Error("expected an expired window not to be cancellable")
+	}
+}
+
+func TestCancelWindow(t *testing.T) {
+	defer clearWindows()
+	window, err := CreateWindow(time.Hour, "core")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !IsGroupUnderMaintenance("core") {
+		t.Error("expected group core to be under maintenance")
+	}
+	if !CancelWindow(window.ID) {
+		t.Error("expected window to have been cancelled")
+	}
+	if IsGroupUnderMaintenance("core") {
+		t.Error("expected group core to no longer be under maintenance, because the window was cancelled")
+	}
+	if CancelWindow(window.ID) {
+		t.Error("expected window not to be cancellable twice")
+	}
+}
+
+func TestRestoreWindows(t *testing.T) {
+	defer clearWindows()
+	now := time.Now()
+	if err := RestoreWindows([]*Window{{Group: "regex:(", Start: now, End: now.Add(time.Hour)}}); !errors.Is(err, ErrInvalidWindowGroup) {
+		t.Errorf("expected error %v, got %v", ErrInvalidWindowGroup, err)
+	}
+	if err := RestoreWindows([]*Window{{Start: now, End: now}}); !errors.Is(err, ErrInvalidWindowEnd) {
+		t.Errorf("expected error %v, got %v", ErrInvalidWindowEnd, err)
+	}
+	if _, err := CreateWindow(time.Hour, "replaced"); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	err := RestoreWindows([]*Window{
+		{ID: "second", Group: "core", Start: now.Add(-time.Minute), End: now.Add(time.Hour)},
+		{ID: "expired", Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)},
+		{Group: "prod-*", Start: now.Add(-time.Hour), End: now.Add(time.Hour)},
+	})
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	restoredWindows := GetWindows()
+	if len(restoredWindows) != 2 {
+		t.Fatalf("expected 2 windows, got %d", len(restoredWindows))
+	}
+	if len(restoredWindows[0].ID) == 0 || restoredWindows[0].Group != "prod-*" {
+		t.Errorf("expected the oldest window to be first and to have been assigned an ID, got id=%s and group=%s", restoredWindows[0].ID, restoredWindows[0].Group)
+	}
+	if restoredWindows[1].ID != "second" {
+		t.Errorf("expected the window with id=second to be second, got id=%s", restoredWindows[1].ID)
+	}
+	if IsGroupUnderMaintenance("replaced") {
+		t.Error("expected the existing windows to have been replaced")
+	}
+	if !IsGroupUnderMaintenance("core") || !IsGroupUnderMaintenance("prod-eu") {
+		t.Error("expected the restored windows to be active")
+	}
+}
+
+func clearWindows() {
+	windowsMutex.Lock()
+	defer windowsMutex.Unlock()
+	windows = make(map[string]*Window)
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TwiN/deepmerge"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidRemoteConfigURL is an error returned when remote-config-url is neither an HTTP(S) nor an S3 URL
+	ErrInvalidRemoteConfigURL = errors.New("invalid remote-config-url, must start with http://, https:// or s3://")
+
+	// ErrInvalidRemoteConfig is an error returned when the remote configuration isn't a list of endpoints
+	ErrInvalidRemoteConfig = errors.New("remote configuration must be a list of endpoints, or have an endpoints key with a list of endpoints")
+
+	// ErrRemoteConfigUnavailable is an error returned when the remote configuration could neither be fetched nor
+	// read from the cache
+	ErrRemoteConfigUnavailable = errors.New("remote configuration could not be fetched and no cached copy is available")
+)
+
+// remoteConfigSource is the subset of the configuration needed to fetch the remote configuration
+type remoteConfigSource struct {
+	URL       string            `yaml:"remote-config-url"`
+	Headers   map[string]string `yaml:"remote-config-headers"`
+	CachePath string            `yaml:"remote-config-cache-path"`
+}
+
+// mergeRemoteConfig fetches the endpoints from remote-config-url, if the configuration has one, and merges them with
+// the configuration.
+//
+// Every successfully fetched remote configuration is cached, so that if the remote configuration cannot be fetched,
+// the last one that could be is used instead.
+func mergeRemoteConfig(configBytes []byte) ([]byte, error) {
+	var source remoteConfigSource
+	if err := yaml.Unmarshal(expandEnvironmentVariables(configBytes), &source); err != nil {
+		return nil, err
+	}
+	if len(source.URL) == 0 {
+		return configBytes, nil
+	}
+	if len(source.CachePath) == 0 {
+		source.CachePath = defaultRemoteConfigCachePath()
+	}
+	remoteConfigBytes, err := source.fetch()
+	if err == nil {
+		remoteConfigBytes, err = normalizeRemoteConfig(remoteConfigBytes)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidRemoteConfigURL) {
+			return nil, err
+		}
+		if len(source.CachePath) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrRemoteConfigUnavailable, err.Error())
+		}
+		log.Printf("[config.mergeRemoteConfig] Failed to fetch remote configuration from %s, falling back to the cache at %s: %s", source.URL, source.CachePath, err.Error())
+		cachedRemoteConfigBytes, cacheErr := os.ReadFile(source.CachePath)
+		if cacheErr != nil {
+			return nil, fmt.Errorf("%w: %s", ErrRemoteConfigUnavailable, err.Error())
+		}
+		remoteConfigBytes = cachedRemoteConfigBytes
+	} else if len(source.CachePath) > 0 {
+		if err := os.WriteFile(source.CachePath, remoteConfigBytes, 0o600); err != nil {
+			log.Printf("[config.mergeRemoteConfig] Failed to cache remote configuration at %s: %s", source.CachePath, err.Error())
+		}
+	}
+	// Environment variables are only expanded in the local configuration, because the remote configuration may be
+	// controlled by someone who shouldn't be able to read them, so every "$" is escaped before merging
+	return deepmerge.YAML(configBytes, []byte(strings.ReplaceAll(string(remoteConfigBytes), "$", "$$")))
+}
+
+// defaultRemoteConfigCachePath returns the path of the cache of the remote configuration if remote-config-cache-path
+// isn't set, which is in the cache directory of the user rather than in the temporary directory, since the latter is
+// shared with every other user and the cached configuration is trusted as much as the remote one.
+//
+// The directory is created if it doesn't exist yet.
+func defaultRemoteConfigCachePath() string {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		log.Printf("[config.defaultRemoteConfigCachePath] Failed to determine the cache directory, the remote configuration won't be cached unless remote-config-cache-path is set: %s", err.Error())
+		return ""
+	}
+	cacheDir := filepath.Join(userCacheDir, "gatus")
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		log.Printf("[config.defaultRemoteConfigCachePath] Failed to create %s, the remote configuration won't be cached unless remote-config-cache-path is set: %s", cacheDir, err.Error())
+		return ""
+	}
+	return filepath.Join(cacheDir, "remote-config.yaml")
+}
+
+// fetch retrieves the remote configuration
+func (source *remoteConfigSource) fetch() ([]byte, error) {
+	remoteConfigURL, err := url.Parse(source.URL)
+	if err != nil {
+		return nil, ErrInvalidRemoteConfigURL
+	}
+	log.Printf("[config.fetch] Fetching remote configuration from %s", remoteConfigURL.Redacted())
+	switch remoteConfigURL.Scheme {
+	case "http", "https":
+		request, err := http.NewRequest(http.MethodGet, source.URL, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range source.Headers {
+			request.Header.Set(name, value)
+		}
+		response, err := client.GetHTTPClient(nil).Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+		}
+		return io.ReadAll(response.Body)
+	case "s3":
+		// Credentials and region are resolved the same way as the AWS CLI, unless the region is specified with ?region=
+		awsConfig := aws.NewConfig()
+		if region := remoteConfigURL.Query().Get("region"); len(region) > 0 {
+			awsConfig = awsConfig.WithRegion(region)
+		}
+		awsSession, err := session.NewSessionWithOptions(session.Options{Config: *awsConfig, SharedConfigState: session.SharedConfigEnable})
+		if err != nil {
+			return nil, err
+		}
+		output, err := s3.New(awsSession).GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(remoteConfigURL.Host),
+			Key:    aws.String(strings.TrimPrefix(remoteConfigURL.Path, "/")),
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer output.Body.Close()
+		return io.ReadAll(output.Body)
+	default:
+		return nil, ErrInvalidRemoteConfigURL
+	}
+}
+
+// normalizeRemoteConfig converts the remote configuration, which may either be a list of endpoints or a document with
+// an endpoints key, to a document that only has an endpoints key, so that nothing but endpoints can be configured
+// remotely
+func normalizeRemoteConfig(remoteConfigBytes []byte) ([]byte, error) {
+	var endpoints []interface{}
+	if err := yaml.Unmarshal(remoteConfigBytes, &endpoints); err != nil {
+		var document struct {
+			Endpoints []interface{} `yaml:"endpoints"`
+		}
+		if err := yaml.Unmarshal(remoteConfigBytes, &document); err != nil {
+			return nil, ErrInvalidRemoteConfig
+		}
+		endpoints = document.Endpoints
+	}
+	if len(endpoints) == 0 {
+		return nil, ErrInvalidRemoteConfig
+	}
+	return yaml.Marshal(map[string]interface{}{"endpoints": endpoints})
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoadConfigurationWithRemoteConfigURL(t *testing.T) {
+	var numberOfHealthChecks atomic.Int32
+	var remoteConfigAvailable atomic.Bool
+	remoteConfigAvailable.Store(true)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/endpoints.yaml":
+			if r.Header.Get("Authorization") != "Bearer remote-config-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if !remoteConfigAvailable.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`
+- name: remote
+  url: ` + server.URL + `/health
+  conditions:
+    - "[STATUS] == 200"
+`))
+		case "/health":
+			numberOfHealthChecks.Add(1)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GATUS_TEST_REMOTE_CONFIG_TOKEN", "remote-config-token")
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	cachePath := filepath.Join(dir, "remote-config-cache.yaml")
+	if err := os.WriteFile(configPath, []byte(`
+remote-config-url: "`+server.URL+`/endpoints.yaml"
+remote-config-headers:
+  Authorization: "Bearer ${GATUS_TEST_REMOTE_CONFIG_TOKEN}"
+remote-config-cache-path: "`+cachePath+`"
+endpoints:
+  - name: local
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	assertEndpointsAreMerged := func(t *testing.T, config *Config) {
+		if len(config.Endpoints) != 2 {
+			t.Fatalf("expected the local and the remote endpoints to be merged, got %d endpoints", len(config.Endpoints))
+		}
+		remoteEndpoint := config.GetEndpointByKey("_remote")
+		if remoteEndpoint == nil {
+			t.Fatal("expected the remote endpoint to be part of the configuration")
+		}
+		numberOfHealthChecksBefore := numberOfHealthChecks.Load()
+		remoteEndpoint.EvaluateHealth()
+		if numberOfHealthChecks.Load() != numberOfHealthChecksBefore+1 {
+			t.Error("expected the remote endpoint to be probed")
+		}
+	}
+	t.Run("fetched", func(t *testing.T) {
+		config, err := LoadConfiguration(configPath)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		assertEndpointsAreMerged(t, config)
+		if _, err := os.Stat(cachePath); err != nil {
+			t.Error("expected the remote configuration to be cached, got", err.Error())
+		}
+	})
+	t.Run("fallback-to-cache", func(t *testing.T) {
+		remoteConfigAvailable.Store(false)
+		defer remoteConfigAvailable.Store(true)
+		config, err := LoadConfiguration(configPath)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		assertEndpointsAreMerged(t, config)
+	})
+	t.Run("unavailable-without-cache", func(t *testing.T) {
+		remoteConfigAvailable.Store(false)
+		defer remoteConfigAvailable.Store(true)
+		if err := os.Remove(cachePath); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadConfiguration(configPath); !errors.Is(err, ErrRemoteConfigUnavailable) {
+			t.Errorf("expected error %v, got %v", ErrRemoteConfigUnavailable, err)
+		}
+	})
+}
+
+func TestLoadConfigurationWithRemoteConfigURLDoesNotExpandEnvironmentVariablesOfRemoteConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+- name: remote
+  url: https://example.org/health
+  body: "${GATUS_TEST_REMOTE_CONFIG_SECRET}$$"
+  conditions:
+    - "[STATUS] == 200"
+`))
+	}))
+	defer server.Close()
+	t.Setenv("GATUS_TEST_REMOTE_CONFIG_SECRET", "secret")
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(`
+remote-config-url: "`+server.URL+`/endpoints.yaml"
+remote-config-cache-path: "`+filepath.Join(dir, "remote-config-cache.yaml")+`"
+endpoints:
+  - name: local
+    url: https://example.org
+    body: "${GATUS_TEST_REMOTE_CONFIG_SECRET}"
+    conditions:
+      - "[STATUS] == 200"
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfiguration(configPath)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if body := config.GetEndpointByKey("_local").Body; body != "secret" {
+		t.Errorf("expected the environment variables of the local configuration to be expanded, got body %q", body)
+	}
+	if body := config.GetEndpointByKey("_remote").Body; body != "${GATUS_TEST_REMOTE_CONFIG_SECRET}$$" {
+		t.Errorf("expected the remote configuration to be left as is, got body %q", body)
+	}
+}
+
+func TestDefaultRemoteConfigCachePath(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the cache directory is only determined by XDG_CACHE_HOME on Linux")
+	}
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	if cachePath := defaultRemoteConfigCachePath(); cachePath != filepath.Join(cacheDir, "gatus", "remote-config.yaml") {
+		t.Errorf("expected the remote configuration to be cached in the cache directory of the user, got %s", cachePath)
+	}
+	if info, err := os.Stat(filepath.Join(cacheDir, "gatus")); err != nil || info.Mode().Perm() != 0o700 {
+		t.Error("expected the directory of the cache to have been created with permissions 0700")
+	}
+}
+
+func TestMergeRemoteConfigWithInvalidURL(t *testing.T) {
+	if _, err := mergeRemoteConfig([]byte(`remote-config-url: "ftp://example.org/endpoints.yaml"`)); !errors.Is(err, ErrInvalidRemoteConfigURL) {
+		t.Errorf("expected error %v, got %v", ErrInvalidRemoteConfigURL, err)
+	}
+}
+
+func TestNormalizeRemoteConfig(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		remote      string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "list",
+			remote:   "- name: a\n  url: https://example.org\n",
+			expected: "endpoints:\n    - name: a\n      url: https://example.org\n",
+		},
+		{
+			name:     "document-with-other-keys",
+			remote:   "alerting:\n  muted: true\nendpoints:\n  - name: a\n",
+			expected: "endpoints:\n    - name: a\n",
+		},
+		{
+			name:        "no-endpoints",
+			remote:      "alerting:\n  muted: true\n",
+			expectedErr: ErrInvalidRemoteConfig,
+		},
+		{
+			name:        "not-yaml",
+			remote:      "<html></html>",
+			expectedErr: ErrInvalidRemoteConfig,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			normalized, err := normalizeRemoteConfig([]byte(scenario.remote))
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if string(normalized) != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, string(normalized))
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package shard
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+var (
+	// ErrMissingInstance is the error returned when the shard configuration doesn't name the current instance
+	ErrMissingInstance = errors.New("shard.instance must be specified")
+
+	// ErrInstanceNotInInstances is the error returned when the current instance isn't part of the list of instances
+	ErrInstanceNotInInstances = errors.New("shard.instance must be one of shard.instances")
+
+	// ErrDuplicateInstance is the error returned when an instance is listed more than once
+	ErrDuplicateInstance = errors.New("shard.instances must not contain duplicates")
+
+	// ErrEndpointAssignedToUnknownInstance is the error returned when the label of an endpoint assigns it to an
+	// instance that isn't part of the list of instances, meaning that no instance would ever monitor it
+	ErrEndpointAssignedToUnknownInstance = errors.New("endpoint is assigned to an instance that is not one of shard.instances")
+)
+
+// Config is the configuration of the sharding of the endpoints between several instances of Gatus sharing the same
+// storage, so that each endpoint is monitored by a single instance while every instance shows all of them.
+//
+// Every instance must have the same endpoints and the same list of instances, and only differ by Instance.
+type Config struct {
+	// Instance is the name of this instance (e.g. us-east)
+	Instance string `yaml:"instance"`
+
+	// Instances is the name of every instance the endpoints are sharded between, including this one
+	Instances []string `yaml:"instances"`
+
+	// Label is the key of the label used to explicitly assign an endpoint to the instance named by its value.
+	// Endpoints without that label are assigned to an instance based on the hash of their key.
+	Label string `yaml:"label,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the shard configuration against the endpoints it shards
+func (c *Config) ValidateAndSetDefaults(endpoints []*endpoint.Endpoint) error {
+	if len(c.Instance) == 0 {
+		return ErrMissingInstance
+	}
+	for i, instance := range c.Instances {
+		if slices.Contains(c.Instances[i+1:], instance) {
+			return fmt.Errorf("%w: %s", ErrDuplicateInstance, instance)
+		}
+	}
+	if !slices.Contains(c.Instances, c.Instance) {
+		return ErrInstanceNotInInstances
+	}
+	if len(c.Label) > 0 {
+		for _, ep := range endpoints {
+			if instance, exists := ep.Labels[c.Label]; exists && !slices.Contains(c.Instances, instance) {
+				return fmt.Errorf("%w: endpoint %s is assigned to %s", ErrEndpointAssignedToUnknownInstance, ep.Key(), instance)
+			}
+		}
+	}
+	return nil
+}
+
+// InstanceOf returns the name of the instance responsible for monitoring the given endpoint
+//
+// Unless the endpoint is explicitly assigned to an instance through Label, the instance is picked with rendezvous
+// hashing: the instance with the highest hash combined with the endpoint's key wins. This doesn't depend on the order
+// of Instances, and only moves the endpoints of an instance that is added or removed.
+func (c *Config) InstanceOf(ep *endpoint.Endpoint) string {
+	if len(c.Label) > 0 {
+		if instance, exists := ep.Labels[c.Label]; exists {
+			return instance
+		}
+	}
+	var owner string
+	var highestScore uint64
+	for _, instance := range c.Instances {
+		hash := sha256.Sum256([]byte(instance + "/" + ep.Key()))
+		if score := binary.BigEndian.Uint64(hash[:8]); len(owner) == 0 || score > highestScore {
+			owner, highestScore = instance, score
+		}
+	}
+	return owner
+}
+
+// IsResponsibleFor returns whether the current instance is responsible for monitoring the given endpoint.
+// Returns true if sharding isn't configured.
+func (c *Config) IsResponsibleFor(ep *endpoint.Endpoint) bool {
+	return c == nil || c.InstanceOf(ep) == c.Instance
+}
@@ -0,0 +1,106 @@
+package shard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		{Name: "a", Labels: map[string]string{"region": "us-east"}},
+		{Name: "b"},
+	}
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{Instance: "us-east", Instances: []string{"us-east", "eu-west"}, Label: "region"},
+		},
+		{
+			name:        "missing-instance",
+			cfg:         &Config{Instances: []string{"us-east", "eu-west"}},
+			expectedErr: ErrMissingInstance,
+		},
+		{
+			name:        "instance-not-in-instances",
+			cfg:         &Config{Instance: "ap-south", Instances: []string{"us-east", "eu-west"}},
+			expectedErr: ErrInstanceNotInInstances,
+		},
+		{
+			name:        "duplicate-instance",
+			cfg:         &Config{Instance: "us-east", Instances: []string{"us-east", "eu-west", "us-east"}},
+			expectedErr: ErrDuplicateInstance,
+		},
+		{
+			name:        "endpoint-assigned-to-unknown-instance",
+			cfg:         &Config{Instance: "eu-west", Instances: []string{"eu-west", "ap-south"}, Label: "region"},
+			expectedErr: ErrEndpointAssignedToUnknownInstance,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(endpoints); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_InstanceOf(t *testing.T) {
+	instances := []string{"us-east", "eu-west", "ap-south"}
+	configs := make([]*Config, len(instances))
+	for i, instance := range instances {
+		configs[i] = &Config{Instance: instance, Instances: instances}
+	}
+	endpointsByInstance := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		ep := &endpoint.Endpoint{Name: fmt.Sprintf("endpoint-%d", i), Group: "core"}
+		responsibleInstances := 0
+		for _, cfg := range configs {
+			if cfg.IsResponsibleFor(ep) {
+				responsibleInstances++
+			}
+		}
+		if responsibleInstances != 1 {
+			t.Fatalf("expected %s to be assigned to exactly one instance, got %d", ep.Key(), responsibleInstances)
+		}
+		instance := configs[0].InstanceOf(ep)
+		// The assignment must not depend on the order of the instances
+		reversed := &Config{Instance: instance, Instances: []string{"ap-south", "eu-west", "us-east"}}
+		if reversed.InstanceOf(ep) != instance || configs[0].InstanceOf(ep) != instance {
+			t.Fatalf("expected %s to always be assigned to %s", ep.Key(), instance)
+		}
+		endpointsByInstance[instance]++
+	}
+	for _, instance := range instances {
+		if endpointsByInstance[instance] < 50 {
+			t.Errorf("expected the endpoints to be spread between the instances, but %s only got %d out of 300", instance, endpointsByInstance[instance])
+		}
+	}
+}
+
+func TestConfig_InstanceOfWithLabel(t *testing.T) {
+	cfg := &Config{Instance: "us-east", Instances: []string{"us-east", "eu-west"}, Label: "region"}
+	for i := 0; i < 20; i++ {
+		ep := &endpoint.Endpoint{Name: fmt.Sprintf("endpoint-%d", i), Labels: map[string]string{"region": "eu-west"}}
+		if instance := cfg.InstanceOf(ep); instance != "eu-west" {
+			t.Errorf("expected %s to be assigned to eu-west by its label, got %s", ep.Key(), instance)
+		}
+		if cfg.IsResponsibleFor(ep) {
+			t.Errorf("expected us-east not to be responsible for %s", ep.Key())
+		}
+	}
+}
+
+func TestConfig_IsResponsibleForWithoutSharding(t *testing.T) {
+	var cfg *Config
+	if !cfg.IsResponsibleFor(&endpoint.Endpoint{Name: "name"}) {
+		t.Error("expected every endpoint to be monitored when sharding isn't configured")
+	}
+}
@@ -28,6 +28,8 @@ type Config struct {
 	Logo        string   `yaml:"logo,omitempty"`        // Logo to display on the page
 	Link        string   `yaml:"link,omitempty"`        // Link to open when clicking on the logo
 	Buttons     []Button `yaml:"buttons,omitempty"`     // Buttons to display below the header
+
+	ContextRoot string `yaml:"-"` // ContextRoot is the path under which the UI is served. Set from web.context-root.
 }
 
 // Button is the configuration for a button on the UI
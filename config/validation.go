@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	"github.com/TwiN/gatus/v5/config/endpoint/resultwebhook"
+	sshconfig "github.com/TwiN/gatus/v5/config/endpoint/ssh"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an issue that makes a configuration invalid
+type Problem struct {
+	// Endpoint is the key of the endpoint or external endpoint the problem is about, if any
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Field is the configuration field the problem is about, if known (e.g. url, conditions, web)
+	Field string `json:"field,omitempty"`
+
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// ValidationResult is the outcome of the validation of a configuration
+type ValidationResult struct {
+	// Problems are all the problems found in the configuration
+	Problems []Problem
+
+	// NumberOfEndpoints is the number of endpoints in the configuration
+	NumberOfEndpoints int
+
+	// NumberOfExternalEndpoints is the number of external endpoints in the configuration
+	NumberOfExternalEndpoints int
+}
+
+// IsValid returns whether no problems were found in the configuration
+func (result *ValidationResult) IsValid() bool {
+	return len(result.Problems) == 0
+}
+
+func (result *ValidationResult) addProblem(endpointKey, field string, err error) {
+	result.Problems = append(result.Problems, Problem{Endpoint: endpointKey, Field: field, Message: err.Error()})
+}
+
+// errDuplicateEndpointKey is the problem reported when multiple endpoints share the same name and group
+var errDuplicateEndpointKey = errors.New("name and group combination must be unique")
+
+// endpointFieldsByError maps the errors returned when validating an endpoint to the field they are about
+var endpointFieldsByError = []struct {
+	err   error
+	field string
+}{
+	{endpoint.ErrEndpointWithNoName, "name"},
+	{endpoint.ErrEndpointWithInvalidNameOrGroup, "name"},
+	{endpoint.ErrEndpointWithNoURL, "url"},
+	{endpoint.ErrUnknownEndpointType, "url"},
+	{endpoint.ErrEndpointWithNoCondition, "conditions"},
+	{endpoint.ErrInvalidConditionFormat, "conditions"},
+	{endpoint.ErrInvalidEndpointIntervalForDomainExpirationPlaceholder, "conditions"},
+	{endpoint.ErrEndpointWithHeadMethodAndBodyPlaceholder, "conditions"},
+	{endpoint.ErrConditionGroupWithNoCondition, "condition-groups"},
+	{endpoint.ErrEndpointWithIntervalAndSchedule, "schedule"},
+	{endpoint.ErrInvalidEndpointSchedule, "schedule"},
+	{endpoint.ErrInvalidResponseTimeWindow, "response-time-window"},
+	{endpoint.ErrInvalidEndpointRetries, "retries"},
+	{endpoint.ErrInvalidMaximumResponseTime, "maximum-response-time"},
+	{endpoint.ErrEndpointWithInvalidBasicAuth, "basic-auth"},
+	{endpoint.ErrEndpointWithInvalidDigestAuth, "digest-auth"},
+	{endpoint.ErrEndpointWithMultipleAuthSchemes, "basic-auth"},
+	{endpoint.ErrStepWithInvalidURL, "steps"},
+	{endpoint.ErrStepWithInvalidCapture, "steps"},
+	{endpoint.ErrEndpointWithStepsAndNonHTTPType, "steps"},
+	{endpoint.ErrExternalEndpointWithNoToken, "token"},
+	{alert.ErrAlertWithInvalidDescription, "alerts"},
+	{dns.ErrDNSWithNoQueryName, "dns"},
+	{dns.ErrDNSWithInvalidQueryType, "dns"},
+	{sshconfig.ErrEndpointWithoutSSHUsername, "ssh"},
+	{sshconfig.ErrEndpointWithoutSSHPassword, "ssh"},
+	{client.ErrInvalidDNSResolver, "client"},
+	{client.ErrInvalidDNSResolverPort, "client"},
+	{client.ErrInvalidClientIAPConfig, "client"},
+	{client.ErrInvalidClientTLSConfig, "client"},
+	{client.ErrInvalidMaxRedirects, "client"},
+	{client.ErrInvalidClientTLSMinVersion, "client"},
+	{client.ErrInvalidClientTLSCipherSuite, "client"},
+	{client.ErrInvalidIPVersion, "client"},
+	{resultwebhook.ErrInvalidURL, "result-webhook"},
+	{resultwebhook.ErrInvalidQueueSize, "result-webhook"},
+}
+
+// endpointFieldOf returns the field of an endpoint that the error returned when validating said endpoint is about, or
+// an empty string if it is unknown
+func endpointFieldOf(err error) string {
+	for _, fieldByError := range endpointFieldsByError {
+		if errors.Is(err, fieldByError.err) {
+			return fieldByError.field
+		}
+	}
+	return ""
+}
+
+// ValidateConfiguration validates the configuration at the given path and, unlike LoadConfiguration, reports every
+// problem found instead of only the first one.
+func ValidateConfiguration(configPath string) *ValidationResult {
+	configBytes, _, err := readConfigurationBytes(configPath)
+	if err != nil {
+		result := &ValidationResult{Problems: []Problem{}}
+		result.addProblem("", "", err)
+		return result
+	}
+	return validateConfigBytes(configBytes)
+}
+
+// validateConfigBytes parses a Gatus configuration and returns all the problems found while validating it
+func validateConfigBytes(yamlBytes []byte) *ValidationResult {
+	result := &ValidationResult{Problems: []Problem{}}
+	var config *Config
+	if err := yaml.Unmarshal(expandEnvironmentVariables(yamlBytes), &config); err != nil {
+		result.addProblem("", "", err)
+		return result
+	}
+	if config == nil || len(config.Endpoints) == 0 {
+		result.addProblem("", "endpoints", ErrNoEndpointInConfig)
+		return result
+	}
+	result.NumberOfEndpoints, result.NumberOfExternalEndpoints = len(config.Endpoints), len(config.ExternalEndpoints)
+	for _, validator := range configValidators {
+		if validator.reportEveryProblem != nil {
+			validator.reportEveryProblem(config, result)
+		} else if err := validator.validate(config, yamlBytes); err != nil {
+			result.addProblem("", validator.field, err)
+		}
+	}
+	return result
+}
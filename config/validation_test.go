@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateConfiguration(t *testing.T) {
+	scenarios := []struct {
+		name                      string
+		config                    string
+		expectedProblems          []Problem
+		expectedEndpoints         int
+		expectedExternalEndpoints int
+	}{
+		{
+			name: "valid",
+			config: `
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"`,
+			expectedProblems:  []Problem{},
+			expectedEndpoints: 1,
+		},
+		{
+			name: "multiple-distinct-errors",
+			config: `
+web:
+  port: 999999
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+  - name: no-url
+    conditions:
+      - "[STATUS] == 200"
+  - name: invalid-condition
+    group: core
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] ? 200"
+  - name: website
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: negative-retries
+    url: https://twin.sh/health
+    retries: -1
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - name: no-token`,
+			expectedProblems: []Problem{
+				{Endpoint: "_no-url", Field: "url", Message: "you must specify an url for each endpoint"},
+				{Endpoint: "core_invalid-condition", Field: "conditions", Message: "invalid condition format: does not match '<VALUE> <COMPARATOR> <VALUE>': invalid condition: [STATUS] ? 200"},
+				{Endpoint: "_website", Field: "name", Message: "name and group combination must be unique"},
+				{Endpoint: "_negative-retries", Field: "retries", Message: "invalid retries: must be greater than or equal to 0"},
+				{Endpoint: "_no-token", Field: "token", Message: "you must specify a token for each external endpoint"},
+				{Field: "web", Message: "invalid port: value should be between 0 and 65535"},
+			},
+			expectedEndpoints:         5,
+			expectedExternalEndpoints: 1,
+		},
+		{
+			name: "same-validation-as-when-loading",
+			config: `
+groups:
+  - interval: 1m
+shard:
+  instances: ["a"]
+heartbeat:
+  url: ftp://example.org
+endpoints:
+  - name: website
+    id: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+  - name: blog
+    id: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"`,
+			expectedProblems: []Problem{
+				{Field: "groups", Message: "invalid group : group must have a name"},
+				{Endpoint: "_blog", Field: "id", Message: "id website must be unique"},
+				{Field: "shard", Message: "shard.instance must be specified"},
+				{Field: "heartbeat", Message: "heartbeat.url must start with http:// or https://"},
+			},
+			expectedEndpoints: 2,
+		},
+		{
+			name:             "no-endpoints",
+			config:           `metrics: true`,
+			expectedProblems: []Problem{{Field: "endpoints", Message: ErrNoEndpointInConfig.Error()}},
+		},
+		{
+			name:             "invalid-yaml",
+			config:           `endpoints: [`,
+			expectedProblems: []Problem{{Message: "yaml: line 1: did not find expected node content"}},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(scenario.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			result := ValidateConfiguration(configPath)
+			if !reflect.DeepEqual(result.Problems, scenario.expectedProblems) {
+				t.Errorf("expected problems:\n%#v\ngot:\n%#v", scenario.expectedProblems, result.Problems)
+			}
+			if result.IsValid() != (len(scenario.expectedProblems) == 0) {
+				t.Errorf("expected IsValid to be %v, got %v", len(scenario.expectedProblems) == 0, result.IsValid())
+			}
+			if result.NumberOfEndpoints != scenario.expectedEndpoints {
+				t.Errorf("expected %d endpoints, got %d", scenario.expectedEndpoints, result.NumberOfEndpoints)
+			}
+			if result.NumberOfExternalEndpoints != scenario.expectedExternalEndpoints {
+				t.Errorf("expected %d external endpoints, got %d", scenario.expectedExternalEndpoints, result.NumberOfExternalEndpoints)
+			}
+		})
+	}
+}
+
+func TestValidateConfiguration_withMissingFile(t *testing.T) {
+	result := ValidateConfiguration(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	if len(result.Problems) != 1 {
+		t.Fatalf("expected 1 problem, got %v", result.Problems)
+	}
+}
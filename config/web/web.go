@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"strings"
+	"time"
 )
 
 const (
@@ -20,6 +23,9 @@ const (
 	// MinimumReadBufferSize is the minimum value for ReadBufferSize, and also the default value set
 	// for fiber.Config.ReadBufferSize
 	MinimumReadBufferSize = 4096
+
+	// DefaultCompressionMinimumSize is the default value for CompressionConfig.MinimumSize
+	DefaultCompressionMinimumSize = 1024
 )
 
 // Config is the structure which supports the configuration of the server listening to requests
@@ -38,10 +44,111 @@ type Config struct {
 	// Defaults to DefaultReadBufferSize
 	ReadBufferSize int `yaml:"read-buffer-size,omitempty"`
 
+	// ContextRoot is the path under which every route is served (e.g. /status), which is useful when Gatus is served
+	// behind a reverse proxy under a path rather than on its own domain.
+	//
+	// Defaults to an empty string, meaning that routes are served at the root.
+	ContextRoot string `yaml:"context-root,omitempty"`
+
+	// PublicURL is the URL at which the dashboard is publicly reachable (e.g. https://status.example.org), including the
+	// context root, if any. It is used to link alerts back to the page of the endpoint they are for.
+	PublicURL string `yaml:"public-url,omitempty"`
+
 	// TLS configuration (optional)
 	TLS *TLSConfig `yaml:"tls,omitempty"`
+
+	// CORS configuration (optional). If not set, no CORS headers are returned by the API.
+	CORS *CORSConfig `yaml:"cors,omitempty"`
+
+	// RateLimits configures the rate limiting of the API routes that may be called by other systems (optional).
+	// If not set, no rate limiting is applied.
+	RateLimits *RateLimitsConfig `yaml:"rate-limits,omitempty"`
+
+	// Compression configures the compression of the responses (optional). Responses are compressed by default.
+	Compression *CompressionConfig `yaml:"compression,omitempty"`
+}
+
+// ErrInvalidPublicURL is the error returned when the public URL is not an absolute http or https URL
+var ErrInvalidPublicURL = errors.New("invalid public-url: must be an absolute URL with a scheme of http or https")
+
+// ErrInvalidContextRoot is the error returned when the context root contains characters that cannot be part of a path
+var ErrInvalidContextRoot = errors.New("invalid context-root: must not contain a query, a fragment, a wildcard or a parameter")
+
+// CORSConfig is the configuration of the Cross-Origin Resource Sharing headers returned by the API
+type CORSConfig struct {
+	// AllowedOrigins is the list of origins allowed to call the API (e.g. https://dashboard.example.org).
+	// A single "*" allows every origin, and a subdomain wildcard (e.g. https://*.example.org) is supported.
+	AllowedOrigins []string `yaml:"allowed-origins"`
+
+	// AllowedMethods is the list of methods allowed when calling the API from another origin.
+	//
+	// Defaults to DefaultCORSAllowedMethods
+	AllowedMethods []string `yaml:"allowed-methods,omitempty"`
+
+	// AllowedHeaders is the list of headers allowed when calling the API from another origin.
+	//
+	// Defaults to an empty list, meaning that the headers requested in preflight requests are allowed.
+	AllowedHeaders []string `yaml:"allowed-headers,omitempty"`
+}
+
+var (
+	// DefaultCORSAllowedMethods is the default value for CORSConfig.AllowedMethods
+	DefaultCORSAllowedMethods = []string{"GET", "HEAD", "POST", "DELETE"}
+
+	// ErrCORSWithNoAllowedOrigin is the error returned when CORS is configured without any allowed origin
+	ErrCORSWithNoAllowedOrigin = errors.New("invalid cors config: at least one allowed origin must be specified")
+
+	// ErrCORSWithInvalidAllowedOrigin is the error returned when one of the allowed origins is not a valid origin
+	ErrCORSWithInvalidAllowedOrigin = errors.New("invalid cors config: allowed origins must be * or a scheme (http or https) followed by a host, without path")
+)
+
+// CompressionConfig is the configuration of the compression of the responses, which uses the encoding requested by the
+// client through the Accept-Encoding header (brotli, gzip or deflate)
+type CompressionConfig struct {
+	// Enabled defines whether responses are compressed. Use CompressionConfig.IsEnabled() to retrieve the value of
+	// this field.
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// MinimumSize is the size in bytes under which responses aren't compressed, because small responses such as badges
+	// gain little to nothing from being compressed.
+	//
+	// Defaults to DefaultCompressionMinimumSize
+	MinimumSize int `yaml:"minimum-size,omitempty"`
+}
+
+// ErrInvalidCompressionMinimumSize is the error returned when the compression minimum size is negative
+var ErrInvalidCompressionMinimumSize = errors.New("invalid compression config: minimum-size must not be negative")
+
+// RateLimitsConfig is the configuration of the rate limits applied on the API, by route
+type RateLimitsConfig struct {
+	// ExternalEndpointResults is the rate limit of the route used to push the results of external endpoints
+	// (POST /api/v1/endpoints/{key}/external)
+	ExternalEndpointResults *RateLimitConfig `yaml:"external-endpoint-results,omitempty"`
 }
 
+// RateLimitConfig is the configuration of a token bucket rate limiter.
+//
+// The bucket holds up to Requests tokens and is refilled with Requests tokens every Interval, and each request consumes
+// one token. Requests made while the bucket is empty are rejected with 429 Too Many Requests.
+type RateLimitConfig struct {
+	// Requests is the maximum number of requests allowed per Interval, which is also the maximum burst
+	Requests int `yaml:"requests"`
+
+	// Interval is the period over which Requests are allowed
+	//
+	// Defaults to DefaultRateLimitInterval
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// PerEndpoint is whether the limit applies to each endpoint key individually rather than to the route as a whole
+	PerEndpoint bool `yaml:"per-endpoint,omitempty"`
+}
+
+// DefaultRateLimitInterval is the default value for RateLimitConfig.Interval
+const DefaultRateLimitInterval = time.Minute
+
+// ErrInvalidRateLimitRequests is the error returned when a rate limit allows less than one request
+var ErrInvalidRateLimitRequests = errors.New("invalid rate limit: requests must be greater than 0")
+
 type TLSConfig struct {
 	// CertificateFile is the public certificate for TLS in PEM format.
 	CertificateFile string `yaml:"certificate-file,omitempty"`
@@ -56,6 +163,7 @@ func GetDefaultConfig() *Config {
 		Address:        DefaultAddress,
 		Port:           DefaultPort,
 		ReadBufferSize: DefaultReadBufferSize,
+		Compression:    &CompressionConfig{MinimumSize: DefaultCompressionMinimumSize},
 	}
 }
 
@@ -77,6 +185,44 @@ func (web *Config) ValidateAndSetDefaults() error {
 	} else if web.ReadBufferSize < MinimumReadBufferSize {
 		web.ReadBufferSize = MinimumReadBufferSize // Below the minimum? Use the minimum value.
 	}
+	// Validate ContextRoot
+	if len(web.ContextRoot) > 0 {
+		if strings.ContainsAny(web.ContextRoot, "?#*:+ ") {
+			return ErrInvalidContextRoot
+		}
+		// Normalize to a leading slash without a trailing slash, so that routes can simply be appended to it
+		web.ContextRoot = "/" + strings.Trim(web.ContextRoot, "/")
+		if web.ContextRoot == "/" {
+			web.ContextRoot = ""
+		}
+	}
+	// Validate PublicURL
+	if len(web.PublicURL) > 0 {
+		parsedURL, err := url.Parse(web.PublicURL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+			return ErrInvalidPublicURL
+		}
+		web.PublicURL = strings.TrimSuffix(web.PublicURL, "/")
+	}
+	// Validate CORS
+	if web.CORS != nil {
+		if err := web.CORS.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	// Validate RateLimits
+	if web.RateLimits != nil && web.RateLimits.ExternalEndpointResults != nil {
+		if err := web.RateLimits.ExternalEndpointResults.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid rate-limits.external-endpoint-results: %w", err)
+		}
+	}
+	// Validate Compression
+	if web.Compression == nil {
+		web.Compression = &CompressionConfig{}
+	}
+	if err := web.Compression.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
 	// Try to load the TLS certificates
 	if web.TLS != nil {
 		if err := web.TLS.isValid(); err != nil {
@@ -90,11 +236,69 @@ func (web *Config) HasTLS() bool {
 	return web.TLS != nil && len(web.TLS.CertificateFile) > 0 && len(web.TLS.PrivateKeyFile) > 0
 }
 
+// EndpointPageURL returns the URL of the page of the endpoint with the given key on the dashboard, or an empty string
+// if PublicURL is not set
+func (web *Config) EndpointPageURL(key string) string {
+	if len(web.PublicURL) == 0 {
+		return ""
+	}
+	return web.PublicURL + "/endpoints/" + key
+}
+
 // SocketAddress returns the combination of the Address and the Port
 func (web *Config) SocketAddress() string {
 	return fmt.Sprintf("%s:%d", web.Address, web.Port)
 }
 
+// ValidateAndSetDefaults validates the CORS configuration and sets the default values if necessary.
+func (c *CORSConfig) ValidateAndSetDefaults() error {
+	if len(c.AllowedOrigins) == 0 {
+		return ErrCORSWithNoAllowedOrigin
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" && len(c.AllowedOrigins) == 1 {
+			continue
+		}
+		parsedOrigin, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+		if err != nil || (parsedOrigin.Scheme != "http" && parsedOrigin.Scheme != "https") || len(parsedOrigin.Host) == 0 ||
+			strings.Contains(parsedOrigin.Host, "*") || (parsedOrigin.Path != "" && parsedOrigin.Path != "/") ||
+			parsedOrigin.RawQuery != "" || parsedOrigin.Fragment != "" {
+			return ErrCORSWithInvalidAllowedOrigin
+		}
+	}
+	if len(c.AllowedMethods) == 0 {
+		c.AllowedMethods = DefaultCORSAllowedMethods
+	}
+	return nil
+}
+
+// ValidateAndSetDefaults validates the rate limit configuration and sets the default values if necessary.
+func (r *RateLimitConfig) ValidateAndSetDefaults() error {
+	if r.Requests <= 0 {
+		return ErrInvalidRateLimitRequests
+	}
+	if r.Interval <= 0 {
+		r.Interval = DefaultRateLimitInterval
+	}
+	return nil
+}
+
+// IsEnabled returns whether responses are compressed. Returns true if the configuration or the field is not set.
+func (c *CompressionConfig) IsEnabled() bool {
+	return c == nil || c.Enabled == nil || *c.Enabled
+}
+
+// ValidateAndSetDefaults validates the compression configuration and sets the default values if necessary.
+func (c *CompressionConfig) ValidateAndSetDefaults() error {
+	if c.MinimumSize < 0 {
+		return ErrInvalidCompressionMinimumSize
+	}
+	if c.MinimumSize == 0 {
+		c.MinimumSize = DefaultCompressionMinimumSize
+	}
+	return nil
+}
+
 func (t *TLSConfig) isValid() error {
 	if len(t.CertificateFile) > 0 && len(t.PrivateKeyFile) > 0 {
 		_, err := tls.LoadX509KeyPair(t.CertificateFile, t.PrivateKeyFile)
@@ -1,7 +1,9 @@
 package web
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestGetDefaultConfig(t *testing.T) {
@@ -113,6 +115,175 @@ func TestConfig_ValidateAndSetDefaults(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidateAndSetDefaultsWithContextRoot(t *testing.T) {
+	scenarios := []struct {
+		contextRoot         string
+		expectedContextRoot string
+		expectedErr         error
+	}{
+		{contextRoot: "", expectedContextRoot: ""},
+		{contextRoot: "/", expectedContextRoot: ""},
+		{contextRoot: "/status", expectedContextRoot: "/status"},
+		{contextRoot: "status", expectedContextRoot: "/status"},
+		{contextRoot: "/status/", expectedContextRoot: "/status"},
+		{contextRoot: "/monitoring/gatus/", expectedContextRoot: "/monitoring/gatus"},
+		{contextRoot: "/status?x=y", expectedErr: ErrInvalidContextRoot},
+		{contextRoot: "/:status", expectedErr: ErrInvalidContextRoot},
+		{contextRoot: "/status/*", expectedErr: ErrInvalidContextRoot},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.contextRoot, func(t *testing.T) {
+			cfg := &Config{ContextRoot: scenario.contextRoot}
+			if err := cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && cfg.ContextRoot != scenario.expectedContextRoot {
+				t.Errorf("expected context root to be %q, got %q", scenario.expectedContextRoot, cfg.ContextRoot)
+			}
+		})
+	}
+}
+
+func TestCORSConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *CORSConfig
+		expectedErr error
+	}{
+		{name: "no-allowed-origin", cfg: &CORSConfig{}, expectedErr: ErrCORSWithNoAllowedOrigin},
+		{name: "wildcard", cfg: &CORSConfig{AllowedOrigins: []string{"*"}}},
+		{name: "wildcard-with-other-origins", cfg: &CORSConfig{AllowedOrigins: []string{"*", "https://example.org"}}, expectedErr: ErrCORSWithInvalidAllowedOrigin},
+		{name: "origins", cfg: &CORSConfig{AllowedOrigins: []string{"https://example.org", "http://localhost:8081"}}},
+		{name: "subdomain-wildcard", cfg: &CORSConfig{AllowedOrigins: []string{"https://*.example.org"}}},
+		{name: "origin-without-scheme", cfg: &CORSConfig{AllowedOrigins: []string{"example.org"}}, expectedErr: ErrCORSWithInvalidAllowedOrigin},
+		{name: "origin-with-unsupported-scheme", cfg: &CORSConfig{AllowedOrigins: []string{"ftp://example.org"}}, expectedErr: ErrCORSWithInvalidAllowedOrigin},
+		{name: "origin-with-path", cfg: &CORSConfig{AllowedOrigins: []string{"https://example.org/dashboard"}}, expectedErr: ErrCORSWithInvalidAllowedOrigin},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && len(scenario.cfg.AllowedMethods) != len(DefaultCORSAllowedMethods) {
+				t.Errorf("expected allowed methods to default to %v, got %v", DefaultCORSAllowedMethods, scenario.cfg.AllowedMethods)
+			}
+		})
+	}
+	t.Run("from-web-config", func(t *testing.T) {
+		cfg := &Config{CORS: &CORSConfig{}}
+		if err := cfg.ValidateAndSetDefaults(); err != ErrCORSWithNoAllowedOrigin {
+			t.Errorf("expected error %v, got %v", ErrCORSWithNoAllowedOrigin, err)
+		}
+	})
+}
+
+func TestRateLimitConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		cfg              *RateLimitConfig
+		expectedInterval time.Duration
+		expectedErr      error
+	}{
+		{name: "no-requests", cfg: &RateLimitConfig{}, expectedErr: ErrInvalidRateLimitRequests},
+		{name: "negative-requests", cfg: &RateLimitConfig{Requests: -1}, expectedErr: ErrInvalidRateLimitRequests},
+		{name: "default-interval", cfg: &RateLimitConfig{Requests: 10}, expectedInterval: DefaultRateLimitInterval},
+		{name: "custom-interval", cfg: &RateLimitConfig{Requests: 10, Interval: time.Second}, expectedInterval: time.Second},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && scenario.cfg.Interval != scenario.expectedInterval {
+				t.Errorf("expected interval to be %s, got %s", scenario.expectedInterval, scenario.cfg.Interval)
+			}
+		})
+	}
+	t.Run("from-web-config", func(t *testing.T) {
+		cfg := &Config{RateLimits: &RateLimitsConfig{ExternalEndpointResults: &RateLimitConfig{}}}
+		if err := cfg.ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidRateLimitRequests) {
+			t.Errorf("expected error %v, got %v", ErrInvalidRateLimitRequests, err)
+		}
+	})
+}
+
+func TestCompressionConfig_ValidateAndSetDefaults(t *testing.T) {
+	disabled := false
+	scenarios := []struct {
+		name                string
+		cfg                 *CompressionConfig
+		expectedMinimumSize int
+		expectedEnabled     bool
+		expectedErr         error
+	}{
+		{name: "default-minimum-size", cfg: &CompressionConfig{}, expectedMinimumSize: DefaultCompressionMinimumSize, expectedEnabled: true},
+		{name: "custom-minimum-size", cfg: &CompressionConfig{MinimumSize: 100}, expectedMinimumSize: 100, expectedEnabled: true},
+		{name: "negative-minimum-size", cfg: &CompressionConfig{MinimumSize: -1}, expectedErr: ErrInvalidCompressionMinimumSize},
+		{name: "disabled", cfg: &CompressionConfig{Enabled: &disabled}, expectedMinimumSize: DefaultCompressionMinimumSize, expectedEnabled: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr != nil {
+				return
+			}
+			if scenario.cfg.MinimumSize != scenario.expectedMinimumSize {
+				t.Errorf("expected minimum size to be %d, got %d", scenario.expectedMinimumSize, scenario.cfg.MinimumSize)
+			}
+			if scenario.cfg.IsEnabled() != scenario.expectedEnabled {
+				t.Errorf("expected enabled to be %v, got %v", scenario.expectedEnabled, scenario.cfg.IsEnabled())
+			}
+		})
+	}
+	t.Run("from-web-config", func(t *testing.T) {
+		cfg := &Config{}
+		if err := cfg.ValidateAndSetDefaults(); err != nil {
+			t.Fatal(err)
+		}
+		if !cfg.Compression.IsEnabled() || cfg.Compression.MinimumSize != DefaultCompressionMinimumSize {
+			t.Error("expected compression to be enabled with the default minimum size when not configured")
+		}
+	})
+}
+
+func TestConfig_ValidateAndSetDefaultsWithPublicURL(t *testing.T) {
+	scenarios := []struct {
+		publicURL         string
+		expectedPublicURL string
+		expectedErr       error
+	}{
+		{publicURL: "", expectedPublicURL: ""},
+		{publicURL: "https://status.example.org", expectedPublicURL: "https://status.example.org"},
+		{publicURL: "https://status.example.org/", expectedPublicURL: "https://status.example.org"},
+		{publicURL: "http://example.org/status/", expectedPublicURL: "http://example.org/status"},
+		{publicURL: "status.example.org", expectedErr: ErrInvalidPublicURL},
+		{publicURL: "ftp://status.example.org", expectedErr: ErrInvalidPublicURL},
+		{publicURL: "/status", expectedErr: ErrInvalidPublicURL},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.publicURL, func(t *testing.T) {
+			cfg := &Config{PublicURL: scenario.publicURL}
+			if err := cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && cfg.PublicURL != scenario.expectedPublicURL {
+				t.Errorf("expected public URL to be %q, got %q", scenario.expectedPublicURL, cfg.PublicURL)
+			}
+		})
+	}
+}
+
+func TestConfig_EndpointPageURL(t *testing.T) {
+	if pageURL := (&Config{}).EndpointPageURL("core_frontend"); pageURL != "" {
+		t.Errorf("expected no page URL without a public URL, got %s", pageURL)
+	}
+	if pageURL := (&Config{PublicURL: "https://status.example.org"}).EndpointPageURL("core_frontend"); pageURL != "https://status.example.org/endpoints/core_frontend" {
+		t.Errorf("expected page URL to be built from the public URL and the key, got %s", pageURL)
+	}
+}
+
 func TestConfig_SocketAddress(t *testing.T) {
 	web := &Config{
 		Address: "0.0.0.0",
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/web"
+)
+
+const healthcheckTimeout = 5 * time.Second
+
+// healthcheck sends a request to the health route of the Gatus instance configured by webConfig, under its context
+// root if it has one, and returns an error if the instance is unreachable or unhealthy.
+//
+// This is used by the --healthcheck flag, which allows minimal container images that don't ship with a tool like
+// curl to have a HEALTHCHECK instruction (e.g. HEALTHCHECK CMD ["/gatus", "--healthcheck"]).
+func healthcheck(webConfig *web.Config) error {
+	address := webConfig.Address
+	if ip := net.ParseIP(address); len(address) == 0 || (ip != nil && ip.IsUnspecified()) {
+		// The server listens on all interfaces, so the loopback interface can be used
+		address = "127.0.0.1"
+	}
+	scheme := "http"
+	if webConfig.HasTLS() {
+		scheme = "https"
+	}
+	httpClient := &http.Client{
+		Timeout: healthcheckTimeout,
+		Transport: &http.Transport{
+			// The certificate is very unlikely to have been issued for the loopback address
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	response, err := httpClient.Get(fmt.Sprintf("%s://%s%s/health", scheme, net.JoinHostPort(address, fmt.Sprint(webConfig.Port)), webConfig.ContextRoot))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("health route returned status code %d", response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/web"
+)
+
+func TestHealthcheck(t *testing.T) {
+	newServer := func(t *testing.T, status int, withTLS bool) (*httptest.Server, *web.Config) {
+		webConfig := &web.Config{}
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != webConfig.ContextRoot+"/health" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(status)
+		}))
+		if withTLS {
+			certificate, err := tls.LoadX509KeyPair("testdata/cert.pem", "testdata/cert.key")
+			if err != nil {
+				t.Fatal("failed to load certificate:", err)
+			}
+			server.TLS = &tls.Config{Certificates: []tls.Certificate{certificate}}
+			server.StartTLS()
+			webConfig.TLS = &web.TLSConfig{CertificateFile: "testdata/cert.pem", PrivateKeyFile: "testdata/cert.key"}
+		} else {
+			server.Start()
+		}
+		host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+		webConfig.Address = host
+		webConfig.Port, _ = strconv.Atoi(port)
+		return server, webConfig
+	}
+	t.Run("healthy", func(t *testing.T) {
+		server, webConfig := newServer(t, http.StatusOK, false)
+		defer server.Close()
+		if err := healthcheck(webConfig); err != nil {
+			t.Error("expected no error, got", err)
+		}
+	})
+	t.Run("healthy-with-unspecified-address", func(t *testing.T) {
+		server, webConfig := newServer(t, http.StatusOK, false)
+		defer server.Close()
+		webConfig.Address = "0.0.0.0"
+		if err := healthcheck(webConfig); err != nil {
+			t.Error("expected no error, got", err)
+		}
+	})
+	t.Run("healthy-with-tls", func(t *testing.T) {
+		server, webConfig := newServer(t, http.StatusOK, true)
+		defer server.Close()
+		if err := healthcheck(webConfig); err != nil {
+			t.Error("expected no error, got", err)
+		}
+	})
+	t.Run("healthy-with-context-root", func(t *testing.T) {
+		server, webConfig := newServer(t, http.StatusOK, false)
+		defer server.Close()
+		webConfig.ContextRoot = "/status"
+		if err := healthcheck(webConfig); err != nil {
+			t.Error("expected no error, got", err)
+		}
+	})
+	t.Run("unhealthy", func(t *testing.T) {
+		server, webConfig := newServer(t, http.StatusServiceUnavailable, false)
+		defer server.Close()
+		if err := healthcheck(webConfig); err == nil {
+			t.Error("expected an error, because the health route returned 503")
+		}
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		server, webConfig := newServer(t, http.StatusOK, false)
+		server.Close()
+		if err := healthcheck(webConfig); err == nil {
+			t.Error("expected an error, because the server is no longer running")
+		}
+	})
+}
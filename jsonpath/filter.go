@@ -0,0 +1,220 @@
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFilterExpression is returned when a filter expression (e.g. ?(@.healthy==true)) is malformed
+var ErrInvalidFilterExpression = errors.New("invalid filter expression")
+
+// comparisonOperators are the operators supported by filter expressions.
+// Operators that are a prefix of another operator must come after it.
+var comparisonOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// isFilterExpression returns whether the content of a bracket is a filter expression (e.g. ?(@.healthy==true))
+func isFilterExpression(index string) bool {
+	return strings.HasPrefix(index, "?(") && strings.HasSuffix(index, ")")
+}
+
+// filter returns the elements of the array for which the filter expression is true
+//
+// Supported predicates are comparisons between a path relative to the current element (@) and a literal
+// (e.g. @.status=='up', @.replicas>=3), existence checks (e.g. @.error) as well as any combination of those using
+// && and ||. Paths may contain filter expressions of their own (e.g. @.pods[?(@.ready==false)]).
+func filter(expression string, array []interface{}) ([]interface{}, error) {
+	predicate := strings.TrimSpace(expression[len("?(") : len(expression)-len(")")])
+	if len(predicate) == 0 {
+		return nil, fmt.Errorf("%w: '%s' has no predicate", ErrInvalidFilterExpression, expression)
+	}
+	if len(array) == 0 {
+		// The predicate is still evaluated so that a malformed filter expression is reported regardless of the body
+		if _, err := evaluatePredicate(predicate, nil); err != nil {
+			return nil, err
+		}
+	}
+	filteredArray := make([]interface{}, 0, len(array))
+	for _, element := range array {
+		matches, err := evaluatePredicate(predicate, element)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			filteredArray = append(filteredArray, element)
+		}
+	}
+	return filteredArray, nil
+}
+
+func evaluatePredicate(predicate string, element interface{}) (bool, error) {
+	// && takes precedence over ||
+	if operands := splitOutsideOfBrackets(predicate, "||"); len(operands) > 1 {
+		for _, operand := range operands {
+			if matches, err := evaluatePredicate(operand, element); err != nil || matches {
+				return matches, err
+			}
+		}
+		return false, nil
+	}
+	if operands := splitOutsideOfBrackets(predicate, "&&"); len(operands) > 1 {
+		for _, operand := range operands {
+			if matches, err := evaluatePredicate(operand, element); err != nil || !matches {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	predicate = strings.TrimSpace(predicate)
+	for _, operator := range comparisonOperators {
+		if operands := splitOutsideOfBrackets(predicate, operator); len(operands) == 2 {
+			left, err := resolveOperand(strings.TrimSpace(operands[0]), element)
+			if err != nil {
+				return false, err
+			}
+			right, err := resolveOperand(strings.TrimSpace(operands[1]), element)
+			if err != nil {
+				return false, err
+			}
+			return compare(left, operator, right), nil
+		}
+	}
+	if !strings.HasPrefix(predicate, "@") || len(splitOutsideOfBrackets(predicate, "=")) > 1 {
+		return false, fmt.Errorf("%w: invalid predicate '%s'", ErrInvalidFilterExpression, predicate)
+	}
+	value, exists, err := resolveRelativePath(predicate, element)
+	if err != nil {
+		return false, err
+	}
+	if array, ok := value.([]interface{}); ok {
+		// This allows nested filter expressions to be used as a predicate
+		return len(array) > 0, nil
+	}
+	return exists, nil
+}
+
+// resolveOperand resolves a path relative to the current element or a literal
+func resolveOperand(operand string, element interface{}) (interface{}, error) {
+	if strings.HasPrefix(operand, "@") {
+		value, _, err := resolveRelativePath(operand, element)
+		return value, err
+	}
+	if len(operand) >= 2 && (operand[0] == '\'' || operand[0] == '"') && operand[len(operand)-1] == operand[0] {
+		return operand[1 : len(operand)-1], nil
+	}
+	switch operand {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(operand, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid operand '%s'", ErrInvalidFilterExpression, operand)
+	}
+	return number, nil
+}
+
+// resolveRelativePath resolves a path relative to the current element (e.g. @.status) and returns whether it exists
+func resolveRelativePath(path string, element interface{}) (interface{}, bool, error) {
+	path = strings.TrimPrefix(path, "@")
+	if len(path) == 0 {
+		return element, element != nil, nil
+	}
+	if path[0] != '.' && path[0] != '[' {
+		return nil, false, fmt.Errorf("%w: invalid path '@%s'", ErrInvalidFilterExpression, path)
+	}
+	value := element
+	for _, key := range splitKeys(strings.TrimPrefix(path, ".")) {
+		if value == nil {
+			return nil, false, nil
+		}
+		var err error
+		if value, err = extractValue(key, value); err != nil {
+			return nil, false, err
+		}
+	}
+	return value, value != nil, nil
+}
+
+// compare compares two values. Values of different types are never equal, nor ordered.
+func compare(left interface{}, operator string, right interface{}) bool {
+	switch leftValue := left.(type) {
+	case float64:
+		rightValue, ok := right.(float64)
+		if !ok {
+			return operator == "!="
+		}
+		switch operator {
+		case "==":
+			return leftValue == rightValue
+		case "!=":
+			return leftValue != rightValue
+		case "<":
+			return leftValue < rightValue
+		case "<=":
+			return leftValue <= rightValue
+		case ">":
+			return leftValue > rightValue
+		case ">=":
+			return leftValue >= rightValue
+		}
+	case string:
+		rightValue, ok := right.(string)
+		if !ok {
+			return operator == "!="
+		}
+		switch operator {
+		case "==":
+			return leftValue == rightValue
+		case "!=":
+			return leftValue != rightValue
+		case "<":
+			return leftValue < rightValue
+		case "<=":
+			return leftValue <= rightValue
+		case ">":
+			return leftValue > rightValue
+		case ">=":
+			return leftValue >= rightValue
+		}
+	default:
+		switch operator {
+		case "==":
+			return reflect.DeepEqual(left, right)
+		case "!=":
+			return !reflect.DeepEqual(left, right)
+		}
+	}
+	return false
+}
+
+// splitOutsideOfBrackets splits s around each instance of separator that isn't inside brackets, parentheses or quotes
+func splitOutsideOfBrackets(s, separator string) []string {
+	var parts []string
+	var quote byte
+	depth, startOfCurrentPart := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '"':
+			quote = s[i]
+		case s[i] == '[' || s[i] == '(':
+			depth++
+		case s[i] == ']' || s[i] == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(s[i:], separator):
+			parts = append(parts, s[startOfCurrentPart:i])
+			i += len(separator) - 1
+			startOfCurrentPart = i + 1
+		}
+	}
+	return append(parts, s[startOfCurrentPart:])
+}
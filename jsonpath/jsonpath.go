@@ -9,19 +9,136 @@ import (
 
 // Eval is a half-baked json path implementation that needs some love
 func Eval(path string, b []byte) (string, int, error) {
-	if len(path) == 0 && !(len(b) != 0 && b[0] == '[' && b[len(b)-1] == ']') {
+	return NewDocument(b).Eval(path)
+}
+
+// Document is a JSON document that is unmarshalled at most once, regardless of how many paths are evaluated against it
+type Document struct {
+	raw    []byte
+	object interface{}
+	err    error
+	parsed bool
+}
+
+// NewDocument creates a Document from raw JSON. The JSON is only unmarshalled once it is first needed.
+func NewDocument(b []byte) *Document {
+	return &Document{raw: b}
+}
+
+// Eval evaluates a path against the document and returns the value as a string as well as its length
+func (d *Document) Eval(path string) (string, int, error) {
+	if len(path) == 0 && !(len(d.raw) != 0 && d.raw[0] == '[' && d.raw[len(d.raw)-1] == ']') {
 		// if there's no path AND the value is not a JSON array, then there's nothing to walk
-		return string(b), len(b), nil
+		return string(d.raw), len(d.raw), nil
 	}
-	var object interface{}
-	if err := json.Unmarshal(b, &object); err != nil {
+	object, err := d.parse()
+	if err != nil {
 		return "", 0, err
 	}
 	return walk(path, object)
 }
 
+// IsValid returns whether the document is valid JSON
+func (d *Document) IsValid() bool {
+	_, err := d.parse()
+	return err == nil
+}
+
+func (d *Document) parse() (interface{}, error) {
+	if !d.parsed {
+		d.err = json.Unmarshal(d.raw, &d.object)
+		d.parsed = true
+	}
+	return d.object, d.err
+}
+
+// Type evaluates a path against the document and returns the JSON type of the value found at said path, which is one
+// of "object", "array", "string", "number", "bool" or "null"
+func (d *Document) Type(path string) (string, error) {
+	object, err := d.parse()
+	if err != nil {
+		return "", err
+	}
+	if len(path) == 0 {
+		return typeOf(object), nil
+	}
+	value, err := find(path, object)
+	if err != nil {
+		return "", err
+	}
+	return typeOf(value), nil
+}
+
 // walk traverses the object and returns the value as a string as well as its length
 func walk(path string, object interface{}) (string, int, error) {
+	value, err := find(path, object)
+	if err != nil {
+		return "", 0, err
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		// Since it's a map, we'll treat it as a string by re-marshaling it to JSON.
+		// Note that the output JSON will be minified.
+		b, err := json.Marshal(value)
+		return string(b), len(b), err
+	case string:
+		return value, len(value), nil
+	case []interface{}:
+		return fmt.Sprintf("%v", value), len(value), nil
+	default:
+		newValue := fmt.Sprintf("%v", value)
+		return newValue, len(newValue), nil
+	}
+}
+
+// find traverses the object and returns the value at the end of the path
+func find(path string, object interface{}) (interface{}, error) {
+	keys := splitKeys(path)
+	currentKey := keys[0]
+	extractedValue, err := extractValue(currentKey, object)
+	if err != nil {
+		return nil, err
+	}
+	switch value := extractedValue.(type) {
+	case map[string]interface{}:
+		newPath := strings.Replace(path, fmt.Sprintf("%s.", currentKey), "", 1)
+		if path == newPath {
+			// If the path hasn't changed, it means we're at the end of the path
+			return value, nil
+		}
+		return find(newPath, value)
+	case string:
+		if len(keys) > 1 {
+			return nil, fmt.Errorf("couldn't walk through '%s', because '%s' was a string instead of an object", keys[1], currentKey)
+		}
+		return value, nil
+	case interface{}:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("couldn't walk through '%s' because type was '%T', but expected 'map[string]interface{}'", currentKey, value)
+	}
+}
+
+// typeOf returns the JSON type of a value unmarshalled by encoding/json
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+// splitKeys splits a path into keys, ignoring the dots inside brackets (e.g. filter expressions)
+func splitKeys(path string) []string {
 	var keys []string
 	startOfCurrentKey, bracketDepth := 0, 0
 	for i := range path {
@@ -39,34 +156,10 @@ func walk(path string, object interface{}) (string, int, error) {
 	if startOfCurrentKey <= len(path) {
 		keys = append(keys, path[startOfCurrentKey:])
 	}
-	currentKey := keys[0]
-	switch value := extractValue(currentKey, object).(type) {
-	case map[string]interface{}:
-		newPath := strings.Replace(path, fmt.Sprintf("%s.", currentKey), "", 1)
-		if path == newPath {
-			// If the path hasn't changed, it means we're at the end of the path
-			// So we'll treat it as a string by re-marshaling it to JSON since it's a map.
-			// Note that the output JSON will be minified.
-			b, err := json.Marshal(value)
-			return string(b), len(b), err
-		}
-		return walk(newPath, value)
-	case string:
-		if len(keys) > 1 {
-			return "", 0, fmt.Errorf("couldn't walk through '%s', because '%s' was a string instead of an object", keys[1], currentKey)
-		}
-		return value, len(value), nil
-	case []interface{}:
-		return fmt.Sprintf("%v", value), len(value), nil
-	case interface{}:
-		newValue := fmt.Sprintf("%v", value)
-		return newValue, len(newValue), nil
-	default:
-		return "", 0, fmt.Errorf("couldn't walk through '%s' because type was '%T', but expected 'map[string]interface{}'", currentKey, value)
-	}
+	return keys
 }
 
-func extractValue(currentKey string, value interface{}) interface{} {
+func extractValue(currentKey string, value interface{}) (interface{}, error) {
 	// Check if the current key ends with [#]
 	if strings.HasSuffix(currentKey, "]") && strings.Contains(currentKey, "[") {
 		var isNestedArray bool
@@ -74,10 +167,16 @@ func extractValue(currentKey string, value interface{}) interface{} {
 		startOfBracket, endOfBracket, bracketDepth := 0, 0, 0
 		for i := range currentKey {
 			if currentKey[i] == '[' {
-				startOfBracket = i
+				// Filter expressions may contain brackets of their own, so only the outermost bracket is relevant
+				if bracketDepth == 0 {
+					startOfBracket = i
+				}
 				bracketDepth++
-			} else if currentKey[i] == ']' && bracketDepth == 1 {
+			} else if currentKey[i] == ']' {
 				bracketDepth--
+				if bracketDepth != 0 {
+					continue
+				}
 				endOfBracket = i
 				index = currentKey[startOfBracket+1 : i]
 				if len(currentKey) > i+1 && currentKey[i+1] == '[' {
@@ -86,11 +185,30 @@ func extractValue(currentKey string, value interface{}) interface{} {
 				break
 			}
 		}
+		currentKeyWithoutIndex := currentKey[:startOfBracket]
+		if isFilterExpression(index) {
+			var array []interface{}
+			if len(currentKeyWithoutIndex) == 0 {
+				array, _ = value.([]interface{})
+			} else if valueAsMap, ok := value.(map[string]interface{}); ok {
+				array, _ = valueAsMap[currentKeyWithoutIndex].([]interface{})
+			}
+			if array == nil {
+				return nil, nil
+			}
+			filteredArray, err := filter(index, array)
+			if err != nil {
+				return nil, err
+			}
+			if isNestedArray {
+				return extractValue(currentKey[endOfBracket+1:], filteredArray)
+			}
+			return filteredArray, nil
+		}
 		arrayIndex, err := strconv.Atoi(index)
 		if err != nil {
-			return nil
+			return nil, nil
 		}
-		currentKeyWithoutIndex := currentKey[:startOfBracket]
 		// if currentKeyWithoutIndex contains only an index (i.e. [0] or 0)
 		if len(currentKeyWithoutIndex) == 0 {
 			array, _ := value.([]interface{})
@@ -98,12 +216,12 @@ func extractValue(currentKey string, value interface{}) interface{} {
 				if isNestedArray {
 					return extractValue(currentKey[endOfBracket+1:], array[arrayIndex])
 				}
-				return array[arrayIndex]
+				return array[arrayIndex], nil
 			}
-			return nil
+			return nil, nil
 		}
 		if value == nil || value.(map[string]interface{})[currentKeyWithoutIndex] == nil {
-			return nil
+			return nil, nil
 		}
 		// if currentKeyWithoutIndex contains both a key and an index (i.e. data[0])
 		array, _ := value.(map[string]interface{})[currentKeyWithoutIndex].([]interface{})
@@ -111,21 +229,21 @@ func extractValue(currentKey string, value interface{}) interface{} {
 			if isNestedArray {
 				return extractValue(currentKey[endOfBracket+1:], array[arrayIndex])
 			}
-			return array[arrayIndex]
+			return array[arrayIndex], nil
 		}
-		return nil
+		return nil, nil
 	}
 	if valueAsSlice, ok := value.([]interface{}); ok {
 		// If the type is a slice, return it
 		// This happens when the body (value) is a JSON array
-		return valueAsSlice
+		return valueAsSlice, nil
 	}
 	if valueAsMap, ok := value.(map[string]interface{}); ok {
 		// If the value is a map, then we get the currentKey from that map
 		// This happens when the body (value) is a JSON object
-		return valueAsMap[currentKey]
+		return valueAsMap[currentKey], nil
 	}
 	// If the value is neither a map, nor a slice, nor an index, then we cannot retrieve the currentKey
 	// from said value. This usually happens when the body (value) is null.
-	return value
+	return value, nil
 }
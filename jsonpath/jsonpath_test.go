@@ -1,6 +1,7 @@
 package jsonpath
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -174,6 +175,126 @@ func TestEval(t *testing.T) {
 			ExpectedOutputLength: 18,
 			ExpectedError:        false,
 		},
+		{
+			Name:                 "filter-with-boolean-equality",
+			Path:                 "services[?(@.healthy==true)]",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "[map[healthy:true name:api pods:[map[ready:true]] replicas:3 zone:map[name:east]] map[error:oom healthy:true name:cache pods:[] replicas:5 zone:map[name:west]]]",
+			ExpectedOutputLength: 2,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-boolean-inequality",
+			Path:                 "services[?(@.healthy!=true)][0].name",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "db",
+			ExpectedOutputLength: 2,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-string-equality",
+			Path:                 "services[?(@.name=='cache')][0].replicas",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "5",
+			ExpectedOutputLength: 1,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-double-quoted-string",
+			Path:                 `services[?(@.name=="api")][0].replicas`,
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "3",
+			ExpectedOutputLength: 1,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-numeric-comparison",
+			Path:                 "services[?(@.replicas>=3)]",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "[map[healthy:true name:api pods:[map[ready:true]] replicas:3 zone:map[name:east]] map[error:oom healthy:true name:cache pods:[] replicas:5 zone:map[name:west]]]",
+			ExpectedOutputLength: 2,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-strict-numeric-comparison",
+			Path:                 "services[?(@.replicas<3)][0].name",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "db",
+			ExpectedOutputLength: 2,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-nested-path",
+			Path:                 "services[?(@.zone.name=='west')]",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "[map[healthy:false name:db pods:[map[ready:false] map[ready:true]] replicas:1 zone:map[name:west]] map[error:oom healthy:true name:cache pods:[] replicas:5 zone:map[name:west]]]",
+			ExpectedOutputLength: 2,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-and",
+			Path:                 "services[?(@.healthy==true && @.zone.name=='west')][0].name",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "cache",
+			ExpectedOutputLength: 5,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-or",
+			Path:                 "services[?(@.name=='api' || @.replicas<2)]",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "[map[healthy:true name:api pods:[map[ready:true]] replicas:3 zone:map[name:east]] map[healthy:false name:db pods:[map[ready:false] map[ready:true]] replicas:1 zone:map[name:west]]]",
+			ExpectedOutputLength: 2,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-existence",
+			Path:                 "services[?(@.error)][0].name",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "cache",
+			ExpectedOutputLength: 5,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-nested-filter",
+			Path:                 "services[?(@.pods[?(@.ready==false)])][0].name",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "db",
+			ExpectedOutputLength: 2,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-no-match",
+			Path:                 "services[?(@.replicas>10)]",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "[]",
+			ExpectedOutputLength: 0,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-on-root-array",
+			Path:                 "[?(@.id>1)][0].id",
+			Data:                 `[{"id": 1}, {"id": 2}]`,
+			ExpectedOutput:       "2",
+			ExpectedOutputLength: 1,
+			ExpectedError:        false,
+		},
+		{
+			Name:                 "filter-with-invalid-operand",
+			Path:                 "services[?(@.name==api)]",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "",
+			ExpectedOutputLength: 0,
+			ExpectedError:        true,
+		},
+		{
+			Name:                 "filter-on-non-array",
+			Path:                 "services[0][?(@.healthy==true)]",
+			Data:                 `{"services": [{"name": "api", "healthy": true, "replicas": 3, "zone": {"name": "east"}, "pods": [{"ready": true}]}, {"name": "db", "healthy": false, "replicas": 1, "zone": {"name": "west"}, "pods": [{"ready": false}, {"ready": true}]}, {"name": "cache", "healthy": true, "replicas": 5, "zone": {"name": "west"}, "error": "oom", "pods": []}]}`,
+			ExpectedOutput:       "",
+			ExpectedOutputLength: 0,
+			ExpectedError:        true,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -194,3 +315,78 @@ func TestEval(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalWithMalformedFilterExpression(t *testing.T) {
+	data := []byte(`{"services": [{"name": "api", "healthy": true, "pods": [{"ready": true}]}], "empty": []}`)
+	for _, path := range []string{
+		"services[?()]",
+		"services[?(@.name==api)]",
+		"services[?(@.name='api')]",
+		"services[?(name=='api')]",
+		"services[?(@name=='api')]",
+		"services[?(@.healthy==true && @.name=)]",
+		"services[?(@.pods[?(@.ready=maybe)])]",
+		"empty[?(@.name==api)]",
+	} {
+		t.Run(path, func(t *testing.T) {
+			if _, _, err := Eval(path, data); !errors.Is(err, ErrInvalidFilterExpression) {
+				t.Errorf("expected error to be %v, got %v", ErrInvalidFilterExpression, err)
+			}
+		})
+	}
+}
+
+func TestDocument(t *testing.T) {
+	document := NewDocument([]byte(`{"data": {"name": "john", "ids": [1, 2]}}`))
+	if !document.IsValid() {
+		t.Error("expected document to be valid")
+	}
+	if output, _, err := document.Eval("data.name"); err != nil || output != "john" {
+		t.Errorf("expected john, got %s (err=%v)", output, err)
+	}
+	if _, length, err := document.Eval("data.ids"); err != nil || length != 2 {
+		t.Errorf("expected length 2, got %d (err=%v)", length, err)
+	}
+	invalidDocument := NewDocument([]byte("<html></html>"))
+	if invalidDocument.IsValid() {
+		t.Error("expected document to be invalid")
+	}
+	if _, _, err := invalidDocument.Eval("data.name"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestDocument_Type(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Path          string
+		Data          string
+		ExpectedType  string
+		ExpectedError bool
+	}{
+		{Name: "number", Path: "count", Data: `{"count": 5}`, ExpectedType: "number"},
+		{Name: "number-as-string", Path: "count", Data: `{"count": "5"}`, ExpectedType: "string"},
+		{Name: "float", Path: "ratio", Data: `{"ratio": 0.5}`, ExpectedType: "number"},
+		{Name: "bool", Path: "enabled", Data: `{"enabled": true}`, ExpectedType: "bool"},
+		{Name: "bool-as-string", Path: "enabled", Data: `{"enabled": "true"}`, ExpectedType: "string"},
+		{Name: "array", Path: "users", Data: `{"users": [1, 2]}`, ExpectedType: "array"},
+		{Name: "object", Path: "user", Data: `{"user": {"id": 1}}`, ExpectedType: "object"},
+		{Name: "nested", Path: "data.user.id", Data: `{"data": {"user": {"id": 1}}}`, ExpectedType: "number"},
+		{Name: "array-element", Path: "users[1].name", Data: `{"users": [{"name": 1}, {"name": "bob"}]}`, ExpectedType: "string"},
+		{Name: "root-object", Path: "", Data: `{"count": 5}`, ExpectedType: "object"},
+		{Name: "root-array", Path: "", Data: `[1, 2]`, ExpectedType: "array"},
+		{Name: "missing", Path: "count", Data: `{}`, ExpectedError: true},
+		{Name: "invalid-json", Path: "count", Data: `invalid`, ExpectedError: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			actualType, err := NewDocument([]byte(scenario.Data)).Type(scenario.Path)
+			if scenario.ExpectedError != (err != nil) {
+				t.Fatalf("expected error to be %v, got %v", scenario.ExpectedError, err)
+			}
+			if actualType != scenario.ExpectedType {
+				t.Errorf("expected type to be %q, got %q", scenario.ExpectedType, actualType)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
 	"os/signal"
@@ -8,13 +9,90 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/logging"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/controller"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/watchdog"
 )
 
+const (
+	// DefaultConfigCheckInterval is the default interval at which the configuration file is checked for changes
+	DefaultConfigCheckInterval = 30 * time.Second
+)
+
+// configCheckInterval is the interval at which the configuration file is checked for changes.
+// Setting it to 0 disables reloading the configuration on the fly altogether.
+var configCheckInterval = DefaultConfigCheckInterval
+
 func main() {
+	healthcheckFlag := flag.Bool("healthcheck", false, "check the health of the running instance and exit with 0 if it is healthy, 1 otherwise")
+	validateFlag := flag.Bool("validate", false, "validate the configuration, report every problem found and exit with 0 if it is valid, 1 otherwise")
+	outputFlag := flag.String("output", OutputText, "output format of -validate, either "+OutputText+" or "+OutputJSON)
+	exportRuntimeStateFlag := flag.Bool("export-runtime-state", false, "write the state created at runtime (e.g. maintenance windows) persisted by the storage to stdout as JSON and exit")
+	importRuntimeStateFlag := flag.Bool("import-runtime-state", false, "replace the state created at runtime persisted by the storage by the JSON read from stdin and exit")
+	printEndpointsFlag := flag.Bool("print-endpoints", false, "print the key, interval, conditions and alert types of every endpoint as loaded from the configuration and exit")
+	flag.DurationVar(&configCheckInterval, "config-check-interval", DefaultConfigCheckInterval, "interval at which the configuration file is checked for changes, or 0 to disable reloading the configuration on the fly")
+	flag.Parse()
+	if configCheckInterval < 0 {
+		log.Println("Invalid value for -config-check-interval: must be 0 or greater")
+		os.Exit(2)
+	}
+	if *validateFlag {
+		valid, err := validate(configPath(), *outputFlag, os.Stdout)
+		if err != nil {
+			log.Println("Failed to validate configuration:", err.Error())
+			os.Exit(2)
+		}
+		if !valid {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *healthcheckFlag {
+		cfg, err := loadConfiguration()
+		if err != nil {
+			log.Println("Failed to load configuration:", err.Error())
+			os.Exit(1)
+		}
+		if err = healthcheck(cfg.Web); err != nil {
+			log.Println("Health check failed:", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *printEndpointsFlag {
+		cfg, err := loadConfiguration()
+		if err != nil {
+			log.Println("Failed to load configuration:", err.Error())
+			os.Exit(1)
+		}
+		if err = printEndpoints(cfg, os.Stdout); err != nil {
+			log.Println("Failed to print endpoints:", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *exportRuntimeStateFlag || *importRuntimeStateFlag {
+		cfg, err := loadConfiguration()
+		if err != nil {
+			log.Println("Failed to load configuration:", err.Error())
+			os.Exit(1)
+		}
+		if *exportRuntimeStateFlag {
+			err = exportRuntimeState(cfg.Storage, os.Stdout)
+		} else {
+			err = importRuntimeState(cfg.Storage, os.Stdin)
+		}
+		if err != nil {
+			log.Println("Failed to export or import runtime state:", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	if delayInSeconds, _ := strconv.Atoi(os.Getenv("GATUS_DELAY_START_SECONDS")); delayInSeconds > 0 {
 		log.Printf("Delaying start by %d seconds", delayInSeconds)
 		time.Sleep(time.Duration(delayInSeconds) * time.Second)
@@ -23,7 +101,11 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	if cfg.Alerting.IsMutedByDefault() {
+		alerting.Mute()
+	}
 	initializeStorage(cfg)
+	loadRuntimeState()
 	start(cfg)
 	// Wait for termination signal
 	signalChannel := make(chan os.Signal, 1)
@@ -34,6 +116,7 @@ func main() {
 		log.Println("Received termination signal, attempting to gracefully shut down")
 		stop(cfg)
 		save()
+		store.Get().Close()
 		done <- true
 	}()
 	<-done
@@ -41,12 +124,22 @@ func main() {
 }
 
 func start(cfg *config.Config) {
+	alert.SetTimestampConfig(cfg.Alerting.GetTimestampConfig())
+	if cfg.Alerting != nil && cfg.Alerting.Log != nil && len(cfg.Alerting.Log.Path) > 0 {
+		logging.HandleSIGHUP()
+	}
 	go controller.Handle(cfg)
 	watchdog.Monitor(cfg)
-	go listenToConfigurationFileChanges(cfg)
+	if cfg.Heartbeat != nil {
+		cfg.Heartbeat.Start(func() error { return store.Get().Ping() })
+	}
+	watchConfigurationFile(cfg, configCheckInterval)
 }
 
 func stop(cfg *config.Config) {
+	if cfg.Heartbeat != nil {
+		cfg.Heartbeat.Stop()
+	}
 	watchdog.Shutdown(cfg)
 	controller.Shutdown()
 }
@@ -58,6 +151,29 @@ func save() {
 }
 
 func loadConfiguration() (*config.Config, error) {
+	return config.LoadConfiguration(configPath())
+}
+
+// loadUpdatedConfiguration loads the updated configuration file, or returns nil if it is invalid and
+// skip-invalid-config-update is enabled, in which case the error is recorded on the current configuration.
+// Panics if the updated configuration is invalid and skip-invalid-config-update is disabled.
+func loadUpdatedConfiguration(cfg *config.Config) *config.Config {
+	updatedConfig, err := loadConfiguration()
+	if err != nil {
+		if !cfg.SkipInvalidConfigUpdate {
+			panic(err)
+		}
+		log.Println("[main.loadUpdatedConfiguration] Failed to load new configuration:", err.Error())
+		log.Println("[main.loadUpdatedConfiguration] The configuration file was updated, but it is not valid. The old configuration will continue being used.")
+		cfg.SetLastReloadError(err)
+		// Update the last file modification time to avoid trying to process the same invalid configuration again
+		cfg.UpdateLastFileModTime()
+		return nil
+	}
+	return updatedConfig
+}
+
+func configPath() string {
 	configPath := os.Getenv("GATUS_CONFIG_PATH")
 	// Backwards compatibility
 	if len(configPath) == 0 {
@@ -65,7 +181,7 @@ func loadConfiguration() (*config.Config, error) {
 			log.Println("WARNING: GATUS_CONFIG_FILE is deprecated. Please use GATUS_CONFIG_PATH instead.")
 		}
 	}
-	return config.LoadConfiguration(configPath)
+	return configPath
 }
 
 // initializeStorage initializes the storage provider
@@ -78,6 +194,28 @@ func initializeStorage(cfg *config.Config) {
 	if err != nil {
 		panic(err)
 	}
+	if cfg.Metrics {
+		store.EnableMetrics()
+	}
+	// Move the history of endpoints with an ID that have been renamed, so that it isn't deleted below
+	endpointsWithID := make([]*endpoint.Endpoint, 0)
+	for _, ep := range cfg.Endpoints {
+		if len(ep.ID) > 0 {
+			endpointsWithID = append(endpointsWithID, ep)
+		}
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		if len(ee.ID) > 0 {
+			endpointsWithID = append(endpointsWithID, ee.ToEndpoint())
+		}
+	}
+	for _, ep := range endpointsWithID {
+		if renamed, err := store.Get().RenameEndpointByID(ep); err != nil {
+			log.Printf("[main.initializeStorage] Failed to associate id=%s with endpoint with key=%s: %s", ep.ID, ep.Key(), err.Error())
+		} else if renamed {
+			log.Printf("[main.initializeStorage] Moved the history of endpoint with id=%s to key=%s, because it was renamed", ep.ID, ep.Key())
+		}
+	}
 	// Remove all EndpointStatus that represent endpoints which no longer exist in the configuration
 	var keys []string
 	for _, ep := range cfg.Endpoints {
@@ -111,7 +249,11 @@ func initializeStorage(cfg *config.Config) {
 			}
 			if exists {
 				alert.Triggered, alert.ResolveKey = true, resolveKey
-				ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				alert.NumberOfSuccessesInARow, alert.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				// Each alert restores its own streak, while the endpoint's streak is restored from the longest of them,
+				// so that it doesn't depend on the order of the alerts
+				ep.NumberOfSuccessesInARow = max(ep.NumberOfSuccessesInARow, numberOfSuccessesInARow)
+				ep.NumberOfFailuresInARow = max(ep.NumberOfFailuresInARow, alert.FailureThreshold)
 				numberOfPersistedTriggeredAlertsLoaded++
 			}
 		}
@@ -136,7 +278,9 @@ func initializeStorage(cfg *config.Config) {
 			}
 			if exists {
 				alert.Triggered, alert.ResolveKey = true, resolveKey
-				ee.NumberOfSuccessesInARow, ee.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				alert.NumberOfSuccessesInARow, alert.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				ee.NumberOfSuccessesInARow = max(ee.NumberOfSuccessesInARow, numberOfSuccessesInARow)
+				ee.NumberOfFailuresInARow = max(ee.NumberOfFailuresInARow, alert.FailureThreshold)
 				numberOfPersistedTriggeredAlertsLoaded++
 			}
 		}
@@ -146,24 +290,39 @@ func initializeStorage(cfg *config.Config) {
 	}
 }
 
-func listenToConfigurationFileChanges(cfg *config.Config) {
+// watchConfigurationFile starts listening to changes made to the configuration file in the background and returns
+// whether it did, which is not the case if the interval is 0
+func watchConfigurationFile(cfg *config.Config, interval time.Duration) bool {
+	if interval <= 0 {
+		log.Println("[main.watchConfigurationFile] Reloading the configuration on the fly is disabled")
+		return false
+	}
+	go listenToConfigurationFileChanges(cfg, interval)
+	return true
+}
+
+// listenToConfigurationFileChanges reloads the configuration once the configuration file has been modified.
+//
+// The updated configuration is loaded and validated before the current configuration is stopped, so that if it is
+// invalid and skip-invalid-config-update is enabled, the current configuration keeps being used.
+func listenToConfigurationFileChanges(cfg *config.Config, interval time.Duration) {
 	for {
-		time.Sleep(30 * time.Second)
+		time.Sleep(interval)
 		if cfg.HasLoadedConfigurationBeenModified() {
 			log.Println("[main.listenToConfigurationFileChanges] Configuration file has been modified")
+			updatedConfig := loadUpdatedConfiguration(cfg)
+			if updatedConfig == nil {
+				continue
+			}
 			stop(cfg)
 			time.Sleep(time.Second) // Wait a bit to make sure everything is done.
 			save()
-			updatedConfig, err := loadConfiguration()
-			if err != nil {
-				if cfg.SkipInvalidConfigUpdate {
-					log.Println("[main.listenToConfigurationFileChanges] Failed to load new configuration:", err.Error())
-					log.Println("[main.listenToConfigurationFileChanges] The configuration file was updated, but it is not valid. The old configuration will continue being used.")
-					// Update the last file modification time to avoid trying to process the same invalid configuration again
-					cfg.UpdateLastFileModTime()
-					continue
+			// Only apply alerting.muted if it changed, so that alerts muted or unmuted through the API stay that way
+			if updatedConfig.Alerting.IsMutedByDefault() != cfg.Alerting.IsMutedByDefault() {
+				if updatedConfig.Alerting.IsMutedByDefault() {
+					alerting.Mute()
 				} else {
-					panic(err)
+					alerting.Unmute()
 				}
 			}
 			store.Get().Close()
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestWatchConfigurationFile(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		numberOfGoroutinesBefore := runtime.NumGoroutine()
+		if watchConfigurationFile(&config.Config{}, 0) {
+			t.Error("expected the configuration file not to be watched when the interval is 0")
+		}
+		if numberOfGoroutines := runtime.NumGoroutine(); numberOfGoroutines != numberOfGoroutinesBefore {
+			t.Errorf("expected no goroutine to be started, had %d before and %d after", numberOfGoroutinesBefore, numberOfGoroutines)
+		}
+	})
+	t.Run("enabled", func(t *testing.T) {
+		numberOfGoroutinesBefore := runtime.NumGoroutine()
+		if !watchConfigurationFile(&config.Config{}, time.Hour) {
+			t.Error("expected the configuration file to be watched when the interval is greater than 0")
+		}
+		if numberOfGoroutines := runtime.NumGoroutine(); numberOfGoroutines != numberOfGoroutinesBefore+1 {
+			t.Errorf("expected one goroutine to be started, had %d before and %d after", numberOfGoroutinesBefore, numberOfGoroutines)
+		}
+	})
+}
+
+func TestLoadUpdatedConfiguration(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("GATUS_CONFIG_PATH", configPath)
+	if err := os.WriteFile(configPath, []byte("endpoints:\n  - name: invalid\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("invalid-with-skip-invalid-config-update", func(t *testing.T) {
+		cfg := &config.Config{SkipInvalidConfigUpdate: true}
+		if updatedConfig := loadUpdatedConfiguration(cfg); updatedConfig != nil {
+			t.Error("expected an invalid configuration not to be returned")
+		}
+		if cfg.LastReloadError() == nil {
+			t.Error("expected the error to be recorded on the current configuration")
+		}
+	})
+	t.Run("invalid-without-skip-invalid-config-update", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected an invalid configuration to panic")
+			}
+		}()
+		loadUpdatedConfiguration(&config.Config{})
+	})
+	t.Run("valid", func(t *testing.T) {
+		if err := os.WriteFile(configPath, []byte("endpoints:\n  - name: valid\n    url: https://example.org\n    conditions:\n      - \"[STATUS] == 200\"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cfg := &config.Config{SkipInvalidConfigUpdate: true}
+		if updatedConfig := loadUpdatedConfiguration(cfg); updatedConfig == nil || len(updatedConfig.Endpoints) != 1 {
+			t.Errorf("expected the updated configuration to be returned, got %v", updatedConfig)
+		}
+		if cfg.LastReloadError() != nil {
+			t.Errorf("expected no error to be recorded, got %v", cfg.LastReloadError())
+		}
+	})
+}
+
+func TestDefaultConfigCheckInterval(t *testing.T) {
+	if configCheckInterval != DefaultConfigCheckInterval {
+		t.Errorf("expected the configuration file to be checked every %s by default, got %s", DefaultConfigCheckInterval, configCheckInterval)
+	}
+}
+
+func TestInitializeStorageRestoresTheStreakOfEachTriggeredAlert(t *testing.T) {
+	storageConfig := &storage.Config{Type: storage.TypeSQLite, Path: filepath.Join(t.TempDir(), "data.db")}
+	defer func() {
+		store.Get().Close()
+	}()
+	newConfig := func() *config.Config {
+		enabled := true
+		return &config.Config{
+			Storage: storageConfig,
+			Endpoints: []*endpoint.Endpoint{{
+				Name: "endpoint-name",
+				URL:  "https://example.com",
+				Alerts: []*alert.Alert{
+					{Type: alert.TypeSlack, Enabled: &enabled, FailureThreshold: 2, SuccessThreshold: 5},
+					{Type: alert.TypeDiscord, Enabled: &enabled, FailureThreshold: 3, SuccessThreshold: 5},
+				},
+			}},
+		}
+	}
+	cfg := newConfig()
+	initializeStorage(cfg)
+	ep := cfg.Endpoints[0]
+	// The slack alert was triggered before the discord alert, and both have been recovering since
+	for i, numberOfSuccessesInARow := range []int{3, 1} {
+		ep.Alerts[i].Triggered, ep.Alerts[i].NumberOfSuccessesInARow = true, numberOfSuccessesInARow
+		if err := store.Get().UpsertTriggeredEndpointAlert(ep, ep.Alerts[i]); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	// Simulate a restart
+	cfg = newConfig()
+	initializeStorage(cfg)
+	ep = cfg.Endpoints[0]
+	for i, expected := range []struct{ numberOfSuccessesInARow, numberOfFailuresInARow int }{{3, 2}, {1, 3}} {
+		if !ep.Alerts[i].Triggered {
+			t.Errorf("expected alert %d to have been restored as triggered", i)
+		}
+		if ep.Alerts[i].NumberOfSuccessesInARow != expected.numberOfSuccessesInARow || ep.Alerts[i].NumberOfFailuresInARow != expected.numberOfFailuresInARow {
+			t.Errorf("expected alert %d to have %d successes and %d failures in a row, got %d and %d", i, expected.numberOfSuccessesInARow, expected.numberOfFailuresInARow, ep.Alerts[i].NumberOfSuccessesInARow, ep.Alerts[i].NumberOfFailuresInARow)
+		}
+	}
+	if ep.NumberOfSuccessesInARow != 3 || ep.NumberOfFailuresInARow != 3 {
+		t.Errorf("expected the endpoint's streak to be restored from the longest streaks of its alerts, got %d successes and %d failures in a row", ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow)
+	}
+}
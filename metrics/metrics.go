@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/prometheus/client_golang/prometheus"
@@ -10,14 +11,28 @@ import (
 
 const namespace = "gatus" // The prefix of the metrics
 
+// Values of the operation label of the storage metrics
+const (
+	StorageOperationInsert = "insert"
+	StorageOperationQuery  = "query"
+	StorageOperationDelete = "delete"
+)
+
 var (
 	initializedMetrics bool // Whether the metrics have been initialized
 
-	resultTotal                        *prometheus.CounterVec
-	resultDurationSeconds              *prometheus.GaugeVec
-	resultConnectedTotal               *prometheus.CounterVec
-	resultCodeTotal                    *prometheus.CounterVec
-	resultCertificateExpirationSeconds *prometheus.GaugeVec
+	resultTotal                         *prometheus.CounterVec
+	resultDurationSeconds               *prometheus.GaugeVec
+	resultConnectedTotal                *prometheus.CounterVec
+	resultCodeTotal                     *prometheus.CounterVec
+	resultCertificateExpirationSeconds  *prometheus.GaugeVec
+	certificateExpirationSeconds        *prometheus.GaugeVec
+	certificateNotAfterTimestampSeconds *prometheus.GaugeVec
+	checkExecutionDurationSeconds       *prometheus.HistogramVec
+	checkOverrunsTotal                  *prometheus.CounterVec
+	storageOperationDurationSeconds     *prometheus.HistogramVec
+	storageErrorsTotal                  *prometheus.CounterVec
+	resultWebhookDroppedResultsTotal    *prometheus.CounterVec
 )
 
 func initializePrometheusMetrics() {
@@ -46,6 +61,43 @@ func initializePrometheusMetrics() {
 		Name:      "results_certificate_expiration_seconds",
 		Help:      "Number of seconds until the certificate expires",
 	}, []string{"key", "group", "name", "type"})
+	certificateExpirationSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "certificate_expiration_seconds",
+		Help:      "Number of seconds until the leaf certificate expires, as of the last check",
+	}, []string{"key", "group", "name", "type"})
+	certificateNotAfterTimestampSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "certificate_not_after_timestamp_seconds",
+		Help:      "Unix timestamp at which the certificate expires, in seconds",
+	}, []string{"key", "group", "name", "type"})
+	checkExecutionDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "check_execution_duration_seconds",
+		Help:      "Duration of the executions of the check, including alerting, in seconds",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"key", "group", "name", "type"})
+	checkOverrunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "check_overruns_total",
+		Help:      "Total number of check executions that took longer than the interval of the endpoint",
+	}, []string{"key", "group", "name", "type"})
+	storageOperationDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "storage_operation_duration_seconds",
+		Help:      "Duration of the operations of the storage provider in seconds",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+	storageErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "storage_errors_total",
+		Help:      "Total number of operations of the storage provider that returned an error",
+	}, []string{"operation"})
+	resultWebhookDroppedResultsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "result_webhook_dropped_results_total",
+		Help:      "Total number of results dropped instead of being sent to the result webhook",
+	}, []string{"key", "group", "name", "type"})
 }
 
 // PublishMetricsForEndpoint publishes metrics for the given endpoint and its result.
@@ -70,4 +122,49 @@ func PublishMetricsForEndpoint(ep *endpoint.Endpoint, result *endpoint.Result) {
 	if result.CertificateExpiration != 0 {
 		resultCertificateExpirationSeconds.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType)).Set(result.CertificateExpiration.Seconds())
 	}
+	if !result.CertificateNotAfter.IsZero() {
+		certificateExpirationSeconds.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType)).Set(time.Until(result.CertificateNotAfter).Seconds())
+		certificateNotAfterTimestampSeconds.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType)).Set(float64(result.CertificateNotAfter.Unix()))
+	}
+}
+
+// PublishCheckExecutionMetricsForEndpoint publishes metrics for the execution of the check of the given endpoint,
+// which took the given duration.
+// These metrics will be exposed at /metrics if the metrics are enabled
+func PublishCheckExecutionMetricsForEndpoint(ep *endpoint.Endpoint, duration time.Duration) {
+	if !initializedMetrics {
+		initializePrometheusMetrics()
+		initializedMetrics = true
+	}
+	endpointType := ep.Type()
+	checkExecutionDurationSeconds.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType)).Observe(duration.Seconds())
+	// Endpoints with a schedule don't have an interval to overrun
+	if ep.Interval > 0 && duration > ep.Interval {
+		checkOverrunsTotal.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType)).Inc()
+	}
+}
+
+// PublishMetricsForStorageOperation publishes metrics for an operation (e.g. insert) of the storage provider, which
+// took the given duration and returned the given error, if any.
+// These metrics will be exposed at /metrics if the metrics are enabled
+func PublishMetricsForStorageOperation(operation string, duration time.Duration, err error) {
+	if !initializedMetrics {
+		initializePrometheusMetrics()
+		initializedMetrics = true
+	}
+	storageOperationDurationSeconds.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		storageErrorsTotal.WithLabelValues(operation).Inc()
+	}
+}
+
+// PublishMetricsForDroppedResult publishes metrics for a result of the given endpoint that was dropped instead of being
+// sent to its result webhook.
+// These metrics will be exposed at /metrics if the metrics are enabled
+func PublishMetricsForDroppedResult(ep *endpoint.Endpoint) {
+	if !initializedMetrics {
+		initializePrometheusMetrics()
+		initializedMetrics = true
+	}
+	resultWebhookDroppedResultsTotal.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(ep.Type())).Inc()
 }
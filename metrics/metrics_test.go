@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 	"time"
 
@@ -115,3 +116,107 @@ gatus_results_total{group="http-ep-group",key="http-ep-group_http-ep-name",name=
 		t.Errorf("Expected no errors but got: %v", err)
 	}
 }
+
+func TestPublishCheckExecutionMetricsForEndpoint(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "check-ep-name", Group: "check-ep-group", URL: "https://example.org", Interval: time.Second}
+	PublishCheckExecutionMetricsForEndpoint(ep, 500*time.Millisecond)
+	PublishCheckExecutionMetricsForEndpoint(ep, 1500*time.Millisecond)
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_check_execution_duration_seconds Duration of the executions of the check, including alerting, in seconds
+# TYPE gatus_check_execution_duration_seconds histogram
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="0.005"} 0
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="0.01"} 0
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="0.025"} 0
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="0.05"} 0
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="0.1"} 0
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="0.25"} 0
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="0.5"} 1
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="1"} 1
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="2.5"} 2
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="5"} 2
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="10"} 2
+gatus_check_execution_duration_seconds_bucket{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP",le="+Inf"} 2
+gatus_check_execution_duration_seconds_sum{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP"} 2
+gatus_check_execution_duration_seconds_count{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP"} 2
+# HELP gatus_check_overruns_total Total number of check executions that took longer than the interval of the endpoint
+# TYPE gatus_check_overruns_total counter
+gatus_check_overruns_total{group="check-ep-group",key="check-ep-group_check-ep-name",name="check-ep-name",type="HTTP"} 1
+`), "gatus_check_execution_duration_seconds", "gatus_check_overruns_total")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
+
+func TestPublishMetricsForEndpointWithCertificateNotAfter(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "tls-ep-name", Group: "tls-ep-group", URL: "tls://example.org:443"}
+	PublishMetricsForEndpoint(ep, &endpoint.Result{
+		Connected:             true,
+		Success:               true,
+		CertificateExpiration: 720 * time.Hour,
+		CertificateNotAfter:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_certificate_not_after_timestamp_seconds Unix timestamp at which the certificate expires, in seconds
+# TYPE gatus_certificate_not_after_timestamp_seconds gauge
+gatus_certificate_not_after_timestamp_seconds{group="tls-ep-group",key="tls-ep-group_tls-ep-name",name="tls-ep-name",type="TLS"} 1.893456e+09
+`), "gatus_certificate_not_after_timestamp_seconds")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
+
+func TestPublishMetricsForEndpointWithCertificateExpiration(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "expiring-tls-ep-name", Group: "tls-ep-group", URL: "tls://example.org:443"}
+	PublishMetricsForEndpoint(ep, &endpoint.Result{
+		Connected:           true,
+		Success:             true,
+		CertificateNotAfter: time.Now().Add(720 * time.Hour),
+	})
+	expirationSeconds := testutil.ToFloat64(certificateExpirationSeconds.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(ep.Type())))
+	if expirationSeconds > (720*time.Hour).Seconds() || expirationSeconds < (720*time.Hour-time.Minute).Seconds() {
+		t.Errorf("expected the certificate to expire in about %v seconds, got %v", (720 * time.Hour).Seconds(), expirationSeconds)
+	}
+}
+
+func TestPublishMetricsForStorageOperation(t *testing.T) {
+	PublishMetricsForStorageOperation("test-insert", 1500*time.Millisecond, nil)
+	PublishMetricsForStorageOperation("test-insert", 20*time.Millisecond, errors.New("database is locked"))
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_storage_errors_total Total number of operations of the storage provider that returned an error
+# TYPE gatus_storage_errors_total counter
+gatus_storage_errors_total{operation="test-insert"} 1
+# HELP gatus_storage_operation_duration_seconds Duration of the operations of the storage provider in seconds
+# TYPE gatus_storage_operation_duration_seconds histogram
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="0.005"} 0
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="0.01"} 0
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="0.025"} 1
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="0.05"} 1
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="0.1"} 1
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="0.25"} 1
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="0.5"} 1
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="1"} 1
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="2.5"} 2
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="5"} 2
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="10"} 2
+gatus_storage_operation_duration_seconds_bucket{operation="test-insert",le="+Inf"} 2
+gatus_storage_operation_duration_seconds_sum{operation="test-insert"} 1.52
+gatus_storage_operation_duration_seconds_count{operation="test-insert"} 2
+`), "gatus_storage_errors_total", "gatus_storage_operation_duration_seconds")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
+
+func TestPublishMetricsForDroppedResult(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "dropped-ep-name", Group: "dropped-ep-group", URL: "https://example.org"}
+	PublishMetricsForDroppedResult(ep)
+	PublishMetricsForDroppedResult(ep)
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_result_webhook_dropped_results_total Total number of results dropped instead of being sent to the result webhook
+# TYPE gatus_result_webhook_dropped_results_total counter
+gatus_result_webhook_dropped_results_total{group="dropped-ep-group",key="dropped-ep-group_dropped-ep-name",name="dropped-ep-name",type="HTTP"} 2
+`), "gatus_result_webhook_dropped_results_total")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
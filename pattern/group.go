@@ -0,0 +1,70 @@
+package pattern
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RegexGroupPrefix is the prefix used to specify that an override group is a regular expression
+//
+// Usage: regex:^prod-(eu|us)$
+const RegexGroupPrefix = "regex:"
+
+// compiledGroupRegexes caches the regular expressions of override groups, which are compiled when the override groups
+// are validated rather than every time a group is matched
+var compiledGroupRegexes sync.Map
+
+// MatchGroup checks whether a group matches the group of an override.
+//
+// The override group may be an exact group name, a wildcard pattern (e.g. prod-*) or, if it starts with
+// RegexGroupPrefix, a regular expression (e.g. regex:^prod-(eu|us)$). Override groups are expected to have been
+// validated with IsValidGroup, so an invalid regular expression never matches.
+func MatchGroup(overrideGroup, group string) bool {
+	if strings.HasPrefix(overrideGroup, RegexGroupPrefix) {
+		re, err := compileGroupRegex(overrideGroup)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(group)
+	}
+	if strings.Contains(overrideGroup, "*") {
+		return Match(overrideGroup, group)
+	}
+	return overrideGroup == group
+}
+
+// IsValidGroup checks whether an override group is valid.
+// Only override groups that are regular expressions may be invalid.
+func IsValidGroup(overrideGroup string) bool {
+	if strings.HasPrefix(overrideGroup, RegexGroupPrefix) {
+		_, err := compileGroupRegex(overrideGroup)
+		return err == nil
+	}
+	return true
+}
+
+// compileGroupRegex returns the compiled regular expression of an override group starting with RegexGroupPrefix
+func compileGroupRegex(overrideGroup string) (*regexp.Regexp, error) {
+	if re, exists := compiledGroupRegexes.Load(overrideGroup); exists {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(strings.TrimPrefix(overrideGroup, RegexGroupPrefix))
+	if err != nil {
+		return nil, err
+	}
+	compiledGroupRegexes.Store(overrideGroup, re)
+	return re, nil
+}
+
+// MatchLabels checks whether labels contain every label of overrideLabels with the same value.
+//
+// If overrideLabels is empty, it always matches.
+func MatchLabels(overrideLabels, labels map[string]string) bool {
+	for key, value := range overrideLabels {
+		if actualValue, exists := labels[key]; !exists || actualValue != value {
+			return false
+		}
+	}
+	return true
+}
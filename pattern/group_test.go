@@ -0,0 +1,89 @@
+package pattern
+
+import "testing"
+
+func TestMatchGroup(t *testing.T) {
+	scenarios := []struct {
+		overrideGroup string
+		group         string
+		expected      bool
+	}{
+		{overrideGroup: "prod", group: "prod", expected: true},
+		{overrideGroup: "prod", group: "production", expected: false},
+		{overrideGroup: "prod-*", group: "prod-eu", expected: true},
+		{overrideGroup: "prod-*", group: "staging-eu", expected: false},
+		{overrideGroup: "*-eu", group: "prod-eu", expected: true},
+		{overrideGroup: "regex:^prod-(eu|us)$", group: "prod-us", expected: true},
+		{overrideGroup: "regex:^prod-(eu|us)$", group: "prod-asia", expected: false},
+		{overrideGroup: "regex:(", group: "(", expected: false},
+		{overrideGroup: "", group: "", expected: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.overrideGroup+"_"+scenario.group, func(t *testing.T) {
+			if matched := MatchGroup(scenario.overrideGroup, scenario.group); matched != scenario.expected {
+				t.Errorf("expected MatchGroup(%q, %q) to return %v, got %v", scenario.overrideGroup, scenario.group, scenario.expected, matched)
+			}
+		})
+	}
+}
+
+func TestIsValidGroup(t *testing.T) {
+	if !IsValidGroup("prod") {
+		t.Error("exact group should've been valid")
+	}
+	if !IsValidGroup("prod-*") {
+		t.Error("wildcard group should've been valid")
+	}
+	if !IsValidGroup("regex:^prod-.*$") {
+		t.Error("regex group should've been valid")
+	}
+	if IsValidGroup("regex:(") {
+		t.Error("regex group with invalid expression shouldn't have been valid")
+	}
+}
+
+func TestMatchGroupUsesRegexCompiledDuringValidation(t *testing.T) {
+	overrideGroup := "regex:^cached-(eu|us)$"
+	if !IsValidGroup(overrideGroup) {
+		t.Fatal("regex group should've been valid")
+	}
+	compiledRegex, exists := compiledGroupRegexes.Load(overrideGroup)
+	if !exists {
+		t.Fatal("expected the regex to have been compiled during validation")
+	}
+	if !MatchGroup(overrideGroup, "cached-eu") {
+		t.Error("expected the group to match")
+	}
+	if regexAfterMatch, _ := compiledGroupRegexes.Load(overrideGroup); regexAfterMatch != compiledRegex {
+		t.Error("expected MatchGroup to reuse the regex compiled during validation")
+	}
+	if IsValidGroup("regex:(") {
+		t.Error("regex group with invalid expression shouldn't have been valid")
+	}
+	if _, exists = compiledGroupRegexes.Load("regex:("); exists {
+		t.Error("expected an invalid regex not to be cached")
+	}
+}
+
+func TestMatchLabels(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		overrideLabels map[string]string
+		labels         map[string]string
+		expected       bool
+	}{
+		{name: "no-override-labels", overrideLabels: nil, labels: map[string]string{"team": "payments"}, expected: true},
+		{name: "exact", overrideLabels: map[string]string{"team": "payments"}, labels: map[string]string{"team": "payments"}, expected: true},
+		{name: "subset", overrideLabels: map[string]string{"team": "payments"}, labels: map[string]string{"team": "payments", "tier": "1"}, expected: true},
+		{name: "different-value", overrideLabels: map[string]string{"team": "payments"}, labels: map[string]string{"team": "search"}, expected: false},
+		{name: "missing-label", overrideLabels: map[string]string{"team": "payments", "tier": "1"}, labels: map[string]string{"team": "payments"}, expected: false},
+		{name: "no-labels", overrideLabels: map[string]string{"team": "payments"}, labels: nil, expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if matched := MatchLabels(scenario.overrideLabels, scenario.labels); matched != scenario.expected {
+				t.Errorf("expected MatchLabels(%v, %v) to return %v, got %v", scenario.overrideLabels, scenario.labels, scenario.expected, matched)
+			}
+		})
+	}
+}
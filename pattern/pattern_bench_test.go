@@ -19,3 +19,12 @@ func BenchmarkMatchWithBackslash(b *testing.B) {
 	}
 	b.ReportAllocs()
 }
+
+func BenchmarkMatchGroupWithRegex(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		if !MatchGroup("regex:^prod-(eu|us)$", "prod-eu") {
+			b.Error("should've matched")
+		}
+	}
+	b.ReportAllocs()
+}
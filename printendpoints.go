@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config"
+)
+
+const (
+	// redacted is what the values of the environment variables referenced by the configuration are replaced by
+	redacted = "[REDACTED]"
+
+	// minimumRedactedValueLength is the length below which the value of an environment variable isn't redacted.
+	// Short values such as "true" or "200" are unlikely to be secrets, but likely to appear in conditions that have
+	// nothing to do with the environment variable, which would make them unreadable.
+	minimumRedactedValueLength = 8
+)
+
+// printEndpoints writes the key, interval, conditions and alert types of every endpoint of cfg to w, as they are once
+// the configuration files have been merged, the environment variables substituted and the defaults applied.
+//
+// This is used by the --print-endpoints flag. Since environment variables are typically used for secrets, the values
+// of those referenced by the configuration are redacted, unless they're too short to be secrets.
+func printEndpoints(cfg *config.Config, w io.Writer) error {
+	var secrets []string
+	for _, name := range cfg.ReferencedEnvironmentVariables() {
+		if value := os.Getenv(name); len(value) >= minimumRedactedValueLength {
+			secrets = append(secrets, value, redacted)
+		}
+	}
+	redactor := strings.NewReplacer(secrets...)
+	for _, ep := range cfg.Endpoints {
+		var notes []string
+		if !ep.IsEnabled() {
+			notes = append(notes, "disabled")
+		}
+		if cfg.Shard != nil && !cfg.Shard.IsResponsibleFor(ep) {
+			notes = append(notes, "assigned to "+cfg.Shard.InstanceOf(ep))
+		}
+		header := ep.Key()
+		if len(notes) > 0 {
+			header += " (" + strings.Join(notes, ", ") + ")"
+		}
+		var alertTypes []string
+		for _, endpointAlert := range ep.Alerts {
+			alertType := string(endpointAlert.Type)
+			if !endpointAlert.IsEnabled() {
+				alertType += " (disabled)"
+			}
+			alertTypes = append(alertTypes, alertType)
+		}
+		if len(alertTypes) == 0 {
+			alertTypes = append(alertTypes, "none")
+		}
+		if _, err := fmt.Fprintf(w, "%s\n  interval: %s\n  conditions:\n", header, ep.Interval); err != nil {
+			return err
+		}
+		for _, condition := range ep.Conditions {
+			if _, err := fmt.Fprintf(w, "    - %s\n", redactor.Replace(string(condition))); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "  alerts: %s\n", strings.Join(alertTypes, ", ")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d endpoint(s)\n", len(cfg.Endpoints))
+	return err
+}
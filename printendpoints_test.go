@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+)
+
+func TestPrintEndpoints(t *testing.T) {
+	t.Setenv("GATUS_TEST_API_TOKEN", "s3cr3t-t0k3n")
+	t.Setenv("GATUS_TEST_ALERTS_ENABLED", "true")
+	configDirectory := t.TempDir()
+	files := map[string]string{
+		"alerting.yaml": `
+alerting:
+  slack:
+    webhook-url: "https://example.com/slack"
+    default-alert:
+      enabled: ${GATUS_TEST_ALERTS_ENABLED}
+      failure-threshold: 5
+  custom:
+    url: "https://example.com/custom"
+`,
+		"endpoints.yaml": `
+endpoints:
+  - name: frontend
+    group: core
+    url: "https://example.org/"
+    interval: 5m
+    conditions:
+      - "[STATUS] == 200"
+      - "[BODY].token == ${GATUS_TEST_API_TOKEN}"
+    alerts:
+      - type: slack
+      - type: custom
+        enabled: false
+  - name: backend
+    url: "https://example.org/health"
+    enabled: false
+    conditions:
+      - "[CONNECTED] == true"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(configDirectory, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg, err := config.LoadConfiguration(configDirectory)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	var output bytes.Buffer
+	if err := printEndpoints(cfg, &output); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	expectedOutput := `core_frontend
+  interval: 5m0s
+  conditions:
+    - [STATUS] == 200
+    - [BODY].token == [REDACTED]
+  alerts: slack, custom (disabled)
+_backend (disabled)
+  interval: 1m0s
+  conditions:
+    - [CONNECTED] == true
+  alerts: none
+2 endpoint(s)
+`
+	if output.String() != expectedOutput {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedOutput, output.String())
+	}
+}
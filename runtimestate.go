@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/TwiN/gatus/v5/state"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+// ErrRuntimeStateNotPersisted is the error returned when exporting or importing the runtime state while the storage
+// type is memory, because the runtime state can only be persisted by the storage types that persist data
+var ErrRuntimeStateNotPersisted = errors.New("runtime state can only be exported or imported with storage of type " + string(storage.TypeSQLite) + " or " + string(storage.TypePostgres))
+
+// exportRuntimeState writes the runtime state persisted by the storage provider configured by storageConfig to w.
+//
+// This is used by the --export-runtime-state flag, which allows the state created at runtime (e.g. maintenance windows)
+// to be backed up.
+func exportRuntimeState(storageConfig *storage.Config, w io.Writer) error {
+	if err := initializeRuntimeStateStorage(storageConfig); err != nil {
+		return err
+	}
+	defer store.Get().Close()
+	runtimeState, err := store.Get().GetRuntimeState()
+	if err != nil {
+		return err
+	}
+	if runtimeState == nil {
+		runtimeState = &state.RuntimeState{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(runtimeState)
+}
+
+// importRuntimeState replaces the runtime state persisted by the storage provider configured by storageConfig by the
+// runtime state read from r, which is expected to have been written by exportRuntimeState.
+//
+// This is used by the --import-runtime-state flag. The imported runtime state is applied the next time Gatus starts.
+func importRuntimeState(storageConfig *storage.Config, r io.Reader) error {
+	var runtimeState state.RuntimeState
+	if err := json.NewDecoder(r).Decode(&runtimeState); err != nil {
+		return err
+	}
+	// Applying the runtime state validates it, removes the maintenance windows that have expired and assigns an ID to
+	// those that don't have one
+	if err := runtimeState.Apply(); err != nil {
+		return err
+	}
+	if err := initializeRuntimeStateStorage(storageConfig); err != nil {
+		return err
+	}
+	defer store.Get().Close()
+	return store.Get().SaveRuntimeState(state.Capture())
+}
+
+// loadRuntimeState applies the runtime state persisted by the storage provider, if any, so that the state created at
+// runtime before Gatus was restarted isn't lost
+func loadRuntimeState() {
+	runtimeState, err := store.Get().GetRuntimeState()
+	if err != nil {
+		log.Println("[main.loadRuntimeState] Failed to load runtime state:", err.Error())
+		return
+	}
+	if runtimeState == nil {
+		return
+	}
+	if err = runtimeState.Apply(); err != nil {
+		log.Println("[main.loadRuntimeState] Failed to apply runtime state:", err.Error())
+		return
+	}
+	log.Printf("[main.loadRuntimeState] Loaded runtime state with muted=%v and %d maintenance window(s)", runtimeState.Muted, len(runtimeState.MaintenanceWindows))
+}
+
+func initializeRuntimeStateStorage(storageConfig *storage.Config) error {
+	if storageConfig == nil || (storageConfig.Type != storage.TypeSQLite && storageConfig.Type != storage.TypePostgres) {
+		return ErrRuntimeStateNotPersisted
+	}
+	return store.Initialize(storageConfig)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/state"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestExportAndImportRuntimeState(t *testing.T) {
+	defer (&state.RuntimeState{}).Apply()
+	storageConfig := &storage.Config{Type: storage.TypeSQLite, Path: t.TempDir() + "/TestExportAndImportRuntimeState.db"}
+	if err := exportRuntimeState(&storage.Config{Type: storage.TypeMemory}, &bytes.Buffer{}); !errors.Is(err, ErrRuntimeStateNotPersisted) {
+		t.Errorf("expected error %v, got %v", ErrRuntimeStateNotPersisted, err)
+	}
+	input := `{"muted":true,"maintenanceWindows":[{"id":"upgrade","group":"core","start":"2024-01-01T00:00:00Z","end":"2999-01-01T00:00:00Z"},{"id":"expired","start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z"}]}`
+	if err := importRuntimeState(storageConfig, strings.NewReader(input)); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	output := &bytes.Buffer{}
+	if err := exportRuntimeState(storageConfig, output); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	var exported state.RuntimeState
+	if err := json.Unmarshal(output.Bytes(), &exported); err != nil {
+		t.Fatal("expected the export to be valid JSON, got", err.Error())
+	}
+	if !exported.Muted || len(exported.MaintenanceWindows) != 1 || exported.MaintenanceWindows[0].ID != "upgrade" {
+		t.Errorf("expected the imported runtime state without the expired maintenance window to be exported, got %s", output.String())
+	}
+	// The runtime state is loaded when Gatus starts
+	if err := (&state.RuntimeState{}).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if err := initializeRuntimeStateStorage(storageConfig); err != nil {
+		t.Fatal(err)
+	}
+	defer store.Get().Close()
+	loadRuntimeState()
+	if !alerting.IsMuted() || !maintenance.IsGroupUnderMaintenance("core") {
+		t.Error("expected the persisted runtime state to have been loaded")
+	}
+}
@@ -0,0 +1,39 @@
+package state
+
+import (
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+)
+
+// RuntimeState is the state created at runtime (e.g. through the API) rather than through the configuration.
+//
+// Because it isn't part of the configuration, it is persisted by the storage provider so that it survives restarts,
+// and it can be exported and imported to be backed up or moved to another instance.
+type RuntimeState struct {
+	// Muted is whether all alerts are muted
+	Muted bool `json:"muted"`
+
+	// MaintenanceWindows are the active maintenance windows
+	MaintenanceWindows []*maintenance.Window `json:"maintenanceWindows"`
+}
+
+// Capture returns the current runtime state
+func Capture() *RuntimeState {
+	return &RuntimeState{
+		Muted:              alerting.IsMuted(),
+		MaintenanceWindows: maintenance.GetWindows(),
+	}
+}
+
+// Apply replaces the current runtime state by the runtime state s
+func (s *RuntimeState) Apply() error {
+	if err := maintenance.RestoreWindows(s.MaintenanceWindows); err != nil {
+		return err
+	}
+	if s.Muted {
+		alerting.Mute()
+	} else {
+		alerting.Unmute()
+	}
+	return nil
+}
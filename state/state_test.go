@@ -0,0 +1,44 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+)
+
+func TestRuntimeState_CaptureAndApply(t *testing.T) {
+	defer alerting.Unmute()
+	defer (&RuntimeState{}).Apply()
+	now := time.Now()
+	runtimeState := &RuntimeState{
+		Muted:              true,
+		MaintenanceWindows: []*maintenance.Window{{ID: "upgrade", Group: "core", Start: now, End: now.Add(time.Hour)}},
+	}
+	if err := runtimeState.Apply(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !alerting.IsMuted() {
+		t.Error("expected alerts to be muted")
+	}
+	if !maintenance.IsGroupUnderMaintenance("core") {
+		t.Error("expected group core to be under maintenance")
+	}
+	capturedRuntimeState := Capture()
+	if !capturedRuntimeState.Muted || len(capturedRuntimeState.MaintenanceWindows) != 1 || capturedRuntimeState.MaintenanceWindows[0].ID != "upgrade" {
+		t.Errorf("expected the captured runtime state to match the applied runtime state, got %+v", capturedRuntimeState)
+	}
+	if err := (&RuntimeState{MaintenanceWindows: []*maintenance.Window{{Group: "regex:(", Start: now, End: now.Add(time.Hour)}}}).Apply(); err == nil {
+		t.Error("expected an error, because the group of the maintenance window is invalid")
+	}
+	if !alerting.IsMuted() || !maintenance.IsGroupUnderMaintenance("core") {
+		t.Error("expected an invalid runtime state to be rejected without modifying the current runtime state")
+	}
+	if err := (&RuntimeState{}).Apply(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if alerting.IsMuted() || maintenance.IsGroupUnderMaintenance("core") {
+		t.Error("expected the runtime state to have been replaced")
+	}
+}
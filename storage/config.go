@@ -2,11 +2,24 @@ package storage
 
 import (
 	"errors"
+	"time"
+)
+
+const (
+	// DefaultBatchInterval is the default maximum amount of time results are batched for before being inserted
+	DefaultBatchInterval = time.Second
+
+	// DefaultDeduplicationBucket is the default size of the buckets in which the duration of two results must fall for
+	// them to be considered identical
+	DefaultDeduplicationBucket = 50 * time.Millisecond
 )
 
 var (
 	ErrSQLStorageRequiresPath          = errors.New("sql storage requires a non-empty path to be defined")
 	ErrMemoryStorageDoesNotSupportPath = errors.New("memory storage does not support persistence, use sqlite if you want persistence on file")
+	ErrInvalidBatchSize                = errors.New("batch-size must not be negative")
+	ErrInvalidBatchInterval            = errors.New("batch-interval must not be negative")
+	ErrInvalidDeduplicationBucket      = errors.New("deduplication-bucket must not be negative")
 )
 
 // Config is the configuration for storage
@@ -25,6 +38,34 @@ type Config struct {
 	// as they happen, also known as the write-through caching strategy.
 	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
 	Caching bool `yaml:"caching,omitempty"`
+
+	// BatchSize is the number of results to accumulate before inserting them in a single transaction.
+	// If 0, batching is disabled and each result is inserted as soon as it is received.
+	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
+	BatchSize int `yaml:"batch-size,omitempty"`
+
+	// BatchInterval is the maximum amount of time results are accumulated for before being inserted, even if
+	// BatchSize hasn't been reached. Only used if BatchSize is greater than 0.
+	//
+	// Defaults to DefaultBatchInterval
+	BatchInterval time.Duration `yaml:"batch-interval,omitempty"`
+
+	// DeduplicateResults is whether a result identical to the previous result of the same endpoint should increment
+	// the count of the previous result instead of being stored as a new result.
+	// The uptime is computed from every result regardless.
+	DeduplicateResults bool `yaml:"deduplicate-results,omitempty"`
+
+	// DeduplicationBucket is the size of the buckets in which the duration of two results must fall for them to be
+	// considered identical (e.g. with 50ms, results that took 30ms and 45ms are identical, but not 45ms and 55ms).
+	// Only used if DeduplicateResults is true.
+	//
+	// Defaults to DefaultDeduplicationBucket
+	DeduplicationBucket time.Duration `yaml:"deduplication-bucket,omitempty"`
+
+	// ExcludeMaintenanceFromUptime is whether the results recorded during a maintenance window should be left out of
+	// the uptime and of the average response time, rather than being counted like any other result.
+	// The results themselves are still stored, flagged as having been recorded during a maintenance window.
+	ExcludeMaintenanceFromUptime bool `yaml:"exclude-maintenance-from-uptime,omitempty"`
 }
 
 // ValidateAndSetDefaults validates the configuration and sets the default values (if applicable)
@@ -38,5 +79,18 @@ func (c *Config) ValidateAndSetDefaults() error {
 	if c.Type == TypeMemory && len(c.Path) > 0 {
 		return ErrMemoryStorageDoesNotSupportPath
 	}
+	if c.BatchSize < 0 {
+		return ErrInvalidBatchSize
+	}
+	if c.BatchInterval < 0 {
+		return ErrInvalidBatchInterval
+	} else if c.BatchInterval == 0 {
+		c.BatchInterval = DefaultBatchInterval
+	}
+	if c.DeduplicationBucket < 0 {
+		return ErrInvalidDeduplicationBucket
+	} else if c.DeduplicationBucket == 0 {
+		c.DeduplicationBucket = DefaultDeduplicationBucket
+	}
 	return nil
 }
@@ -1,5 +1,11 @@
 package paging
 
+import (
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
 // EndpointStatusParams represents all parameters that can be used for paging purposes
 type EndpointStatusParams struct {
 	EventsPage      int // Number of the event page
@@ -26,3 +32,43 @@ func (params *EndpointStatusParams) WithResults(page, pageSize int) *EndpointSta
 	params.ResultsPageSize = pageSize
 	return params
 }
+
+// ResultSearchParams represents the parameters used to search through the results of an endpoint
+type ResultSearchParams struct {
+	Condition    string // Text that one of the conditions of the result must contain. If empty, every result matches.
+	OnlyFailures bool   // Whether to only return results in which the condition, or the result if Condition is empty, failed
+	Page         int    // Number of the result page
+	PageSize     int    // Size of the result page
+}
+
+// NewResultSearchParams creates a new ResultSearchParams
+func NewResultSearchParams() *ResultSearchParams {
+	return &ResultSearchParams{}
+}
+
+// WithCondition sets the values for Condition and OnlyFailures
+func (params *ResultSearchParams) WithCondition(condition string, onlyFailures bool) *ResultSearchParams {
+	params.Condition = condition
+	params.OnlyFailures = onlyFailures
+	return params
+}
+
+// WithResults sets the values for Page and PageSize
+func (params *ResultSearchParams) WithResults(page, pageSize int) *ResultSearchParams {
+	params.Page = page
+	params.PageSize = pageSize
+	return params
+}
+
+// Matches returns whether the result matches the search parameters, ignoring paging
+func (params *ResultSearchParams) Matches(result *endpoint.Result) bool {
+	if len(params.Condition) == 0 {
+		return !params.OnlyFailures || !result.Success
+	}
+	for _, conditionResult := range result.ConditionResults {
+		if strings.Contains(conditionResult.Condition, params.Condition) && (!params.OnlyFailures || !conditionResult.Success) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/state"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+const (
+	operationInsert = metrics.StorageOperationInsert
+	operationQuery  = metrics.StorageOperationQuery
+	operationDelete = metrics.StorageOperationDelete
+)
+
+// instrumentedStore is a Store that publishes metrics about the duration and the errors of the insert, query and
+// delete operations of the Store it wraps, so that a degradation of the database doesn't go unnoticed.
+//
+// Operations that don't return their error, such as DeleteAllEndpointStatusesNotInKeys, aren't instrumented, since
+// their failures would go unnoticed anyways.
+type instrumentedStore struct {
+	Store
+
+	// isInsertBatched is whether Insert only queues the result to be inserted later, in which case it's the store
+	// itself that publishes the metrics of the actual inserts
+	isInsertBatched bool
+}
+
+// observe publishes the metrics of an operation that started at start and returned err
+func (s *instrumentedStore) observe(operation string, start time.Time, err error) {
+	metrics.PublishMetricsForStorageOperation(operation, time.Since(start), err)
+}
+
+func (s *instrumentedStore) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
+	start := time.Now()
+	statuses, err := s.Store.GetAllEndpointStatuses(params)
+	s.observe(operationQuery, start, err)
+	return statuses, err
+}
+
+func (s *instrumentedStore) GetEndpointStatus(groupName, endpointName string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
+	start := time.Now()
+	status, err := s.Store.GetEndpointStatus(groupName, endpointName, params)
+	s.observe(operationQuery, start, err)
+	return status, err
+}
+
+func (s *instrumentedStore) GetEndpointStatusByKey(key string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
+	start := time.Now()
+	status, err := s.Store.GetEndpointStatusByKey(key, params)
+	s.observe(operationQuery, start, err)
+	return status, err
+}
+
+func (s *instrumentedStore) GetUptimeByKey(key string, from, to time.Time) (float64, error) {
+	start := time.Now()
+	uptime, err := s.Store.GetUptimeByKey(key, from, to)
+	s.observe(operationQuery, start, err)
+	return uptime, err
+}
+
+func (s *instrumentedStore) GetAverageResponseTimeByKey(key string, from, to time.Time) (int, error) {
+	start := time.Now()
+	averageResponseTime, err := s.Store.GetAverageResponseTimeByKey(key, from, to)
+	s.observe(operationQuery, start, err)
+	return averageResponseTime, err
+}
+
+func (s *instrumentedStore) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (map[int64]int, error) {
+	start := time.Now()
+	hourlyAverageResponseTimes, err := s.Store.GetHourlyAverageResponseTimeByKey(key, from, to)
+	s.observe(operationQuery, start, err)
+	return hourlyAverageResponseTimes, err
+}
+
+func (s *instrumentedStore) SearchResultsByKey(key string, params *paging.ResultSearchParams) ([]*endpoint.Result, error) {
+	start := time.Now()
+	results, err := s.Store.SearchResultsByKey(key, params)
+	s.observe(operationQuery, start, err)
+	return results, err
+}
+
+func (s *instrumentedStore) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	if s.isInsertBatched {
+		return s.Store.Insert(ep, result)
+	}
+	start := time.Now()
+	err := s.Store.Insert(ep, result)
+	s.observe(operationInsert, start, err)
+	return err
+}
+
+func (s *instrumentedStore) GetRuntimeState() (*state.RuntimeState, error) {
+	start := time.Now()
+	runtimeState, err := s.Store.GetRuntimeState()
+	s.observe(operationQuery, start, err)
+	return runtimeState, err
+}
+
+func (s *instrumentedStore) SaveRuntimeState(runtimeState *state.RuntimeState) error {
+	start := time.Now()
+	err := s.Store.SaveRuntimeState(runtimeState)
+	s.observe(operationInsert, start, err)
+	return err
+}
+
+func (s *instrumentedStore) GetTriggeredEndpointAlert(ep *endpoint.Endpoint, alert *alert.Alert) (bool, string, int, error) {
+	start := time.Now()
+	exists, resolveKey, numberOfSuccessesInARow, err := s.Store.GetTriggeredEndpointAlert(ep, alert)
+	s.observe(operationQuery, start, err)
+	return exists, resolveKey, numberOfSuccessesInARow, err
+}
+
+func (s *instrumentedStore) UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
+	start := time.Now()
+	err := s.Store.UpsertTriggeredEndpointAlert(ep, triggeredAlert)
+	s.observe(operationInsert, start, err)
+	return err
+}
+
+func (s *instrumentedStore) DeleteTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
+	start := time.Now()
+	err := s.Store.DeleteTriggeredEndpointAlert(ep, triggeredAlert)
+	s.observe(operationDelete, start, err)
+	return err
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/sql"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// storageMetrics returns the number of operations observed and the number of errors counted for the given operation
+func storageMetrics(t *testing.T, operation string) (observed uint64, errors float64) {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal("failed to gather metrics:", err.Error())
+	}
+	for _, metricFamily := range metricFamilies {
+		for _, metric := range metricFamily.GetMetric() {
+			if len(metric.GetLabel()) != 1 || metric.GetLabel()[0].GetValue() != operation {
+				continue
+			}
+			switch metricFamily.GetName() {
+			case "gatus_storage_operation_duration_seconds":
+				observed = metric.GetHistogram().GetSampleCount()
+			case "gatus_storage_errors_total":
+				errors = metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return
+}
+
+func TestInstrumentedStore(t *testing.T) {
+	sqliteStore, err := sql.NewStore("sqlite", t.TempDir()+"/TestInstrumentedStore.db", false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	instrumented := &instrumentedStore{Store: sqliteStore}
+	insertsBefore, insertErrorsBefore := storageMetrics(t, operationInsert)
+	if err := instrumented.Insert(&testEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if inserts, insertErrors := storageMetrics(t, operationInsert); inserts != insertsBefore+1 || insertErrors != insertErrorsBefore {
+		t.Errorf("expected 1 more insert and no more errors, got %d more inserts and %v more errors", inserts-insertsBefore, insertErrors-insertErrorsBefore)
+	}
+	queriesBefore, queryErrorsBefore := storageMetrics(t, operationQuery)
+	if _, err := instrumented.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour), now); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if queries, queryErrors := storageMetrics(t, operationQuery); queries != queriesBefore+1 || queryErrors != queryErrorsBefore {
+		t.Errorf("expected 1 more query and no more errors, got %d more queries and %v more errors", queries-queriesBefore, queryErrors-queryErrorsBefore)
+	}
+	// Closing the database forces the operations to fail
+	sqliteStore.Close()
+	insertsBefore, insertErrorsBefore = storageMetrics(t, operationInsert)
+	if err := instrumented.Insert(&testEndpoint, &testUnsuccessfulResult); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if inserts, insertErrors := storageMetrics(t, operationInsert); inserts != insertsBefore+1 || insertErrors != insertErrorsBefore+1 {
+		t.Errorf("expected 1 more insert and 1 more error, got %d more inserts and %v more errors", inserts-insertsBefore, insertErrors-insertErrorsBefore)
+	}
+}
+
+func TestEnableMetrics(t *testing.T) {
+	if err := Initialize(nil); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	EnableMetrics()
+	EnableMetrics()
+	instrumented, ok := Get().(*instrumentedStore)
+	if !ok {
+		t.Fatal("expected the store to be instrumented")
+	}
+	if _, ok := instrumented.Store.(*instrumentedStore); ok {
+		t.Error("expected the store not to be instrumented twice")
+	}
+	if err := Initialize(nil); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if _, ok := Get().(*instrumentedStore); ok {
+		t.Error("expected the store not to be instrumented after being initialized again")
+	}
+}
+
+func TestInstrumentedStoreWithBatching(t *testing.T) {
+	sqliteStore, err := sql.NewStore("sqlite", t.TempDir()+"/TestInstrumentedStoreWithBatching.db", false)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer sqliteStore.Close()
+	sqliteStore.EnableBatching(2, time.Hour)
+	store = sqliteStore
+	EnableMetrics()
+	defer Initialize(nil)
+	insertsBefore, insertErrorsBefore := storageMetrics(t, operationInsert)
+	if err := Get().Insert(&testEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if inserts, _ := storageMetrics(t, operationInsert); inserts != insertsBefore {
+		t.Errorf("expected queuing a result not to be observed as an insert, got %d more inserts", inserts-insertsBefore)
+	}
+	// The batch is full, so it's flushed
+	if err := Get().Insert(&testEndpoint, &testUnsuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if inserts, insertErrors := storageMetrics(t, operationInsert); inserts != insertsBefore+1 || insertErrors != insertErrorsBefore {
+		t.Errorf("expected 1 more insert and no more errors, got %d more inserts and %v more errors", inserts-insertsBefore, insertErrors-insertErrorsBefore)
+	}
+	// The stable id of an endpoint must be unique, so the result of the second endpoint cannot be inserted
+	insertsBefore, insertErrorsBefore = storageMetrics(t, operationInsert)
+	_ = Get().Insert(&endpoint.Endpoint{Name: "first", ID: "duplicate-id"}, &testSuccessfulResult)
+	if err := Get().Insert(&endpoint.Endpoint{Name: "second", ID: "duplicate-id"}, &testSuccessfulResult); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if inserts, insertErrors := storageMetrics(t, operationInsert); inserts != insertsBefore+1 || insertErrors != insertErrorsBefore+1 {
+		t.Errorf("expected 1 more insert and 1 more error, got %d more inserts and %v more errors", inserts-insertsBefore, insertErrors-insertErrorsBefore)
+	}
+}
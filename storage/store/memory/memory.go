@@ -7,6 +7,7 @@ import (
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/state"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gocache/v2"
@@ -17,6 +18,19 @@ type Store struct {
 	sync.RWMutex
 
 	cache *gocache.Cache
+
+	// keysByID is the key each endpoint ID was last associated with. See RenameEndpointByID.
+	keysByID map[string]string
+
+	// runtimeState is the runtime state that was last saved. See SaveRuntimeState.
+	runtimeState *state.RuntimeState
+
+	// deduplicationBucket is the size of the buckets in which the duration of two consecutive results must fall for
+	// the latter to be merged into the former. If 0, results are not deduplicated.
+	deduplicationBucket time.Duration
+
+	// excludeMaintenanceFromUptime is whether results recorded during a maintenance window are left out of the uptime
+	excludeMaintenanceFromUptime bool
 }
 
 // NewStore creates a new store using gocache.Cache
@@ -25,11 +39,23 @@ type Store struct {
 // supports eventual persistence.
 func NewStore() (*Store, error) {
 	store := &Store{
-		cache: gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		cache:    gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		keysByID: make(map[string]string),
 	}
 	return store, nil
 }
 
+// EnableDeduplication makes the store merge results identical to the previous result of the same endpoint into the
+// previous result instead of adding them as new results
+func (s *Store) EnableDeduplication(durationBucket time.Duration) {
+	s.deduplicationBucket = durationBucket
+}
+
+// EnableMaintenanceExclusion makes the store leave the results recorded during a maintenance window out of the uptime
+func (s *Store) EnableMaintenanceExclusion() {
+	s.excludeMaintenanceFromUptime = true
+}
+
 // GetAllEndpointStatuses returns all monitored endpoint.Status
 // with a subset of endpoint.Result defined by the page and pageSize parameters
 func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
@@ -139,6 +165,73 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
+// SearchResultsByKey returns the results of an endpoint that match the search parameters, from newest to oldest
+func (s *Store) SearchResultsByKey(key string, params *paging.ResultSearchParams) ([]*endpoint.Result, error) {
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	s.RLock()
+	defer s.RUnlock()
+	results := endpointStatus.(*endpoint.Status).Results
+	matchingResults := make([]*endpoint.Result, 0)
+	numberOfMatchesToSkip := (params.Page - 1) * params.PageSize
+	for i := len(results) - 1; i >= 0 && len(matchingResults) < params.PageSize; i-- {
+		if !params.Matches(results[i]) {
+			continue
+		}
+		if numberOfMatchesToSkip > 0 {
+			numberOfMatchesToSkip--
+			continue
+		}
+		matchingResults = append(matchingResults, results[i])
+	}
+	return matchingResults, nil
+}
+
+// RenameEndpointByID associates the ID of the endpoint with its key. If the ID was previously associated with another
+// key, the status stored under the previous key is moved to the current key.
+//
+// Returns whether the status of the endpoint was moved.
+func (s *Store) RenameEndpointByID(ep *endpoint.Endpoint) (bool, error) {
+	if len(ep.ID) == 0 {
+		return false, nil
+	}
+	key := ep.Key()
+	s.Lock()
+	defer s.Unlock()
+	previousKey, exists := s.keysByID[ep.ID]
+	s.keysByID[ep.ID] = key
+	if !exists || previousKey == key {
+		return false, nil
+	}
+	status := s.cache.GetValue(previousKey)
+	if status == nil {
+		return false, nil
+	}
+	s.cache.Delete(previousKey)
+	status.(*endpoint.Status).Name = ep.Name
+	status.(*endpoint.Status).Group = ep.Group
+	status.(*endpoint.Status).Key = key
+	s.cache.Set(key, status)
+	return true, nil
+}
+
+// GetRuntimeState returns the runtime state that was last saved, or nil if no runtime state has ever been saved
+func (s *Store) GetRuntimeState() (*state.RuntimeState, error) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.runtimeState, nil
+}
+
+// SaveRuntimeState saves the runtime state, replacing the runtime state that was previously saved, if any
+func (s *Store) SaveRuntimeState(runtimeState *state.RuntimeState) error {
+	s.Lock()
+	defer s.Unlock()
+	s.runtimeState = runtimeState
+	return nil
+}
+
 // Insert adds the observed result for the specified endpoint into the store
 func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	key := ep.Key()
@@ -151,7 +244,14 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 			Timestamp: time.Now(),
 		})
 	}
-	AddResult(status.(*endpoint.Status), result)
+	if s.deduplicationBucket > 0 {
+		deduplicateOrAppendResult(status.(*endpoint.Status), result, s.deduplicationBucket)
+	} else {
+		appendResult(status.(*endpoint.Status), result)
+	}
+	if !s.excludeMaintenanceFromUptime || !result.Maintenance {
+		processUptimeAfterResult(status.(*endpoint.Status).Uptime, result)
+	}
 	s.cache.Set(key, status)
 	s.Unlock()
 	return nil
@@ -209,6 +309,10 @@ func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.En
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	s.cache.Clear()
+	s.Lock()
+	s.keysByID = make(map[string]string)
+	s.runtimeState = nil
+	s.Unlock()
 }
 
 // Save persists the cache to the store file
@@ -216,6 +320,11 @@ func (s *Store) Save() error {
 	return nil
 }
 
+// Ping does nothing, because the store is always reachable
+func (s *Store) Ping() error {
+	return nil
+}
+
 // Close does nothing, because there's nothing to close
 func (s *Store) Close() {
 	return
@@ -121,6 +121,41 @@ func TestStore_SanityCheck(t *testing.T) {
 	}
 }
 
+func TestStore_InsertWithDeduplication(t *testing.T) {
+	store, _ := NewStore()
+	defer store.Close()
+	store.EnableDeduplication(50 * time.Millisecond)
+	start := time.Now().Truncate(time.Hour)
+	for i := 0; i < 10; i++ {
+		result := testSuccessfulResult
+		result.Timestamp = start.Add(time.Duration(i) * time.Minute)
+		result.Duration = time.Duration(130+i) * time.Millisecond
+		store.Insert(&testEndpoint, &result)
+	}
+	unsuccessfulResult := testUnsuccessfulResult
+	unsuccessfulResult.Timestamp = start.Add(10 * time.Minute)
+	store.Insert(&testEndpoint, &unsuccessfulResult)
+	ss, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
+	if len(ss.Results) != 2 {
+		t.Fatalf("expected the 10 identical results to have been compressed into 1, got %d results", len(ss.Results))
+	}
+	if ss.Results[0].Count != 10 {
+		t.Errorf("expected the first result to have a count of 10, got %d", ss.Results[0].Count)
+	}
+	if !ss.Results[0].Timestamp.Equal(start.Add(9 * time.Minute)) {
+		t.Errorf("expected the timestamp of the first result to be the timestamp of the last identical result, got %s", ss.Results[0].Timestamp)
+	}
+	if ss.Results[1].Count != 0 {
+		t.Errorf("expected the second result not to have a count, got %d", ss.Results[1].Count)
+	}
+	if len(ss.Events) != 3 {
+		t.Errorf("expected 3 events, got %d", len(ss.Events))
+	}
+	if uptime, _ := store.GetUptimeByKey(testEndpoint.Key(), start, start.Add(time.Hour)); uptime != 10.0/11.0 {
+		t.Errorf("expected every result to have been accounted for in the uptime, got %f", uptime)
+	}
+}
+
 func TestStore_Save(t *testing.T) {
 	store, err := NewStore()
 	if err != nil {
@@ -1,6 +1,8 @@
 package memory
 
 import (
+	"time"
+
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
@@ -55,6 +57,12 @@ func AddResult(ss *endpoint.Status, result *endpoint.Result) {
 	if ss == nil {
 		return
 	}
+	appendResult(ss, result)
+	processUptimeAfterResult(ss.Uptime, result)
+}
+
+// appendResult does the same as AddResult, except that the Result isn't accounted for in the uptime
+func appendResult(ss *endpoint.Status, result *endpoint.Result) {
 	if len(ss.Results) > 0 {
 		// Check if there's any change since the last result
 		if ss.Results[len(ss.Results)-1].Success != result.Success {
@@ -77,5 +85,29 @@ func AddResult(ss *endpoint.Status, result *endpoint.Result) {
 		// MaximumNumberOfResults by using ss.Results[len(ss.Results)-MaximumNumberOfResults:] instead
 		ss.Results = ss.Results[len(ss.Results)-common.MaximumNumberOfResults:]
 	}
+}
+
+// DeduplicateOrAddResult merges a Result into the last result of Status.Results if they are identical, or adds it to
+// Status.Results otherwise. Either way, the Result is accounted for in the uptime.
+func DeduplicateOrAddResult(ss *endpoint.Status, result *endpoint.Result, durationBucket time.Duration) {
+	if ss == nil {
+		return
+	}
+	deduplicateOrAppendResult(ss, result, durationBucket)
 	processUptimeAfterResult(ss.Uptime, result)
 }
+
+// deduplicateOrAppendResult does the same as DeduplicateOrAddResult, except that the Result isn't accounted for in
+// the uptime
+func deduplicateOrAppendResult(ss *endpoint.Status, result *endpoint.Result, durationBucket time.Duration) {
+	if len(ss.Results) == 0 || !ss.Results[len(ss.Results)-1].IsIdenticalTo(result, durationBucket) {
+		appendResult(ss, result)
+		return
+	}
+	// The previous result may be referenced by a shallow copy that is being read, so rather than modifying it, we
+	// replace it by a modified copy in a new slice
+	lastResult := *ss.Results[len(ss.Results)-1]
+	lastResult.Count = max(lastResult.Count, 1) + 1
+	lastResult.Timestamp = result.Timestamp
+	ss.Results = append(ss.Results[:len(ss.Results)-1:len(ss.Results)-1], &lastResult)
+}
@@ -0,0 +1,127 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/metrics"
+)
+
+// batchEntry is a result waiting to be inserted along with the endpoint it belongs to
+type batchEntry struct {
+	endpoint *endpoint.Endpoint
+	result   *endpoint.Result
+}
+
+// EnableBatching makes the store accumulate results and insert them in a single transaction once batchSize results
+// have been accumulated or once flushInterval has elapsed, whichever comes first.
+//
+// This drastically reduces the number of transactions when monitoring a large number of endpoints. Results are only
+// visible to reads once they have been flushed, so reads may lag behind by up to flushInterval.
+func (s *Store) EnableBatching(batchSize int, flushInterval time.Duration) {
+	s.batchSize = batchSize
+	var ctx context.Context
+	ctx, s.stopPeriodicFlush = context.WithCancel(context.Background())
+	go s.flushPeriodically(ctx, flushInterval)
+}
+
+// IsBatching returns whether results are batched, see EnableBatching
+func (s *Store) IsBatching() bool {
+	return s.batchSize > 0
+}
+
+// EnableMetrics makes the store publish the duration and the errors of the insertion of each batch of results.
+//
+// Since Insert only queues results once batching is enabled, timing Insert itself would say nothing about the
+// database, and the errors of the flushes that happen in the background would go unnoticed.
+func (s *Store) EnableMetrics() {
+	s.publishMetrics = true
+}
+
+// flushPeriodically flushes the batched results at every interval until the context is cancelled
+func (s *Store) flushPeriodically(ctx context.Context, interval time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+			if err := s.flush(); err != nil {
+				log.Println("[sql.flushPeriodically] Failed to flush batched results:", err.Error())
+			}
+		}
+	}
+}
+
+// flush inserts all batched results in a single transaction.
+//
+// Each result is inserted under its own savepoint, so that a result that cannot be inserted is dropped without
+// discarding the rest of the batch. If the transaction itself fails, the results are queued again to be retried with
+// the next batch.
+func (s *Store) flush() error {
+	s.flushMutex.Lock()
+	defer s.flushMutex.Unlock()
+	s.batchMutex.Lock()
+	entries := s.batch
+	s.batch = nil
+	s.batchMutex.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+	start := time.Now()
+	err := s.insertBatch(entries)
+	if s.publishMetrics {
+		metrics.PublishMetricsForStorageOperation(metrics.StorageOperationInsert, time.Since(start), err)
+	}
+	return err
+}
+
+// insertBatch inserts entries in a single transaction, see flush
+func (s *Store) insertBatch(entries []*batchEntry) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.requeue(entries)
+		return err
+	}
+	var numberOfFailedEntries int
+	for _, entry := range entries {
+		if _, err = tx.Exec("SAVEPOINT batch_entry"); err != nil {
+			_ = tx.Rollback()
+			s.requeue(entries)
+			return err
+		}
+		if err = s.insert(tx, entry.endpoint, entry.result); err != nil {
+			log.Printf("[sql.flush] Failed to insert batched result for endpoint with key=%s: %s", entry.endpoint.Key(), err.Error())
+			numberOfFailedEntries++
+			if _, err = tx.Exec("ROLLBACK TO SAVEPOINT batch_entry"); err != nil {
+				_ = tx.Rollback()
+				s.requeue(entries)
+				return err
+			}
+		}
+		if _, err = tx.Exec("RELEASE SAVEPOINT batch_entry"); err != nil {
+			_ = tx.Rollback()
+			s.requeue(entries)
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		s.requeue(entries)
+		return err
+	}
+	if numberOfFailedEntries > 0 {
+		return fmt.Errorf("failed to insert %d out of %d batched results", numberOfFailedEntries, len(entries))
+	}
+	return nil
+}
+
+// requeue puts entries back at the front of the batch, ahead of the results that were batched while they were being
+// flushed, so that they are retried with the next flush
+func (s *Store) requeue(entries []*batchEntry) {
+	s.batchMutex.Lock()
+	defer s.batchMutex.Unlock()
+	s.batch = append(entries, s.batch...)
+}
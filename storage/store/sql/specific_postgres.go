@@ -3,10 +3,11 @@ package sql
 func (s *Store) createPostgresSchema() error {
 	_, err := s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoints (
-			endpoint_id    BIGSERIAL PRIMARY KEY,
-			endpoint_key   TEXT UNIQUE,
-			endpoint_name  TEXT NOT NULL,
-			endpoint_group TEXT NOT NULL,
+			endpoint_id        BIGSERIAL PRIMARY KEY,
+			endpoint_key       TEXT UNIQUE,
+			endpoint_name      TEXT NOT NULL,
+			endpoint_group     TEXT NOT NULL,
+			endpoint_stable_id TEXT UNIQUE,
 			UNIQUE(endpoint_name, endpoint_group)
 		)
 	`)
@@ -38,7 +39,10 @@ func (s *Store) createPostgresSchema() error {
 			hostname               TEXT      NOT NULL,
 			ip                     TEXT      NOT NULL,
 			duration               BIGINT    NOT NULL,
-			timestamp              TIMESTAMP NOT NULL
+			timestamp              TIMESTAMP NOT NULL,
+			alerts                 TEXT      NOT NULL DEFAULT '',
+			count                  INTEGER   NOT NULL DEFAULT 1,
+			maintenance            BOOLEAN   NOT NULL DEFAULT FALSE
 		)
 	`)
 	if err != nil {
@@ -49,7 +53,8 @@ func (s *Store) createPostgresSchema() error {
 			endpoint_result_condition_id  BIGSERIAL PRIMARY KEY,
 			endpoint_result_id            BIGINT  NOT NULL REFERENCES endpoint_results(endpoint_result_id) ON DELETE CASCADE,
 			condition                     TEXT    NOT NULL,
-			success                       BOOLEAN NOT NULL
+			success                       BOOLEAN NOT NULL,
+			message                       TEXT    NOT NULL DEFAULT ''
 		)
 	`)
 	if err != nil {
@@ -79,7 +84,35 @@ func (s *Store) createPostgresSchema() error {
 			UNIQUE(endpoint_id, configuration_checksum)
 		)
 	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS runtime_state (
+			runtime_state_id  INTEGER PRIMARY KEY,
+			data              TEXT    NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	// Speeds up the retrieval of the conditions of a result, which is needed to search results by condition
+	_, err = s.db.Exec(`CREATE INDEX IF NOT EXISTS endpoint_result_conditions_endpoint_result_id_index ON endpoint_result_conditions (endpoint_result_id)`)
 	// Silent table modifications TODO: Remove this in v6.0.0
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS domain_expiration BIGINT NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS alerts TEXT NOT NULL DEFAULT ''`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS count INTEGER NOT NULL DEFAULT 1`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoints ADD IF NOT EXISTS endpoint_stable_id TEXT`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_result_conditions ADD IF NOT EXISTS message TEXT NOT NULL DEFAULT ''`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS maintenance BOOLEAN NOT NULL DEFAULT FALSE`)
+	if err != nil {
+		return err
+	}
+	// Adding a column with ALTER TABLE doesn't carry the UNIQUE constraint it has when the table is created with it, so
+	// the uniqueness of endpoint_stable_id must be enforced by an index on databases created before that column existed.
+	// Any duplicate left behind by older versions is cleared first, keeping it only on the oldest endpoint, as the index
+	// couldn't be created otherwise
+	_, _ = s.db.Exec(`UPDATE endpoints SET endpoint_stable_id = NULL WHERE endpoint_stable_id IS NOT NULL AND endpoint_id NOT IN (SELECT MIN(endpoint_id) FROM endpoints WHERE endpoint_stable_id IS NOT NULL GROUP BY endpoint_stable_id)`)
+	_, err = s.db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS endpoints_endpoint_stable_id_index ON endpoints (endpoint_stable_id)`)
 	return err
 }
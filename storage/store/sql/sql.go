@@ -1,16 +1,20 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/state"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gocache/v2"
@@ -24,10 +28,13 @@ import (
 
 const (
 	// arraySeparator is the separator used to separate multiple strings in a single column.
-	// It's a dirty hack, but it's only used for persisting errors, and since this data will likely only ever be used
+	// It's a dirty hack, but it's only used for persisting errors and alerts, and since this data will likely only ever be used
 	// for aesthetic purposes, I deemed it wasn't worth the performance impact of yet another one-to-many table.
 	arraySeparator = "|~|"
 
+	// alertStateSeparator is the separator used to separate the type of an alert from its state in the alerts column
+	alertStateSeparator = ":"
+
 	eventsCleanUpThreshold  = common.MaximumNumberOfEvents + 10  // Maximum number of events before triggering a cleanup
 	resultsCleanUpThreshold = common.MaximumNumberOfResults + 10 // Maximum number of results before triggering a cleanup
 
@@ -37,6 +44,17 @@ const (
 	uptimeHourlyBuffer               = 48 * time.Hour      // Number of hours to buffer from now when determining which hourly uptime entries can be merged into daily uptime entries
 
 	cacheTTL = 10 * time.Minute
+
+	// runtimeStateID is the ID of the only row of the runtime_state table
+	runtimeStateID = 1
+
+	// schemaVersion is the version of the schema created by this version of Gatus.
+	// It must be incremented whenever the schema is modified in a way that older versions of Gatus cannot handle:
+	//   1: initial version
+	//   2: endpoints.endpoint_stable_id, which lets the history of an endpoint follow it when it's renamed
+	//   3: runtime_state table
+	//   4: endpoint_results.maintenance
+	schemaVersion = 4
 )
 
 var (
@@ -46,6 +64,10 @@ var (
 	// ErrDatabaseDriverNotSpecified is the error returned when the driver parameter passed in NewStore is blank
 	ErrDatabaseDriverNotSpecified = errors.New("database driver cannot be empty")
 
+	// ErrSchemaVersionTooRecent is the error returned by NewStore when the schema of the database was created by a
+	// more recent version of Gatus than this one
+	ErrSchemaVersionTooRecent = errors.New("database schema is more recent than what this version of Gatus supports; upgrade Gatus to use this database")
+
 	errNoRowsReturned = errors.New("expected a row to be returned, but none was")
 )
 
@@ -58,6 +80,26 @@ type Store struct {
 	// writeThroughCache is a cache used to drastically decrease read latency by pre-emptively
 	// caching writes as they happen. If nil, writes are not cached.
 	writeThroughCache *gocache.Cache
+
+	// batchSize is the number of results to accumulate before inserting them in a single transaction.
+	// If 0, results are inserted as soon as they are received.
+	batchSize int
+
+	batch      []*batchEntry
+	batchMutex sync.Mutex // protects batch
+	flushMutex sync.Mutex // ensures that batches are flushed in the order they were accumulated
+
+	stopPeriodicFlush context.CancelFunc
+
+	// publishMetrics is whether the metrics of the flushes of batched results are published
+	publishMetrics bool
+
+	// deduplicationBucket is the size of the buckets in which the duration of two consecutive results must fall for
+	// the latter to be merged into the former. If 0, results are not deduplicated.
+	deduplicationBucket time.Duration
+
+	// excludeMaintenanceFromUptime is whether results recorded during a maintenance window are left out of the uptime
+	excludeMaintenanceFromUptime bool
 }
 
 // NewStore initializes the database and creates the schema if it doesn't already exist in the path specified
@@ -94,12 +136,68 @@ func NewStore(driver, path string, caching bool) (*Store, error) {
 	return store, nil
 }
 
-// createSchema creates the schema required to perform all database operations.
+// EnableDeduplication makes the store merge results identical to the previous result of the same endpoint into the
+// previous result instead of inserting them as new rows
+func (s *Store) EnableDeduplication(durationBucket time.Duration) {
+	s.deduplicationBucket = durationBucket
+}
+
+// EnableMaintenanceExclusion makes the store leave the results recorded during a maintenance window out of the uptime
+func (s *Store) EnableMaintenanceExclusion() {
+	s.excludeMaintenanceFromUptime = true
+}
+
+// createSchema creates the schema required to perform all database operations, or migrates it if it was created by
+// an older version of Gatus.
+//
+// To prevent older versions of Gatus from corrupting the data, the schema is left untouched and ErrSchemaVersionTooRecent
+// is returned if the schema was created by a more recent version of Gatus.
 func (s *Store) createSchema() error {
+	version, err := s.getSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("%w (database schema version: %d, supported schema version: %d)", ErrSchemaVersionTooRecent, version, schemaVersion)
+	}
 	if s.driver == "sqlite" {
-		return s.createSQLiteSchema()
+		err = s.createSQLiteSchema()
+	} else {
+		err = s.createPostgresSchema()
+	}
+	if err != nil {
+		return err
+	}
+	if version < schemaVersion {
+		return s.setSchemaVersion(schemaVersion)
+	}
+	return nil
+}
+
+// getSchemaVersion returns the version of the schema of the database, or 0 if it has never been recorded
+func (s *Store) getSchemaVersion() (version int, err error) {
+	if _, err = s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return 0, err
+	}
+	err = s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version)
+	return version, err
+}
+
+// setSchemaVersion records the version of the schema of the database
+func (s *Store) setSchemaVersion(version int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`DELETE FROM schema_version`); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err = tx.Exec(`INSERT INTO schema_version (version) VALUES ($1)`, version); err != nil {
+		_ = tx.Rollback()
+		return err
 	}
-	return s.createPostgresSchema()
+	return tx.Commit()
 }
 
 // GetAllEndpointStatuses returns all monitored endpoint.Status
@@ -225,23 +323,67 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
+// SearchResultsByKey returns the results of an endpoint that match the search parameters, from newest to oldest
+func (s *Store) SearchResultsByKey(key string, params *paging.ResultSearchParams) ([]*endpoint.Result, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	results, err := s.searchEndpointResultsByEndpointID(tx, endpointID, params)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return results, nil
+}
+
 // Insert adds the observed result for the specified endpoint into the store
 func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	if s.batchSize > 0 {
+		s.batchMutex.Lock()
+		s.batch = append(s.batch, &batchEntry{endpoint: ep, result: result})
+		batchIsFull := len(s.batch) >= s.batchSize
+		s.batchMutex.Unlock()
+		if batchIsFull {
+			return s.flush()
+		}
+		return nil
+	}
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
+	if err = s.insert(tx, ep, result); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return err
+}
+
+// insert adds the observed result for the specified endpoint using the transaction passed as parameter
+//
+// The caller is responsible for committing or rolling back the transaction.
+func (s *Store) insert(tx *sql.Tx, ep *endpoint.Endpoint, result *endpoint.Result) error {
 	endpointID, err := s.getEndpointID(tx, ep)
 	if err != nil {
 		if errors.Is(err, common.ErrEndpointNotFound) {
 			// Endpoint doesn't exist in the database, insert it
 			if endpointID, err = s.insertEndpoint(tx, ep); err != nil {
-				_ = tx.Rollback()
 				log.Printf("[sql.Insert] Failed to create endpoint with key=%s: %s", ep.Key(), err.Error())
 				return err
 			}
 		} else {
-			_ = tx.Rollback()
 			log.Printf("[sql.Insert] Failed to retrieve id of endpoint with key=%s: %s", ep.Key(), err.Error())
 			return err
 		}
@@ -302,27 +444,36 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 			}
 		}
 	}
-	// Second, we need to insert the result.
-	if err = s.insertEndpointResult(tx, endpointID, result); err != nil {
-		log.Printf("[sql.Insert] Failed to insert result for endpoint with key=%s: %s", ep.Key(), err.Error())
-		_ = tx.Rollback() // If we can't insert the result, we'll rollback now since there's no point continuing
-		return err
+	// Second, we need to insert the result, unless it can be merged into the previous result.
+	deduplicated := false
+	if s.deduplicationBucket > 0 && numberOfEvents > 0 {
+		if deduplicated, err = s.deduplicateEndpointResult(tx, endpointID, result); err != nil {
+			log.Printf("[sql.Insert] Failed to deduplicate result for endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
 	}
-	// Clean up old results
-	numberOfResults, err := s.getNumberOfResultsByEndpointID(tx, endpointID)
-	if err != nil {
-		log.Printf("[sql.Insert] Failed to retrieve total number of results for endpoint with key=%s: %s", ep.Key(), err.Error())
-	} else {
-		if numberOfResults > resultsCleanUpThreshold {
-			if err = s.deleteOldEndpointResults(tx, endpointID); err != nil {
-				log.Printf("[sql.Insert] Failed to delete old results for endpoint with key=%s: %s", ep.Key(), err.Error())
+	if !deduplicated {
+		if err = s.insertEndpointResult(tx, endpointID, result); err != nil {
+			log.Printf("[sql.Insert] Failed to insert result for endpoint with key=%s: %s", ep.Key(), err.Error())
+			return err // If we can't insert the result, there's no point continuing
+		}
+		// Clean up old results
+		numberOfResults, err := s.getNumberOfResultsByEndpointID(tx, endpointID)
+		if err != nil {
+			log.Printf("[sql.Insert] Failed to retrieve total number of results for endpoint with key=%s: %s", ep.Key(), err.Error())
+		} else {
+			if numberOfResults > resultsCleanUpThreshold {
+				if err = s.deleteOldEndpointResults(tx, endpointID); err != nil {
+					log.Printf("[sql.Insert] Failed to delete old results for endpoint with key=%s: %s", ep.Key(), err.Error())
+				}
 			}
 		}
 	}
 	// Finally, we need to insert the uptime data.
 	// Because the uptime data significantly outlives the results, we can't rely on the results for determining the uptime
-	if err = s.updateEndpointUptime(tx, endpointID, result); err != nil {
-		log.Printf("[sql.Insert] Failed to update uptime for endpoint with key=%s: %s", ep.Key(), err.Error())
+	if !s.excludeMaintenanceFromUptime || !result.Maintenance {
+		if err = s.updateEndpointUptime(tx, endpointID, result); err != nil {
+			log.Printf("[sql.Insert] Failed to update uptime for endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
 	}
 	// Merge hourly uptime entries that can be merged into daily entries and clean up old uptime entries
 	numberOfUptimeEntries, err := s.getNumberOfUptimeEntriesByEndpointID(tx, endpointID)
@@ -363,9 +514,85 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 			_, _ = s.getEndpointStatusByKey(tx, endpointKey, params)
 		}
 	}
-	if err = tx.Commit(); err != nil {
+	return nil
+}
+
+// RenameEndpointByID associates the ID of the endpoint with its key. If the ID was previously associated with another
+// key, the endpoint was renamed, so the history stored under the previous key is moved to the current key, replacing
+// any history that may already have been stored under the current key.
+//
+// Returns whether the history of the endpoint was moved.
+func (s *Store) RenameEndpointByID(ep *endpoint.Endpoint) (bool, error) {
+	if len(ep.ID) == 0 {
+		return false, nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	var endpointID int64
+	var previousKey string
+	err = tx.QueryRow("SELECT endpoint_id, endpoint_key FROM endpoints WHERE endpoint_stable_id = $1", ep.ID).Scan(&endpointID, &previousKey)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			_ = tx.Rollback()
+			return false, err
+		}
+		// The ID is assigned for the first time, so the existing history of the endpoint, if any, becomes tied to it
+		if _, err = tx.Exec("UPDATE endpoints SET endpoint_stable_id = $1 WHERE endpoint_key = $2", ep.ID, ep.Key()); err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		return false, tx.Commit()
+	}
+	if previousKey == ep.Key() {
+		return false, tx.Commit()
+	}
+	if _, err = tx.Exec("DELETE FROM endpoints WHERE endpoint_key = $1", ep.Key()); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if _, err = tx.Exec("UPDATE endpoints SET endpoint_key = $1, endpoint_name = $2, endpoint_group = $3 WHERE endpoint_id = $4", ep.Key(), ep.Name, ep.Group, endpointID); err != nil {
 		_ = tx.Rollback()
+		return false, err
 	}
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	if s.writeThroughCache != nil {
+		_ = s.writeThroughCache.DeleteKeysByPattern(previousKey + "*")
+		_ = s.writeThroughCache.DeleteKeysByPattern(ep.Key() + "*")
+	}
+	return true, nil
+}
+
+// GetRuntimeState returns the runtime state that was last saved, or nil if no runtime state has ever been saved
+func (s *Store) GetRuntimeState() (*state.RuntimeState, error) {
+	var data string
+	if err := s.db.QueryRow("SELECT data FROM runtime_state WHERE runtime_state_id = $1", runtimeStateID).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	runtimeState := &state.RuntimeState{}
+	if err := json.Unmarshal([]byte(data), runtimeState); err != nil {
+		return nil, err
+	}
+	return runtimeState, nil
+}
+
+// SaveRuntimeState persists the runtime state, replacing the runtime state that was previously saved, if any
+func (s *Store) SaveRuntimeState(runtimeState *state.RuntimeState) error {
+	data, err := json.Marshal(runtimeState)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		"INSERT INTO runtime_state (runtime_state_id, data) VALUES ($1, $2) ON CONFLICT(runtime_state_id) DO UPDATE SET data = $2",
+		runtimeStateID,
+		string(data),
+	)
 	return err
 }
 
@@ -453,7 +680,7 @@ func (s *Store) UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAle
 		endpointID,
 		triggeredAlert.Checksum(),
 		triggeredAlert.ResolveKey,
-		ep.NumberOfSuccessesInARow, // We only persist NumberOfSuccessesInARow, because all alerts in this table are already triggered
+		triggeredAlert.NumberOfSuccessesInARow, // We only persist NumberOfSuccessesInARow, because all alerts in this table are already triggered
 	)
 	if err != nil {
 		_ = tx.Rollback()
@@ -509,18 +736,33 @@ func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.En
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	_, _ = s.db.Exec("DELETE FROM endpoints")
+	_, _ = s.db.Exec("DELETE FROM runtime_state")
 	if s.writeThroughCache != nil {
 		_ = s.writeThroughCache.DeleteKeysByPattern("*")
 	}
 }
 
-// Save does nothing, because this store is immediately persistent.
+// Save inserts the results that have been batched, if any.
+// If batching isn't enabled, this does nothing, because this store is immediately persistent.
 func (s *Store) Save() error {
-	return nil
+	return s.flush()
+}
+
+// Ping verifies that the connection to the database is still alive
+func (s *Store) Ping() error {
+	return s.db.Ping()
 }
 
 // Close the database handle
+//
+// If batching is enabled, results that have yet to be inserted are flushed first.
 func (s *Store) Close() {
+	if s.stopPeriodicFlush != nil {
+		s.stopPeriodicFlush()
+	}
+	if err := s.flush(); err != nil {
+		log.Println("[sql.Close] Failed to flush batched results:", err.Error())
+	}
 	_ = s.db.Close()
 	if s.writeThroughCache != nil {
 		// Clear the cache too. If the store's been closed, we don't want to keep the cache around.
@@ -533,10 +775,11 @@ func (s *Store) insertEndpoint(tx *sql.Tx, ep *endpoint.Endpoint) (int64, error)
 	//log.Printf("[sql.insertEndpoint] Inserting endpoint with group=%s and name=%s", ep.Group, ep.Name)
 	var id int64
 	err := tx.QueryRow(
-		"INSERT INTO endpoints (endpoint_key, endpoint_name, endpoint_group) VALUES ($1, $2, $3) RETURNING endpoint_id",
+		"INSERT INTO endpoints (endpoint_key, endpoint_name, endpoint_group, endpoint_stable_id) VALUES ($1, $2, $3, $4) RETURNING endpoint_id",
 		ep.Key(),
 		ep.Name,
 		ep.Group,
+		sql.NullString{String: ep.ID, Valid: len(ep.ID) > 0},
 	).Scan(&id)
 	if err != nil {
 		return 0, err
@@ -563,8 +806,8 @@ func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *endpo
 	var endpointResultID int64
 	err := tx.QueryRow(
 		`
-			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, alerts, count, maintenance)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 			RETURNING endpoint_result_id
 		`,
 		endpointID,
@@ -579,6 +822,9 @@ func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *endpo
 		result.IP,
 		result.Duration,
 		result.Timestamp.UTC(),
+		joinResultAlerts(result.Alerts),
+		max(result.Count, 1),
+		result.Maintenance,
 	).Scan(&endpointResultID)
 	if err != nil {
 		return err
@@ -586,13 +832,81 @@ func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *endpo
 	return s.insertConditionResults(tx, endpointResultID, result.ConditionResults)
 }
 
+// deduplicateEndpointResult merges the result into the previous result of the endpoint if they are identical, in which
+// case the count of the previous result is incremented and its timestamp is replaced by the timestamp of the result.
+//
+// Returns whether the result was merged into the previous result.
+func (s *Store) deduplicateEndpointResult(tx *sql.Tx, endpointID int64, result *endpoint.Result) (bool, error) {
+	var lastEndpointResultID int64
+	var joinedErrors, joinedAlerts string
+	lastResult := &endpoint.Result{}
+	err := tx.QueryRow(
+		`
+			SELECT endpoint_result_id, success, errors, status, duration, alerts, maintenance
+			FROM endpoint_results
+			WHERE endpoint_id = $1
+			ORDER BY endpoint_result_id DESC
+			LIMIT 1
+		`,
+		endpointID,
+	).Scan(&lastEndpointResultID, &lastResult.Success, &joinedErrors, &lastResult.HTTPStatus, &lastResult.Duration, &joinedAlerts, &lastResult.Maintenance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if len(joinedErrors) != 0 {
+		lastResult.Errors = strings.Split(joinedErrors, arraySeparator)
+	}
+	lastResult.Alerts = splitResultAlerts(joinedAlerts)
+	if lastResult.ConditionResults, err = s.getConditionResultsByEndpointResultID(tx, lastEndpointResultID); err != nil {
+		return false, err
+	}
+	if !lastResult.IsIdenticalTo(result, s.deduplicationBucket) {
+		return false, nil
+	}
+	_, err = tx.Exec(
+		"UPDATE endpoint_results SET count = count + $1, timestamp = $2 WHERE endpoint_result_id = $3",
+		max(result.Count, 1),
+		result.Timestamp.UTC(),
+		lastEndpointResultID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getConditionResultsByEndpointResultID returns the condition results of a result, in the order they were inserted
+func (s *Store) getConditionResultsByEndpointResultID(tx *sql.Tx, endpointResultID int64) ([]*endpoint.ConditionResult, error) {
+	rows, err := tx.Query(
+		"SELECT condition, success, message FROM endpoint_result_conditions WHERE endpoint_result_id = $1 ORDER BY endpoint_result_condition_id",
+		endpointResultID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var conditionResults []*endpoint.ConditionResult
+	for rows.Next() {
+		conditionResult := &endpoint.ConditionResult{}
+		if err = rows.Scan(&conditionResult.Condition, &conditionResult.Success, &conditionResult.Message); err != nil {
+			return nil, err
+		}
+		conditionResults = append(conditionResults, conditionResult)
+	}
+	return conditionResults, rows.Err()
+}
+
 func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, conditionResults []*endpoint.ConditionResult) error {
 	var err error
 	for _, cr := range conditionResults {
-		_, err = tx.Exec("INSERT INTO endpoint_result_conditions (endpoint_result_id, condition, success) VALUES ($1, $2, $3)",
+		_, err = tx.Exec("INSERT INTO endpoint_result_conditions (endpoint_result_id, condition, success, message) VALUES ($1, $2, $3, $4)",
 			endpointResultID,
 			cr.Condition,
 			cr.Success,
+			cr.Message,
 		)
 		if err != nil {
 			return err
@@ -715,7 +1029,7 @@ func (s *Store) getEndpointEventsByEndpointID(tx *sql.Tx, endpointID int64, page
 func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, page, pageSize int) (results []*endpoint.Result, err error) {
 	rows, err := tx.Query(
 		`
-			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp
+			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, alerts, count, maintenance
 			FROM endpoint_results
 			WHERE endpoint_id = $1
 			ORDER BY endpoint_result_id DESC -- Normally, we'd sort by timestamp, but sorting by endpoint_result_id is faster
@@ -732,8 +1046,9 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 	for rows.Next() {
 		result := &endpoint.Result{}
 		var id int64
-		var joinedErrors string
-		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp)
+		var joinedErrors, joinedAlerts string
+		var count int
+		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp, &joinedAlerts, &count, &result.Maintenance)
 		if err != nil {
 			log.Printf("[sql.getEndpointResultsByEndpointID] Silently failed to retrieve endpoint result for endpointID=%d: %s", endpointID, err.Error())
 			err = nil
@@ -741,6 +1056,10 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		if len(joinedErrors) != 0 {
 			result.Errors = strings.Split(joinedErrors, arraySeparator)
 		}
+		result.Alerts = splitResultAlerts(joinedAlerts)
+		if count > 1 {
+			result.Count = count
+		}
 		// This is faster than using a subselect
 		results = append([]*endpoint.Result{result}, results...)
 		idResultMap[id] = result
@@ -751,7 +1070,7 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 	}
 	// Get condition results
 	args := make([]interface{}, 0, len(idResultMap))
-	query := `SELECT endpoint_result_id, condition, success
+	query := `SELECT endpoint_result_id, condition, success, message
 				FROM endpoint_result_conditions
 				WHERE endpoint_result_id IN (`
 	index := 1
@@ -769,7 +1088,7 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 	for rows.Next() {
 		conditionResult := &endpoint.ConditionResult{}
 		var endpointResultID int64
-		if err = rows.Scan(&endpointResultID, &conditionResult.Condition, &conditionResult.Success); err != nil {
+		if err = rows.Scan(&endpointResultID, &conditionResult.Condition, &conditionResult.Success, &conditionResult.Message); err != nil {
 			return
 		}
 		idResultMap[endpointResultID].ConditionResults = append(idResultMap[endpointResultID].ConditionResults, conditionResult)
@@ -777,6 +1096,98 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 	return
 }
 
+// searchEndpointResultsByEndpointID returns the results of an endpoint that match the search parameters, from newest
+// to oldest. The filtering is done by the database so that only the requested page of results is loaded.
+func (s *Store) searchEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, params *paging.ResultSearchParams) ([]*endpoint.Result, error) {
+	query := `
+		SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, alerts, count, maintenance
+		FROM endpoint_results
+		WHERE endpoint_id = $1`
+	args := []interface{}{endpointID}
+	if len(params.Condition) > 0 {
+		// instr and strpos are used instead of LIKE so that the condition doesn't need to be escaped
+		containsFunction := "strpos"
+		if s.driver == "sqlite" {
+			containsFunction = "instr"
+		}
+		query += `
+			AND EXISTS (
+				SELECT 1
+				FROM endpoint_result_conditions
+				WHERE endpoint_result_conditions.endpoint_result_id = endpoint_results.endpoint_result_id
+				AND ` + containsFunction + `(condition, $2) > 0`
+		args = append(args, params.Condition)
+		if params.OnlyFailures {
+			query += `
+				AND endpoint_result_conditions.success = $3`
+			args = append(args, false)
+		}
+		query += `
+			)`
+	} else if params.OnlyFailures {
+		query += `
+			AND success = $2`
+		args = append(args, false)
+	}
+	query += `
+		ORDER BY endpoint_result_id DESC
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var results []*endpoint.Result
+	idResultMap := make(map[int64]*endpoint.Result)
+	for rows.Next() {
+		result := &endpoint.Result{}
+		var id int64
+		var joinedErrors, joinedAlerts string
+		var count int
+		if err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp, &joinedAlerts, &count, &result.Maintenance); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		if len(joinedErrors) != 0 {
+			result.Errors = strings.Split(joinedErrors, arraySeparator)
+		}
+		result.Alerts = splitResultAlerts(joinedAlerts)
+		if count > 1 {
+			result.Count = count
+		}
+		results = append(results, result)
+		idResultMap[id] = result
+	}
+	_ = rows.Close()
+	if len(idResultMap) == 0 {
+		return results, nil
+	}
+	// Get the condition results of the matching results, so that the caller can see which conditions failed
+	conditionsQuery := `SELECT endpoint_result_id, condition, success, message
+				FROM endpoint_result_conditions
+				WHERE endpoint_result_id IN (`
+	conditionsArgs := make([]interface{}, 0, len(idResultMap))
+	for endpointResultID := range idResultMap {
+		conditionsArgs = append(conditionsArgs, endpointResultID)
+		conditionsQuery += "$" + strconv.Itoa(len(conditionsArgs)) + ","
+	}
+	conditionsQuery = conditionsQuery[:len(conditionsQuery)-1] + ") ORDER BY endpoint_result_condition_id"
+	rows, err = tx.Query(conditionsQuery, conditionsArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		conditionResult := &endpoint.ConditionResult{}
+		var endpointResultID int64
+		if err = rows.Scan(&endpointResultID, &conditionResult.Condition, &conditionResult.Success, &conditionResult.Message); err != nil {
+			return nil, err
+		}
+		idResultMap[endpointResultID].ConditionResults = append(idResultMap[endpointResultID].ConditionResults, conditionResult)
+	}
+	return results, nil
+}
+
 func (s *Store) getEndpointUptime(tx *sql.Tx, endpointID int64, from, to time.Time) (uptime float64, avgResponseTime time.Duration, err error) {
 	rows, err := tx.Query(
 		`
@@ -1057,6 +1468,28 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 	return nil
 }
 
+// joinResultAlerts serializes the alerts of a result into a single string so that they can be stored in one column
+func joinResultAlerts(alerts []*endpoint.ResultAlert) string {
+	serializedAlerts := make([]string, 0, len(alerts))
+	for _, resultAlert := range alerts {
+		serializedAlerts = append(serializedAlerts, string(resultAlert.Type)+alertStateSeparator+string(resultAlert.State))
+	}
+	return strings.Join(serializedAlerts, arraySeparator)
+}
+
+// splitResultAlerts is the inverse of joinResultAlerts
+func splitResultAlerts(joinedAlerts string) []*endpoint.ResultAlert {
+	if len(joinedAlerts) == 0 {
+		return nil
+	}
+	var alerts []*endpoint.ResultAlert
+	for _, serializedAlert := range strings.Split(joinedAlerts, arraySeparator) {
+		alertType, state, _ := strings.Cut(serializedAlert, alertStateSeparator)
+		alerts = append(alerts, &endpoint.ResultAlert{Type: alert.Type(alertType), State: endpoint.ResultAlertState(state)})
+	}
+	return alerts
+}
+
 func generateCacheKey(endpointKey string, p *paging.EndpointStatusParams) string {
 	return fmt.Sprintf("%s-%d-%d-%d-%d", endpointKey, p.EventsPage, p.EventsPageSize, p.ResultsPage, p.ResultsPageSize)
 }
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/state"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
@@ -74,6 +77,7 @@ var (
 			{
 				Condition: "[RESPONSE_TIME] < 500",
 				Success:   false,
+				Message:   "Response time is too high",
 			},
 			{
 				Condition: "[CERTIFICATE_EXPIRATION] < 72h",
@@ -97,6 +101,55 @@ func TestNewStore(t *testing.T) {
 	}
 }
 
+func TestNewStore_SchemaVersion(t *testing.T) {
+	path := t.TempDir() + "/TestNewStore_SchemaVersion.db"
+	store, err := NewStore("sqlite", path, false)
+	if err != nil {
+		t.Fatal("shouldn't have returned any error, got", err.Error())
+	}
+	if version, err := store.getSchemaVersion(); err != nil || version != schemaVersion {
+		t.Errorf("expected schema version to be %d, got %d (err=%v)", schemaVersion, version, err)
+	}
+	store.Close()
+	// Opening the same database again must be idempotent
+	if store, err = NewStore("sqlite", path, false); err != nil {
+		t.Fatal("shouldn't have returned any error when opening an existing database, got", err.Error())
+	}
+	var numberOfRows int
+	if err := store.db.QueryRow("SELECT COUNT(*) FROM schema_version").Scan(&numberOfRows); err != nil || numberOfRows != 1 {
+		t.Errorf("expected exactly one schema version to be recorded, got %d (err=%v)", numberOfRows, err)
+	}
+	// Simulate a database that was last used by an older version of Gatus
+	if err := store.setSchemaVersion(schemaVersion - 1); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+	if store, err = NewStore("sqlite", path, false); err != nil {
+		t.Fatal("shouldn't have returned any error when migrating an older schema, got", err.Error())
+	}
+	if version, _ := store.getSchemaVersion(); version != schemaVersion {
+		t.Errorf("expected schema version to have been migrated to %d, got %d", schemaVersion, version)
+	}
+	// Simulate a database that was migrated by a more recent version of Gatus
+	if err := store.setSchemaVersion(schemaVersion + 1); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+	if _, err = NewStore("sqlite", path, false); !errors.Is(err, ErrSchemaVersionTooRecent) {
+		t.Fatalf("expected error %v, got %v", ErrSchemaVersionTooRecent, err)
+	}
+	// The schema must be left untouched
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var version int
+	if err := db.QueryRow("SELECT version FROM schema_version").Scan(&version); err != nil || version != schemaVersion+1 {
+		t.Errorf("expected schema version to still be %d, got %d (err=%v)", schemaVersion+1, version, err)
+	}
+}
+
 func TestStore_InsertCleansUpOldUptimeEntriesProperly(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertCleansUpOldUptimeEntriesProperly.db", false)
 	defer store.Close()
@@ -324,6 +377,151 @@ func TestStore_InsertWithCaching(t *testing.T) {
 	}
 }
 
+func TestStore_InsertWithBatching(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithBatching.db", false)
+	defer store.Close()
+	store.EnableBatching(5, time.Hour)
+	numberOfBatchedResults := func() int {
+		store.batchMutex.Lock()
+		defer store.batchMutex.Unlock()
+		return len(store.batch)
+	}
+	for i := 0; i < 4; i++ {
+		store.Insert(&testEndpoint, &testSuccessfulResult)
+	}
+	if n := numberOfBatchedResults(); n != 4 {
+		t.Fatalf("expected 4 results to be batched before the batch was full, got %d", n)
+	}
+	// The 5th result fills the batch, which means all 5 results should be inserted in the same transaction
+	store.Insert(&testEndpoint, &testUnsuccessfulResult)
+	if n := numberOfBatchedResults(); n != 0 {
+		t.Fatalf("expected the batch to have been flushed, got %d batched results", n)
+	}
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	if n := numberOfBatchedResults(); n != 2 {
+		t.Fatalf("expected the 2 new results to still be batched, got %d", n)
+	}
+	// Reading the results must not flush the results that haven't been inserted yet
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(endpointStatus.Results))
+	}
+	if n := numberOfBatchedResults(); n != 2 {
+		t.Fatalf("expected the 2 new results to still be batched after the read, got %d", n)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err = store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 7 {
+		t.Fatalf("expected 7 results, got %d", len(endpointStatus.Results))
+	}
+	// EventStart, EventHealthy, EventUnhealthy and EventHealthy
+	if len(endpointStatus.Events) != 4 {
+		t.Errorf("expected 4 events, got %d", len(endpointStatus.Events))
+	}
+}
+
+func TestStore_InsertWithBatchingDoesNotDiscardBatchWhenOneResultFails(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithBatchingDoesNotDiscardBatchWhenOneResultFails.db", false)
+	defer store.Close()
+	store.EnableBatching(100, time.Hour)
+	firstEndpoint := endpoint.Endpoint{Name: "first", ID: "duplicate-id"}
+	// The stable id of an endpoint must be unique, so this endpoint cannot be inserted
+	secondEndpoint := endpoint.Endpoint{Name: "second", ID: "duplicate-id"}
+	store.Insert(&firstEndpoint, &testSuccessfulResult)
+	store.Insert(&secondEndpoint, &testSuccessfulResult)
+	store.Insert(&firstEndpoint, &testUnsuccessfulResult)
+	if err := store.Save(); err == nil {
+		t.Error("expected an error, because one of the batched results could not be inserted")
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(firstEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 2 {
+		t.Errorf("expected the 2 results of the first endpoint to have been inserted, got %d", len(endpointStatus.Results))
+	}
+	if _, err = store.GetEndpointStatusByKey(secondEndpoint.Key(), paging.NewEndpointStatusParams()); err == nil {
+		t.Error("expected the second endpoint not to have been inserted")
+	}
+}
+
+func TestNewStore_EnforcesUniqueStableIDOnMigratedDatabase(t *testing.T) {
+	path := t.TempDir() + "/TestNewStore_EnforcesUniqueStableIDOnMigratedDatabase.db"
+	// Simulate a database created before endpoint_stable_id existed, which then had it added without its UNIQUE
+	// constraint and ended up with a duplicate
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, query := range []string{
+		"CREATE TABLE endpoints (endpoint_id INTEGER PRIMARY KEY, endpoint_key TEXT UNIQUE, endpoint_name TEXT NOT NULL, endpoint_group TEXT NOT NULL, UNIQUE(endpoint_name, endpoint_group))",
+		"ALTER TABLE endpoints ADD endpoint_stable_id TEXT",
+		"INSERT INTO endpoints (endpoint_key, endpoint_name, endpoint_group, endpoint_stable_id) VALUES ('_first', 'first', '', 'duplicate-id')",
+		"INSERT INTO endpoints (endpoint_key, endpoint_name, endpoint_group, endpoint_stable_id) VALUES ('_second', 'second', '', 'duplicate-id')",
+	} {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+	store, err := NewStore("sqlite", path, false)
+	if err != nil {
+		t.Fatal("shouldn't have returned any error when migrating the database, got", err.Error())
+	}
+	defer store.Close()
+	var numberOfEndpointsWithStableID int
+	if err := store.db.QueryRow("SELECT COUNT(*) FROM endpoints WHERE endpoint_stable_id = 'duplicate-id'").Scan(&numberOfEndpointsWithStableID); err != nil || numberOfEndpointsWithStableID != 1 {
+		t.Errorf("expected the duplicate stable id to have been kept on only one endpoint, got %d (err=%v)", numberOfEndpointsWithStableID, err)
+	}
+	if _, err := store.db.Exec("UPDATE endpoints SET endpoint_stable_id = 'duplicate-id' WHERE endpoint_key = '_second'"); err == nil {
+		t.Error("expected an error, because the stable id of an endpoint must be unique")
+	}
+}
+
+func TestStore_InsertWithBatchingFlushesPeriodically(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithBatchingFlushesPeriodically.db", false)
+	defer store.Close()
+	store.EnableBatching(100, 10*time.Millisecond)
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	time.Sleep(100 * time.Millisecond)
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+	if err != nil {
+		t.Fatal("expected the batched results to have been flushed, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(endpointStatus.Results))
+	}
+}
+
+func TestStore_CloseWithBatchingFlushesBatchedResults(t *testing.T) {
+	path := t.TempDir() + "/TestStore_CloseWithBatchingFlushesBatchedResults.db"
+	store, _ := NewStore("sqlite", path, false)
+	store.EnableBatching(100, time.Hour)
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	store.Insert(&testEndpoint, &testUnsuccessfulResult)
+	store.Close()
+	// Reopen the store to make sure the results were persisted
+	store, _ = NewStore("sqlite", path, false)
+	defer store.Close()
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+	if err != nil {
+		t.Fatal("expected the batched results to have been persisted, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(endpointStatus.Results))
+	}
+}
+
 func TestStore_Persistence(t *testing.T) {
 	path := t.TempDir() + "/TestStore_Persistence.db"
 	store, _ := NewStore("sqlite", path, false)
@@ -356,6 +554,9 @@ func TestStore_Persistence(t *testing.T) {
 	if ssFromNewStore == ssFromOldStore {
 		t.Fatal("ss from the old and new store should have a different memory address")
 	}
+	if message := ssFromNewStore.Results[1].ConditionResults[1].Message; message != "Response time is too high" {
+		t.Errorf("expected the message of the failed condition to have been persisted, got %q", message)
+	}
 	for i := range ssFromNewStore.Events {
 		if ssFromNewStore.Events[i].Timestamp != ssFromOldStore.Events[i].Timestamp {
 			t.Error("new and old should've been the same")
@@ -405,11 +606,144 @@ func TestStore_Persistence(t *testing.T) {
 				if ssFromNewStore.Results[i].ConditionResults[j].Success != ssFromOldStore.Results[i].ConditionResults[j].Success {
 					t.Error("new and old should've been the same")
 				}
+				if ssFromNewStore.Results[i].ConditionResults[j].Message != ssFromOldStore.Results[i].ConditionResults[j].Message {
+					t.Error("new and old should've been the same")
+				}
 			}
 		}
 	}
 }
 
+func TestStore_RenameEndpointByIDAcrossRestarts(t *testing.T) {
+	path := t.TempDir() + "/TestStore_RenameEndpointByIDAcrossRestarts.db"
+	store, _ := NewStore("sqlite", path, false)
+	// The history is recorded before the endpoint is assigned an id
+	endpointWithoutID := endpoint.Endpoint{Name: "website", Group: "core"}
+	store.Insert(&endpointWithoutID, &testSuccessfulResult)
+	store.Insert(&endpointWithoutID, &testUnsuccessfulResult)
+	store.Close()
+	// The id is assigned, which ties the existing history to it
+	store, _ = NewStore("sqlite", path, false)
+	endpointWithID := endpoint.Endpoint{ID: "website", Name: "website", Group: "core"}
+	if renamed, err := store.RenameEndpointByID(&endpointWithID); err != nil || renamed {
+		t.Fatalf("expected nothing to be renamed when the id is first assigned, got renamed=%v and err=%v", renamed, err)
+	}
+	store.Close()
+	// The endpoint is renamed
+	store, _ = NewStore("sqlite", path, false)
+	defer store.Close()
+	renamedEndpoint := endpoint.Endpoint{ID: "website", Name: "homepage", Group: "frontend"}
+	if renamed, err := store.RenameEndpointByID(&renamedEndpoint); err != nil || !renamed {
+		t.Fatalf("expected the endpoint to be renamed, got renamed=%v and err=%v", renamed, err)
+	}
+	if removed := store.DeleteAllEndpointStatusesNotInKeys([]string{renamedEndpoint.Key()}); removed != 0 {
+		t.Errorf("expected no endpoint to be removed, got %d", removed)
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(renamedEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err != nil {
+		t.Fatal("expected the status to be available under the new key, got", err)
+	}
+	if endpointStatus.Name != "homepage" || endpointStatus.Group != "frontend" || len(endpointStatus.Results) != 2 {
+		t.Errorf("expected the history to survive the rename, got name=%s, group=%s and %d results", endpointStatus.Name, endpointStatus.Group, len(endpointStatus.Results))
+	}
+	// New results are added to the same history
+	store.Insert(&renamedEndpoint, &testSuccessfulResult)
+	if uptime, _ := store.GetUptimeByKey(renamedEndpoint.Key(), time.Now().Add(-time.Hour), time.Now()); uptime < 0.66 || uptime > 0.67 {
+		t.Errorf("expected the uptime to include the results from before the rename, got %f", uptime)
+	}
+}
+
+func TestStore_InsertWithAlerts(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithAlerts.db", false)
+	defer store.Close()
+	resultWithAlerts := testUnsuccessfulResult
+	resultWithAlerts.Alerts = []*endpoint.ResultAlert{
+		{Type: alert.TypeSlack, State: endpoint.ResultAlertTriggered},
+		{Type: alert.TypePagerDuty, State: endpoint.ResultAlertTriggered},
+	}
+	if err := store.Insert(&testEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := store.Insert(&testEndpoint, &resultWithAlerts); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(endpointStatus.Results))
+	}
+	if endpointStatus.Results[0].Alerts != nil {
+		t.Errorf("expected the first result to have no alerts, got %d", len(endpointStatus.Results[0].Alerts))
+	}
+	if len(endpointStatus.Results[1].Alerts) != len(resultWithAlerts.Alerts) {
+		t.Fatalf("expected the second result to have %d alerts, got %d", len(resultWithAlerts.Alerts), len(endpointStatus.Results[1].Alerts))
+	}
+	for i, expectedAlert := range resultWithAlerts.Alerts {
+		if *endpointStatus.Results[1].Alerts[i] != *expectedAlert {
+			t.Errorf("expected alert %+v, got %+v", *expectedAlert, *endpointStatus.Results[1].Alerts[i])
+		}
+	}
+}
+
+func TestStore_InsertWithDeduplication(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithDeduplication.db", false)
+	defer store.Close()
+	store.EnableDeduplication(50 * time.Millisecond)
+	start := time.Now().Truncate(time.Hour)
+	for i := 0; i < 10; i++ {
+		result := testSuccessfulResult
+		result.Timestamp = start.Add(time.Duration(i) * time.Minute)
+		result.Duration = time.Duration(130+i) * time.Millisecond
+		if err := store.Insert(&testEndpoint, &result); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	unsuccessfulResult := testUnsuccessfulResult
+	unsuccessfulResult.Timestamp = start.Add(10 * time.Minute)
+	if err := store.Insert(&testEndpoint, &unsuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 2 {
+		t.Fatalf("expected the 10 identical results to have been compressed into 1, got %d results", len(endpointStatus.Results))
+	}
+	if endpointStatus.Results[0].Count != 10 {
+		t.Errorf("expected the first result to have a count of 10, got %d", endpointStatus.Results[0].Count)
+	}
+	if !endpointStatus.Results[0].Timestamp.Equal(start.Add(9 * time.Minute)) {
+		t.Errorf("expected the timestamp of the first result to be the timestamp of the last identical result, got %s", endpointStatus.Results[0].Timestamp)
+	}
+	if len(endpointStatus.Results[0].ConditionResults) != len(testSuccessfulResult.ConditionResults) {
+		t.Errorf("expected the condition results of the first result to have been kept, got %d", len(endpointStatus.Results[0].ConditionResults))
+	}
+	if endpointStatus.Results[1].Count != 0 {
+		t.Errorf("expected the second result not to have a count, got %d", endpointStatus.Results[1].Count)
+	}
+	if uptime, _ := store.GetUptimeByKey(testEndpoint.Key(), start, start.Add(time.Hour)); uptime != 10.0/11.0 {
+		t.Errorf("expected every result to have been accounted for in the uptime, got %f", uptime)
+	}
+	// A result that failed for a different reason must not be merged into the previous one
+	otherUnsuccessfulResult := testUnsuccessfulResult
+	otherUnsuccessfulResult.Timestamp = start.Add(11 * time.Minute)
+	otherUnsuccessfulResult.ConditionResults = []*endpoint.ConditionResult{
+		{Condition: "[STATUS] == 200", Success: true},
+		{Condition: "[RESPONSE_TIME] < 500", Success: false},
+		{Condition: "[CERTIFICATE_EXPIRATION] < 72h", Success: true},
+	}
+	if err := store.Insert(&testEndpoint, &otherUnsuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, _ = store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if len(endpointStatus.Results) != 3 {
+		t.Errorf("expected results with different condition outcomes not to have been compressed, got %d results", len(endpointStatus.Results))
+	}
+}
+
 func TestStore_Save(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_Save.db", false)
 	defer store.Close()
@@ -418,6 +752,17 @@ func TestStore_Save(t *testing.T) {
 	}
 }
 
+func TestStore_Ping(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_Ping.db", false)
+	if err := store.Ping(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	store.Close()
+	if err := store.Ping(); err == nil {
+		t.Fatal("expected an error, because the store was closed")
+	}
+}
+
 // Note that are much more extensive tests in /storage/store/store_test.go.
 // This test is simply an extra sanity check
 func TestStore_SanityCheck(t *testing.T) {
@@ -729,7 +1074,6 @@ func TestTriggeredEndpointAlertsPersistence(t *testing.T) {
 	defer store.Close()
 	yes, desc := false, "description"
 	ep := testEndpoint
-	ep.NumberOfSuccessesInARow = 0
 	alrt := &alert.Alert{
 		Type:             alert.TypePagerDuty,
 		Enabled:          &yes,
@@ -754,11 +1098,11 @@ func TestTriggeredEndpointAlertsPersistence(t *testing.T) {
 	if resolveKey != alrt.ResolveKey {
 		t.Errorf("expected resolveKey %s, got %s", alrt.ResolveKey, resolveKey)
 	}
-	if numberOfSuccessesInARow != ep.NumberOfSuccessesInARow {
+	if numberOfSuccessesInARow != alrt.NumberOfSuccessesInARow {
 		t.Errorf("expected persisted NumberOfSuccessesInARow to be %d, got %d", ep.NumberOfSuccessesInARow, numberOfSuccessesInARow)
 	}
-	// Endpoint just had a successful evaluation, so NumberOfSuccessesInARow is now 1
-	ep.NumberOfSuccessesInARow++
+	// Endpoint just had a successful evaluation, so the alert's NumberOfSuccessesInARow is now 1
+	alrt.NumberOfSuccessesInARow++
 	if err := store.UpsertTriggeredEndpointAlert(&ep, alrt); err != nil {
 		t.Fatal("expected no error, got", err.Error())
 	}
@@ -772,12 +1116,12 @@ func TestTriggeredEndpointAlertsPersistence(t *testing.T) {
 	if resolveKey != alrt.ResolveKey {
 		t.Errorf("expected resolveKey %s, got %s", alrt.ResolveKey, resolveKey)
 	}
-	if numberOfSuccessesInARow != ep.NumberOfSuccessesInARow {
+	if numberOfSuccessesInARow != alrt.NumberOfSuccessesInARow {
 		t.Errorf("expected persisted NumberOfSuccessesInARow to be %d, got %d", ep.NumberOfSuccessesInARow, numberOfSuccessesInARow)
 	}
 	// Simulate the endpoint having another successful evaluation, which means the alert is now resolved,
 	// and we should delete the triggered alert from the store
-	ep.NumberOfSuccessesInARow++
+	alrt.NumberOfSuccessesInARow++
 	if err := store.DeleteTriggeredEndpointAlert(&ep, alrt); err != nil {
 		t.Fatal("expected no error, got", err.Error())
 	}
@@ -851,3 +1195,29 @@ func TestStore_DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(t *testing.T) {
 		t.Error("expected alert3 to exist for ep2")
 	}
 }
+
+func TestStore_RuntimeStateAcrossRestarts(t *testing.T) {
+	path := t.TempDir() + "/TestStore_RuntimeStateAcrossRestarts.db"
+	store, _ := NewStore("sqlite", path, false)
+	start := time.Now().Truncate(time.Second)
+	err := store.SaveRuntimeState(&state.RuntimeState{
+		Muted:              true,
+		MaintenanceWindows: []*maintenance.Window{{ID: "upgrade", Group: "core", Start: start, End: start.Add(time.Hour)}},
+	})
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	store.Close()
+	store, _ = NewStore("sqlite", path, false)
+	defer store.Close()
+	runtimeState, err := store.GetRuntimeState()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if runtimeState == nil || !runtimeState.Muted || len(runtimeState.MaintenanceWindows) != 1 {
+		t.Fatalf("expected the runtime state to survive the restart, got %+v", runtimeState)
+	}
+	if window := runtimeState.MaintenanceWindows[0]; window.ID != "upgrade" || window.Group != "core" || !window.End.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected the maintenance window to survive the restart, got %+v", window)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/state"
 	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gatus/v5/storage/store/memory"
@@ -34,9 +35,26 @@ type Store interface {
 	// GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
 	GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (map[int64]int, error)
 
+	// SearchResultsByKey returns the results of an endpoint that match the search parameters, from newest to oldest
+	SearchResultsByKey(key string, params *paging.ResultSearchParams) ([]*endpoint.Result, error)
+
 	// Insert adds the observed result for the specified endpoint into the store
 	Insert(ep *endpoint.Endpoint, result *endpoint.Result) error
 
+	// RenameEndpointByID associates the ID of the endpoint with its key. If the ID was previously associated with
+	// another key, the history stored under the previous key is moved to the current key.
+	//
+	// Used to preserve the history of endpoints that have an ID across changes to their name or group.
+	RenameEndpointByID(ep *endpoint.Endpoint) (bool, error)
+
+	// GetRuntimeState returns the runtime state that was last saved, or nil if no runtime state has ever been saved
+	GetRuntimeState() (*state.RuntimeState, error)
+
+	// SaveRuntimeState persists the runtime state, replacing the runtime state that was previously saved, if any
+	//
+	// Used to preserve the state created at runtime, such as maintenance windows, across restarts
+	SaveRuntimeState(runtimeState *state.RuntimeState) error
+
 	// DeleteAllEndpointStatusesNotInKeys removes all Status that are not within the keys provided
 	//
 	// Used to delete endpoints that have been persisted but are no longer part of the configured endpoints
@@ -63,13 +81,14 @@ type Store interface {
 	// Save persists the data if and where it needs to be persisted
 	Save() error
 
+	// Ping checks whether the store is reachable
+	Ping() error
+
 	// Close terminates every connection and closes the store, if applicable.
 	// Should only be used before stopping the application.
 	Close()
 }
 
-// TODO: add method to check state of store (by keeping track of silent errors)
-
 var (
 	// Validate interface implementation on compile
 	_ Store = (*memory.Store)(nil)
@@ -119,18 +138,49 @@ func Initialize(cfg *storage.Config) error {
 	ctx, cancelFunc = context.WithCancel(context.Background())
 	switch cfg.Type {
 	case storage.TypeSQLite, storage.TypePostgres:
-		store, err = sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching)
-		if err != nil {
+		var sqlStore *sql.Store
+		if sqlStore, err = sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching); err != nil {
 			return err
 		}
+		if cfg.BatchSize > 0 {
+			sqlStore.EnableBatching(cfg.BatchSize, cfg.BatchInterval)
+		}
+		if cfg.DeduplicateResults {
+			sqlStore.EnableDeduplication(cfg.DeduplicationBucket)
+		}
+		if cfg.ExcludeMaintenanceFromUptime {
+			sqlStore.EnableMaintenanceExclusion()
+		}
+		store = sqlStore
 	case storage.TypeMemory:
 		fallthrough
 	default:
-		store, _ = memory.NewStore()
+		memoryStore, _ := memory.NewStore()
+		if cfg.DeduplicateResults {
+			memoryStore.EnableDeduplication(cfg.DeduplicationBucket)
+		}
+		if cfg.ExcludeMaintenanceFromUptime {
+			memoryStore.EnableMaintenanceExclusion()
+		}
+		store = memoryStore
 	}
 	return nil
 }
 
+// EnableMetrics makes the storage provider publish metrics about the duration and the errors of its operations.
+// Must be called after Initialize.
+func EnableMetrics() {
+	if _, isInstrumented := store.(*instrumentedStore); isInstrumented {
+		return
+	}
+	instrumented := &instrumentedStore{Store: store}
+	if sqlStore, ok := store.(*sql.Store); ok && sqlStore.IsBatching() {
+		sqlStore.EnableMetrics()
+		instrumented.isInsertBatched = true
+	}
+	store = instrumented
+}
+
 // autoSave automatically calls the Save function of the provider at every interval
 func autoSave(ctx context.Context, store Store, interval time.Duration) {
 	for {
@@ -2,11 +2,14 @@ package store
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/state"
 	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
@@ -363,6 +366,119 @@ func TestStore_GetUptimeByKey(t *testing.T) {
 	}
 }
 
+func TestStore_GetUptimeByKeyWithMaintenanceExclusion(t *testing.T) {
+	firstResult := testSuccessfulResult
+	firstResult.Timestamp = now.Add(-3 * time.Minute)
+	resultDuringMaintenance := testUnsuccessfulResult
+	resultDuringMaintenance.Timestamp = now.Add(-2 * time.Minute)
+	resultDuringMaintenance.Maintenance = true
+	lastResult := testSuccessfulResult
+	lastResult.Timestamp = now.Add(-time.Minute)
+	for _, excludeMaintenanceFromUptime := range []bool{false, true} {
+		scenarios := initStoresAndBaseScenarios(t, fmt.Sprintf("TestStore_GetUptimeByKeyWithMaintenanceExclusion-%v", excludeMaintenanceFromUptime))
+		for _, scenario := range scenarios {
+			t.Run(fmt.Sprintf("%s-exclude-maintenance-%v", scenario.Name, excludeMaintenanceFromUptime), func(t *testing.T) {
+				if excludeMaintenanceFromUptime {
+					scenario.Store.(interface{ EnableMaintenanceExclusion() }).EnableMaintenanceExclusion()
+				}
+				scenario.Store.Insert(&testEndpoint, &firstResult)
+				scenario.Store.Insert(&testEndpoint, &resultDuringMaintenance)
+				scenario.Store.Insert(&testEndpoint, &lastResult)
+				expectedUptime := 2.0 / 3.0
+				if excludeMaintenanceFromUptime {
+					expectedUptime = 1
+				}
+				if uptime, _ := scenario.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour), time.Now()); uptime != expectedUptime {
+					t.Errorf("the uptime over the past 1h should've been %f, got %f", expectedUptime, uptime)
+				}
+				// The result recorded during the maintenance window must be stored regardless
+				endpointStatus, err := scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 10))
+				if err != nil {
+					t.Fatal("expected no error, got", err.Error())
+				}
+				if len(endpointStatus.Results) != 3 || !endpointStatus.Results[1].Maintenance || endpointStatus.Results[0].Maintenance {
+					t.Error("expected the result recorded during the maintenance window to be stored and flagged as such")
+				}
+			})
+		}
+		cleanUp(scenarios)
+	}
+}
+
+func TestStore_SearchResultsByKey(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_SearchResultsByKey")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if _, err := scenario.Store.SearchResultsByKey(testEndpoint.Key(), paging.NewResultSearchParams().WithResults(1, 20)); err != common.ErrEndpointNotFound {
+				t.Errorf("should've returned not found because there's nothing yet, got %v", err)
+			}
+			// Seed the history with 6 results, alternating between successful and unsuccessful results
+			for i := 0; i < 6; i++ {
+				result := testSuccessfulResult
+				if i%2 == 1 {
+					result = testUnsuccessfulResult
+				}
+				result.Timestamp = now.Add(time.Duration(i-6) * time.Minute)
+				scenario.Store.Insert(&testEndpoint, &result)
+			}
+			searchScenarios := []struct {
+				Name               string
+				Params             *paging.ResultSearchParams
+				ExpectedTimestamps []time.Time
+			}{
+				{
+					Name:               "condition-only-failures",
+					Params:             paging.NewResultSearchParams().WithCondition("[RESPONSE_TIME]", true).WithResults(1, 20),
+					ExpectedTimestamps: []time.Time{now.Add(-time.Minute), now.Add(-3 * time.Minute), now.Add(-5 * time.Minute)},
+				},
+				{
+					Name:               "condition-only-failures-page-2",
+					Params:             paging.NewResultSearchParams().WithCondition("[RESPONSE_TIME]", true).WithResults(2, 2),
+					ExpectedTimestamps: []time.Time{now.Add(-5 * time.Minute)},
+				},
+				{
+					Name:               "condition-that-never-failed",
+					Params:             paging.NewResultSearchParams().WithCondition("[STATUS] == 200", true).WithResults(1, 20),
+					ExpectedTimestamps: []time.Time{},
+				},
+				{
+					Name:               "condition-including-successes",
+					Params:             paging.NewResultSearchParams().WithCondition("[STATUS] == 200", false).WithResults(1, 3),
+					ExpectedTimestamps: []time.Time{now.Add(-time.Minute), now.Add(-2 * time.Minute), now.Add(-3 * time.Minute)},
+				},
+				{
+					Name:               "unknown-condition",
+					Params:             paging.NewResultSearchParams().WithCondition("[BODY]", false).WithResults(1, 20),
+					ExpectedTimestamps: []time.Time{},
+				},
+				{
+					Name:               "no-condition-only-failures",
+					Params:             paging.NewResultSearchParams().WithCondition("", true).WithResults(1, 2),
+					ExpectedTimestamps: []time.Time{now.Add(-time.Minute), now.Add(-3 * time.Minute)},
+				},
+			}
+			for _, searchScenario := range searchScenarios {
+				results, err := scenario.Store.SearchResultsByKey(testEndpoint.Key(), searchScenario.Params)
+				if err != nil {
+					t.Fatalf("[%s] expected no error, got %v", searchScenario.Name, err)
+				}
+				if len(results) != len(searchScenario.ExpectedTimestamps) {
+					t.Fatalf("[%s] expected %d results, got %d", searchScenario.Name, len(searchScenario.ExpectedTimestamps), len(results))
+				}
+				for i, result := range results {
+					if !result.Timestamp.Equal(searchScenario.ExpectedTimestamps[i]) {
+						t.Errorf("[%s] expected result #%d to have timestamp %s, got %s", searchScenario.Name, i, searchScenario.ExpectedTimestamps[i], result.Timestamp)
+					}
+					if len(result.ConditionResults) != 3 {
+						t.Errorf("[%s] expected result #%d to have its 3 condition results, got %d", searchScenario.Name, i, len(result.ConditionResults))
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestStore_GetAverageResponseTimeByKey(t *testing.T) {
 	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetAverageResponseTimeByKey")
 	defer cleanUp(scenarios)
@@ -570,6 +686,47 @@ func TestStore_DeleteAllEndpointStatusesNotInKeys(t *testing.T) {
 	}
 }
 
+func TestStore_RenameEndpointByID(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_RenameEndpointByID")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			originalEndpoint := endpoint.Endpoint{ID: "website", Name: "website", Group: "core"}
+			renamedEndpoint := endpoint.Endpoint{ID: "website", Name: "homepage", Group: "frontend"}
+			if renamed, err := scenario.Store.RenameEndpointByID(&originalEndpoint); err != nil || renamed {
+				t.Fatalf("expected nothing to be renamed when the id is first seen, got renamed=%v and err=%v", renamed, err)
+			}
+			scenario.Store.Insert(&originalEndpoint, &testSuccessfulResult)
+			scenario.Store.Insert(&originalEndpoint, &testUnsuccessfulResult)
+			if renamed, err := scenario.Store.RenameEndpointByID(&originalEndpoint); err != nil || renamed {
+				t.Fatalf("expected nothing to be renamed when the key didn't change, got renamed=%v and err=%v", renamed, err)
+			}
+			if renamed, err := scenario.Store.RenameEndpointByID(&renamedEndpoint); err != nil || !renamed {
+				t.Fatalf("expected the endpoint to be renamed, got renamed=%v and err=%v", renamed, err)
+			}
+			if _, err := scenario.Store.GetEndpointStatusByKey(originalEndpoint.Key(), paging.NewEndpointStatusParams()); err != common.ErrEndpointNotFound {
+				t.Errorf("expected the status under the previous key to be gone, got %v", err)
+			}
+			endpointStatus, err := scenario.Store.GetEndpointStatusByKey(renamedEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+			if err != nil {
+				t.Fatal("expected the status to be available under the new key, got", err)
+			}
+			if endpointStatus.Name != renamedEndpoint.Name || endpointStatus.Group != renamedEndpoint.Group || endpointStatus.Key != renamedEndpoint.Key() {
+				t.Errorf("expected the status to have the new name, group and key, got %s, %s and %s", endpointStatus.Name, endpointStatus.Group, endpointStatus.Key)
+			}
+			if len(endpointStatus.Results) != 2 {
+				t.Errorf("expected the history to survive the rename, got %d results", len(endpointStatus.Results))
+			}
+			if uptime, _ := scenario.Store.GetUptimeByKey(renamedEndpoint.Key(), now.Add(-time.Hour), time.Now()); uptime != 0.5 {
+				t.Errorf("expected the uptime to survive the rename, got %f", uptime)
+			}
+			if renamed, err := scenario.Store.RenameEndpointByID(&endpoint.Endpoint{Name: "no-id"}); err != nil || renamed {
+				t.Errorf("expected endpoints without an id to be ignored, got renamed=%v and err=%v", renamed, err)
+			}
+		})
+	}
+}
+
 func TestGet(t *testing.T) {
 	store := Get()
 	if store == nil {
@@ -651,3 +808,49 @@ func TestAutoSave(t *testing.T) {
 	cancelFunc()
 	time.Sleep(50 * time.Millisecond)
 }
+
+func TestStore_RuntimeState(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_RuntimeState")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if runtimeState, err := scenario.Store.GetRuntimeState(); err != nil || runtimeState != nil {
+				t.Fatalf("expected no runtime state before one is saved, got %v and err=%v", runtimeState, err)
+			}
+			savedRuntimeState := &state.RuntimeState{
+				Muted: true,
+				MaintenanceWindows: []*maintenance.Window{
+					{ID: "a", Group: "core", Start: now, End: now.Add(time.Hour)},
+					{ID: "b", Start: now, End: now.Add(2 * time.Hour)},
+				},
+			}
+			if err := scenario.Store.SaveRuntimeState(savedRuntimeState); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			// Saving again must replace the runtime state rather than fail
+			if err := scenario.Store.SaveRuntimeState(savedRuntimeState); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			runtimeState, err := scenario.Store.GetRuntimeState()
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if !runtimeState.Muted || len(runtimeState.MaintenanceWindows) != 2 {
+				t.Fatalf("expected muted runtime state with 2 maintenance windows, got muted=%v and %d maintenance windows", runtimeState.Muted, len(runtimeState.MaintenanceWindows))
+			}
+			if window := runtimeState.MaintenanceWindows[0]; window.ID != "a" || window.Group != "core" || !window.Start.Equal(now) || !window.End.Equal(now.Add(time.Hour)) {
+				t.Errorf("expected the first maintenance window to have been round-tripped, got %+v", window)
+			}
+			if err = scenario.Store.SaveRuntimeState(&state.RuntimeState{}); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if runtimeState, _ = scenario.Store.GetRuntimeState(); runtimeState.Muted || len(runtimeState.MaintenanceWindows) != 0 {
+				t.Errorf("expected the runtime state to have been replaced, got muted=%v and %d maintenance windows", runtimeState.Muted, len(runtimeState.MaintenanceWindows))
+			}
+			scenario.Store.Clear()
+			if runtimeState, _ = scenario.Store.GetRuntimeState(); runtimeState != nil {
+				t.Error("expected the runtime state to have been cleared")
+			}
+		})
+	}
+}
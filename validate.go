@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/TwiN/gatus/v5/config"
+)
+
+const (
+	// OutputText is the output format of the --validate flag meant to be read by humans
+	OutputText = "text"
+
+	// OutputJSON is the output format of the --validate flag meant to be parsed by other tools (e.g. CI pipelines)
+	OutputJSON = "json"
+)
+
+// ErrInvalidOutput is the error returned when the output format passed to the --output flag is not supported
+var ErrInvalidOutput = errors.New("invalid output: must be one of " + OutputText + " or " + OutputJSON)
+
+// validationReport is what's written by the --validate flag when the output is OutputJSON
+type validationReport struct {
+	Valid    bool              `json:"valid"`
+	Problems []config.Problem  `json:"problems"`
+	Summary  validationSummary `json:"summary"`
+}
+
+type validationSummary struct {
+	Endpoints         int `json:"endpoints"`
+	ExternalEndpoints int `json:"externalEndpoints"`
+	Problems          int `json:"problems"`
+}
+
+// validate validates the configuration at configPath, writes every problem found to w in the given output format and
+// returns whether the configuration is valid.
+//
+// This is used by the --validate flag, which allows CI pipelines to check a configuration before deploying it.
+func validate(configPath, output string, w io.Writer) (bool, error) {
+	if output != OutputText && output != OutputJSON {
+		return false, ErrInvalidOutput
+	}
+	result := config.ValidateConfiguration(configPath)
+	if output == OutputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return result.IsValid(), encoder.Encode(validationReport{
+			Valid:    result.IsValid(),
+			Problems: result.Problems,
+			Summary: validationSummary{
+				Endpoints:         result.NumberOfEndpoints,
+				ExternalEndpoints: result.NumberOfExternalEndpoints,
+				Problems:          len(result.Problems),
+			},
+		})
+	}
+	for _, problem := range result.Problems {
+		location := problem.Endpoint
+		if len(problem.Field) > 0 {
+			if len(location) > 0 {
+				location += "."
+			}
+			location += problem.Field
+		}
+		if len(location) > 0 {
+			location += ": "
+		}
+		if _, err := fmt.Fprintf(w, "%s%s\n", location, problem.Message); err != nil {
+			return false, err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d problem(s) found in %d endpoint(s) and %d external endpoint(s)\n", len(result.Problems), result.NumberOfEndpoints, result.NumberOfExternalEndpoints)
+	return result.IsValid(), err
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const configWithMultipleProblems = `
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+  - name: no-url
+    conditions:
+      - "[STATUS] == 200"
+  - name: negative-retries
+    group: core
+    url: https://twin.sh/health
+    retries: -1
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - name: no-token
+`
+
+func TestValidate(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configWithMultipleProblems), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("json", func(t *testing.T) {
+		var output bytes.Buffer
+		valid, err := validate(configPath, OutputJSON, &output)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if valid {
+			t.Error("expected the configuration to be invalid")
+		}
+		var report map[string]interface{}
+		if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+			t.Fatal("expected the output to be valid JSON, got error:", err.Error())
+		}
+		expectedReport := map[string]interface{}{
+			"valid": false,
+			"problems": []interface{}{
+				map[string]interface{}{"endpoint": "_no-url", "field": "url", "message": "you must specify an url for each endpoint"},
+				map[string]interface{}{"endpoint": "core_negative-retries", "field": "retries", "message": "invalid retries: must be greater than or equal to 0"},
+				map[string]interface{}{"endpoint": "_no-token", "field": "token", "message": "you must specify a token for each external endpoint"},
+			},
+			"summary": map[string]interface{}{"endpoints": float64(3), "externalEndpoints": float64(1), "problems": float64(3)},
+		}
+		if !reflect.DeepEqual(report, expectedReport) {
+			t.Errorf("expected:\n%v\ngot:\n%v", expectedReport, report)
+		}
+	})
+	t.Run("text", func(t *testing.T) {
+		var output bytes.Buffer
+		valid, err := validate(configPath, OutputText, &output)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if valid {
+			t.Error("expected the configuration to be invalid")
+		}
+		expectedOutput := strings.Join([]string{
+			"_no-url.url: you must specify an url for each endpoint",
+			"core_negative-retries.retries: invalid retries: must be greater than or equal to 0",
+			"_no-token.token: you must specify a token for each external endpoint",
+			"3 problem(s) found in 3 endpoint(s) and 1 external endpoint(s)",
+		}, "\n") + "\n"
+		if output.String() != expectedOutput {
+			t.Errorf("expected:\n%s\ngot:\n%s", expectedOutput, output.String())
+		}
+	})
+	t.Run("invalid-output", func(t *testing.T) {
+		if _, err := validate(configPath, "xml", &bytes.Buffer{}); !errors.Is(err, ErrInvalidOutput) {
+			t.Errorf("expected error %v, got %v", ErrInvalidOutput, err)
+		}
+	})
+}
+
+func TestValidate_withValidConfiguration(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("endpoints:\n  - name: website\n    url: https://twin.sh/health\n    conditions:\n      - \"[STATUS] == 200\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	valid, err := validate(configPath, OutputJSON, &output)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !valid {
+		t.Error("expected the configuration to be valid")
+	}
+	if !strings.Contains(output.String(), `"problems": []`) {
+		t.Errorf("expected problems to be an empty array, got %s", output.String())
+	}
+}
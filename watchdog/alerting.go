@@ -1,33 +1,105 @@
 package watchdog
 
 import (
+	"context"
 	"errors"
 	"log"
 	"os"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
 // HandleAlerting takes care of alerts to resolve and alerts to trigger based on result success or failure
 func HandleAlerting(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
+	handleAlerting(context.Background(), ep, result, alertingConfig, debug)
+}
+
+// handleAlerting is HandleAlerting with a context that, once done, interrupts the retries of the alerts being sent
+func handleAlerting(ctx context.Context, ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
 	if alertingConfig == nil {
 		return
 	}
+	history := loadSuccessHistory(ep, result)
 	if result.Success {
-		handleAlertsToResolve(ep, result, alertingConfig, debug)
+		handleAlertsToResolve(ctx, ep, result, history, alertingConfig, debug)
 	} else {
-		handleAlertsToTrigger(ep, result, alertingConfig, debug)
+		handleAlertsToTrigger(ctx, ep, result, history, alertingConfig, debug)
+	}
+}
+
+// loadSuccessHistory returns whether each of the most recent results of the endpoint was successful, ordered from the
+// oldest to the newest, and ending with the result being handled, which hasn't been persisted yet.
+//
+// The storage is only queried if one of the alerts of the endpoint relies on a success ratio, and only for as many
+// results as the largest window requires.
+func loadSuccessHistory(ep *endpoint.Endpoint, result *endpoint.Result) []bool {
+	window := 0
+	for _, endpointAlert := range ep.Alerts {
+		if endpointAlert.SuccessRatio != nil && endpointAlert.SuccessRatio.Window > window {
+			window = endpointAlert.SuccessRatio.Window
+		}
+	}
+	if window == 0 {
+		return nil
+	}
+	history := make([]bool, 0, window)
+	if window > 1 {
+		endpointStatus, err := store.Get().GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams().WithResults(1, window-1))
+		if err != nil && !errors.Is(err, common.ErrEndpointNotFound) {
+			log.Printf("[watchdog.loadSuccessHistory] Failed to retrieve results of endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+		if endpointStatus != nil {
+			for _, previousResult := range endpointStatus.Results {
+				// A deduplicated result stands for Count identical results
+				for i := 0; i < max(previousResult.Count, 1); i++ {
+					history = append(history, previousResult.Success)
+				}
+			}
+		}
+		// Only the most recent results fit in the window
+		if len(history) > window-1 {
+			history = history[len(history)-(window-1):]
+		}
 	}
+	return append(history, result.Success)
 }
 
-func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
+// hasReachedFailureThreshold returns whether the alert should be triggered, which is when its failure streak reached
+// its failure threshold or, if it relies on a success ratio, when the ratio dropped below its minimum
+func hasReachedFailureThreshold(endpointAlert *alert.Alert, history []bool) bool {
+	if endpointAlert.SuccessRatio != nil {
+		isMet, isWindowFull := endpointAlert.SuccessRatio.Evaluate(history)
+		return isWindowFull && !isMet
+	}
+	return endpointAlert.NumberOfFailuresInARow >= endpointAlert.FailureThreshold
+}
+
+// hasReachedSuccessThreshold returns whether the alert may be resolved, which is when its success streak reached its
+// success threshold or, if it relies on a success ratio, when the ratio is back to its minimum or above
+func hasReachedSuccessThreshold(endpointAlert *alert.Alert, history []bool) bool {
+	if endpointAlert.SuccessRatio != nil {
+		isMet, isWindowFull := endpointAlert.SuccessRatio.Evaluate(history)
+		return isWindowFull && isMet
+	}
+	return endpointAlert.NumberOfSuccessesInARow >= endpointAlert.SuccessThreshold
+}
+
+func handleAlertsToTrigger(ctx context.Context, ep *endpoint.Endpoint, result *endpoint.Result, history []bool, alertingConfig *alerting.Config, debug bool) {
 	ep.NumberOfSuccessesInARow = 0
 	ep.NumberOfFailuresInARow++
+	hasFailingDependency := ep.HasFailingDependency()
 	for _, endpointAlert := range ep.Alerts {
+		endpointAlert.NumberOfSuccessesInARow = 0
+		endpointAlert.RecoveryStartedAt = time.Time{}
+		endpointAlert.NumberOfFailuresInARow++
 		// If the alert hasn't been triggered, move to the next one
-		if !endpointAlert.IsEnabled() || endpointAlert.FailureThreshold > ep.NumberOfFailuresInARow {
+		if !endpointAlert.IsEnabled() || !hasReachedFailureThreshold(endpointAlert, history) {
 			continue
 		}
 		if endpointAlert.Triggered {
@@ -36,6 +108,20 @@ func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alert
 			}
 			continue
 		}
+		if hasFailingDependency {
+			// The failure is most likely caused by the upstream endpoint, which has alerts of its own. The alert isn't
+			// marked as triggered, so that it's sent if the endpoint is still failing once its dependencies recover.
+			if debug {
+				log.Printf("[watchdog.handleAlertsToTrigger] Not sending %s alert for endpoint=%s with description='%s' despite reaching its threshold, because one of its dependencies is failing", endpointAlert.Type, ep.Name, endpointAlert.GetDescription())
+			}
+			continue
+		}
+		if alerting.IsMuted() {
+			// The alert isn't marked as triggered, so that it's sent if the endpoint is still failing once alerts are
+			// unmuted, and so that no resolution is ever sent for an incident nobody was alerted about
+			log.Printf("[watchdog.handleAlertsToTrigger] Not sending %s alert for endpoint=%s with description='%s' despite reaching its threshold, because alerts are muted", endpointAlert.Type, ep.Name, endpointAlert.GetDescription())
+			continue
+		}
 		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
 		if alertProvider != nil {
 			log.Printf("[watchdog.handleAlertsToTrigger] Sending %s alert because alert for endpoint=%s with description='%s' has been TRIGGERED", endpointAlert.Type, ep.Name, endpointAlert.GetDescription())
@@ -45,12 +131,15 @@ func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alert
 					err = errors.New("error")
 				}
 			} else {
-				err = alertProvider.Send(ep, endpointAlert, result, false)
+				err = alertProvider.SendWithRetry(ctx, func() error {
+					return alertProvider.Send(ep, endpointAlert, result, false)
+				})
 			}
 			if err != nil {
 				log.Printf("[watchdog.handleAlertsToTrigger] Failed to send an alert for endpoint=%s: %s", ep.Name, err.Error())
 			} else {
 				endpointAlert.Triggered = true
+				result.AddAlert(endpointAlert.Type, endpoint.ResultAlertTriggered)
 				if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
 					log.Printf("[watchdog.handleAlertsToTrigger] Failed to persist triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
 				}
@@ -61,17 +150,26 @@ func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alert
 	}
 }
 
-func handleAlertsToResolve(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
+func handleAlertsToResolve(ctx context.Context, ep *endpoint.Endpoint, result *endpoint.Result, history []bool, alertingConfig *alerting.Config, debug bool) {
 	ep.NumberOfSuccessesInARow++
 	for _, endpointAlert := range ep.Alerts {
-		isStillBelowSuccessThreshold := endpointAlert.SuccessThreshold > ep.NumberOfSuccessesInARow
-		if isStillBelowSuccessThreshold && endpointAlert.IsEnabled() && endpointAlert.Triggered {
+		endpointAlert.NumberOfFailuresInARow = 0
+		endpointAlert.NumberOfSuccessesInARow++
+		if endpointAlert.RecoveryStartedAt.IsZero() {
+			endpointAlert.RecoveryStartedAt = result.Timestamp
+		}
+		isStillBelowSuccessThreshold := !hasReachedSuccessThreshold(endpointAlert, history)
+		isStillStabilizing := result.Timestamp.Sub(endpointAlert.RecoveryStartedAt) < endpointAlert.StabilizationWindow
+		if isStillStabilizing && !isStillBelowSuccessThreshold && debug && endpointAlert.Triggered {
+			log.Printf("[watchdog.handleAlertsToResolve] Not resolving alert for endpoint with key=%s with description='%s' yet, because it has only been healthy for %s out of %s", ep.Key(), endpointAlert.GetDescription(), result.Timestamp.Sub(endpointAlert.RecoveryStartedAt), endpointAlert.StabilizationWindow)
+		}
+		if (isStillBelowSuccessThreshold || isStillStabilizing) && endpointAlert.IsEnabled() && endpointAlert.Triggered {
 			// Persist NumberOfSuccessesInARow
 			if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
 				log.Printf("[watchdog.handleAlertsToResolve] Failed to update triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
 			}
 		}
-		if !endpointAlert.IsEnabled() || !endpointAlert.Triggered || isStillBelowSuccessThreshold {
+		if !endpointAlert.IsEnabled() || !endpointAlert.Triggered || isStillBelowSuccessThreshold || isStillStabilizing {
 			continue
 		}
 		// Even if the alert provider returns an error, we still set the alert's Triggered variable to false.
@@ -83,12 +181,26 @@ func handleAlertsToResolve(ep *endpoint.Endpoint, result *endpoint.Result, alert
 		if !endpointAlert.IsSendingOnResolved() {
 			continue
 		}
+		if alerting.IsMuted() {
+			log.Printf("[watchdog.handleAlertsToResolve] Not sending %s alert for endpoint with key=%s with description='%s' despite being RESOLVED, because alerts are muted", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
+			continue
+		}
 		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
+		if alertProvider != nil && !alertProvider.IsSendingOnResolved() {
+			if debug {
+				log.Printf("[watchdog.handleAlertsToResolve] Not sending %s alert for endpoint with key=%s despite being RESOLVED, because the provider has send-on-resolved disabled", endpointAlert.Type, ep.Key())
+			}
+			continue
+		}
 		if alertProvider != nil {
 			log.Printf("[watchdog.handleAlertsToResolve] Sending %s alert because alert for endpoint with key=%s with description='%s' has been RESOLVED", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
-			err := alertProvider.Send(ep, endpointAlert, result, true)
+			err := alertProvider.SendWithRetry(ctx, func() error {
+				return alertProvider.Send(ep, endpointAlert, result, true)
+			})
 			if err != nil {
 				log.Printf("[watchdog.handleAlertsToResolve] Failed to send an alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+			} else {
+				result.AddAlert(endpointAlert.Type, endpoint.ResultAlertResolved)
 			}
 		} else {
 			log.Printf("[watchdog.handleAlertsToResolve] Not sending alert of type=%s despite being RESOLVED, because the provider wasn't configured properly", endpointAlert.Type)
@@ -1,14 +1,22 @@
 package watchdog
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
+	"github.com/TwiN/gatus/v5/alerting/provider/homeassistant"
 	"github.com/TwiN/gatus/v5/alerting/provider/jetbrainsspace"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
@@ -19,8 +27,10 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
+	"github.com/TwiN/gatus/v5/alerting/provider/webex"
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
 )
 
 func TestHandleAlerting(t *testing.T) {
@@ -385,6 +395,27 @@ func TestHandleAlertingWithProviderThatReturnsAnError(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:      "homeassistant",
+			AlertType: alert.TypeHomeAssistant,
+			AlertingConfig: &alerting.Config{
+				HomeAssistant: &homeassistant.AlertProvider{
+					URL:     "https://example.com",
+					Token:   "1",
+					Service: "2",
+				},
+			},
+		},
+		{
+			Name:      "webex",
+			AlertType: alert.TypeWebex,
+			AlertingConfig: &alerting.Config{
+				Webex: &webex.AlertProvider{
+					BotToken: "1",
+					RoomID:   "2",
+				},
+			},
+		},
 	}
 
 	for _, scenario := range scenarios {
@@ -487,6 +518,418 @@ func TestHandleAlertingWithProviderThatOnlyReturnsErrorOnResolve(t *testing.T) {
 	verify(t, ep, 0, 2, false, "")
 }
 
+func TestHandleAlertingWithFailureAndSuccessCountInMessages(t *testing.T) {
+	var descriptions []string
+	numberOfRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfRequests++
+		if numberOfRequests == 1 {
+			// Fail the first request so that the alert is only sent on the next failed evaluation
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload discord.Body
+		if err := json.Unmarshal(body, &payload); err != nil || len(payload.Embeds) == 0 {
+			t.Errorf("unexpected request body: %s", body)
+		} else {
+			descriptions = append(descriptions, payload.Embeds[0].Description)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Discord: &discord.AlertProvider{
+				WebhookURL: server.URL,
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name: "endpoint-name",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeDiscord,
+				Enabled:          &enabled,
+				FailureThreshold: 3,
+				SuccessThreshold: 2,
+				SendOnResolved:   &enabled,
+				TriggerMessage:   "[ENDPOINT_NAME] failed [FAILURE_COUNT] times in a row",
+				ResolveMessage:   "[ENDPOINT_NAME] succeeded [SUCCESS_COUNT] times in a row",
+			},
+		},
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 3, 0, false, "The alert shouldn't have triggered, because the provider returned an error")
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 4, 0, true, "The alert should've triggered")
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 2, false, "The alert should've been resolved")
+	expectedDescriptions := []string{
+		"endpoint-name failed 4 times in a row",
+		"endpoint-name succeeded 2 times in a row",
+	}
+	if len(descriptions) != len(expectedDescriptions) {
+		t.Fatalf("expected %d alerts to be sent, got %d", len(expectedDescriptions), len(descriptions))
+	}
+	for i, expectedDescription := range expectedDescriptions {
+		if descriptions[i] != expectedDescription {
+			t.Errorf("expected alert #%d to have description %q, got %q", i+1, expectedDescription, descriptions[i])
+		}
+	}
+}
+
+func TestHandleAlertingWithProviderThatDoesNotSendOnResolved(t *testing.T) {
+	var descriptions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload discord.Body
+		if err := json.Unmarshal(body, &payload); err != nil || len(payload.Embeds) == 0 {
+			t.Errorf("unexpected request body: %s", body)
+		} else {
+			descriptions = append(descriptions, payload.Embeds[0].Description)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	disabled := false
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Discord: &discord.AlertProvider{
+				WebhookURL:         server.URL,
+				BaseProviderConfig: alert.BaseProviderConfig{SendOnResolved: &disabled},
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name: "endpoint-name",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeDiscord,
+				Enabled:          &enabled,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				SendOnResolved:   &enabled,
+				TriggerMessage:   "triggered",
+				ResolveMessage:   "resolved",
+			},
+		},
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, true, "The alert should've triggered")
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved even though no notification was sent")
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, true, "The alert should've triggered again")
+	expectedDescriptions := []string{"triggered", "triggered"}
+	if len(descriptions) != len(expectedDescriptions) {
+		t.Fatalf("expected %d alerts to be sent, got %d: %v", len(expectedDescriptions), len(descriptions), descriptions)
+	}
+	for i, expectedDescription := range expectedDescriptions {
+		if descriptions[i] != expectedDescription {
+			t.Errorf("expected alert #%d to have description %q, got %q", i+1, expectedDescription, descriptions[i])
+		}
+	}
+}
+
+func TestHandleAlertingWithFailingDependency(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	newEndpoint := func(name string, dependencies ...*endpoint.Endpoint) *endpoint.Endpoint {
+		return &endpoint.Endpoint{
+			Name: name,
+			URL:  "https://example.com/" + name,
+			Alerts: []*alert.Alert{
+				{
+					Type:             alert.TypeCustom,
+					Enabled:          &enabled,
+					FailureThreshold: 1,
+					SuccessThreshold: 1,
+				},
+			},
+			Dependencies: dependencies,
+		}
+	}
+	gateway := newEndpoint("gateway")
+	api := newEndpoint("api", gateway)
+	frontend := newEndpoint("frontend", api)
+
+	// The gateway goes down, taking every endpoint that depends on it, directly or not, with it
+	HandleAlerting(gateway, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(api, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(frontend, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, gateway, 1, 0, true, "The gateway's alert should've triggered")
+	verify(t, api, 1, 0, false, "The api's alert shouldn't have triggered, because the gateway is failing")
+	verify(t, frontend, 1, 0, false, "The frontend's alert shouldn't have triggered, because the gateway is failing")
+	// The gateway recovers, but the api remains down
+	HandleAlerting(gateway, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(api, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(frontend, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, gateway, 0, 1, false, "The gateway's alert should've been resolved")
+	verify(t, api, 2, 0, true, "The api's alert should've triggered, because the gateway is no longer failing")
+	verify(t, frontend, 2, 0, false, "The frontend's alert shouldn't have triggered, because the api is failing")
+	// The failures that happened while the dependencies were failing still count towards the failure threshold
+	if frontend.Alerts[0].NumberOfFailuresInARow != 2 {
+		t.Errorf("expected the frontend's alert to have 2 failures in a row, got %d", frontend.Alerts[0].NumberOfFailuresInARow)
+	}
+	// The api recovers, which resolves its alert and lets the frontend's alert trigger
+	HandleAlerting(api, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(frontend, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, api, 0, 1, false, "The api's alert should've been resolved")
+	verify(t, frontend, 3, 0, true, "The frontend's alert should've triggered, because none of its dependencies are failing")
+}
+
+func TestHandleAlertingWithEndpointExpectingNon2xxStatus(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer target.Close()
+	numberOfAlertsSent := 0
+	alertProviderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfAlertsSent++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer alertProviderServer.Close()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Discord: &discord.AlertProvider{
+				WebhookURL: alertProviderServer.URL,
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name:       "auth-gated",
+		URL:        target.URL,
+		Conditions: []endpoint.Condition{"[STATUS] == 403"},
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeDiscord,
+				Enabled:          &enabled,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+			},
+		},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("did not expect an error, got", err)
+	}
+	for i := 0; i < 3; i++ {
+		result := ep.EvaluateHealth()
+		if !result.Success {
+			t.Fatalf("expected the result to be successful, because [STATUS] == 403 is satisfied, got %v", result.ConditionResults[0].Condition)
+		}
+		HandleAlerting(ep, result, cfg.Alerting, cfg.Debug)
+	}
+	verify(t, ep, 0, 3, false, "The alert shouldn't have triggered, because the endpoint responded with the expected status")
+	if numberOfAlertsSent != 0 {
+		t.Errorf("expected no alert to be sent, got %d", numberOfAlertsSent)
+	}
+	// The endpoint is now failing, because it no longer responds with the expected status
+	ep.Conditions = []endpoint.Condition{"[STATUS] == 200"}
+	HandleAlerting(ep, ep.EvaluateHealth(), cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, true, "The alert should've triggered, because the endpoint no longer satisfies its conditions")
+	if numberOfAlertsSent != 1 {
+		t.Errorf("expected 1 alert to be sent, got %d", numberOfAlertsSent)
+	}
+}
+
+func TestHandleAlertingWithMultipleAlertsWithDifferentThresholds(t *testing.T) {
+	numberOfAlertsSentByType := make(map[alert.Type]int)
+	alertProviderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfAlertsSentByType[alert.Type(strings.TrimPrefix(r.URL.Path, "/"))]++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer alertProviderServer.Close()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Slack:   &slack.AlertProvider{WebhookURL: alertProviderServer.URL + "/slack"},
+			Discord: &discord.AlertProvider{WebhookURL: alertProviderServer.URL + "/discord"},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name: "endpoint-name",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeSlack, Enabled: &enabled, FailureThreshold: 2, SuccessThreshold: 1, SendOnResolved: &enabled},
+			{Type: alert.TypeDiscord, Enabled: &enabled, FailureThreshold: 5, SuccessThreshold: 3, SendOnResolved: &enabled},
+		},
+	}
+	slackAlert, discordAlert := ep.Alerts[0], ep.Alerts[1]
+	expectSent := func(description string, expectedSlack, expectedDiscord int) {
+		t.Helper()
+		if numberOfAlertsSentByType[alert.TypeSlack] != expectedSlack || numberOfAlertsSentByType[alert.TypeDiscord] != expectedDiscord {
+			t.Errorf("%s: expected %d slack and %d discord alerts to have been sent, got %d and %d", description, expectedSlack, expectedDiscord, numberOfAlertsSentByType[alert.TypeSlack], numberOfAlertsSentByType[alert.TypeDiscord])
+		}
+	}
+	for i := 0; i < 2; i++ {
+		HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	}
+	if !slackAlert.Triggered || discordAlert.Triggered {
+		t.Error("only the slack alert should've triggered after 2 failures")
+	}
+	expectSent("after 2 failures", 1, 0)
+	for i := 0; i < 3; i++ {
+		HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	}
+	if !slackAlert.Triggered || !discordAlert.Triggered {
+		t.Error("both alerts should've triggered after 5 failures")
+	}
+	expectSent("after 5 failures", 1, 1)
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	if slackAlert.Triggered || !discordAlert.Triggered {
+		t.Error("only the slack alert should've been resolved after 1 success")
+	}
+	expectSent("after 1 success", 2, 1)
+	// The discord alert was restored with its own streak (e.g. from the storage), which must not affect the slack alert
+	discordAlert.NumberOfSuccessesInARow = 2
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	if slackAlert.Triggered || discordAlert.Triggered {
+		t.Error("the discord alert should've been resolved, because its own success streak reached its threshold")
+	}
+	expectSent("after the discord alert was resolved", 2, 2)
+	if slackAlert.NumberOfSuccessesInARow != 2 || discordAlert.NumberOfSuccessesInARow != 3 {
+		t.Errorf("expected alerts to have independent success streaks of 2 and 3, got %d and %d", slackAlert.NumberOfSuccessesInARow, discordAlert.NumberOfSuccessesInARow)
+	}
+	if slackAlert.NumberOfFailuresInARow != 0 || discordAlert.NumberOfFailuresInARow != 0 {
+		t.Error("expected the failure streak of both alerts to have been reset")
+	}
+}
+
+func TestHandleAlertingWithStabilizationWindow(t *testing.T) {
+	numberOfResolvedAlertsSent := 0
+	alertProviderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "resolved") {
+			numberOfResolvedAlertsSent++
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer alertProviderServer.Close()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Slack: &slack.AlertProvider{WebhookURL: alertProviderServer.URL},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name: "endpoint-name",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeSlack, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 2, SendOnResolved: &enabled, StabilizationWindow: 5 * time.Minute},
+		},
+	}
+	now := time.Now()
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: now}, cfg.Alerting, cfg.Debug)
+	if !ep.Alerts[0].Triggered {
+		t.Fatal("the alert should've been triggered")
+	}
+	// Brief recovery: the success threshold is met, but the endpoint fails again before the end of the window
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: now.Add(time.Minute)}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: now.Add(2 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	if !ep.Alerts[0].Triggered || numberOfResolvedAlertsSent != 0 {
+		t.Error("the alert shouldn't have been resolved before the endpoint stayed healthy for the whole stabilization window")
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: now.Add(3 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	if !ep.Alerts[0].RecoveryStartedAt.IsZero() {
+		t.Error("the recovery start time should've been reset by the failure")
+	}
+	// The recovery that started before the failure must not count towards the window
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: now.Add(4 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: now.Add(8 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	if !ep.Alerts[0].Triggered || numberOfResolvedAlertsSent != 0 {
+		t.Error("the alert shouldn't have been resolved, because the endpoint has only been healthy for 4 minutes")
+	}
+	// Sustained recovery
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: now.Add(9 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	if ep.Alerts[0].Triggered || numberOfResolvedAlertsSent != 1 {
+		t.Error("the alert should've been resolved once the endpoint stayed healthy for the whole stabilization window")
+	}
+}
+
+func TestHandleAlertingWhileMuted(t *testing.T) {
+	defer alerting.Unmute()
+	numberOfAlertsSent := 0
+	alertProviderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfAlertsSent++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer alertProviderServer.Close()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Discord: &discord.AlertProvider{
+				WebhookURL: alertProviderServer.URL,
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name: "endpoint-name",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeDiscord,
+				Enabled:          &enabled,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				SendOnResolved:   &enabled,
+			},
+		},
+	}
+	alerting.Mute()
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 2, 0, false, "The alert shouldn't have triggered while alerts are muted")
+	if numberOfAlertsSent != 0 {
+		t.Errorf("expected no alert to be sent while muted, got %d", numberOfAlertsSent)
+	}
+	alerting.Unmute()
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 3, 0, true, "The alert should've triggered once alerts were unmuted, because the endpoint is still failing")
+	if numberOfAlertsSent != 1 {
+		t.Errorf("expected the triggered alert to be sent after unmuting, got %d alerts", numberOfAlertsSent)
+	}
+	// An alert that's resolved while muted is resolved silently
+	alerting.Mute()
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved despite alerts being muted")
+	if numberOfAlertsSent != 1 {
+		t.Errorf("expected only the triggered alert to be sent, got %d alerts", numberOfAlertsSent)
+	}
+	// An incident that both starts and ends while muted is never sent, not even its resolution
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	alerting.Unmute()
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 1, false, "The alert shouldn't have triggered")
+	if numberOfAlertsSent != 1 {
+		t.Errorf("expected no alert to be sent for an incident that started and ended while muted, got %d alerts", numberOfAlertsSent)
+	}
+}
+
 func verify(t *testing.T, ep *endpoint.Endpoint, expectedNumberOfFailuresInARow, expectedNumberOfSuccessInARow int, expectedTriggered bool, expectedTriggeredReason string) {
 	if ep.NumberOfFailuresInARow != expectedNumberOfFailuresInARow {
 		t.Errorf("endpoint.NumberOfFailuresInARow should've been %d, got %d", expectedNumberOfFailuresInARow, ep.NumberOfFailuresInARow)
@@ -506,3 +949,149 @@ func verify(t *testing.T, ep *endpoint.Endpoint, expectedNumberOfFailuresInARow,
 		}
 	}
 }
+
+func TestHandleAlertingWithAlertIdentityInCustomProvider(t *testing.T) {
+	var bodies []string
+	alertProviderServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer alertProviderServer.Close()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    alertProviderServer.URL,
+				Method: "POST",
+				Body:   "[ALERT_TYPE]|[ALERT_NAME]|[ALERT_TRIGGERED_OR_RESOLVED]",
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name: "endpoint-name",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Name: "warning", Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled},
+			{Type: alert.TypeCustom, Name: "critical", Enabled: &enabled, FailureThreshold: 3, SuccessThreshold: 1, SendOnResolved: &enabled},
+		},
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	if len(bodies) != 1 || bodies[0] != "custom|warning|TRIGGERED" {
+		t.Fatalf("expected only the warning alert to have been sent after 1 failure, got %v", bodies)
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	if len(bodies) != 2 || bodies[1] != "custom|critical|TRIGGERED" {
+		t.Fatalf("expected the critical alert to have been sent after 3 failures, got %v", bodies)
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	expectedBodies := []string{"custom|warning|TRIGGERED", "custom|critical|TRIGGERED", "custom|warning|RESOLVED", "custom|critical|RESOLVED"}
+	if strings.Join(bodies, ",") != strings.Join(expectedBodies, ",") {
+		t.Errorf("expected %v, got %v", expectedBodies, bodies)
+	}
+}
+
+func TestHandleAlertingWithSuccessRatio(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	scenarios := []struct {
+		name              string
+		history           []bool
+		expectedTriggered bool
+	}{
+		{
+			name:              "not-enough-results",
+			history:           []bool{false, false, false, false},
+			expectedTriggered: false,
+		},
+		{
+			name:              "all-successful",
+			history:           []bool{true, true, true, true, true},
+			expectedTriggered: false,
+		},
+		{
+			name:              "noisy-but-at-minimum",
+			history:           []bool{false, true, true, true, true, false, true, true, true, true},
+			expectedTriggered: false,
+		},
+		{
+			name:              "below-minimum-without-failure-streak",
+			history:           []bool{true, false, true, false, true, false, true},
+			expectedTriggered: true,
+		},
+		{
+			name:              "still-below-minimum-despite-success-streak",
+			history:           []bool{false, false, false, false, false, true, true, true},
+			expectedTriggered: true,
+		},
+		{
+			name:              "resolved-once-back-to-minimum",
+			history:           []bool{false, false, false, false, false, true, true, true, true},
+			expectedTriggered: false,
+		},
+		{
+			name:              "not-triggered-again-by-a-single-failure",
+			history:           []bool{false, false, false, false, false, true, true, true, true, false},
+			expectedTriggered: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			enabled := true
+			ep := &endpoint.Endpoint{
+				Name: "success-ratio-" + scenario.name,
+				URL:  "https://example.com",
+				Alerts: []*alert.Alert{
+					{
+						Type:             alert.TypeCustom,
+						Enabled:          &enabled,
+						FailureThreshold: 1,
+						SuccessThreshold: 1,
+						SendOnResolved:   &enabled,
+						SuccessRatio:     &alert.SuccessRatio{Minimum: 80, Window: 5},
+					},
+				},
+			}
+			for _, success := range scenario.history {
+				result := &endpoint.Result{Success: success, Timestamp: time.Now()}
+				HandleAlerting(ep, result, cfg.Alerting, cfg.Debug)
+				UpdateEndpointStatuses(ep, result)
+			}
+			if ep.Alerts[0].Triggered != scenario.expectedTriggered {
+				t.Errorf("expected triggered to be %v, got %v", scenario.expectedTriggered, ep.Alerts[0].Triggered)
+			}
+		})
+	}
+}
+
+func TestLoadSuccessHistoryWithDeduplicatedResults(t *testing.T) {
+	defer store.Get().Clear()
+	ep := &endpoint.Endpoint{
+		Name: "deduplicated",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, SuccessRatio: &alert.SuccessRatio{Minimum: 80, Window: 5}},
+		},
+	}
+	if err := store.Get().Insert(ep, &endpoint.Result{Success: false, Timestamp: time.Now()}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// The 10 successful results were deduplicated into a single one
+	if err := store.Get().Insert(ep, &endpoint.Result{Success: true, Count: 10, Timestamp: time.Now()}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	history := loadSuccessHistory(ep, &endpoint.Result{Success: false})
+	if expected := []bool{true, true, true, true, false}; !slices.Equal(history, expected) {
+		t.Errorf("expected the deduplicated result to fill the window, got %v", history)
+	}
+}
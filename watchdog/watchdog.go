@@ -2,6 +2,8 @@ package watchdog
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"log"
 	"sync"
 	"time"
@@ -13,6 +15,8 @@ import (
 	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/metrics"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
 var (
@@ -22,32 +26,54 @@ var (
 
 	ctx        context.Context
 	cancelFunc context.CancelFunc
+
+	// monitorsWaitGroup keeps track of the goroutines monitoring endpoints, which only return once their current
+	// execution, if any, has completed
+	monitorsWaitGroup sync.WaitGroup
+
+	// timeNow and timeAfter are used to wait for the next execution of an endpoint.
+	// They're variables so that tests can control the clock.
+	timeNow   = time.Now
+	timeAfter = time.After
 )
 
 // Monitor loops over each endpoint and starts a goroutine to monitor each endpoint separately
 func Monitor(cfg *config.Config) {
 	ctx, cancelFunc = context.WithCancel(context.Background())
-	for _, endpoint := range cfg.Endpoints {
-		if endpoint.IsEnabled() {
+	for _, ep := range cfg.Endpoints {
+		if !cfg.Shard.IsResponsibleFor(ep) {
+			if cfg.Debug {
+				log.Printf("[watchdog.Monitor] Not monitoring group=%s; endpoint=%s, which is assigned to instance=%s", ep.Group, ep.Name, cfg.Shard.InstanceOf(ep))
+			}
+			continue
+		}
+		if ep.IsEnabled() {
 			// To prevent multiple requests from running at the same time, we'll wait for a little before each iteration
 			time.Sleep(777 * time.Millisecond)
-			go monitor(endpoint, cfg.Alerting, cfg.Maintenance, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug, ctx)
+			monitorsWaitGroup.Add(1)
+			go func(ep *endpoint.Endpoint, ctx context.Context) {
+				defer monitorsWaitGroup.Done()
+				monitor(ep, cfg.Alerting, cfg.Maintenance, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug, ctx)
+			}(ep, ctx)
 		}
 	}
 }
 
 // monitor a single endpoint in a loop
 func monitor(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) {
-	// Run it immediately on start
-	execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+	// Run it immediately on start, unless the endpoint should only be checked at the times defined by its schedule
+	if len(ep.Schedule) == 0 {
+		execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug, ctx)
+	}
 	// Loop for the next executions
 	for {
+		now := timeNow()
 		select {
 		case <-ctx.Done():
 			log.Printf("[watchdog.monitor] Canceling current execution of group=%s; endpoint=%s", ep.Group, ep.Name)
 			return
-		case <-time.After(ep.Interval):
-			execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+		case <-timeAfter(ep.NextExecution(now).Sub(now)):
+			execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug, ctx)
 		}
 	}
 	// Just in case somebody wandered all the way to here and wonders, "what about ExternalEndpoints?"
@@ -55,40 +81,131 @@ func monitor(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenance
 	// periodically like they are for normal endpoints.
 }
 
-func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool) {
+func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) {
+	result, start := evaluate(ep, connectivityConfig, disableMonitoringLock, enabledMetrics, debug, ctx)
+	if result == nil {
+		return
+	}
+	// The duration of the execution excludes the time spent waiting for the monitoring lock, as that time depends on
+	// the other endpoints rather than on this one
+	defer func() {
+		executionDuration := time.Since(start)
+		if enabledMetrics {
+			metrics.PublishCheckExecutionMetricsForEndpoint(ep, executionDuration)
+		}
+		if ep.Interval > 0 && executionDuration > ep.Interval {
+			log.Printf("[watchdog.execute] Execution for group=%s; endpoint=%s took %s, which is longer than its interval of %s", ep.Group, ep.Name, executionDuration.Round(time.Millisecond), ep.Interval)
+		}
+	}()
+	// Alerting is handled once the monitoring lock has been released, so that an alerting provider that is slow to
+	// respond, or whose alerts are being retried, doesn't delay the evaluation of every other endpoint
+	result.Maintenance = maintenanceConfig.IsUnderMaintenance() || maintenance.IsGroupUnderMaintenance(ep.Group)
+	if !result.Maintenance {
+		handleAlerting(ctx, ep, result, alertingConfig, debug)
+	} else if debug {
+		log.Println("[watchdog.execute] Not handling alerting because currently in the maintenance window")
+	}
+	// The result is persisted after alerting has been handled so that it includes the alerts that were sent
+	UpdateEndpointStatuses(ep, result)
+	publishResult(ep, result, enabledMetrics)
+	if debug {
+		log.Printf("[watchdog.execute] Waiting until %s before monitoring group=%s endpoint=%s again", ep.NextExecution(time.Now()).Format(time.RFC3339), ep.Group, ep.Name)
+	}
+}
+
+// evaluate evaluates the health of the endpoint while holding the monitoring lock, unless it is disabled, and returns
+// the result along with the time at which the evaluation started, or nil if the endpoint wasn't evaluated
+func evaluate(ep *endpoint.Endpoint, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) (*endpoint.Result, time.Time) {
 	if !disableMonitoringLock {
 		// By placing the lock here, we prevent multiple endpoints from being monitored at the exact same time, which
 		// could cause performance issues and return inaccurate results
 		monitoringMutex.Lock()
 		defer monitoringMutex.Unlock()
 	}
+	start := time.Now()
+	// If Gatus started shutting down while waiting for the lock, don't start a new check
+	if ctx.Err() != nil {
+		return nil, start
+	}
 	// If there's a connectivity checker configured, check if Gatus has internet connectivity
 	if connectivityConfig != nil && connectivityConfig.Checker != nil && !connectivityConfig.Checker.IsConnected() {
-		log.Println("[watchdog.execute] No connectivity; skipping execution")
-		return
+		log.Println("[watchdog.evaluate] No connectivity; skipping execution")
+		return nil, start
 	}
 	if debug {
-		log.Printf("[watchdog.execute] Monitoring group=%s; endpoint=%s", ep.Group, ep.Name)
+		log.Printf("[watchdog.evaluate] Monitoring group=%s; endpoint=%s", ep.Group, ep.Name)
+	}
+	if ep.PreviousResult == nil && ep.NeedsPreviousResult() {
+		loadPreviousResult(ep)
+	}
+	if !ep.HasRecentResponseTimes() && ep.NeedsRecentResponseTimes() {
+		loadRecentResponseTimes(ep)
 	}
-	result := ep.EvaluateHealth()
+	result := ep.EvaluateHealthWithRetryWait(func(delay time.Duration) bool {
+		// Other endpoints may be monitored while waiting to retry, and retrying stops as soon as Gatus shuts down
+		if !disableMonitoringLock {
+			monitoringMutex.Unlock()
+			defer monitoringMutex.Lock()
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		}
+	})
 	if enabledMetrics {
 		metrics.PublishMetricsForEndpoint(ep, result)
 	}
-	UpdateEndpointStatuses(ep, result)
 	if debug && !result.Success {
-		log.Printf("[watchdog.execute] Monitored group=%s; endpoint=%s; success=%v; errors=%d; duration=%s; body=%s", ep.Group, ep.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond), result.Body)
+		log.Printf("[watchdog.evaluate] Monitored group=%s; endpoint=%s; success=%v; errors=%d; duration=%s; body=%s", ep.Group, ep.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond), result.Body)
 	} else {
-		log.Printf("[watchdog.execute] Monitored group=%s; endpoint=%s; success=%v; errors=%d; duration=%s", ep.Group, ep.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond))
+		log.Printf("[watchdog.evaluate] Monitored group=%s; endpoint=%s; success=%v; errors=%d; duration=%s", ep.Group, ep.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond))
 	}
-	if !maintenanceConfig.IsUnderMaintenance() {
-		// TODO: Consider moving this after the monitoring lock is unlocked? I mean, how much noise can a single alerting provider cause...
-		HandleAlerting(ep, result, alertingConfig, debug)
-	} else if debug {
-		log.Println("[watchdog.execute] Not handling alerting because currently in the maintenance window")
+	return result, start
+}
+
+// loadPreviousResult retrieves the most recent result of the endpoint from the storage so that conditions relying on
+// the previous result survive restarts and configuration reloads.
+// If the endpoint has never been evaluated, the previous result is left empty.
+func loadPreviousResult(ep *endpoint.Endpoint) {
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams().WithResults(1, 1))
+	if err != nil {
+		if !errors.Is(err, common.ErrEndpointNotFound) {
+			log.Printf("[watchdog.loadPreviousResult] Failed to retrieve previous result of endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+		return
 	}
-	if debug {
-		log.Printf("[watchdog.execute] Waiting for interval=%s before monitoring group=%s endpoint=%s again", ep.Interval, ep.Group, ep.Name)
+	if len(endpointStatus.Results) > 0 {
+		ep.PreviousResult = endpointStatus.Results[len(endpointStatus.Results)-1]
+	}
+}
+
+// loadRecentResponseTimes retrieves the response times of the most recent results of the endpoint from the storage so
+// that the response time percentiles survive restarts and configuration reloads, instead of being computed from a
+// handful of evaluations until the window is full again.
+func loadRecentResponseTimes(ep *endpoint.Endpoint) {
+	// The window includes the evaluation that is about to happen
+	if ep.ResponseTimeWindow <= 1 {
+		return
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams().WithResults(1, ep.ResponseTimeWindow-1))
+	if err != nil {
+		if !errors.Is(err, common.ErrEndpointNotFound) {
+			log.Printf("[watchdog.loadRecentResponseTimes] Failed to retrieve results of endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+		return
+	}
+	var responseTimes []time.Duration
+	for _, previousResult := range endpointStatus.Results {
+		// A deduplicated result stands for Count identical results
+		for i := 0; i < max(previousResult.Count, 1); i++ {
+			responseTimes = append(responseTimes, previousResult.Duration)
+		}
 	}
+	ep.SeedRecentResponseTimes(responseTimes)
 }
 
 // UpdateEndpointStatuses updates the slice of endpoint statuses
@@ -98,11 +215,52 @@ func UpdateEndpointStatuses(ep *endpoint.Endpoint, result *endpoint.Result) {
 	}
 }
 
+// resultWebhookPayload is the body of the requests sent to the result webhook of an endpoint
+type resultWebhookPayload struct {
+	Key    string           `json:"key"`
+	Group  string           `json:"group,omitempty"`
+	Name   string           `json:"name"`
+	Result *endpoint.Result `json:"result"`
+}
+
+// publishResult queues the result to be sent to the result webhook of the endpoint, if it has one.
+// If the webhook can't keep up, the result is dropped rather than delaying the next checks.
+func publishResult(ep *endpoint.Endpoint, result *endpoint.Result, enabledMetrics bool) {
+	if ep.ResultWebhook == nil {
+		return
+	}
+	body, err := json.Marshal(resultWebhookPayload{Key: ep.Key(), Group: ep.Group, Name: ep.Name, Result: result})
+	if err != nil {
+		log.Printf("[watchdog.publishResult] Failed to marshal result of endpoint with key=%s: %s", ep.Key(), err.Error())
+		return
+	}
+	if !ep.ResultWebhook.Enqueue(body) && enabledMetrics {
+		metrics.PublishMetricsForDroppedResult(ep)
+	}
+}
+
 // Shutdown stops monitoring all endpoints
+//
+// Checks that are already in progress are given up to cfg.ShutdownGracePeriod to complete, so that their results
+// can be persisted before the storage is closed.
 func Shutdown(cfg *config.Config) {
+	cancelFunc()
+	drained := make(chan struct{})
+	go func() {
+		monitorsWaitGroup.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(cfg.ShutdownGracePeriod):
+		log.Printf("[watchdog.Shutdown] Timed out after %s while waiting for in-flight checks to complete", cfg.ShutdownGracePeriod)
+	}
 	// Disable all the old HTTP connections
 	for _, ep := range cfg.Endpoints {
 		ep.Close()
 	}
-	cancelFunc()
+	// Release the resources held by the alerting providers (e.g. connections to message brokers)
+	if cfg.Alerting != nil {
+		cfg.Alerting.Close()
+	}
 }
@@ -0,0 +1,520 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/resultwebhook"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestExecuteWithCheckThatOverrunsItsInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name             string
+		interval         time.Duration
+		expectedOverruns float64
+	}{
+		{
+			name:             "interval-longer-than-check",
+			interval:         time.Minute,
+			expectedOverruns: 0,
+		},
+		{
+			name:             "interval-shorter-than-check",
+			interval:         10 * time.Millisecond,
+			expectedOverruns: 2,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ep := &endpoint.Endpoint{
+				Name:       scenario.name,
+				Group:      "TestExecuteWithCheckThatOverrunsItsInterval",
+				URL:        server.URL,
+				Conditions: []endpoint.Condition{"[STATUS] == 200"},
+			}
+			if err := ep.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			ep.Interval = scenario.interval
+			execute(ep, nil, maintenance.GetDefaultConfig(), nil, true, true, false, context.Background())
+			execute(ep, nil, maintenance.GetDefaultConfig(), nil, true, true, false, context.Background())
+			if overruns := getCounterValue(t, "gatus_check_overruns_total", ep.Key()); overruns != scenario.expectedOverruns {
+				t.Errorf("expected %v overruns, got %v", scenario.expectedOverruns, overruns)
+			}
+		})
+	}
+}
+
+func TestShutdownWaitsForInFlightChecks(t *testing.T) {
+	defer store.Get().Clear()
+	requestReceived := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestReceived <- struct{}{}
+		time.Sleep(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ep := &endpoint.Endpoint{
+		Name:       "slow",
+		Group:      "TestShutdownWaitsForInFlightChecks",
+		URL:        server.URL,
+		Conditions: []endpoint.Condition{"[STATUS] == 200"},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	cfg := &config.Config{
+		Endpoints:           []*endpoint.Endpoint{ep},
+		Maintenance:         maintenance.GetDefaultConfig(),
+		ShutdownGracePeriod: 5 * time.Second,
+	}
+	Monitor(cfg)
+	<-requestReceived
+	// The check is now in progress, so shutting down should wait for it to complete before returning
+	Shutdown(cfg)
+	status, err := store.Get().GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams().WithResults(1, 10))
+	if err != nil {
+		t.Fatal("expected the result of the in-flight check to have been persisted, got", err.Error())
+	}
+	if len(status.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(status.Results))
+	}
+	if !status.Results[0].Success {
+		t.Error("expected the result of the in-flight check to be successful")
+	}
+}
+
+func TestShutdownWithInFlightCheckThatExceedsGracePeriod(t *testing.T) {
+	defer store.Get().Clear()
+	requestReceived := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestReceived <- struct{}{}
+		time.Sleep(2 * time.Second)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ep := &endpoint.Endpoint{
+		Name:       "very-slow",
+		Group:      "TestShutdownWithInFlightCheckThatExceedsGracePeriod",
+		URL:        server.URL,
+		Conditions: []endpoint.Condition{"[STATUS] == 200"},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	cfg := &config.Config{
+		Endpoints:           []*endpoint.Endpoint{ep},
+		Maintenance:         maintenance.GetDefaultConfig(),
+		ShutdownGracePeriod: 100 * time.Millisecond,
+	}
+	Monitor(cfg)
+	<-requestReceived
+	start := time.Now()
+	Shutdown(cfg)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected shutdown to give up on the in-flight check after the grace period, took %s", elapsed)
+	}
+	// Wait for the in-flight check to complete so that it doesn't leak into other tests
+	monitorsWaitGroup.Wait()
+}
+
+// getCounterValue returns the value of the counter with the given name for the endpoint with the given key
+func getCounterValue(t *testing.T, name, key string) float64 {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal("failed to gather metrics:", err.Error())
+	}
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() != name {
+			continue
+		}
+		for _, metric := range metricFamily.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "key" && label.GetValue() == key {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestExecuteWithPreviousResultPlaceholders(t *testing.T) {
+	defer store.Get().Clear()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	newEndpoint := func(name string) *endpoint.Endpoint {
+		ep := &endpoint.Endpoint{
+			Name:  name,
+			Group: "TestExecuteWithPreviousResultPlaceholders",
+			URL:   server.URL,
+			// Only successful when the endpoint recovers from a failure
+			Conditions: []endpoint.Condition{"[STATUS] == 200", "[PREVIOUS_SUCCESS] == false", "[PREVIOUS_STATUS] == 503"},
+		}
+		if err := ep.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		return ep
+	}
+	getLatestResult := func(ep *endpoint.Endpoint) *endpoint.Result {
+		endpointStatus, err := store.Get().GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams().WithResults(1, 1))
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		return endpointStatus.Results[0]
+	}
+	t.Run("previous-result-seeded-from-storage", func(t *testing.T) {
+		ep := newEndpoint("seeded")
+		// Simulate a result stored before Gatus was restarted
+		UpdateEndpointStatuses(ep, &endpoint.Result{HTTPStatus: 503, Success: false, Timestamp: time.Now().Add(-time.Minute)})
+		execute(ep, nil, maintenance.GetDefaultConfig(), nil, true, false, false, context.Background())
+		if result := getLatestResult(ep); !result.Success {
+			t.Error("expected the first evaluation to be successful, because the stored result was a failure with status 503")
+		}
+		execute(ep, nil, maintenance.GetDefaultConfig(), nil, true, false, false, context.Background())
+		if result := getLatestResult(ep); result.Success {
+			t.Error("expected the second evaluation to fail, because the previous evaluation was successful")
+		}
+	})
+	t.Run("first-ever-evaluation", func(t *testing.T) {
+		ep := newEndpoint("first-ever")
+		execute(ep, nil, maintenance.GetDefaultConfig(), nil, true, false, false, context.Background())
+		result := getLatestResult(ep)
+		if result.Success {
+			t.Error("expected the evaluation to fail, because there is no previous result")
+		}
+		expectedConditionResults := []endpoint.ConditionResult{
+			{Condition: "[STATUS] == 200", Success: true},
+			{Condition: "[PREVIOUS_SUCCESS] () == false", Success: false},
+			{Condition: "[PREVIOUS_STATUS] (0) == 503", Success: false},
+		}
+		for i, expected := range expectedConditionResults {
+			if *result.ConditionResults[i] != expected {
+				t.Errorf("expected condition result %+v, got %+v", expected, *result.ConditionResults[i])
+			}
+		}
+		if ep.PreviousResult == nil || ep.PreviousResult.Success != result.Success || ep.PreviousResult.HTTPStatus != 200 {
+			t.Errorf("expected the previous result to have been kept for the next evaluation, got %+v", ep.PreviousResult)
+		}
+	})
+}
+
+func TestExecuteWithResponseTimePercentilesSeededFromStorage(t *testing.T) {
+	defer store.Get().Clear()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ep := &endpoint.Endpoint{
+		Name:               "seeded",
+		Group:              "TestExecuteWithResponseTimePercentilesSeededFromStorage",
+		URL:                server.URL,
+		ResponseTimeWindow: 5,
+		Conditions:         []endpoint.Condition{"[RESPONSE_TIME_P50] < 1000"},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// Simulate slow results stored before Gatus was restarted
+	for i := 4; i > 0; i-- {
+		UpdateEndpointStatuses(ep, &endpoint.Result{Success: true, Duration: time.Duration(i) * 2 * time.Second, Timestamp: time.Now().Add(-time.Duration(i) * time.Minute)})
+	}
+	execute(ep, nil, maintenance.GetDefaultConfig(), nil, true, false, false, context.Background())
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams().WithResults(1, 1))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpointStatus.Results[0].Success {
+		t.Error("expected the evaluation to fail, because the p50 of the response times stored before the restart is above 1s")
+	}
+}
+
+func TestMonitorWithSchedule(t *testing.T) {
+	defer store.Get().Clear()
+	defer func() {
+		timeNow = time.Now
+		timeAfter = time.After
+	}()
+	var numberOfRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfRequests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                   string
+		schedule               string
+		start                  time.Time
+		expectedExecutionTimes []time.Time
+	}{
+		{
+			name:     "daily-at-3am",
+			schedule: "0 3 * * *",
+			start:    time.Date(2024, 1, 1, 2, 59, 0, 0, time.UTC),
+			expectedExecutionTimes: []time.Time{
+				time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 3, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "business-hours",
+			schedule: "0 9-17 * * 1-5",
+			start:    time.Date(2024, 1, 5, 16, 30, 0, 0, time.UTC), // Friday
+			expectedExecutionTimes: []time.Time{
+				time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), // Monday
+				time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			numberOfRequests.Store(0)
+			ep := &endpoint.Endpoint{
+				Name:       scenario.name,
+				Group:      "TestMonitorWithSchedule",
+				URL:        server.URL,
+				Schedule:   scenario.schedule,
+				Conditions: []endpoint.Condition{"[STATUS] == 200"},
+			}
+			if err := ep.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			clock := scenario.start
+			var executionTimes []time.Time
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			timeNow = func() time.Time {
+				return clock
+			}
+			timeAfter = func(d time.Duration) <-chan time.Time {
+				if len(executionTimes) == len(scenario.expectedExecutionTimes) {
+					cancel()
+					return make(chan time.Time)
+				}
+				clock = clock.Add(d)
+				executionTimes = append(executionTimes, clock)
+				c := make(chan time.Time, 1)
+				c <- clock
+				return c
+			}
+			monitor(ep, nil, maintenance.GetDefaultConfig(), nil, true, false, false, ctx)
+			if int(numberOfRequests.Load()) != len(scenario.expectedExecutionTimes) {
+				t.Errorf("expected %d executions, got %d", len(scenario.expectedExecutionTimes), numberOfRequests.Load())
+			}
+			for i, expected := range scenario.expectedExecutionTimes {
+				if !executionTimes[i].Equal(expected) {
+					t.Errorf("expected execution #%d to happen at %s, got %s", i+1, expected, executionTimes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteAnnotatesResultsWithAlerts(t *testing.T) {
+	defer store.Get().Clear()
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" && !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	alertingConfig := &alerting.Config{Custom: &custom.AlertProvider{URL: server.URL + "/alert"}}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name:       "annotated",
+		Group:      "TestExecuteAnnotatesResultsWithAlerts",
+		URL:        server.URL + "/health",
+		Conditions: []endpoint.Condition{"[STATUS] == 200"},
+		Alerts:     []*alert.Alert{{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 2, SuccessThreshold: 1, SendOnResolved: &enabled}},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for i := 0; i < 3; i++ {
+		execute(ep, alertingConfig, maintenance.GetDefaultConfig(), nil, true, false, false, context.Background())
+	}
+	healthy.Store(true)
+	for i := 0; i < 2; i++ {
+		execute(ep, alertingConfig, maintenance.GetDefaultConfig(), nil, true, false, false, context.Background())
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams().WithResults(1, 5))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(endpointStatus.Results))
+	}
+	expectedAlertStates := []endpoint.ResultAlertState{"", endpoint.ResultAlertTriggered, "", endpoint.ResultAlertResolved, ""}
+	for i, result := range endpointStatus.Results {
+		if len(expectedAlertStates[i]) == 0 {
+			if len(result.Alerts) != 0 {
+				t.Errorf("expected result #%d to have no alerts, got %d", i, len(result.Alerts))
+			}
+			continue
+		}
+		if len(result.Alerts) != 1 {
+			t.Fatalf("expected result #%d to have 1 alert, got %d", i, len(result.Alerts))
+		}
+		if result.Alerts[0].Type != alert.TypeCustom || result.Alerts[0].State != expectedAlertStates[i] {
+			t.Errorf("expected result #%d to have a %s alert that was %s, got a %s alert that was %s", i, alert.TypeCustom, expectedAlertStates[i], result.Alerts[0].Type, result.Alerts[0].State)
+		}
+	}
+}
+
+func TestExecuteSendsEveryResultToResultWebhook(t *testing.T) {
+	defer store.Get().Clear()
+	received := make(chan resultWebhookPayload, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/results" {
+			var payload resultWebhookPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Error("expected the payload to be JSON, got", err.Error())
+			}
+			received <- payload
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ep := &endpoint.Endpoint{
+		Name:          "streamed",
+		Group:         "TestExecuteSendsEveryResultToResultWebhook",
+		URL:           server.URL + "/health",
+		Conditions:    []endpoint.Condition{"[STATUS] == 200"},
+		ResultWebhook: &resultwebhook.Config{URL: server.URL + "/results"},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer ep.Close()
+	for i := 0; i < 3; i++ {
+		execute(ep, nil, maintenance.GetDefaultConfig(), nil, true, false, false, context.Background())
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case payload := <-received:
+			if payload.Key != ep.Key() || payload.Name != ep.Name || payload.Group != ep.Group {
+				t.Errorf("expected the payload to identify endpoint with key=%s, got key=%s", ep.Key(), payload.Key)
+			}
+			if payload.Result == nil || !payload.Result.Success || payload.Result.HTTPStatus != http.StatusOK {
+				t.Errorf("expected the payload to contain the successful result, got %+v", payload.Result)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected 3 results to be sent to the result webhook, got %d", i)
+		}
+	}
+}
+
+func TestExecuteHandlesAlertingOutsideOfMonitoringLock(t *testing.T) {
+	defer store.Get().Clear()
+	var alertsSent, alertsSentWhileLocked atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alert" {
+			alertsSent.Add(1)
+			if monitoringMutex.TryLock() {
+				monitoringMutex.Unlock()
+			} else {
+				alertsSentWhileLocked.Add(1)
+			}
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	alertingConfig := &alerting.Config{Custom: &custom.AlertProvider{URL: server.URL + "/alert"}}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name:       "alerting-outside-of-lock",
+		Group:      "TestExecuteHandlesAlertingOutsideOfMonitoringLock",
+		URL:        server.URL + "/health",
+		Conditions: []endpoint.Condition{"[STATUS] == 200"},
+		Alerts:     []*alert.Alert{{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1}},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	execute(ep, alertingConfig, maintenance.GetDefaultConfig(), nil, false, false, false, context.Background())
+	if alertsSent.Load() != 1 {
+		t.Fatalf("expected 1 alert to have been sent, got %d", alertsSent.Load())
+	}
+	if alertsSentWhileLocked.Load() != 0 {
+		t.Error("expected the alert to be sent after the monitoring lock was released")
+	}
+}
+
+func TestEvaluateReleasesMonitoringLockBetweenRetries(t *testing.T) {
+	var numberOfRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numberOfRequests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	ep := &endpoint.Endpoint{
+		Name:       "retrying",
+		Group:      "TestEvaluateReleasesMonitoringLockBetweenRetries",
+		URL:        server.URL,
+		Conditions: []endpoint.Condition{"[STATUS] == 200"},
+		Retries:    3,
+		RetryDelay: time.Hour,
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := make(chan *endpoint.Result)
+	go func() {
+		result, _ := evaluate(ep, nil, false, false, false, ctx)
+		results <- result
+	}()
+	for start := time.Now(); numberOfRequests.Load() == 0; time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("timed out waiting for the first attempt")
+		}
+	}
+	// The lock is released while waiting for the retry delay to elapse
+	for start := time.Now(); !monitoringMutex.TryLock(); time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("expected the monitoring lock to be released while waiting to retry")
+		}
+	}
+	monitoringMutex.Unlock()
+	// Shutting down interrupts the retry delay
+	cancel()
+	select {
+	case result := <-results:
+		if result == nil || result.Success {
+			t.Error("expected the result of the failed attempt to be returned")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the retries to stop once the context was cancelled")
+	}
+	if numberOfRequests.Load() != 1 {
+		t.Errorf("expected only 1 attempt, got %d", numberOfRequests.Load())
+	}
+}
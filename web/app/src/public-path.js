@@ -0,0 +1,6 @@
+// Assets such as the logo are loaded relative to the context root the server renders into index.html (see
+// web.context-root), which is only known at runtime. This must be imported before any other module.
+if (process.env.NODE_ENV === 'production') {
+  // eslint-disable-next-line no-undef
+  __webpack_public_path__ = window.config.contextRoot + '/'
+}
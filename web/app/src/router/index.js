@@ -16,7 +16,7 @@ const routes = [
 ];
 
 const router = createRouter({
-    history: createWebHistory(process.env.BASE_URL),
+    history: createWebHistory(process.env.NODE_ENV === 'production' ? window.config.contextRoot + '/' : process.env.BASE_URL),
     routes
 });
 
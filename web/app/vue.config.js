@@ -2,5 +2,13 @@ module.exports = {
 	filenameHashing: false,
 	productionSourceMap: false,
 	outputDir: '../static',
-	publicPath: '/'
+	// Overridden at runtime by src/public-path.js, since the context root is only known once index.html is rendered
+	publicPath: '/',
+	chainWebpack: config => {
+		// Assets are prefixed by the context root the server renders into index.html (see web.context-root)
+		config.plugin('html').tap(args => {
+			args[0].publicPath = '{{ .ContextRoot }}/'
+			return args
+		})
+	}
 }
\ No newline at end of file